@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"os"
+	"time"
+)
+
+// newFlagSet はサブコマンドのフラグを解析するFlagSetを作成する
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+// parseFlags はフラグを解析し、不正な場合はerrUsageを返す
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	return nil
+}
+
+// addTenantFlag は操作対象のテナントを指定するフラグを追加する
+func addTenantFlag(fs *flag.FlagSet) *string {
+	return fs.String("tenant", tenant.DefaultID, "操作対象のテナントID")
+}
+
+// withTenant はidのテナントを操作対象とするコンテキストを返す
+func withTenant(ctx context.Context, id string) (context.Context, error) {
+	if err := tenant.ValidateID(id); err != nil {
+		return nil, fmt.Errorf("%w: --tenant: %v", errUsage, err)
+	}
+	return tenant.WithID(ctx, id), nil
+}
+
+// criteriaFlags はメッセージを絞り込むフラグ
+type criteriaFlags struct {
+	channelID string
+	sender    string
+	from      string
+	to        string
+}
+
+func addCriteriaFlags(fs *flag.FlagSet) *criteriaFlags {
+	f := &criteriaFlags{}
+	fs.StringVar(&f.channelID, "channel-id", "", "チャンネルID")
+	fs.StringVar(&f.sender, "sender", "", "送信者")
+	fs.StringVar(&f.from, "from", "", "送信日時の開始（RFC3339）")
+	fs.StringVar(&f.to, "to", "", "送信日時の終了（RFC3339）")
+	return f
+}
+
+// criteria は指定されたフラグから検索条件を作成する
+func (f *criteriaFlags) criteria() (message.SearchCriteria, error) {
+	var criteria message.SearchCriteria
+	if f.channelID != "" {
+		criteria.ChannelIDs = []string{f.channelID}
+	}
+	if f.sender != "" {
+		criteria.Senders = []string{f.sender}
+	}
+	for _, d := range []struct {
+		flag  string
+		value string
+		dst   **time.Time
+	}{
+		{"from", f.from, &criteria.FromDate},
+		{"to", f.to, &criteria.ToDate},
+	} {
+		if d.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, d.value)
+		if err != nil {
+			return criteria, fmt.Errorf("%w: --%s must be an RFC3339 time (got %q)", errUsage, d.flag, d.value)
+		}
+		*d.dst = &t
+	}
+	return criteria, nil
+}
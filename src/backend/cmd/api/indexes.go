@@ -0,0 +1,23 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// runIndexes はindexesサブコマンドを実行する
+//
+//	indexes sync  宣言されたインデックスを作成し、実際のインデックスとの差分をログに出力する
+//
+// server.skip_index_syncで起動時の作成を省略している環境で、作成の時期を選んで実行する
+func runIndexes(ctx context.Context, st *storage, args []string) error {
+	if len(args) != 1 || args[0] != "sync" {
+		return fmt.Errorf("%w: expected \"indexes sync\"", errUsage)
+	}
+	// SQLのストレージのインデックスはマイグレーションで作成される
+	if st.mongo == nil {
+		return errors.New("indexes sync is only available for MongoDB storage")
+	}
+	return syncIndexes(ctx, st.mongo, false)
+}
@@ -2,49 +2,118 @@ package main
 
 import (
 	"context"
-	"log"
-	"message-service/internal/adapter/handler"
-	"message-service/internal/infrastructure/middleware"
-	"message-service/internal/infrastructure/mongodb/repository"
-	"message-service/pkg/api"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"message-service/internal/config"
+	"message-service/internal/infrastructure/logging"
 	"os"
-	"time"
-
-	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
+	"os/signal"
+	"syscall"
 )
 
-func main() {
-	// MongoDB接続設定
-	mongoURI := os.Getenv("MONGODB_URI")
-	dbName := os.Getenv("MONGODB_NAME")
+// usage はサブコマンドの一覧
+const usage = `Usage: api [flags] [command]
+
+Commands:
+  serve                                HTTPとgRPCのサーバーを起動する（既定）
+  migrate [up | down [n] | status]     MongoDBのマイグレーションを適用・巻き戻し・表示する
+  indexes sync                         MongoDBの宣言されたインデックスを作成し、差分を表示する
+  tenant create --id --name [quota]    テナントを作成する
+  tenant list                          テナントの一覧を表示する
+  tenant update [--name] [quota] <id>  テナントの名前と上限を変更する
+  token create [--name] [--ttl]        トークンを作成し、トークン文字列を表示する
+  token list                           トークンの一覧を表示する
+  token revoke <id>                    トークンを無効にする
+  messages export [filters] [--format] 条件に一致するメッセージを書き出す
+  messages purge [filters] [--yes]     条件に一致するメッセージを削除する
+
+token、messagesのコマンドは --tenant で対象のテナントを指定します（省略時は default）。
+
+設定のフラグは "api --help"、コマンドのフラグは "api <command> <subcommand> --help" で表示します。
+`
 
-	opts := options.Client().
-		ApplyURI(mongoURI).
-		SetTimeout(10 * time.Second).
-		SetServerSelectionTimeout(5 * time.Second)
+// errUsage はサブコマンドの引数が不正な場合のエラー
+var errUsage = errors.New("invalid arguments")
 
-	client, err := mongo.Connect(context.Background(), opts)
+func main() {
+	cfg, args, err := config.Load(os.Args[1:], os.LookupEnv)
 	if err != nil {
-		log.Fatalf("MongoDB connection error: %v", err)
+		fatal("Invalid configuration", err)
 	}
+	// 標準出力はコマンドの結果やstdoutのトレースに使うため、ログは標準エラー出力に書く
+	logger := logging.New(os.Stderr, cfg.Log)
+	slog.SetDefault(logger)
 
-	db := client.Database(dbName)
+	command := "serve"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
 
-	// 依存関係の構築
-	messageRepo := repository.NewMessageRepository(db)
-	tokenRepo := repository.NewTokenRepository(db)
-	handler := handler.NewHandler(messageRepo, tokenRepo)
-	authMiddleware := middleware.NewAuthMiddleware(tokenRepo)
+	switch command {
+	case "serve":
+		runServe(cfg, logger)
+	case "migrate":
+		runAdmin(cfg, func(ctx context.Context, st *storage) error {
+			return runMigrate(ctx, st, args)
+		})
+	case "indexes":
+		runAdmin(cfg, func(ctx context.Context, st *storage) error {
+			return runIndexes(ctx, st, args)
+		})
+	case "tenant":
+		runAdmin(cfg, func(ctx context.Context, st *storage) error {
+			return runTenant(ctx, st.tenants, args, os.Stdout)
+		})
+	case "token":
+		runAdmin(cfg, func(ctx context.Context, st *storage) error {
+			return runToken(ctx, st.tokens, st.tenants, cfg.Token.DefaultTTL, args, os.Stdout)
+		})
+	case "messages":
+		runAdmin(cfg, func(ctx context.Context, st *storage) error {
+			return runMessages(ctx, st.messages, st.audit, args, os.Stdout)
+		})
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n%s", command, usage)
+		os.Exit(2)
+	}
+}
 
-	// Ginルーターの設定
-	router := gin.Default()
-	router.Use(authMiddleware.RequireAuth())
-	api.RegisterHandlers(router, api.NewStrictHandler(handler, nil))
+// runAdmin はストレージに接続して管理コマンドを実行する
+// HTTPサーバーを起動せずに、サーバーと同じ設定とリポジトリで操作する
+func runAdmin(cfg *config.Config, run func(ctx context.Context, st *storage) error) {
+	// プロセス内にのみデータを持つストレージは、別のプロセスから操作できない
+	if cfg.Storage.Type == config.StorageMemory {
+		fatal("Admin commands require persistent storage (use token.bootstrap_token to create the first token for memory storage)", fmt.Errorf("storage.type=%s", cfg.Storage.Type))
+	}
+
+	// 書き出しなどの長い処理はSIGINTで中断する
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	st, err := openStorage(ctx, cfg)
+	if err != nil {
+		fatal("Failed to open storage", err)
+	}
 
-	// サーバー起動
-	if err := router.Run(":8080"); err != nil {
-		log.Fatal(err)
+	err = run(ctx, st)
+	if closeErr := st.Close(context.Background()); closeErr != nil {
+		slog.Error("Failed to close storage", slog.String("error", closeErr.Error()))
+	}
+	switch {
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(os.Stderr, "%v\n\n%s", err, usage)
+		os.Exit(2)
+	case errors.Is(err, flag.ErrHelp):
+		// フラグの説明はFlagSetが表示済み
+	case err != nil:
+		fatal("Command failed", err)
 	}
 }
+
+// fatal はエラーを記録して終了する
+func fatal(msg string, err error) {
+	slog.Error(msg, slog.String("error", err.Error()))
+	os.Exit(1)
+}
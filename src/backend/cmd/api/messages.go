@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"message-service/internal/adapter/handler"
+	"message-service/internal/domain/audit"
+	"message-service/internal/domain/message"
+	"message-service/pkg/api"
+	"os"
+)
+
+// runMessages はmessagesサブコマンドを実行する
+//
+//	messages export [filters] [--format ndjson|csv|json] [--output FILE]  条件に一致するメッセージを書き出す
+//	messages purge [filters] [--yes]                                      条件に一致するメッセージを削除する
+//
+// filtersは--channel-id、--sender、--from、--toで指定する
+// どちらも--tenantで指定したテナント（省略時は既定のテナント）のメッセージのみを対象とする
+func runMessages(ctx context.Context, repo message.Repository, auditRepo audit.Repository, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: messages requires a subcommand (export or purge)", errUsage)
+	}
+
+	switch args[0] {
+	case "export":
+		fs := newFlagSet("messages export")
+		filters := addCriteriaFlags(fs)
+		tenantID := addTenantFlag(fs)
+		format := fs.String("format", string(api.Ndjson), "出力形式（ndjson、csvまたはjson）")
+		output := fs.String("output", "", "出力先のファイル（省略時は標準出力）")
+		criteria, err := parseCriteria(fs, filters, args[1:])
+		if err != nil {
+			return err
+		}
+		switch f := api.GetApiMessagesExportParamsFormat(*format); f {
+		case api.Ndjson, api.Csv, api.Json:
+		default:
+			return fmt.Errorf("%w: --format must be ndjson, csv or json (got %q)", errUsage, f)
+		}
+		ctx, err := withTenant(ctx, *tenantID)
+		if err != nil {
+			return err
+		}
+		return exportMessages(ctx, repo, criteria, api.GetApiMessagesExportParamsFormat(*format), *output, out)
+
+	case "purge":
+		fs := newFlagSet("messages purge")
+		filters := addCriteriaFlags(fs)
+		tenantID := addTenantFlag(fs)
+		yes := fs.Bool("yes", false, "確認せずに削除する。省略時は削除対象の件数のみを表示する")
+		criteria, err := parseCriteria(fs, filters, args[1:])
+		if err != nil {
+			return err
+		}
+		ctx, err := withTenant(ctx, *tenantID)
+		if err != nil {
+			return err
+		}
+		// 確認と削除を同じプロセスで続けて行うため、署名鍵は都度生成する
+		bulkDelete := handler.NewBulkDeleteHandler(repo, auditRepo, handler.NewConfirmationSecret(), handler.DefaultConfirmationTTL)
+		return purgeMessages(ctx, bulkDelete, criteria, *yes, out)
+
+	default:
+		return fmt.Errorf("%w: unknown messages subcommand %q", errUsage, args[0])
+	}
+}
+
+// parseCriteria はフラグを解析して検索条件を作成する
+func parseCriteria(fs *flag.FlagSet, filters *criteriaFlags, args []string) (message.SearchCriteria, error) {
+	if err := parseFlags(fs, args); err != nil {
+		return message.SearchCriteria{}, err
+	}
+	if fs.NArg() > 0 {
+		return message.SearchCriteria{}, fmt.Errorf("%w: unexpected arguments %v", errUsage, fs.Args())
+	}
+	return filters.criteria()
+}
+
+// exportMessages はメッセージをpathのファイル、またはpathが空の場合はoutに書き出す
+func exportMessages(ctx context.Context, repo message.Repository, criteria message.SearchCriteria, format api.GetApiMessagesExportParamsFormat, path string, out io.Writer) (err error) {
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		out = f
+	}
+
+	w := bufio.NewWriter(out)
+	if err := handler.WriteExport(ctx, w, repo, criteria, format); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// purgeMessages は条件に一致するメッセージを削除する
+// yesが指定されない場合は件数のみを表示し、削除しない
+func purgeMessages(ctx context.Context, h *handler.BulkDeleteHandler, criteria message.SearchCriteria, yes bool, out io.Writer) error {
+	matched, confirmation, err := h.Preview(ctx, criteria)
+	if errors.Is(err, message.ErrCriteriaRequired) {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if err != nil {
+		return err
+	}
+	if !yes {
+		_, err := fmt.Fprintf(out, "%d messages match. Re-run with --yes to delete them.\n", matched)
+		return err
+	}
+
+	_, deleted, err := h.Delete(ctx, criteria, confirmation)
+	if errors.Is(err, handler.ErrConfirmationTokenMismatch) {
+		return errors.New("matching messages changed while purging; run the command again")
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "Deleted %d messages\n", deleted)
+	return err
+}
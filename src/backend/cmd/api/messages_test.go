@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"message-service/internal/domain/audit"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/infrastructure/memory"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// seedMessages はテスト用のメッセージを作成したリポジトリを返す
+func seedMessages(t *testing.T) message.Repository {
+	t.Helper()
+	repo := memory.NewMessageRepository()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, m := range []struct{ channel, sender string }{
+		{"general", "alice"},
+		{"general", "bob"},
+		{"random", "alice"},
+	} {
+		err := repo.Create(tenant.WithID(context.Background(), tenant.DefaultID), &message.Message{
+			UID:       "msg-" + string(rune('a'+i)),
+			SentAt:    base.Add(time.Duration(i) * time.Hour),
+			Sender:    m.sender,
+			ChannelID: m.channel,
+			Content:   "hello",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return repo
+}
+
+func TestRunMessages_Export(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectedLines int
+		expectedUIDs  []string
+		expectedError error
+	}{
+		{
+			name:          "正常系：条件なしで全件をNDJSONで書き出す",
+			args:          []string{"export"},
+			expectedLines: 3,
+			expectedUIDs:  []string{"msg-a", "msg-b", "msg-c"},
+		},
+		{
+			name:          "正常系：チャンネルと送信者で絞り込む",
+			args:          []string{"export", "--channel-id", "general", "--sender", "alice"},
+			expectedLines: 1,
+			expectedUIDs:  []string{"msg-a"},
+		},
+		{
+			name:          "正常系：期間で絞り込んでCSVで書き出す",
+			args:          []string{"export", "--format", "csv", "--from", "2024-01-01T01:00:00Z"},
+			expectedLines: 3, // ヘッダーを含む
+			expectedUIDs:  []string{"msg-b", "msg-c"},
+		},
+		{
+			name:          "正常系：他のテナントのメッセージは書き出さない",
+			args:          []string{"export", "--tenant", "acme", "--format", "csv"},
+			expectedLines: 1, // ヘッダーのみ
+		},
+		{
+			name:          "異常系：不正なテナントID",
+			args:          []string{"export", "--tenant", "Acme"},
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：不明な形式",
+			args:          []string{"export", "--format", "xml"},
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：日時の形式が不正",
+			args:          []string{"export", "--from", "2024-01-01"},
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：不明なフラグ",
+			args:          []string{"export", "--channel", "general"},
+			expectedError: errUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			err := runMessages(context.Background(), seedMessages(t), memory.NewAuditRepository(), tt.args, &out)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, strings.Split(strings.TrimSpace(out.String()), "\n"), tt.expectedLines)
+			for _, uid := range tt.expectedUIDs {
+				assert.Contains(t, out.String(), uid)
+			}
+		})
+	}
+}
+
+func TestRunMessages_ExportToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.json")
+	var out bytes.Buffer
+
+	err := runMessages(context.Background(), seedMessages(t), memory.NewAuditRepository(),
+		[]string{"export", "--format", "json", "--output", path}, &out)
+
+	assert.NoError(t, err)
+	assert.Empty(t, out.String())
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "["))
+	assert.Contains(t, string(data), "msg-c")
+}
+
+func TestRunMessages_Purge(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		expectedOutput  string
+		expectedRemain  int64
+		expectedAffects []int64
+		expectedError   error
+	}{
+		{
+			name:           "正常系：--yesが無い場合は件数のみを表示する",
+			args:           []string{"purge", "--sender", "alice"},
+			expectedOutput: "2 messages match",
+			expectedRemain: 3,
+		},
+		{
+			name:            "正常系：--yesを指定すると削除して監査ログに記録する",
+			args:            []string{"purge", "--sender", "alice", "--yes"},
+			expectedOutput:  "Deleted 2 messages",
+			expectedRemain:  1,
+			expectedAffects: []int64{2},
+		},
+		{
+			name:          "異常系：条件が無い",
+			args:          []string{"purge", "--yes"},
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：不明なサブコマンド",
+			args:          []string{"delete"},
+			expectedError: errUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := seedMessages(t)
+			auditRepo := memory.NewAuditRepository()
+			var out bytes.Buffer
+
+			err := runMessages(context.Background(), repo, auditRepo, tt.args, &out)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Contains(t, out.String(), tt.expectedOutput)
+			remain, err := repo.Count(tenant.WithID(context.Background(), tenant.DefaultID), message.SearchCriteria{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRemain, remain)
+
+			logs := auditRepo.Logs()
+			assert.Len(t, logs, len(tt.expectedAffects))
+			for i, affected := range tt.expectedAffects {
+				assert.Equal(t, audit.ActionMessageBulkDelete, logs[i].Action)
+				assert.Equal(t, affected, logs[i].Affected)
+				// 管理コマンドはトークンで認証されない
+				assert.Empty(t, logs[i].TokenID)
+			}
+		})
+	}
+}
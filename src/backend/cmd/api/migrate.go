@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"message-service/internal/infrastructure/mongodb/migration"
+	"strconv"
+	"time"
+)
+
+// runMigrate はmigrateサブコマンドを実行する
+//
+//	migrate [up]      未適用のマイグレーションを全て適用する
+//	migrate down [n]  適用済みのマイグレーションを新しいものからn件（既定値1）巻き戻す
+//	migrate status    マイグレーションの適用状況を表示する
+func runMigrate(ctx context.Context, st *storage, args []string) error {
+	// SQLのストレージは起動時に自動でマイグレーションを適用する
+	if st.mongo == nil {
+		return errors.New("migrate is only available for MongoDB storage")
+	}
+
+	migrator, err := migration.NewMigrator(st.mongo, migration.Migrations())
+	if err != nil {
+		return fmt.Errorf("invalid migrations: %w", err)
+	}
+
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "up":
+		done, err := migrator.Up(ctx)
+		printMigrations("Applied", done)
+		if err != nil {
+			return fmt.Errorf("migration failed: %w", err)
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("%w: number of steps must be a positive integer (got %q)", errUsage, args[1])
+			}
+		}
+		done, err := migrator.Down(ctx, steps)
+		printMigrations("Reverted", done)
+		if err != nil {
+			return fmt.Errorf("migration failed: %w", err)
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get migration status: %w", err)
+		}
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%d\t%s\t%s\n", s.Version, appliedAt, s.Description)
+		}
+	default:
+		return fmt.Errorf("%w: unknown migrate command %q (expected up, down or status)", errUsage, command)
+	}
+	return nil
+}
+
+func printMigrations(action string, migrations []migration.Migration) {
+	if len(migrations) == 0 {
+		slog.Info(action + " no migrations")
+		return
+	}
+	for _, m := range migrations {
+		slog.Info(action+" migration", slog.Int64("version", m.Version), slog.String("description", m.Description))
+	}
+}
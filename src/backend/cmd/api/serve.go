@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"message-service/internal/adapter/handler"
+	"message-service/internal/adapter/rpc"
+	"message-service/internal/config"
+	"message-service/internal/domain/message"
+	"message-service/internal/infrastructure/broker"
+	"message-service/internal/infrastructure/graceful"
+	"message-service/internal/infrastructure/health"
+	"message-service/internal/infrastructure/logging"
+	"message-service/internal/infrastructure/metrics"
+	"message-service/internal/infrastructure/middleware"
+	"message-service/internal/infrastructure/mongodb"
+	"message-service/internal/infrastructure/mongodb/migration"
+	"message-service/internal/infrastructure/mongodb/watcher"
+	"message-service/internal/infrastructure/quota"
+	"message-service/internal/infrastructure/sweeper"
+	"message-service/internal/infrastructure/tracing"
+	"message-service/pkg/api"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+// errShuttingDown は停止処理の開始後にレディネスの確認で返すエラー
+var errShuttingDown = errors.New("server is shutting down")
+
+// runServe はHTTPとgRPCのサーバーを起動し、SIGTERMまたはSIGINTを受け取るまで処理する
+func runServe(cfg *config.Config, logger *slog.Logger) {
+	slog.Info("Effective configuration", slog.String("config", cfg.String()))
+
+	// SIGTERM（Kubernetesのポッド停止）またはSIGINTで停止処理を開始する
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	// トレースはサーバーの停止後に送信しきってから終了する
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
+	if err != nil {
+		fatal("Failed to set up tracing", err)
+	}
+
+	// ウォッチャーやスイーパーはサーバーの停止後に止める
+	bgCtx, cancelBackground := context.WithCancel(context.Background())
+	var background sync.WaitGroup
+	goBackground := func(f func(ctx context.Context)) {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			f(bgCtx)
+		}()
+	}
+
+	st, err := openStorage(context.Background(), cfg)
+	if err != nil {
+		fatal("Failed to open storage", err)
+	}
+	messageRepo, tokenRepo, auditRepo, tenantRepo := st.messages, st.tokens, st.audit, st.tenants
+	if cfg.Token.BootstrapToken != "" {
+		tkn, err := createBootstrapToken(context.Background(), st.tokens, cfg.Token.BootstrapToken, cfg.Token.DefaultTTL)
+		if err != nil {
+			fatal("Failed to create bootstrap token", err)
+		}
+		slog.Info("Created bootstrap token", slog.String("token_id", tkn.ID.Hex()), slog.Time("expires_at", tkn.ExpiresAt))
+	}
+	eventBroker := broker.NewBroker()
+	// events はWatchMessagesの購読元。変更を監視できるMongoDBでのみ設定する
+	var events message.EventSubscriber
+	// readinessChecker は/readyzで確認する依存先
+	readinessChecker := health.NewChecker(health.DefaultCheckTimeout)
+	// 停止を開始したら、受け付けを止める前に/readyzを失敗させて振り分け先から外させる
+	readinessChecker.Add("shutdown", func(context.Context) error {
+		if ctx.Err() != nil {
+			return errShuttingDown
+		}
+		return nil
+	})
+
+	switch {
+	case st.sql != nil:
+		readinessChecker.Add(cfg.Storage.Type, st.sql.PingContext)
+	case st.mongo != nil:
+		db := st.mongo
+		if err := syncIndexes(context.Background(), db, cfg.MongoDB.SkipIndexSync); err != nil {
+			fatal("MongoDB index sync error", err)
+		}
+
+		migrator, err := migration.NewMigrator(db, migration.Migrations())
+		if err != nil {
+			fatal("Invalid migrations", err)
+		}
+		readinessChecker.Add("mongodb", func(ctx context.Context) error {
+			return db.Client().Ping(ctx, readpref.Primary())
+		})
+		readinessChecker.Add("indexes", func(ctx context.Context) error {
+			return mongodb.VerifyIndexes(ctx, mongodb.NewDatabase(db))
+		})
+		// マイグレーションは自動で適用しないため、migrateサブコマンドの実行が済むまで受け付けない
+		readinessChecker.Add("migrations", func(ctx context.Context) error {
+			pending, err := migrator.Pending(ctx)
+			if err != nil {
+				return err
+			}
+			if len(pending) > 0 {
+				return fmt.Errorf("%d pending migrations (oldest version %d)", len(pending), pending[0].Version)
+			}
+			return nil
+		})
+
+		// 他のレプリカで発生した変更も含めてイベントを配信する
+		// 一時的なエラーで止まっても、保存済みの位置から監視を再開する
+		goBackground(watcher.NewWatcher(db, eventBroker).RunWithRetry)
+		events = eventBroker
+	default:
+		slog.Info("Using in-memory storage")
+	}
+
+	// TTLインデックスを持たないストレージでは、有効期限を過ぎたデータを定期的に削除する
+	expirySweeper := sweeper.New(cfg.Storage.ExpirySweepInterval)
+	for name, repo := range map[string]interface{}{"messages": messageRepo, "tokens": tokenRepo} {
+		if expirer, ok := repo.(sweeper.Expirer); ok {
+			expirySweeper.Add(name, expirer)
+		}
+	}
+	goBackground(expirySweeper.Run)
+
+	// ストレージの処理時間などを記録する
+	// スイーパーは期限切れデータの削除メソッドを型アサーションで探すため、その登録後にラップする
+	serviceMetrics := metrics.New(metrics.DefaultChannelLimit)
+	serviceMetrics.RegisterSubscribers(eventBroker.SubscriberCount)
+	messageRepo = serviceMetrics.InstrumentMessageRepository(cfg.Storage.Type, messageRepo)
+	tokenRepo = serviceMetrics.InstrumentTokenRepository(cfg.Storage.Type, tokenRepo)
+	auditRepo = serviceMetrics.InstrumentAuditRepository(cfg.Storage.Type, auditRepo)
+	// テナントの上限を超える作成を拒否する
+	messageRepo = quota.NewMessageRepository(messageRepo, tenantRepo)
+	tokenRepo = quota.NewTokenRepository(tokenRepo, tenantRepo)
+
+	// 依存関係の構築
+	confirmationKey := []byte(cfg.BulkDelete.ConfirmationKey)
+	if len(confirmationKey) == 0 {
+		slog.Warn("bulk_delete.confirmation_key is not set; bulk delete confirmation tokens are only valid on this instance")
+		confirmationKey = handler.NewConfirmationSecret()
+	}
+	apiHandler := handler.NewHandler(messageRepo, tokenRepo, auditRepo, tenantRepo, cfg.Token.DefaultTTL, confirmationKey, cfg.BulkDelete.ConfirmationTTL)
+	authMiddleware := middleware.NewAuthMiddleware(tokenRepo).WithObserver(serviceMetrics)
+
+	// Ginルーターの設定
+	// ハンドラーのスパンやリクエストIDをgin.Context経由でリポジトリに引き継ぐため、リクエストのコンテキストを参照させる
+	router := gin.New()
+	router.ContextWithFallback = true
+	// otelginは処理の後にリクエストのコンテキストを戻すため、アクセスログはその内側で記録する
+	router.Use(tracing.GinMiddleware(health.LivenessPath, health.ReadinessPath))
+	router.Use(logging.RequestID(), logging.AccessLog(logger), logging.Recovery(logger))
+	router.Use(serviceMetrics.GinMiddleware())
+	router.Use(authMiddleware.RequireAuth(health.LivenessPath, health.ReadinessPath))
+	health.Register(router, readinessChecker)
+	api.RegisterHandlers(router, api.NewStrictHandler(apiHandler, []api.StrictMiddlewareFunc{
+		serviceMetrics.StrictMiddleware(),
+		tracing.StrictMiddleware(),
+		logging.StrictMiddleware(),
+		handler.ErrorResponseMiddleware(),
+	}))
+
+	// gRPCサーバーの設定
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		// 呼び出しのログにトークンIDを含めるため、ログは認証の後に記録する
+		grpc.ChainUnaryInterceptor(
+			authMiddleware.UnaryServerInterceptor(),
+			logging.UnaryServerInterceptor(logger),
+		),
+		grpc.ChainStreamInterceptor(
+			authMiddleware.StreamServerInterceptor(),
+			logging.StreamServerInterceptor(logger),
+		),
+	)
+	rpc.Register(grpcServer, messageRepo, tokenRepo, events, cfg.Token.DefaultTTL)
+
+	httpListener, err := net.Listen("tcp", cfg.Server.HTTPAddress)
+	if err != nil {
+		fatal("HTTP listen error", err)
+	}
+	grpcListener, err := net.Listen("tcp", cfg.Server.GRPCAddress)
+	if err != nil {
+		fatal("gRPC listen error", err)
+	}
+	slog.Info("Listening", slog.String("http_address", httpListener.Addr().String()), slog.String("grpc_address", grpcListener.Addr().String()))
+	servers := []graceful.Server{
+		// 一括登録などの長いリクエストが停止の開始を知れるようにする
+		graceful.NewHTTPServer(&http.Server{Handler: router, BaseContext: func(net.Listener) context.Context {
+			return graceful.WithStopping(context.Background(), ctx.Done())
+		}}, httpListener),
+		graceful.NewGRPCServer(grpcServer, grpcListener),
+	}
+	// メトリクスは認証なしで返すため、APIとは別のアドレスで待ち受ける
+	if cfg.Server.MetricsAddress != "" {
+		metricsListener, err := net.Listen("tcp", cfg.Server.MetricsAddress)
+		if err != nil {
+			fatal("Metrics listen error", err)
+		}
+		slog.Info("Serving metrics", slog.String("metrics_address", metricsListener.Addr().String()))
+		servers = append(servers, graceful.NewHTTPServer(&http.Server{Handler: serviceMetrics.Mux()}, metricsListener))
+	}
+
+	// 停止を開始したら購読を終了し、イベントを待っているストリームがサーバーの停止を妨げないようにする
+	go func() {
+		<-ctx.Done()
+		eventBroker.Close()
+	}()
+
+	// サーバー起動
+	// 停止時は/readyzを失敗させてからshutdown_delayの間待ち、新しいリクエストの受け付けを止めて処理中のリクエストとストリームの完了を待つ
+	serveErr := graceful.Run(ctx, cfg.Server.ShutdownDelay, cfg.Server.ShutdownTimeout, servers...)
+	if serveErr != nil {
+		slog.Error("Server error", slog.String("error", serveErr.Error()))
+	}
+
+	// 処理中のリクエストが無くなってから、バックグラウンド処理を止めてストレージを切断する
+	cancelBackground()
+	background.Wait()
+
+	closeCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := st.Close(closeCtx); err != nil {
+		slog.Error("Failed to close storage", slog.String("error", err.Error()))
+	}
+	if err := shutdownTracing(closeCtx); err != nil {
+		slog.Error("Failed to flush traces", slog.String("error", err.Error()))
+	}
+
+	if serveErr != nil {
+		os.Exit(1)
+	}
+	slog.Info("Server stopped")
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"message-service/internal/config"
+	"message-service/internal/domain/audit"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/domain/token"
+	"message-service/internal/infrastructure/memory"
+	"message-service/internal/infrastructure/mongodb"
+	"message-service/internal/infrastructure/mongodb/repository"
+	"message-service/internal/infrastructure/sqldb"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// storage は設定されたストレージのリポジトリと接続
+// サーバーと管理コマンドで共通して使う
+type storage struct {
+	messages message.Repository
+	tokens   token.Repository
+	audit    audit.Repository
+	tenants  tenant.Repository
+
+	// mongo はstorage.typeがmongodbの場合の接続先
+	mongo *mongo.Database
+	// sql はstorage.typeがsqliteまたはpostgresの場合の接続先
+	sql *sqldb.DB
+}
+
+// openStorage は設定に従ってストレージに接続し、リポジトリを作成する
+// SQLのストレージは接続時に未適用のマイグレーションを適用する。MongoDBのインデックスは作成しない
+func openStorage(ctx context.Context, cfg *config.Config) (*storage, error) {
+	switch cfg.Storage.Type {
+	case config.StorageMemory:
+		// データはプロセス内にのみ保持され、再起動すると失われる
+		return &storage{
+			messages: memory.NewMessageRepository(),
+			tokens:   memory.NewTokenRepository(),
+			audit:    memory.NewAuditRepository(),
+			tenants:  memory.NewTenantRepository(),
+		}, nil
+	case config.StorageSQLite, config.StoragePostgres:
+		db, err := sqldb.Open(ctx, sqldb.Dialect(cfg.Storage.Type), cfg.Storage.DatabaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("SQL database connection error: %w", err)
+		}
+		return &storage{
+			messages: sqldb.NewMessageRepository(db),
+			tokens:   sqldb.NewTokenRepository(db),
+			audit:    sqldb.NewAuditRepository(db),
+			tenants:  sqldb.NewTenantRepository(db),
+			sql:      db,
+		}, nil
+	case config.StorageMongoDB:
+		db, err := connectMongo(ctx, cfg.MongoDB)
+		if err != nil {
+			return nil, err
+		}
+		return &storage{
+			messages: repository.NewMessageRepository(db),
+			tokens:   repository.NewTokenRepository(db),
+			audit:    repository.NewAuditRepository(db),
+			tenants:  repository.NewTenantRepository(db),
+			mongo:    db,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage type: %s", cfg.Storage.Type)
+	}
+}
+
+// Close はストレージとの接続を切断する
+func (s *storage) Close(ctx context.Context) error {
+	switch {
+	case s.mongo != nil:
+		return s.mongo.Client().Disconnect(ctx)
+	case s.sql != nil:
+		return s.sql.Close()
+	default:
+		return nil
+	}
+}
+
+// connectMongo は設定に従ってMongoDBに接続する
+func connectMongo(ctx context.Context, cfg config.MongoDBConfig) (*mongo.Database, error) {
+	opts := options.Client().
+		ApplyURI(cfg.URI).
+		SetTimeout(cfg.Timeout).
+		SetServerSelectionTimeout(cfg.ServerSelectionTimeout)
+
+	client, err := mongo.Connect(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("MongoDB connection error: %w", err)
+	}
+	return client.Database(cfg.Name), nil
+}
+
+// syncIndexes は宣言されたインデックスを作成し、実際のインデックスとの差分を報告する
+// 大きなコレクションではインデックスの作成に時間がかかるため、skipの場合は報告のみ行う
+func syncIndexes(ctx context.Context, db *mongo.Database, skip bool) error {
+	check := mongodb.SyncIndexes
+	if skip {
+		check = mongodb.CheckIndexes
+	}
+	reports, err := check(ctx, mongodb.NewDatabase(db))
+	if err != nil {
+		return err
+	}
+
+	for _, report := range reports {
+		if len(report.Created) > 0 {
+			slog.Info("Created indexes", slog.String("collection", report.Collection), slog.Any("indexes", report.Created))
+		}
+		if len(report.Missing) > 0 {
+			slog.Warn("Index drift: missing indexes (index sync skipped)", slog.String("collection", report.Collection), slog.Any("indexes", report.Missing))
+		}
+		if len(report.Conflicting) > 0 {
+			slog.Warn("Index drift: options differ from declaration", slog.String("collection", report.Collection), slog.Any("indexes", report.Conflicting))
+		}
+		if len(report.Extra) > 0 {
+			slog.Warn("Index drift: undeclared indexes", slog.String("collection", report.Collection), slog.Any("indexes", report.Extra))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"message-service/internal/domain/tenant"
+	"text/tabwriter"
+	"time"
+)
+
+// runTenant はtenantサブコマンドを実行する
+//
+//	tenant create --id ID --name NAME [--max-messages N] [--max-tokens N]  テナントを作成する
+//	tenant list                                                            テナントの一覧を表示する
+//	tenant update [--name NAME] [--max-messages N] [--max-tokens N] <id>   テナントの名前と上限を変更する
+//
+// 上限の0は無制限を表す
+func runTenant(ctx context.Context, repo tenant.Repository, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: tenant requires a subcommand (create, list or update)", errUsage)
+	}
+
+	switch args[0] {
+	case "create":
+		fs := newFlagSet("tenant create")
+		id := fs.String("id", "", "テナントID（英小文字、数字、ハイフン）")
+		name := fs.String("name", "", "テナントの名前")
+		quota := addQuotaFlags(fs)
+		if err := parseFlags(fs, args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() > 0 {
+			return fmt.Errorf("%w: unexpected arguments %v", errUsage, fs.Args())
+		}
+
+		t := &tenant.Tenant{ID: *id, Name: *name}
+		quota.apply(fs, &t.Quota)
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", errUsage, err)
+		}
+		if err := repo.Create(ctx, t); err != nil {
+			return fmt.Errorf("failed to create tenant %s: %w", t.ID, err)
+		}
+		_, err := fmt.Fprintf(out, "Created tenant %s\n", t.ID)
+		return err
+
+	case "list":
+		if len(args) > 1 {
+			return fmt.Errorf("%w: tenant list takes no arguments", errUsage)
+		}
+		tenants, err := repo.List(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tMAX_MESSAGES\tMAX_TOKENS\tCREATED_AT")
+		for _, t := range tenants {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\n",
+				t.ID,
+				t.Name,
+				t.Quota.MaxMessages,
+				t.Quota.MaxTokens,
+				t.CreatedAt.Format(time.RFC3339),
+			)
+		}
+		return w.Flush()
+
+	case "update":
+		fs := newFlagSet("tenant update")
+		name := fs.String("name", "", "テナントの名前（省略時は変更しない）")
+		quota := addQuotaFlags(fs)
+		if err := parseFlags(fs, args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return fmt.Errorf("%w: tenant update requires exactly one tenant ID", errUsage)
+		}
+
+		id := fs.Arg(0)
+		t, err := repo.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return fmt.Errorf("tenant %s not found", id)
+		}
+		if *name != "" {
+			t.Name = *name
+		}
+		quota.apply(fs, &t.Quota)
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", errUsage, err)
+		}
+		if err := repo.Update(ctx, t); err != nil {
+			return fmt.Errorf("failed to update tenant %s: %w", id, err)
+		}
+		_, err = fmt.Fprintf(out, "Updated tenant %s\n", id)
+		return err
+
+	default:
+		return fmt.Errorf("%w: unknown tenant subcommand %q", errUsage, args[0])
+	}
+}
+
+// quotaFlags はテナントの上限を指定するフラグ
+type quotaFlags struct {
+	maxMessages int64
+	maxTokens   int64
+}
+
+func addQuotaFlags(fs *flag.FlagSet) *quotaFlags {
+	f := &quotaFlags{}
+	fs.Int64Var(&f.maxMessages, "max-messages", 0, "有効なメッセージ数の上限（0は無制限）")
+	fs.Int64Var(&f.maxTokens, "max-tokens", 0, "有効なトークン数の上限（0は無制限）")
+	return f
+}
+
+// apply は指定されたフラグの上限のみをquotaに反映する
+func (f *quotaFlags) apply(fs *flag.FlagSet, quota *tenant.Quota) {
+	fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "max-messages":
+			quota.MaxMessages = f.maxMessages
+		case "max-tokens":
+			quota.MaxTokens = f.maxTokens
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"message-service/internal/domain/tenant"
+	"message-service/internal/infrastructure/memory"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunTenant(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		checkOutput   func(t *testing.T, out string, repo *memory.TenantRepository)
+		expectedError error
+		errorContains string
+	}{
+		{
+			name: "正常系：上限を指定してテナントを作成する",
+			args: []string{"create", "--id", "globex", "--name", "Globex", "--max-messages", "1000"},
+			checkOutput: func(t *testing.T, out string, repo *memory.TenantRepository) {
+				assert.Contains(t, out, "Created tenant globex")
+				created, err := repo.Get(context.Background(), "globex")
+				assert.NoError(t, err)
+				if assert.NotNil(t, created) {
+					assert.Equal(t, tenant.Quota{MaxMessages: 1000}, created.Quota)
+				}
+			},
+		},
+		{
+			name: "正常系：一覧を表示する",
+			args: []string{"list"},
+			checkOutput: func(t *testing.T, out string, repo *memory.TenantRepository) {
+				assert.Contains(t, out, "MAX_MESSAGES")
+				assert.Contains(t, out, tenant.DefaultID)
+				assert.Contains(t, out, "acme")
+			},
+		},
+		{
+			name: "正常系：指定した上限のみ変更する",
+			args: []string{"update", "--max-tokens", "0", "acme"},
+			checkOutput: func(t *testing.T, out string, repo *memory.TenantRepository) {
+				assert.Contains(t, out, "Updated tenant acme")
+				updated, err := repo.Get(context.Background(), "acme")
+				assert.NoError(t, err)
+				if assert.NotNil(t, updated) {
+					assert.Equal(t, "Acme", updated.Name)
+					assert.Equal(t, tenant.Quota{MaxMessages: 100, MaxTokens: 0}, updated.Quota)
+				}
+			},
+		},
+		{
+			name:          "異常系：既に存在するテナントを作成する",
+			args:          []string{"create", "--id", "acme", "--name", "Acme"},
+			errorContains: "failed to create tenant acme",
+		},
+		{
+			name:          "異常系：名前が無い",
+			args:          []string{"create", "--id", "globex"},
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：負の上限",
+			args:          []string{"update", "--max-messages", "-1", "acme"},
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：存在しないテナントを変更する",
+			args:          []string{"update", "--name", "Unknown", "unknown"},
+			errorContains: "tenant unknown not found",
+		},
+		{
+			name:          "異常系：変更するテナントIDが無い",
+			args:          []string{"update", "--name", "Acme"},
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：サブコマンドが無い",
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：不明なサブコマンド",
+			args:          []string{"delete"},
+			expectedError: errUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewTenantRepository()
+			assert.NoError(t, repo.Create(context.Background(), &tenant.Tenant{ID: "acme", Name: "Acme", Quota: tenant.Quota{MaxMessages: 100, MaxTokens: 5}}))
+			var out bytes.Buffer
+
+			err := runTenant(context.Background(), repo, tt.args, &out)
+
+			switch {
+			case tt.expectedError != nil:
+				assert.ErrorIs(t, err, tt.expectedError)
+			case tt.errorContains != "":
+				assert.ErrorContains(t, err, tt.errorContains)
+			default:
+				assert.NoError(t, err)
+				tt.checkOutput(t, out.String(), repo)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"message-service/internal/adapter/handler"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/domain/token"
+	"text/tabwriter"
+	"time"
+)
+
+// createdToken はtoken createで表示するトークン
+// トークン文字列は作成時にしか表示されない
+type createdToken struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Token      string    `json:"token"`
+	TenantID   string    `json:"tenant_id"`
+	Admin      bool      `json:"admin"`
+	SuperAdmin bool      `json:"super_admin"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// runToken はtokenサブコマンドを実行する
+//
+//	token create [--name NAME] [--ttl DURATION] [--admin|--super-admin]  トークンを作成し、トークン文字列をJSONで表示する
+//	token list                                                           トークンの一覧を表示する。トークン文字列は表示しない
+//	token revoke <id>                                                    トークンを無効にする
+//
+// いずれも--tenantで指定したテナント（省略時は既定のテナント）のトークンを対象とする
+// 全てのテナントを管理できるトークン（--super-admin）はAPIからは発行できず、このコマンドでのみ作成できる
+func runToken(ctx context.Context, repo token.Repository, tenants tenant.Repository, defaultTTL time.Duration, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: token requires a subcommand (create, list or revoke)", errUsage)
+	}
+
+	switch args[0] {
+	case "create":
+		fs := newFlagSet("token create")
+		name := fs.String("name", "", "トークンの名前")
+		ttl := fs.Duration("ttl", defaultTTL, "トークンの有効期間")
+		admin := fs.Bool("admin", false, "所属するテナントを管理できるトークンとする")
+		superAdmin := fs.Bool("super-admin", false, "全てのテナントを管理できるトークンとする")
+		tenantID := addTenantFlag(fs)
+		if err := parseFlags(fs, args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() > 0 {
+			return fmt.Errorf("%w: unexpected arguments %v", errUsage, fs.Args())
+		}
+		if *ttl <= 0 {
+			return fmt.Errorf("%w: --ttl must be positive", errUsage)
+		}
+		if *admin && *superAdmin {
+			return fmt.Errorf("%w: --admin and --super-admin are mutually exclusive", errUsage)
+		}
+		ctx, err := withTenant(ctx, *tenantID)
+		if err != nil {
+			return err
+		}
+		// 存在しないテナントのトークンはどのテナントからも管理できないため作成しない
+		t, err := tenants.Get(ctx, *tenantID)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return fmt.Errorf("tenant %s not found", *tenantID)
+		}
+
+		tokens := handler.NewTokenHandler(repo, defaultTTL)
+		var tkn *token.Token
+		if *superAdmin {
+			tkn, err = tokens.IssueSuperAdmin(ctx, *name, *ttl)
+		} else {
+			tkn, err = tokens.Issue(ctx, *name, *ttl, *admin)
+		}
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(createdToken{
+			ID:         tkn.ID.Hex(),
+			Name:       tkn.Name,
+			Token:      tkn.Token,
+			TenantID:   tkn.TenantID,
+			Admin:      tkn.Admin,
+			SuperAdmin: tkn.SuperAdmin,
+			ExpiresAt:  tkn.ExpiresAt,
+		})
+
+	case "list":
+		fs := newFlagSet("token list")
+		tenantID := addTenantFlag(fs)
+		if err := parseFlags(fs, args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() > 0 {
+			return fmt.Errorf("%w: token list takes no arguments", errUsage)
+		}
+		ctx, err := withTenant(ctx, *tenantID)
+		if err != nil {
+			return err
+		}
+		tokens, err := repo.List(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tADMIN\tSUPER_ADMIN\tEXPIRES_AT\tCREATED_AT")
+		for _, tkn := range tokens {
+			fmt.Fprintf(w, "%s\t%s\t%t\t%t\t%s\t%s\n",
+				tkn.ID.Hex(),
+				tkn.Name,
+				tkn.Admin,
+				tkn.SuperAdmin,
+				tkn.ExpiresAt.Format(time.RFC3339),
+				tkn.CreatedAt.Format(time.RFC3339),
+			)
+		}
+		return w.Flush()
+
+	case "revoke":
+		fs := newFlagSet("token revoke")
+		tenantID := addTenantFlag(fs)
+		if err := parseFlags(fs, args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return fmt.Errorf("%w: token revoke requires exactly one token ID", errUsage)
+		}
+		ctx, err := withTenant(ctx, *tenantID)
+		if err != nil {
+			return err
+		}
+		id := fs.Arg(0)
+		if err := repo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to revoke token %s: %w", id, err)
+		}
+		_, err = fmt.Fprintf(out, "Revoked token %s\n", id)
+		return err
+
+	default:
+		return fmt.Errorf("%w: unknown token subcommand %q", errUsage, args[0])
+	}
+}
+
+// bootstrapTokenName はtoken.bootstrap_tokenから作成するトークンの名前
+const bootstrapTokenName = "bootstrap"
+
+// createBootstrapToken は設定されたトークン文字列で、既定のテナントに全てのテナントを管理できるトークンを作成する
+// 管理コマンドを使えないインメモリストレージで、最初のトークンを用意するために起動時に呼ぶ
+func createBootstrapToken(ctx context.Context, repo token.Repository, value string, ttl time.Duration) (*token.Token, error) {
+	tkn := &token.Token{
+		Token:      value,
+		Name:       bootstrapTokenName,
+		Admin:      true,
+		SuperAdmin: true,
+		ExpiresAt:  time.Now().Add(ttl),
+	}
+	if err := repo.Create(tenant.WithID(ctx, tenant.DefaultID), tkn); err != nil {
+		return nil, err
+	}
+	return tkn, nil
+}
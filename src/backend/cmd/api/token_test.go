@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"message-service/internal/domain/tenant"
+	"message-service/internal/domain/token"
+	"message-service/internal/infrastructure/memory"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRunToken(t *testing.T) {
+	existing := &token.Token{Token: "secret-token", Name: "existing", ExpiresAt: time.Now().Add(time.Hour)}
+
+	tests := []struct {
+		name          string
+		args          func(existingID string) []string
+		checkOutput   func(t *testing.T, out string, repo *memory.TokenRepository)
+		expectedError error
+		errorContains string
+	}{
+		{
+			name: "正常系：名前と有効期間を指定してトークンを作成する",
+			args: func(string) []string { return []string{"create", "--name", "ci", "--ttl", "2h"} },
+			checkOutput: func(t *testing.T, out string, repo *memory.TokenRepository) {
+				var created createdToken
+				if !assert.NoError(t, json.Unmarshal([]byte(out), &created)) {
+					return
+				}
+				assert.Equal(t, "ci", created.Name)
+				assert.NotEmpty(t, created.Token)
+				assert.WithinDuration(t, time.Now().Add(2*time.Hour), created.ExpiresAt, time.Minute)
+				// 作成したトークンで認証できる
+				tkn, err := repo.FindByToken(context.Background(), created.Token)
+				assert.NoError(t, err)
+				if assert.NotNil(t, tkn) {
+					assert.Equal(t, created.ID, tkn.ID.Hex())
+				}
+			},
+		},
+		{
+			name: "正常系：テナントを指定して管理用のトークンを作成する",
+			args: func(string) []string { return []string{"create", "--tenant", "acme", "--admin"} },
+			checkOutput: func(t *testing.T, out string, repo *memory.TokenRepository) {
+				var created createdToken
+				if !assert.NoError(t, json.Unmarshal([]byte(out), &created)) {
+					return
+				}
+				assert.Equal(t, "acme", created.TenantID)
+				assert.True(t, created.Admin)
+				tkn, err := repo.FindByToken(context.Background(), created.Token)
+				assert.NoError(t, err)
+				if assert.NotNil(t, tkn) {
+					assert.Equal(t, "acme", tkn.TenantID)
+				}
+			},
+		},
+		{
+			name: "正常系：全てのテナントを管理できるトークンを作成する",
+			args: func(string) []string { return []string{"create", "--name", "operator", "--super-admin"} },
+			checkOutput: func(t *testing.T, out string, repo *memory.TokenRepository) {
+				var created createdToken
+				if !assert.NoError(t, json.Unmarshal([]byte(out), &created)) {
+					return
+				}
+				assert.True(t, created.SuperAdmin)
+				tkn, err := repo.FindByToken(context.Background(), created.Token)
+				assert.NoError(t, err)
+				if assert.NotNil(t, tkn) {
+					assert.True(t, tkn.SuperAdmin)
+				}
+			},
+		},
+		{
+			name:          "異常系：--adminと--super-adminは同時に指定できない",
+			args:          func(string) []string { return []string{"create", "--admin", "--super-admin"} },
+			expectedError: errUsage,
+		},
+		{
+			name: "正常系：他のテナントのトークンは一覧に表示しない",
+			args: func(string) []string { return []string{"list", "--tenant", "acme"} },
+			checkOutput: func(t *testing.T, out string, repo *memory.TokenRepository) {
+				assert.Contains(t, out, "ADMIN")
+				assert.NotContains(t, out, "existing")
+			},
+		},
+		{
+			name:          "異常系：他のテナントのトークンは無効にできない",
+			args:          func(id string) []string { return []string{"revoke", "--tenant", "acme", id} },
+			errorContains: "failed to revoke token",
+		},
+		{
+			name:          "異常系：存在しないテナントにはトークンを作成できない",
+			args:          func(string) []string { return []string{"create", "--tenant", "unknown"} },
+			errorContains: "tenant unknown not found",
+		},
+		{
+			name:          "異常系：不正なテナントID",
+			args:          func(string) []string { return []string{"list", "--tenant", "Acme"} },
+			expectedError: errUsage,
+		},
+		{
+			name: "正常系：有効期間を省略すると既定値を使う",
+			args: func(string) []string { return []string{"create"} },
+			checkOutput: func(t *testing.T, out string, repo *memory.TokenRepository) {
+				var created createdToken
+				assert.NoError(t, json.Unmarshal([]byte(out), &created))
+				assert.WithinDuration(t, time.Now().Add(24*time.Hour), created.ExpiresAt, time.Minute)
+			},
+		},
+		{
+			name: "正常系：一覧にはトークン文字列を表示しない",
+			args: func(string) []string { return []string{"list"} },
+			checkOutput: func(t *testing.T, out string, repo *memory.TokenRepository) {
+				assert.Contains(t, out, "ID")
+				assert.Contains(t, out, "existing")
+				assert.NotContains(t, out, "secret-token")
+			},
+		},
+		{
+			name: "正常系：トークンを無効にする",
+			args: func(id string) []string { return []string{"revoke", id} },
+			checkOutput: func(t *testing.T, out string, repo *memory.TokenRepository) {
+				assert.Contains(t, out, "Revoked token")
+				tkn, err := repo.FindByToken(context.Background(), "secret-token")
+				assert.NoError(t, err)
+				assert.Nil(t, tkn)
+			},
+		},
+		{
+			name:          "異常系：存在しないトークンを無効にする",
+			args:          func(string) []string { return []string{"revoke", primitive.NewObjectID().Hex()} },
+			errorContains: "failed to revoke token",
+		},
+		{
+			name:          "異常系：サブコマンドが無い",
+			args:          func(string) []string { return nil },
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：不明なサブコマンド",
+			args:          func(string) []string { return []string{"rotate"} },
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：有効期間が0以下",
+			args:          func(string) []string { return []string{"create", "--ttl", "0s"} },
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：無効にするトークンIDが無い",
+			args:          func(string) []string { return []string{"revoke"} },
+			expectedError: errUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewTokenRepository()
+			tkn := *existing
+			assert.NoError(t, repo.Create(tenant.WithID(context.Background(), tenant.DefaultID), &tkn))
+			tenants := memory.NewTenantRepository()
+			assert.NoError(t, tenants.Create(context.Background(), &tenant.Tenant{ID: "acme", Name: "Acme"}))
+			var out bytes.Buffer
+
+			err := runToken(context.Background(), repo, tenants, 24*time.Hour, tt.args(tkn.ID.Hex()), &out)
+
+			switch {
+			case tt.expectedError != nil:
+				assert.ErrorIs(t, err, tt.expectedError)
+			case tt.errorContains != "":
+				assert.ErrorContains(t, err, tt.errorContains)
+			default:
+				assert.NoError(t, err)
+				tt.checkOutput(t, out.String(), repo)
+			}
+		})
+	}
+}
+
+func TestCreateBootstrapToken(t *testing.T) {
+	t.Run("正常系：設定したトークン文字列で運用者用トークンを作成する", func(t *testing.T) {
+		repo := memory.NewTokenRepository()
+
+		created, err := createBootstrapToken(context.Background(), repo, "demo-bootstrap-token", time.Hour)
+
+		assert.NoError(t, err)
+		tkn, err := repo.FindByToken(context.Background(), "demo-bootstrap-token")
+		assert.NoError(t, err)
+		if assert.NotNil(t, tkn) {
+			assert.Equal(t, created.ID, tkn.ID)
+			assert.Equal(t, tenant.DefaultID, tkn.TenantID)
+			assert.True(t, tkn.SuperAdmin)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), tkn.ExpiresAt, time.Minute)
+		}
+	})
+
+	t.Run("異常系：同じトークン文字列のトークンが既にある", func(t *testing.T) {
+		repo := memory.NewTokenRepository()
+		_, err := createBootstrapToken(context.Background(), repo, "demo-bootstrap-token", time.Hour)
+		assert.NoError(t, err)
+
+		_, err = createBootstrapToken(context.Background(), repo, "demo-bootstrap-token", time.Hour)
+
+		assert.ErrorIs(t, err, token.ErrDuplicateToken)
+	})
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// config は設定ファイルの内容
+//
+//	default_profile: local
+//	profiles:
+//	  local:
+//	    server: http://localhost:8080
+//	    token: <トークン>
+//	  prod:
+//	    server: https://messages.example.com
+//	    token: <トークン>
+type config struct {
+	DefaultProfile string             `yaml:"default_profile"`
+	Profiles       map[string]profile `yaml:"profiles"`
+}
+
+// profile は接続先と認証トークンの組
+type profile struct {
+	Server string `yaml:"server"`
+	Token  string `yaml:"token"`
+}
+
+// defaultConfigPath は既定の設定ファイルのパスを返す
+func defaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "msgcli", "config.yaml"), nil
+}
+
+// loadProfile は設定ファイルからプロファイルを読み込む
+// pathが空の場合は既定のパスを使い、既定の設定ファイルが無い場合は空のプロファイルを返す
+// nameが空の場合はdefault_profile、それも無い場合は"default"を使う
+func loadProfile(path, name string) (profile, error) {
+	explicit := path != ""
+	if !explicit {
+		p, err := defaultConfigPath()
+		if err != nil {
+			// 設定ディレクトリが無い環境ではフラグのみで接続先を指定する
+			if name != "" {
+				return profile{}, fmt.Errorf("failed to locate config file: %w", err)
+			}
+			return profile{}, nil
+		}
+		path = p
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit && name == "" {
+		return profile{}, nil
+	}
+	if err != nil {
+		return profile{}, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return profile{}, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	if name == "" {
+		name = cfg.DefaultProfile
+	}
+	if name == "" {
+		name = "default"
+	}
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		return profile{}, fmt.Errorf("profile %q not found in %s", name, path)
+	}
+	return p, nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadProfile(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	withDefault := writeConfig("default.yaml", `
+default_profile: prod
+profiles:
+  local:
+    server: http://localhost:8080
+    token: local-token
+  prod:
+    server: https://messages.example.com
+    token: prod-token
+`)
+	withoutDefault := writeConfig("nodefault.yaml", `
+profiles:
+  default:
+    server: http://localhost:8080
+`)
+	invalid := writeConfig("invalid.yaml", "profiles: [")
+
+	tests := []struct {
+		name          string
+		path          string
+		profile       string
+		expected      profile
+		errorContains string
+	}{
+		{
+			name:     "正常系：default_profileを使う",
+			path:     withDefault,
+			expected: profile{Server: "https://messages.example.com", Token: "prod-token"},
+		},
+		{
+			name:     "正常系：指定したプロファイルを使う",
+			path:     withDefault,
+			profile:  "local",
+			expected: profile{Server: "http://localhost:8080", Token: "local-token"},
+		},
+		{
+			name:     "正常系：default_profileが無い場合はdefaultを使う",
+			path:     withoutDefault,
+			expected: profile{Server: "http://localhost:8080"},
+		},
+		{
+			name:          "異常系：存在しないプロファイル",
+			path:          withDefault,
+			profile:       "staging",
+			errorContains: `profile "staging" not found`,
+		},
+		{
+			name:          "異常系：指定した設定ファイルが無い",
+			path:          filepath.Join(dir, "missing.yaml"),
+			errorContains: "failed to read config file",
+		},
+		{
+			name:          "異常系：設定ファイルの形式が不正",
+			path:          invalid,
+			errorContains: "failed to parse config file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := loadProfile(tt.path, tt.profile)
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, p)
+		})
+	}
+}
+
+func TestLoadProfile_DefaultPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	// 既定の設定ファイルが無い場合はフラグで接続先を指定できるよう空のプロファイルを返す
+	p, err := loadProfile("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, profile{}, p)
+
+	// プロファイルを指定した場合は設定ファイルが必要
+	_, err = loadProfile("", "prod")
+	assert.ErrorContains(t, err, "failed to read config file")
+
+	path, err := defaultConfigPath()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	assert.NoError(t, os.WriteFile(path, []byte("profiles:\n  default:\n    server: http://localhost:8080\n"), 0o600))
+	p, err = loadProfile("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080", p.Server)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// newFlagSet はコマンドのフラグを解析するFlagSetを作成する
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+// parseFlags はフラグを解析し、不正な場合はerrUsageを返す
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	return nil
+}
+
+// stringsFlag は繰り返し指定できる文字列のフラグ
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// ptr は空でない場合に値のポインタを返す
+func (f stringsFlag) ptr() *[]string {
+	if len(f) == 0 {
+		return nil
+	}
+	return (*[]string)(&f)
+}
+
+// optionalBoolFlag は指定された場合のみ値を持つ真偽値のフラグ
+// 値を省略した場合はtrueとなり、--flag=falseで明示的にfalseを指定できる
+type optionalBoolFlag struct {
+	value *bool
+}
+
+func (f *optionalBoolFlag) String() string {
+	if f.value == nil {
+		return ""
+	}
+	return strconv.FormatBool(*f.value)
+}
+
+func (f *optionalBoolFlag) Set(value string) error {
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	f.value = &v
+	return nil
+}
+
+func (f *optionalBoolFlag) IsBoolFlag() bool {
+	return true
+}
+
+// noArgs はフラグ以外の引数が無いことを確認する
+func noArgs(fs *flag.FlagSet) error {
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", errUsage, fs.Args())
+	}
+	return nil
+}
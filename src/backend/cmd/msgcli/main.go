@@ -0,0 +1,124 @@
+// msgcli はメッセージサービスのHTTP APIを呼び出すコマンドラインクライアント
+//
+// 接続先とトークンは設定ファイルのプロファイルから読み込む（config.goを参照）
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"message-service/pkg/client"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// usage はサブコマンドの一覧
+const usage = `Usage: msgcli [--config FILE] [--profile NAME] [--server URL] [--token TOKEN] <command>
+
+Commands:
+  post --channel-id --sender [--uid] [--sent-at] [--ttl]  標準入力の内容をメッセージとして投稿する
+  tail --channel-id [--since] [--interval] [--format]     チャンネルの新しいメッセージを表示し続ける
+  search [filters] [--sort] [--limit] [--format]          条件に一致するメッセージを表示する
+  token create [--name] [--ttl]                           トークンを作成し、トークン文字列を表示する
+  token list                                              トークンの一覧を表示する（管理用トークンが必要）
+  token revoke <id>                                       トークンを無効にする（管理用トークンが必要）
+
+共通のフラグは "msgcli --help"、コマンドのフラグは "msgcli <command> --help" で表示します。
+`
+
+// errUsage はコマンドの引数が不正な場合のエラー
+var errUsage = errors.New("invalid arguments")
+
+func main() {
+	// tailなどの長い処理はSIGINTで中断する
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Getenv, os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run はコマンドを実行し、終了コードを返す
+func run(ctx context.Context, args []string, getenv func(string) string, in io.Reader, out, errOut io.Writer) int {
+	err := runCommand(ctx, args, getenv, in, out)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		// フラグの説明はFlagSetが表示済み
+		return 0
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(errOut, "%v\n\n%s", err, usage)
+		return 2
+	case errors.Is(err, context.Canceled):
+		// SIGINTでtailを終了した場合は正常終了とする
+		return 0
+	default:
+		fmt.Fprintf(errOut, "msgcli: %v\n", err)
+		return 1
+	}
+}
+
+func runCommand(ctx context.Context, args []string, getenv func(string) string, in io.Reader, out io.Writer) error {
+	fs := newFlagSet("msgcli")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage+"\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	configPath := fs.String("config", getenv("MSGCLI_CONFIG"), "設定ファイル（省略時は $HOME/.config/msgcli/config.yaml）")
+	profileName := fs.String("profile", getenv("MSGCLI_PROFILE"), "使用するプロファイル（省略時は設定ファイルのdefault_profile）")
+	server := fs.String("server", "", "接続先のURL（プロファイルの値を上書きする）")
+	token := fs.String("token", getenv("MSGCLI_TOKEN"), "認証トークン（プロファイルの値を上書きする）")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("%w: command is required", errUsage)
+	}
+	command, args := fs.Arg(0), fs.Args()[1:]
+
+	profile, err := loadProfile(*configPath, *profileName)
+	if err != nil {
+		return err
+	}
+	if *server != "" {
+		profile.Server = *server
+	}
+	if *token != "" {
+		profile.Token = *token
+	}
+	// コマンドのフラグの説明は接続先が無くても表示する
+	if profile.Server == "" && !helpRequested(args) {
+		return fmt.Errorf("%w: no server configured; set server in the profile or use --server", errUsage)
+	}
+	c, err := client.New(profile.Server, client.WithToken(profile.Token))
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "post":
+		return runPost(ctx, c, args, in, out)
+	case "tail":
+		return runTail(ctx, c, args, out)
+	case "search":
+		return runSearch(ctx, c, args, out)
+	case "token":
+		return runToken(ctx, c, args, out)
+	default:
+		return fmt.Errorf("%w: unknown command %q", errUsage, command)
+	}
+}
+
+// helpRequested はコマンドの引数でフラグの説明が求められているかを返す
+func helpRequested(args []string) bool {
+	for _, arg := range args {
+		switch arg {
+		case "--":
+			return false
+		case "-h", "-help", "--h", "--help":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"message-service/internal/adapter/handler"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/domain/token"
+	"message-service/internal/infrastructure/memory"
+	"message-service/internal/infrastructure/middleware"
+	"message-service/pkg/api"
+	"message-service/pkg/client"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// testToken はテスト用のサーバーで有効な管理用のトークン
+const testToken = "test-token"
+
+// newTestServer はインメモリのストレージでサーバーと同じルーターを起動する
+// 返したリポジトリでCLIを介さずにメッセージを作成できる
+func newTestServer(t *testing.T) (*httptest.Server, message.Repository) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	messageRepo := memory.NewMessageRepository()
+	tokenRepo := memory.NewTokenRepository()
+	if err := tokenRepo.Create(tenant.WithID(context.Background(), tenant.DefaultID), &token.Token{
+		Token:     testToken,
+		Name:      "msgcli test",
+		Admin:     true,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	router := gin.New()
+	router.ContextWithFallback = true
+	router.Use(middleware.NewAuthMiddleware(tokenRepo).RequireAuth())
+	api.RegisterHandlers(router, api.NewStrictHandler(
+		handler.NewHandler(messageRepo, tokenRepo, memory.NewAuditRepository(), memory.NewTenantRepository(), time.Hour, handler.NewConfirmationSecret(), handler.DefaultConfirmationTTL),
+		[]api.StrictMiddlewareFunc{handler.ErrorResponseMiddleware()}))
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server, messageRepo
+}
+
+// newTestClient はテスト用のサーバーを呼び出すクライアントを作成する
+func newTestClient(t *testing.T, server *httptest.Server) *client.Client {
+	t.Helper()
+	c, err := client.New(server.URL, client.WithToken(testToken), client.WithRetryPolicy(client.RetryPolicy{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestRun(t *testing.T) {
+	server, _ := newTestServer(t)
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	config := "default_profile: local\nprofiles:\n" +
+		"  local:\n    server: " + server.URL + "\n    token: " + testToken + "\n" +
+		"  revoked:\n    server: " + server.URL + "\n    token: unknown\n"
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		args           []string
+		env            map[string]string
+		expectedCode   int
+		expectedOut    string
+		expectedErrOut string
+	}{
+		{
+			name:         "正常系：既定のプロファイルで接続する",
+			args:         []string{"--config", configPath, "token", "list"},
+			expectedCode: 0,
+			expectedOut:  "msgcli test",
+		},
+		{
+			name:         "正常系：環境変数で設定ファイルとプロファイルを指定する",
+			args:         []string{"--token", testToken, "token", "list"},
+			env:          map[string]string{"MSGCLI_CONFIG": configPath, "MSGCLI_PROFILE": "revoked"},
+			expectedCode: 0,
+			expectedOut:  "msgcli test",
+		},
+		{
+			name:         "正常系：設定ファイルを使わずにフラグで接続先を指定する",
+			args:         []string{"--server", server.URL, "--token", testToken, "token", "list"},
+			env:          map[string]string{"HOME": t.TempDir(), "XDG_CONFIG_HOME": ""},
+			expectedCode: 0,
+			expectedOut:  "msgcli test",
+		},
+		{
+			name:         "正常系：接続先が無くてもコマンドのフラグの説明を表示する",
+			args:         []string{"search", "--help"},
+			env:          map[string]string{"HOME": t.TempDir(), "XDG_CONFIG_HOME": ""},
+			expectedCode: 0,
+		},
+		{
+			name:           "異常系：無効なトークンはAPIのエラーを表示する",
+			args:           []string{"--config", configPath, "--profile", "revoked", "token", "list"},
+			expectedCode:   1,
+			expectedErrOut: "401",
+		},
+		{
+			name:           "異常系：存在しないプロファイル",
+			args:           []string{"--config", configPath, "--profile", "staging", "token", "list"},
+			expectedCode:   1,
+			expectedErrOut: `profile "staging" not found`,
+		},
+		{
+			name:           "異常系：接続先が無い",
+			args:           []string{"token", "list"},
+			env:            map[string]string{"HOME": t.TempDir(), "XDG_CONFIG_HOME": ""},
+			expectedCode:   2,
+			expectedErrOut: "no server configured",
+		},
+		{
+			name:           "異常系：コマンドが無い",
+			args:           []string{"--config", configPath},
+			expectedCode:   2,
+			expectedErrOut: "command is required",
+		},
+		{
+			name:           "異常系：不明なコマンド",
+			args:           []string{"--config", configPath, "watch"},
+			expectedCode:   2,
+			expectedErrOut: "Usage: msgcli",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			var out, errOut bytes.Buffer
+
+			code := run(context.Background(), tt.args, os.Getenv, strings.NewReader(""), &out, &errOut)
+
+			assert.Equal(t, tt.expectedCode, code, errOut.String())
+			assert.Contains(t, out.String(), tt.expectedOut)
+			assert.Contains(t, errOut.String(), tt.expectedErrOut)
+		})
+	}
+}
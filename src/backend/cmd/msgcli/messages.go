@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"message-service/pkg/client"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// tailPageSize はtailで1回に取得する件数
+const tailPageSize = 100
+
+// runPost はpostコマンドを実行する
+//
+//	post --channel-id ID --sender NAME [--uid UID] [--sent-at TIME] [--ttl DURATION] [--reply-to UID]
+//
+// 標準入力の内容（末尾の改行を除く）を本文として投稿し、作成したメッセージをJSONで表示する
+func runPost(ctx context.Context, c *client.Client, args []string, in io.Reader, out io.Writer) error {
+	fs := newFlagSet("post")
+	channelID := fs.String("channel-id", "", "投稿先のチャンネルID（必須）")
+	sender := fs.String("sender", "", "送信者（必須）")
+	uid := fs.String("uid", "", "メッセージのUID（省略時はUUIDを生成する）")
+	sentAt := fs.String("sent-at", "", "送信日時（RFC3339、省略時は現在時刻）")
+	ttl := fs.Duration("ttl", 0, "有効期間。指定するとこの期間の経過後に削除される")
+	replyTo := fs.String("reply-to", "", "返信先のメッセージのUID")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+	if *channelID == "" || *sender == "" {
+		return fmt.Errorf("%w: --channel-id and --sender are required", errUsage)
+	}
+
+	body := client.MessageCreate{
+		Uid:       *uid,
+		ChannelId: *channelID,
+		Sender:    *sender,
+		SentAt:    time.Now().UTC(),
+	}
+	if body.Uid == "" {
+		body.Uid = uuid.NewString()
+	}
+	if *replyTo != "" {
+		body.ReplyTo = replyTo
+	}
+	if *sentAt != "" {
+		t, err := time.Parse(time.RFC3339, *sentAt)
+		if err != nil {
+			return fmt.Errorf("%w: --sent-at must be an RFC3339 time (got %q)", errUsage, *sentAt)
+		}
+		body.SentAt = t
+	}
+	if *ttl != 0 {
+		if *ttl < time.Second {
+			return fmt.Errorf("%w: --ttl must be at least 1s", errUsage)
+		}
+		seconds := int64(*ttl / time.Second)
+		body.TtlSeconds = &seconds
+	}
+
+	content, err := io.ReadAll(in)
+	if err != nil {
+		return fmt.Errorf("failed to read content from stdin: %w", err)
+	}
+	body.Content = strings.TrimSuffix(strings.TrimSuffix(string(content), "\n"), "\r")
+	if body.Content == "" {
+		return fmt.Errorf("%w: message content must be given on stdin", errUsage)
+	}
+
+	resp, err := c.PostApiMessagesWithResponse(ctx, body)
+	if err != nil {
+		return err
+	}
+	if resp.JSON201 == nil {
+		return &client.APIError{StatusCode: resp.StatusCode(), Body: resp.Body}
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(resp.JSON201)
+}
+
+// runSearch はsearchコマンドを実行する
+//
+//	search [--channel-id ID]... [--sender NAME]... [--sender-prefix P] [--exclude-sender NAME]...
+//	       [--is-reply[=false]] [--has-thread[=false]]
+//	       [--from TIME] [--to TIME] [--sort ORDER] [--limit N] [--format table|json|ndjson]
+//
+// ページを順に取得し、条件に一致するメッセージを全件（--limitを指定した場合はN件まで）表示する
+func runSearch(ctx context.Context, c *client.Client, args []string, out io.Writer) error {
+	fs := newFlagSet("search")
+	var channelIDs, senders, excludeSenders stringsFlag
+	fs.Var(&channelIDs, "channel-id", "チャンネルID（繰り返し指定するといずれかに一致）")
+	fs.Var(&senders, "sender", "送信者（繰り返し指定するといずれかに一致）")
+	senderPrefix := fs.String("sender-prefix", "", "送信者の前方一致")
+	fs.Var(&excludeSenders, "exclude-sender", "除外する送信者（繰り返し指定できる）")
+	var isReply, hasThread optionalBoolFlag
+	fs.Var(&isReply, "is-reply", "返信のみ（=falseで返信以外のみ）")
+	fs.Var(&hasThread, "has-thread", "返信があるメッセージのみ（=falseで返信が無いもののみ）")
+	from := fs.String("from", "", "送信日時の開始（RFC3339）")
+	to := fs.String("to", "", "送信日時の終了（RFC3339）")
+	sort := fs.String("sort", "", "並び順（sent_at、-sent_atまたはcreated_at）")
+	limit := fs.Int("limit", 0, "表示する最大件数（0は全件）")
+	format := fs.String("format", formatTable, "出力形式（table、jsonまたはndjson）")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+	if *limit < 0 {
+		return fmt.Errorf("%w: --limit must not be negative", errUsage)
+	}
+	if *format == formatText {
+		return fmt.Errorf("%w: unknown format %q", errUsage, *format)
+	}
+
+	params := client.GetApiMessagesSearchParams{
+		ChannelId:     channelIDs.ptr(),
+		Sender:        senders.ptr(),
+		ExcludeSender: excludeSenders.ptr(),
+		IsReply:       isReply.value,
+		HasThread:     hasThread.value,
+	}
+	if *senderPrefix != "" {
+		params.SenderPrefix = senderPrefix
+	}
+	if *sort != "" {
+		s := client.GetApiMessagesSearchParamsSort(*sort)
+		params.Sort = &s
+	}
+	for _, d := range []struct {
+		flag  string
+		value string
+		dst   **time.Time
+	}{
+		{"from", *from, &params.FromDate},
+		{"to", *to, &params.ToDate},
+	} {
+		if d.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, d.value)
+		if err != nil {
+			return fmt.Errorf("%w: --%s must be an RFC3339 time (got %q)", errUsage, d.flag, d.value)
+		}
+		*d.dst = &t
+	}
+	// 表示しない分まで取得しないよう、ページの件数を--limitに合わせる
+	if *limit > 0 && *limit < client.DefaultPageSize {
+		params.Limit = limit
+	}
+
+	w, err := newMessageWriter(*format, out)
+	if err != nil {
+		return err
+	}
+	n := 0
+	for msg, err := range c.SearchMessages(ctx, params) {
+		if err != nil {
+			return err
+		}
+		if err := w.Write(msg); err != nil {
+			return err
+		}
+		n++
+		if n == *limit {
+			break
+		}
+	}
+	return w.Close()
+}
+
+// runTail はtailコマンドを実行する
+//
+//	tail --channel-id ID [--since DURATION] [--interval DURATION] [--format text|ndjson]
+//
+// 送信日時が--since前以降のメッセージを作成順に表示し、以降は--intervalごとに新しいメッセージを確認して表示する
+// ctxがキャンセルされるまで終了しない
+func runTail(ctx context.Context, c *client.Client, args []string, out io.Writer) error {
+	fs := newFlagSet("tail")
+	channelID := fs.String("channel-id", "", "チャンネルID（必須）")
+	since := fs.Duration("since", 10*time.Minute, "開始時に表示する期間")
+	interval := fs.Duration("interval", 2*time.Second, "新しいメッセージを確認する間隔")
+	format := fs.String("format", formatText, "出力形式（textまたはndjson）")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+	if *channelID == "" {
+		return fmt.Errorf("%w: --channel-id is required", errUsage)
+	}
+	if *since < 0 || *interval <= 0 {
+		return fmt.Errorf("%w: --since must not be negative and --interval must be positive", errUsage)
+	}
+	if *format != formatText && *format != formatNDJSON {
+		return fmt.Errorf("%w: --format must be text or ndjson (got %q)", errUsage, *format)
+	}
+	w, err := newMessageWriter(*format, out)
+	if err != nil {
+		return err
+	}
+
+	from := time.Now().Add(-*since)
+	sort := client.CreatedAt
+	pageSize := tailPageSize
+	params := client.GetApiMessagesSearchParams{
+		ChannelId: &[]string{*channelID},
+		FromDate:  &from,
+		Sort:      &sort,
+		Limit:     &pageSize,
+	}
+	// seen は現在のカーソルで取得したページのうち、表示済みのメッセージのUID
+	seen := map[string]bool{}
+	for {
+		resp, err := c.GetApiMessagesSearchWithResponse(ctx, &params)
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return &client.APIError{StatusCode: resp.StatusCode(), Body: resp.Body}
+		}
+		for _, msg := range *resp.JSON200 {
+			if seen[deref(msg.Uid)] {
+				continue
+			}
+			seen[deref(msg.Uid)] = true
+			if err := w.Write(msg); err != nil {
+				return err
+			}
+		}
+
+		// 次のページは前のページの後から始まるため、表示済みのUIDは持ち越さない
+		if next := resp.HTTPResponse.Header.Get(client.NextCursorHeader); next != "" {
+			params.Cursor = &next
+			seen = map[string]bool{}
+			continue
+		}
+
+		// 最後のページは次の確認でも同じカーソルで取得し、後から作成されたメッセージのみを表示する
+		timer := time.NewTimer(*interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
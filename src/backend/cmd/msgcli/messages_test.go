@@ -0,0 +1,353 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/pkg/client"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// seedMessages はテスト用のメッセージを送信日時の順に作成する
+func seedMessages(t *testing.T, repo message.Repository) {
+	t.Helper()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, m := range []struct{ channel, sender, content, replyTo string }{
+		{"general", "alice", "hello", ""},
+		{"general", "bob", "multi\nline", "msg-a"},
+		{"random", "alice", "hi", ""},
+	} {
+		err := repo.Create(tenant.WithID(context.Background(), tenant.DefaultID), &message.Message{
+			UID:       "msg-" + string(rune('a'+i)),
+			SentAt:    base.Add(time.Duration(i) * time.Hour),
+			Sender:    m.sender,
+			ChannelID: m.channel,
+			Content:   m.content,
+			ReplyTo:   m.replyTo,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRunPost(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		stdin         string
+		check         func(t *testing.T, created client.Message)
+		expectedError error
+		errorContains string
+	}{
+		{
+			name:  "正常系：標準入力の内容を投稿する",
+			args:  []string{"--channel-id", "general", "--sender", "alice", "--uid", "msg-1", "--sent-at", "2024-01-01T00:00:00Z"},
+			stdin: "hello\nworld\n",
+			check: func(t *testing.T, created client.Message) {
+				assert.Equal(t, "msg-1", *created.Uid)
+				// 末尾の改行のみを除く
+				assert.Equal(t, "hello\nworld", *created.Content)
+				assert.True(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Equal(*created.SentAt))
+				assert.Nil(t, created.ExpiresAt)
+			},
+		},
+		{
+			name:  "正常系：UIDと送信日時を省略し、有効期間を指定する",
+			args:  []string{"--channel-id", "general", "--sender", "alice", "--ttl", "1h"},
+			stdin: "ephemeral",
+			check: func(t *testing.T, created client.Message) {
+				assert.NotEmpty(t, *created.Uid)
+				assert.WithinDuration(t, time.Now(), *created.SentAt, time.Minute)
+				if assert.NotNil(t, created.ExpiresAt) {
+					assert.WithinDuration(t, time.Now().Add(time.Hour), *created.ExpiresAt, time.Minute)
+				}
+			},
+		},
+		{
+			name:  "正常系：返信先を指定する",
+			args:  []string{"--channel-id", "general", "--sender", "bob", "--reply-to", "msg-a"},
+			stdin: "reply",
+			check: func(t *testing.T, created client.Message) {
+				if assert.NotNil(t, created.ReplyTo) {
+					assert.Equal(t, "msg-a", *created.ReplyTo)
+				}
+			},
+		},
+		{
+			name:          "異常系：チャンネルIDが無い",
+			args:          []string{"--sender", "alice"},
+			stdin:         "hello",
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：本文が空",
+			args:          []string{"--channel-id", "general", "--sender", "alice"},
+			stdin:         "\n",
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：送信日時の形式が不正",
+			args:          []string{"--channel-id", "general", "--sender", "alice", "--sent-at", "yesterday"},
+			stdin:         "hello",
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：UIDが重複する",
+			args:          []string{"--channel-id", "general", "--sender", "alice", "--uid", "msg-a"},
+			stdin:         "hello",
+			errorContains: "400",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, repo := newTestServer(t)
+			seedMessages(t, repo)
+			var out bytes.Buffer
+
+			err := runPost(context.Background(), newTestClient(t, server), tt.args, strings.NewReader(tt.stdin), &out)
+
+			switch {
+			case tt.expectedError != nil:
+				assert.ErrorIs(t, err, tt.expectedError)
+			case tt.errorContains != "":
+				assert.ErrorContains(t, err, tt.errorContains)
+			default:
+				if !assert.NoError(t, err) {
+					return
+				}
+				var created client.Message
+				assert.NoError(t, json.Unmarshal(out.Bytes(), &created))
+				tt.check(t, created)
+			}
+		})
+	}
+}
+
+func TestRunSearch(t *testing.T) {
+	server, repo := newTestServer(t)
+	seedMessages(t, repo)
+	c := newTestClient(t, server)
+
+	tests := []struct {
+		name          string
+		args          []string
+		check         func(t *testing.T, out string)
+		expectedError error
+		errorContains string
+	}{
+		{
+			name: "正常系：表形式で表示し、本文の改行をエスケープする",
+			args: []string{"--channel-id", "general"},
+			check: func(t *testing.T, out string) {
+				lines := strings.Split(strings.TrimSpace(out), "\n")
+				if assert.Len(t, lines, 3) {
+					assert.True(t, strings.HasPrefix(lines[0], "SENT_AT"))
+					assert.Contains(t, lines[1], "msg-a")
+					assert.Contains(t, lines[2], `multi\nline`)
+				}
+			},
+		},
+		{
+			name: "正常系：JSONの配列で表示する",
+			args: []string{"--sender", "alice", "--sort", "-sent_at", "--format", "json"},
+			check: func(t *testing.T, out string) {
+				var messages []client.Message
+				assert.NoError(t, json.Unmarshal([]byte(out), &messages))
+				if assert.Len(t, messages, 2) {
+					assert.Equal(t, "msg-c", *messages[0].Uid)
+					assert.Equal(t, "msg-a", *messages[1].Uid)
+				}
+			},
+		},
+		{
+			name: "正常系：一致しない場合は空の配列",
+			args: []string{"--channel-id", "unknown", "--format", "json"},
+			check: func(t *testing.T, out string) {
+				assert.JSONEq(t, "[]", out)
+			},
+		},
+		{
+			name: "正常系：NDJSONで件数を制限して表示する",
+			args: []string{"--limit", "2", "--format", "ndjson"},
+			check: func(t *testing.T, out string) {
+				lines := strings.Split(strings.TrimSpace(out), "\n")
+				if assert.Len(t, lines, 2) {
+					var msg client.Message
+					assert.NoError(t, json.Unmarshal([]byte(lines[1]), &msg))
+					assert.Equal(t, "msg-b", *msg.Uid)
+				}
+			},
+		},
+		{
+			name: "正常系：期間と除外する送信者で絞り込む",
+			args: []string{"--from", "2024-01-01T00:30:00Z", "--exclude-sender", "bob", "--format", "ndjson"},
+			check: func(t *testing.T, out string) {
+				assert.Equal(t, 1, strings.Count(out, "\n"))
+				assert.Contains(t, out, `"uid":"msg-c"`)
+			},
+		},
+		{
+			name: "正常系：返信があるメッセージのみ",
+			args: []string{"--has-thread", "--format", "ndjson"},
+			check: func(t *testing.T, out string) {
+				assert.Equal(t, 1, strings.Count(out, "\n"))
+				assert.Contains(t, out, `"uid":"msg-a"`)
+			},
+		},
+		{
+			name: "正常系：返信以外のメッセージのみ",
+			args: []string{"--is-reply=false", "--format", "ndjson"},
+			check: func(t *testing.T, out string) {
+				assert.Equal(t, 2, strings.Count(out, "\n"))
+				assert.NotContains(t, out, `"uid":"msg-b"`)
+			},
+		},
+		{
+			name:          "異常系：真偽値の形式が不正",
+			args:          []string{"--has-thread=maybe"},
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：不明な出力形式",
+			args:          []string{"--format", "yaml"},
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：日時の形式が不正",
+			args:          []string{"--to", "2024-01-01"},
+			expectedError: errUsage,
+		},
+		{
+			name:          "異常系：不正な並び順はAPIのエラーを返す",
+			args:          []string{"--sort", "-created_at"},
+			errorContains: "400",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			err := runSearch(context.Background(), c, tt.args, &out)
+
+			switch {
+			case tt.expectedError != nil:
+				assert.ErrorIs(t, err, tt.expectedError)
+			case tt.errorContains != "":
+				assert.ErrorContains(t, err, tt.errorContains)
+			default:
+				assert.NoError(t, err)
+				tt.check(t, out.String())
+			}
+		})
+	}
+}
+
+// syncBuffer はtailの書き込みとテストの読み出しを排他するバッファ
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestRunTail(t *testing.T) {
+	server, repo := newTestServer(t)
+	c := newTestClient(t, server)
+	create := func(uid, channel string, sentAt time.Time) {
+		t.Helper()
+		err := repo.Create(tenant.WithID(context.Background(), tenant.DefaultID), &message.Message{
+			UID: uid, SentAt: sentAt, Sender: "alice", ChannelID: channel, Content: "content of " + uid,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now()
+	create("old", "general", now.Add(-time.Hour))
+	create("recent", "general", now.Add(-time.Minute))
+	create("other", "random", now.Add(-time.Minute))
+	// 1ページの件数を超えるメッセージも取りこぼさない
+	for i := range tailPageSize + 5 {
+		create(fmt.Sprintf("bulk-%03d", i), "general", now.Add(-time.Minute))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var out syncBuffer
+	done := make(chan error, 1)
+	go func() {
+		done <- runTail(ctx, c, []string{"--channel-id", "general", "--since", "10m", "--interval", "10ms"}, &out)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return strings.Contains(out.String(), "bulk-104")
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// 開始後に作成されたメッセージを表示する
+	create("new-1", "general", now)
+	create("new-2", "general", now)
+	assert.Eventually(t, func() bool {
+		return strings.Contains(out.String(), "content of new-2")
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("tail did not stop after the context was canceled")
+	}
+
+	output := out.String()
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	// recent、bulk-000〜104、new-1、new-2を1回ずつ表示する
+	assert.Len(t, lines, 1+tailPageSize+5+2)
+	assert.NotContains(t, output, "content of old")
+	assert.NotContains(t, output, "content of other")
+	assert.Contains(t, lines[0], "content of recent")
+	assert.Contains(t, lines[len(lines)-1], "content of new-2")
+	assert.Equal(t, 1, strings.Count(output, "content of new-1"))
+}
+
+func TestRunTail_InvalidArgs(t *testing.T) {
+	server, _ := newTestServer(t)
+	c := newTestClient(t, server)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "異常系：チャンネルIDが無い", args: []string{}},
+		{name: "異常系：間隔が0", args: []string{"--channel-id", "general", "--interval", "0s"}},
+		{name: "異常系：表形式は使えない", args: []string{"--channel-id", "general", "--format", "table"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runTail(context.Background(), c, tt.args, &bytes.Buffer{})
+
+			assert.ErrorIs(t, err, errUsage)
+		})
+	}
+}
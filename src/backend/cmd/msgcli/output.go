@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"message-service/pkg/client"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// 出力形式
+const (
+	formatTable  = "table"
+	formatText   = "text"
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+)
+
+// messageWriter はメッセージを指定された形式で書き出す
+// Closeで書き出しを完了する
+type messageWriter interface {
+	Write(msg client.Message) error
+	Close() error
+}
+
+// newMessageWriter はformatの形式でoutに書き出すmessageWriterを作成する
+func newMessageWriter(format string, out io.Writer) (messageWriter, error) {
+	switch format {
+	case formatTable:
+		w := &tableWriter{w: tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)}
+		fmt.Fprintln(w.w, "SENT_AT\tCHANNEL_ID\tSENDER\tUID\tCONTENT")
+		return w, nil
+	case formatText:
+		return &textWriter{out: out}, nil
+	case formatJSON:
+		return &jsonWriter{out: out, messages: []client.Message{}}, nil
+	case formatNDJSON:
+		return &ndjsonWriter{enc: json.NewEncoder(out)}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q", errUsage, format)
+	}
+}
+
+// tableWriter は列を揃えた表を書き出す
+// 列幅を揃えるため、Closeまで出力を溜める
+type tableWriter struct {
+	w *tabwriter.Writer
+}
+
+func (t *tableWriter) Write(msg client.Message) error {
+	_, err := fmt.Fprintf(t.w, "%s\t%s\t%s\t%s\t%s\n",
+		formatTime(msg.SentAt), deref(msg.ChannelId), deref(msg.Sender), deref(msg.Uid), oneLine(deref(msg.Content)))
+	return err
+}
+
+func (t *tableWriter) Close() error {
+	return t.w.Flush()
+}
+
+// textWriter は1件ずつ1行で書き出す
+// tailのように出力を溜めずに表示する場合に使う
+type textWriter struct {
+	out io.Writer
+}
+
+func (t *textWriter) Write(msg client.Message) error {
+	_, err := fmt.Fprintf(t.out, "%s [%s] %s: %s\n",
+		formatTime(msg.SentAt), deref(msg.ChannelId), deref(msg.Sender), oneLine(deref(msg.Content)))
+	return err
+}
+
+func (t *textWriter) Close() error {
+	return nil
+}
+
+// jsonWriter はメッセージの配列を書き出す
+type jsonWriter struct {
+	out      io.Writer
+	messages []client.Message
+}
+
+func (j *jsonWriter) Write(msg client.Message) error {
+	j.messages = append(j.messages, msg)
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	enc := json.NewEncoder(j.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(j.messages)
+}
+
+// ndjsonWriter は1件ずつ1行のJSONで書き出す
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func (n *ndjsonWriter) Write(msg client.Message) error {
+	return n.enc.Encode(msg)
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// oneLine は改行をエスケープして1行にする
+func oneLine(s string) string {
+	return strings.NewReplacer("\r", `\r`, "\n", `\n`).Replace(s)
+}
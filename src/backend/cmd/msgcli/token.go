@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"message-service/pkg/client"
+	"net/http"
+	"text/tabwriter"
+	"time"
+)
+
+// runToken はtokenコマンドを実行する
+//
+//	token create [--name NAME] [--ttl DURATION]  トークンを作成し、トークン文字列をJSONで表示する
+//	token list                                   トークンの一覧を表示する。トークン文字列は表示しない
+//	token revoke <id>                            トークンを無効にする
+func runToken(ctx context.Context, c *client.Client, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: token requires a subcommand (create, list or revoke)", errUsage)
+	}
+
+	switch args[0] {
+	case "create":
+		fs := newFlagSet("token create")
+		name := fs.String("name", "", "トークンの名前")
+		ttl := fs.Duration("ttl", 0, "トークンの有効期間（省略時はサーバーの既定値）")
+		if err := parseFlags(fs, args[1:]); err != nil {
+			return err
+		}
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		body := client.TokenCreate{Name: *name}
+		if *ttl != 0 {
+			if *ttl < time.Second {
+				return fmt.Errorf("%w: --ttl must be at least 1s", errUsage)
+			}
+			seconds := int(*ttl / time.Second)
+			body.ExpiresIn = &seconds
+		}
+
+		resp, err := c.PostApiTokensWithResponse(ctx, body)
+		if err != nil {
+			return err
+		}
+		if resp.JSON201 == nil {
+			return &client.APIError{StatusCode: resp.StatusCode(), Body: resp.Body}
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(resp.JSON201)
+
+	case "list":
+		if len(args) > 1 {
+			return fmt.Errorf("%w: token list takes no arguments", errUsage)
+		}
+		resp, err := c.GetApiTokensWithResponse(ctx)
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return &client.APIError{StatusCode: resp.StatusCode(), Body: resp.Body}
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tEXPIRES_AT\tCREATED_AT")
+		for _, tkn := range *resp.JSON200 {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+				deref(tkn.Id),
+				deref(tkn.Name),
+				formatTime(tkn.ExpiresAt),
+				formatTime(tkn.CreatedAt),
+			)
+		}
+		return w.Flush()
+
+	case "revoke":
+		if len(args) != 2 {
+			return fmt.Errorf("%w: token revoke requires exactly one token ID", errUsage)
+		}
+		resp, err := c.DeleteApiTokensIdWithResponse(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode() != http.StatusNoContent {
+			return &client.APIError{StatusCode: resp.StatusCode(), Body: resp.Body}
+		}
+		_, err = fmt.Fprintf(out, "Revoked token %s\n", args[1])
+		return err
+
+	default:
+		return fmt.Errorf("%w: unknown token subcommand %q", errUsage, args[0])
+	}
+}
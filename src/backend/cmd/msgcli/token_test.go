@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"message-service/pkg/client"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunToken(t *testing.T) {
+	server, _ := newTestServer(t)
+	c := newTestClient(t, server)
+	ctx := context.Background()
+	var created client.TokenResponse
+
+	t.Run("正常系：名前と有効期間を指定してトークンを作成する", func(t *testing.T) {
+		var out bytes.Buffer
+
+		err := runToken(ctx, c, []string{"create", "--name", "on-call", "--ttl", "2h"}, &out)
+
+		assert.NoError(t, err)
+		if assert.NoError(t, json.Unmarshal(out.Bytes(), &created)) && assert.NotNil(t, created.Id) {
+			assert.Equal(t, "on-call", *created.Name)
+			assert.NotEmpty(t, *created.Token)
+			assert.WithinDuration(t, time.Now().Add(2*time.Hour), *created.ExpiresAt, time.Minute)
+		}
+	})
+
+	t.Run("正常系：一覧にはトークン文字列を表示しない", func(t *testing.T) {
+		var out bytes.Buffer
+
+		err := runToken(ctx, c, []string{"list"}, &out)
+
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "NAME")
+		assert.Contains(t, out.String(), "on-call")
+		assert.NotContains(t, out.String(), testToken)
+	})
+
+	t.Run("正常系：トークンを無効にする", func(t *testing.T) {
+		if created.Id == nil {
+			t.Skip("token was not created")
+		}
+		var out bytes.Buffer
+
+		err := runToken(ctx, c, []string{"revoke", *created.Id}, &out)
+
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "Revoked token "+*created.Id)
+	})
+
+	tests := []struct {
+		name          string
+		args          []string
+		expectedError error
+		errorContains string
+	}{
+		{name: "異常系：存在しないトークンを無効にする", args: []string{"revoke", "000000000000000000000000"}, errorContains: "404"},
+		{name: "異常系：サブコマンドが無い", args: nil, expectedError: errUsage},
+		{name: "異常系：不明なサブコマンド", args: []string{"rotate"}, expectedError: errUsage},
+		{name: "異常系：有効期間が1秒未満", args: []string{"create", "--ttl", "1ms"}, expectedError: errUsage},
+		{name: "異常系：無効にするトークンIDが無い", args: []string{"revoke"}, expectedError: errUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runToken(ctx, c, tt.args, &bytes.Buffer{})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.ErrorContains(t, err, tt.errorContains)
+			}
+		})
+	}
+}
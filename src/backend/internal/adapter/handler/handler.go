@@ -2,20 +2,49 @@ package handler
 
 import (
 	"context"
+	"message-service/internal/domain/audit"
 	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
 	"message-service/internal/domain/token"
 	"message-service/pkg/api"
+	"time"
+
+	"github.com/gin-gonic/gin"
 )
 
+// ErrorResponseMiddleware はハンドラーがエラーと共に返したレスポンスを書き込むミドルウェア
+// 生成されたstrict serverはエラーがあるとレスポンスに関わらず500を返すため、
+// 400や404のレスポンスが指定されている場合はエラーをアクセスログ用に記録してそのレスポンスを返す
+// レスポンスがnilの場合は従来どおり500とする
+func ErrorResponseMiddleware() api.StrictMiddlewareFunc {
+	return func(f api.StrictHandlerFunc, operationID string) api.StrictHandlerFunc {
+		return func(c *gin.Context, request interface{}) (interface{}, error) {
+			response, err := f(c, request)
+			if err != nil && response != nil {
+				_ = c.Error(err)
+				return response, nil
+			}
+			return response, err
+		}
+	}
+}
+
 type Handler struct {
-	messageHandler *MessageHandler
-	tokenHandler   *TokenHandler
+	messageHandler    *MessageHandler
+	bulkDeleteHandler *BulkDeleteHandler
+	tokenHandler      *TokenHandler
+	tenantHandler     *TenantHandler
 }
 
-func NewHandler(messageRepo message.Repository, tokenRepo token.Repository) api.StrictServerInterface {
+// NewHandler はAPIのハンドラーを作成する
+// confirmationSecretとconfirmationTTLは一括削除の確認トークンの署名鍵と有効期間
+func NewHandler(messageRepo message.Repository, tokenRepo token.Repository, auditRepo audit.Repository, tenantRepo tenant.Repository, tokenTTL time.Duration, confirmationSecret []byte, confirmationTTL time.Duration) api.StrictServerInterface {
+	tokenHandler := NewTokenHandler(tokenRepo, tokenTTL)
 	return &Handler{
-		messageHandler: NewMessageHandler(messageRepo),
-		tokenHandler:   NewTokenHandler(tokenRepo),
+		messageHandler:    NewMessageHandler(messageRepo),
+		bulkDeleteHandler: NewBulkDeleteHandler(messageRepo, auditRepo, confirmationSecret, confirmationTTL),
+		tokenHandler:      tokenHandler,
+		tenantHandler:     NewTenantHandler(tenantRepo, messageRepo, tokenRepo, tokenHandler),
 	}
 }
 
@@ -24,10 +53,30 @@ func (h *Handler) PostApiMessages(ctx context.Context, request api.PostApiMessag
 	return h.messageHandler.PostApiMessages(ctx, request)
 }
 
+func (h *Handler) PostApiMessagesBatch(ctx context.Context, request api.PostApiMessagesBatchRequestObject) (api.PostApiMessagesBatchResponseObject, error) {
+	return h.messageHandler.PostApiMessagesBatch(ctx, request)
+}
+
 func (h *Handler) GetApiMessagesSearch(ctx context.Context, request api.GetApiMessagesSearchRequestObject) (api.GetApiMessagesSearchResponseObject, error) {
 	return h.messageHandler.GetApiMessagesSearch(ctx, request)
 }
 
+func (h *Handler) GetApiMessagesExport(ctx context.Context, request api.GetApiMessagesExportRequestObject) (api.GetApiMessagesExportResponseObject, error) {
+	return h.messageHandler.GetApiMessagesExport(ctx, request)
+}
+
+func (h *Handler) PostApiMessagesBulkDelete(ctx context.Context, request api.PostApiMessagesBulkDeleteRequestObject) (api.PostApiMessagesBulkDeleteResponseObject, error) {
+	return h.bulkDeleteHandler.PostApiMessagesBulkDelete(ctx, request)
+}
+
+func (h *Handler) GetApiMessagesUidContext(ctx context.Context, request api.GetApiMessagesUidContextRequestObject) (api.GetApiMessagesUidContextResponseObject, error) {
+	return h.messageHandler.GetApiMessagesUidContext(ctx, request)
+}
+
+func (h *Handler) GetApiStatsMessages(ctx context.Context, request api.GetApiStatsMessagesRequestObject) (api.GetApiStatsMessagesResponseObject, error) {
+	return h.messageHandler.GetApiStatsMessages(ctx, request)
+}
+
 func (h *Handler) DeleteApiMessagesUid(ctx context.Context, request api.DeleteApiMessagesUidRequestObject) (api.DeleteApiMessagesUidResponseObject, error) {
 	return h.messageHandler.DeleteApiMessagesUid(ctx, request)
 }
@@ -44,3 +93,24 @@ func (h *Handler) PostApiTokens(ctx context.Context, request api.PostApiTokensRe
 func (h *Handler) DeleteApiTokensId(ctx context.Context, request api.DeleteApiTokensIdRequestObject) (api.DeleteApiTokensIdResponseObject, error) {
 	return h.tokenHandler.DeleteApiTokensId(ctx, request)
 }
+
+// テナント関連のメソッド
+func (h *Handler) GetApiTenants(ctx context.Context, request api.GetApiTenantsRequestObject) (api.GetApiTenantsResponseObject, error) {
+	return h.tenantHandler.GetApiTenants(ctx, request)
+}
+
+func (h *Handler) PostApiTenants(ctx context.Context, request api.PostApiTenantsRequestObject) (api.PostApiTenantsResponseObject, error) {
+	return h.tenantHandler.PostApiTenants(ctx, request)
+}
+
+func (h *Handler) GetApiTenantsId(ctx context.Context, request api.GetApiTenantsIdRequestObject) (api.GetApiTenantsIdResponseObject, error) {
+	return h.tenantHandler.GetApiTenantsId(ctx, request)
+}
+
+func (h *Handler) PatchApiTenantsId(ctx context.Context, request api.PatchApiTenantsIdRequestObject) (api.PatchApiTenantsIdResponseObject, error) {
+	return h.tenantHandler.PatchApiTenantsId(ctx, request)
+}
+
+func (h *Handler) PostApiTenantsIdTokens(ctx context.Context, request api.PostApiTenantsIdTokensRequestObject) (api.PostApiTenantsIdTokensResponseObject, error) {
+	return h.tenantHandler.PostApiTenantsIdTokens(ctx, request)
+}
@@ -2,12 +2,17 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
 	"message-service/internal/domain/token"
+	"message-service/internal/infrastructure/middleware"
 	"message-service/pkg/api"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -16,7 +21,7 @@ func TestNewHandler(t *testing.T) {
 	messageRepo := new(mockMessageRepository)
 	tokenRepo := new(mockTokenRepository)
 
-	handler := NewHandler(messageRepo, tokenRepo)
+	handler := NewHandler(messageRepo, tokenRepo, new(mockAuditRepository), new(mockTenantRepository), testTokenTTL, testConfirmationKey, DefaultConfirmationTTL)
 
 	assert.NotNil(t, handler)
 	assert.IsType(t, &Handler{}, handler)
@@ -26,7 +31,7 @@ func TestHandler_MessageMethods(t *testing.T) {
 	ctx := context.Background()
 	messageRepo := new(mockMessageRepository)
 	tokenRepo := new(mockTokenRepository)
-	handler := NewHandler(messageRepo, tokenRepo)
+	handler := NewHandler(messageRepo, tokenRepo, new(mockAuditRepository), new(mockTenantRepository), testTokenTTL, testConfirmationKey, DefaultConfirmationTTL)
 
 	t.Run("PostApiMessages", func(t *testing.T) {
 		msg := &message.Message{
@@ -56,6 +61,28 @@ func TestHandler_MessageMethods(t *testing.T) {
 		messageRepo.AssertExpectations(t)
 	})
 
+	t.Run("PostApiMessagesBatch", func(t *testing.T) {
+		messageRepo.On("CreateMany", ctx, mock.Anything).Return([]error{nil}, nil)
+
+		request := api.PostApiMessagesBatchRequestObject{
+			JSONBody: &api.PostApiMessagesBatchJSONRequestBody{
+				Messages: []api.MessageCreate{{
+					Uid:       "batch-uid",
+					SentAt:    time.Now(),
+					Sender:    "test-sender",
+					ChannelId: "test-channel",
+					Content:   "test content",
+				}},
+			},
+		}
+
+		response, err := handler.PostApiMessagesBatch(ctx, request)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, response)
+		messageRepo.AssertExpectations(t)
+	})
+
 	t.Run("GetApiMessagesSearch", func(t *testing.T) {
 		now := time.Now()
 		channelID := "test-channel"
@@ -73,12 +100,12 @@ func TestHandler_MessageMethods(t *testing.T) {
 			},
 		}
 
-		messageRepo.On("Search", ctx, mock.AnythingOfType("message.SearchCriteria")).Return(messages, nil)
+		messageRepo.On("Search", ctx, mock.AnythingOfType("message.SearchCriteria"), mock.Anything).Return(messages, nil)
 
 		request := api.GetApiMessagesSearchRequestObject{
 			Params: api.GetApiMessagesSearchParams{
-				ChannelId: &channelID,
-				Sender:    &sender,
+				ChannelId: &[]string{channelID},
+				Sender:    &[]string{sender},
 				FromDate:  &fromDate,
 				ToDate:    &toDate,
 			},
@@ -91,6 +118,34 @@ func TestHandler_MessageMethods(t *testing.T) {
 		messageRepo.AssertExpectations(t)
 	})
 
+	t.Run("GetApiMessagesExport", func(t *testing.T) {
+		request := api.GetApiMessagesExportRequestObject{}
+
+		response, err := handler.GetApiMessagesExport(ctx, request)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, response)
+	})
+
+	t.Run("PostApiMessagesBulkDelete", func(t *testing.T) {
+		channelID := "bulk-channel"
+		// 確認トークンはテナントごとに署名する
+		tenantCtx := tenant.WithID(ctx, tenant.DefaultID)
+		messageRepo.On("Count", tenantCtx, mock.MatchedBy(func(c message.SearchCriteria) bool {
+			return assert.ObjectsAreEqual([]string{channelID}, c.ChannelIDs) && c.CreatedBefore != nil
+		})).Return(int64(2), nil)
+
+		request := api.PostApiMessagesBulkDeleteRequestObject{
+			Body: &api.PostApiMessagesBulkDeleteJSONRequestBody{ChannelId: &[]string{channelID}},
+		}
+
+		response, err := handler.PostApiMessagesBulkDelete(tenantCtx, request)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, response)
+		messageRepo.AssertExpectations(t)
+	})
+
 	t.Run("DeleteApiMessagesUid", func(t *testing.T) {
 		uid := "test-uid"
 		messageRepo.On("Delete", ctx, uid).Return(nil)
@@ -108,10 +163,10 @@ func TestHandler_MessageMethods(t *testing.T) {
 }
 
 func TestHandler_TokenMethods(t *testing.T) {
-	ctx := context.Background()
+	ctx := middleware.ContextWithToken(context.Background(), &token.Token{Admin: true})
 	messageRepo := new(mockMessageRepository)
 	tokenRepo := new(mockTokenRepository)
-	handler := NewHandler(messageRepo, tokenRepo)
+	handler := NewHandler(messageRepo, tokenRepo, new(mockAuditRepository), new(mockTenantRepository), testTokenTTL, testConfirmationKey, DefaultConfirmationTTL)
 
 	t.Run("GetApiTokens", func(t *testing.T) {
 		tokens := []token.Token{
@@ -151,6 +206,7 @@ func TestHandler_TokenMethods(t *testing.T) {
 
 	t.Run("DeleteApiTokensId", func(t *testing.T) {
 		id := "test-id"
+		tokenRepo.On("FindByID", ctx, id).Return(&token.Token{Name: "test-token"}, nil)
 		tokenRepo.On("Delete", ctx, id).Return(nil)
 
 		request := api.DeleteApiTokensIdRequestObject{
@@ -164,3 +220,52 @@ func TestHandler_TokenMethods(t *testing.T) {
 		tokenRepo.AssertExpectations(t)
 	})
 }
+
+func TestErrorResponseMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handlerErr := errors.New("handler error")
+
+	tests := []struct {
+		name             string
+		response         interface{}
+		err              error
+		expectedResponse interface{}
+		expectedErr      error
+		expectedErrors   int
+	}{
+		{
+			name:             "正常系：エラーが無い場合はそのまま返す",
+			response:         api.DeleteApiMessagesUid204Response{},
+			expectedResponse: api.DeleteApiMessagesUid204Response{},
+		},
+		{
+			name:             "正常系：エラーと共に返した404のレスポンスを書き込む",
+			response:         api.DeleteApiMessagesUid404Response{},
+			err:              handlerErr,
+			expectedResponse: api.DeleteApiMessagesUid404Response{},
+			expectedErrors:   1,
+		},
+		{
+			name:           "異常系：レスポンスが無いエラーはそのまま返して500とする",
+			err:            handlerErr,
+			expectedErr:    handlerErr,
+			expectedErrors: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			next := func(*gin.Context, interface{}) (interface{}, error) {
+				return tt.response, tt.err
+			}
+
+			response, err := ErrorResponseMiddleware()(next, "DeleteApiMessagesUid")(c, nil)
+
+			assert.Equal(t, tt.expectedResponse, response)
+			assert.Equal(t, tt.expectedErr, err)
+			// エラーはアクセスログに記録されるようgin.Contextに残る
+			assert.Len(t, c.Errors, tt.expectedErrors)
+		})
+	}
+}
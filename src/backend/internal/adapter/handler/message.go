@@ -2,8 +2,11 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
 	"message-service/pkg/api"
+	"time"
 )
 
 type MessageHandler struct {
@@ -15,56 +18,101 @@ func NewMessageHandler(repo message.Repository) *MessageHandler {
 }
 
 func (h *MessageHandler) PostApiMessages(ctx context.Context, req api.PostApiMessagesRequestObject) (api.PostApiMessagesResponseObject, error) {
-	msg := &message.Message{
-		UID:       req.Body.Uid,
-		SentAt:    req.Body.SentAt,
-		Sender:    req.Body.Sender,
-		ChannelID: req.Body.ChannelId,
-		Content:   req.Body.Content,
+	msg, err := toMessage(*req.Body)
+	if err != nil {
+		return api.PostApiMessages400Response{}, err
+	}
+	if err := msg.Validate(); err != nil {
+		return api.PostApiMessages400Response{}, err
 	}
 
 	if err := h.repo.Create(ctx, msg); err != nil {
+		if errors.Is(err, tenant.ErrQuotaExceeded) {
+			return api.PostApiMessages403Response{}, err
+		}
 		return api.PostApiMessages400Response{}, err
 	}
 
-	return api.PostApiMessages201JSONResponse{
-		ChannelId: &msg.ChannelID,
-		Content:   &msg.Content,
-		CreatedAt: &msg.CreatedAt,
-		Sender:    &msg.Sender,
-		SentAt:    &msg.SentAt,
-		Uid:       &msg.UID,
-		UpdatedAt: &msg.UpdatedAt,
-	}, nil
+	return api.PostApiMessages201JSONResponse(toAPIMessage(msg)), nil
 }
 
 func (h *MessageHandler) GetApiMessagesSearch(ctx context.Context, req api.GetApiMessagesSearchRequestObject) (api.GetApiMessagesSearchResponseObject, error) {
-	criteria := message.SearchCriteria{
-		ChannelID: req.Params.ChannelId,
-		Sender:    req.Params.Sender,
-		FromDate:  req.Params.FromDate,
-		ToDate:    req.Params.ToDate,
+	criteria, err := searchCriteria(req.Params)
+	if err != nil {
+		return api.GetApiMessagesSearch400Response{}, err
 	}
 
-	messages, err := h.repo.Search(ctx, criteria)
+	var opts message.SearchOptions
+	if req.Params.Sort != nil {
+		opts.Sort = message.SortOrder(*req.Params.Sort)
+	}
+	if req.Params.Fields != nil {
+		opts.Fields = *req.Params.Fields
+	}
+	if req.Params.Limit != nil {
+		opts.Limit = *req.Params.Limit
+		if opts.Limit == 0 {
+			return api.GetApiMessagesSearch400Response{}, message.ErrInvalidLimit
+		}
+	}
+	if req.Params.Cursor != nil {
+		cursor, err := message.ParseSearchCursor(*req.Params.Cursor)
+		if err != nil {
+			return api.GetApiMessagesSearch400Response{}, err
+		}
+		opts.After = cursor
+	}
+	if err := opts.Validate(); err != nil {
+		return api.GetApiMessagesSearch400Response{}, err
+	}
+
+	fields := opts.Fields
+	if opts.Limit > 0 {
+		// 続きがあるかを判定するため1件多く取得する
+		opts.Limit++
+		// 次のページのカーソルを作るため、並び順のキーは指定されていなくても取得する
+		if len(fields) > 0 {
+			opts.Fields = append(append([]string{}, fields...), opts.Sort.Field())
+		}
+	}
+
+	messages, err := h.repo.Search(ctx, criteria, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	response := make(api.GetApiMessagesSearch200JSONResponse, len(messages))
-	for i, msg := range messages {
-		response[i] = api.Message{
-			ChannelId: &msg.ChannelID,
-			Content:   &msg.Content,
-			CreatedAt: &msg.CreatedAt,
-			Sender:    &msg.Sender,
-			SentAt:    &msg.SentAt,
-			Uid:       &msg.UID,
-			UpdatedAt: &msg.UpdatedAt,
+	var nextCursor string
+	if opts.Limit > 0 && len(messages) == opts.Limit {
+		messages = messages[:len(messages)-1]
+		nextCursor = opts.Sort.CursorAfter(&messages[len(messages)-1]).String()
+	}
+
+	response := toAPIMessages(messages)
+	if len(fields) > 0 {
+		for i := range response {
+			response[i] = selectFields(response[i], fields)
 		}
 	}
+	return api.GetApiMessagesSearch200JSONResponse{
+		Body:    response,
+		Headers: api.GetApiMessagesSearch200ResponseHeaders{XNextCursor: nextCursor},
+	}, nil
+}
 
-	return response, nil
+// searchCriteria は検索のパラメーターを検索条件に変換し、検証する
+// エクスポートも同じパラメーターを受け付け、この関数で検索条件に変換する
+func searchCriteria(params api.GetApiMessagesSearchParams) (message.SearchCriteria, error) {
+	criteria := message.SearchCriteria{
+		FromDate:       params.FromDate,
+		ToDate:         params.ToDate,
+		ChannelIDs:     deref(params.ChannelId),
+		Senders:        deref(params.Sender),
+		SenderPrefix:   params.SenderPrefix,
+		ExcludeSenders: deref(params.ExcludeSender),
+		IsReply:        params.IsReply,
+		HasThread:      params.HasThread,
+	}
+	return criteria, criteria.Validate()
 }
 
 func (h *MessageHandler) DeleteApiMessagesUid(ctx context.Context, req api.DeleteApiMessagesUidRequestObject) (api.DeleteApiMessagesUidResponseObject, error) {
@@ -73,3 +121,98 @@ func (h *MessageHandler) DeleteApiMessagesUid(ctx context.Context, req api.Delet
 	}
 	return api.DeleteApiMessagesUid204Response{}, nil
 }
+
+func (h *MessageHandler) GetApiMessagesUidContext(ctx context.Context, req api.GetApiMessagesUidContextRequestObject) (api.GetApiMessagesUidContextResponseObject, error) {
+	before, after := message.DefaultAroundLimit, message.DefaultAroundLimit
+	if req.Params.Before != nil {
+		before = *req.Params.Before
+	}
+	if req.Params.After != nil {
+		after = *req.Params.After
+	}
+	if err := message.ValidateAroundLimits(before, after); err != nil {
+		return api.GetApiMessagesUidContext400Response{}, err
+	}
+
+	target, err := h.repo.FindByUID(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return api.GetApiMessagesUidContext404Response{}, nil
+	}
+
+	older, newer, err := h.repo.Around(ctx, target, before, after)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.GetApiMessagesUidContext200JSONResponse{
+		Before:  toAPIMessages(older),
+		Message: toAPIMessage(target),
+		After:   toAPIMessages(newer),
+	}, nil
+}
+
+// deref はポインタが指す値を返す。nilの場合はゼロ値を返す
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+// toAPIMessages はドメインのメッセージのスライスをAPIのレスポンス形式に変換する
+func toAPIMessages(messages []message.Message) []api.Message {
+	response := make([]api.Message, len(messages))
+	for i := range messages {
+		response[i] = toAPIMessage(&messages[i])
+	}
+	return response
+}
+
+// selectFields は指定されたフィールドのみを残し、それ以外をレスポンスから除く
+func selectFields(msg api.Message, fields []string) api.Message {
+	var selected api.Message
+	for _, field := range fields {
+		switch field {
+		case "uid":
+			selected.Uid = msg.Uid
+		case "sent_at":
+			selected.SentAt = msg.SentAt
+		case "sender":
+			selected.Sender = msg.Sender
+		case "channel_id":
+			selected.ChannelId = msg.ChannelId
+		case "content":
+			selected.Content = msg.Content
+		case "created_at":
+			selected.CreatedAt = msg.CreatedAt
+		case "updated_at":
+			selected.UpdatedAt = msg.UpdatedAt
+		case "expires_at":
+			selected.ExpiresAt = msg.ExpiresAt
+		case "reply_to":
+			selected.ReplyTo = msg.ReplyTo
+		}
+	}
+	return selected
+}
+
+// toMessage はリクエストボディをドメインのメッセージに変換する
+// 有効期限の指定が不正な場合もメッセージは返す
+func toMessage(body api.MessageCreate) (*message.Message, error) {
+	msg := &message.Message{
+		UID:       body.Uid,
+		SentAt:    body.SentAt,
+		Sender:    body.Sender,
+		ChannelID: body.ChannelId,
+		Content:   body.Content,
+		ReplyTo:   deref(body.ReplyTo),
+	}
+
+	expiresAt, err := message.ResolveExpiry(body.ExpiresAt, body.TtlSeconds, time.Now())
+	msg.ExpiresAt = expiresAt
+	return msg, err
+}
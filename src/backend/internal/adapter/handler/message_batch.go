@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/infrastructure/graceful"
+	"message-service/pkg/api"
+)
+
+const (
+	// maxBatchSize はJSONで受け付ける最大件数、およびNDJSONを書き込む単位
+	maxBatchSize = 1000
+
+	// maxNDJSONLineSize はNDJSONの1行あたりの最大バイト数
+	maxNDJSONLineSize = 1024 * 1024
+)
+
+// batchItem はバッチ内の1件分の入力
+type batchItem struct {
+	index int
+	msg   *message.Message
+	err   error
+	// written は書き込みを試みたかどうか。書き込み前のエラーは不正な入力として扱う
+	written bool
+}
+
+// errServerStopping はサーバーの停止が始まったためNDJSONの読み込みを打ち切ったことを示す
+var errServerStopping = errors.New("server is shutting down; messages after the last result were not processed")
+
+// batchStoppedError はNDJSONの処理を途中で打ち切ったことを示す
+// 結果はそれまでに処理したメッセージのみを含む
+type batchStoppedError struct {
+	reason string
+}
+
+func (e *batchStoppedError) Error() string {
+	return e.reason
+}
+
+func (h *MessageHandler) PostApiMessagesBatch(ctx context.Context, req api.PostApiMessagesBatchRequestObject) (api.PostApiMessagesBatchResponseObject, error) {
+	result := api.MessageBatchResult{Results: []api.MessageBatchItemResult{}}
+
+	switch {
+	case req.JSONBody != nil:
+		if len(req.JSONBody.Messages) > maxBatchSize {
+			return api.PostApiMessagesBatch400Response{}, fmt.Errorf("batch size exceeds %d messages", maxBatchSize)
+		}
+
+		items := make([]batchItem, len(req.JSONBody.Messages))
+		for i, body := range req.JSONBody.Messages {
+			items[i] = batchItem{index: i}
+			items[i].msg, items[i].err = toMessage(body)
+		}
+		if err := h.createBatch(ctx, items, &result); err != nil {
+			return nil, err
+		}
+
+	case req.Body != nil:
+		if err := h.createNDJSON(ctx, req.Body, &result); err != nil {
+			if errors.Is(err, errServerStopping) {
+				// 停止の開始までに読んだ分は書き込み済みのため、その結果を返す
+				if len(result.Results) == 0 {
+					return api.PostApiMessagesBatch503Response{}, err
+				}
+				reason := err.Error()
+				result.Error = &reason
+				return api.PostApiMessagesBatch207JSONResponse(result), nil
+			}
+			var stopped *batchStoppedError
+			// 作成済みのメッセージがある場合は、どこまで処理したかを結果で返す
+			if result.Created > 0 && errors.As(err, &stopped) {
+				result.Error = &stopped.reason
+				return api.PostApiMessagesBatch207JSONResponse(result), nil
+			}
+			if errors.As(err, &stopped) {
+				return api.PostApiMessagesBatch400Response{}, err
+			}
+			return nil, err
+		}
+
+	default:
+		return api.PostApiMessagesBatch400Response{}, errors.New("unsupported content type")
+	}
+
+	if result.Failed > 0 {
+		return api.PostApiMessagesBatch207JSONResponse(result), nil
+	}
+	return api.PostApiMessagesBatch200JSONResponse(result), nil
+}
+
+// createNDJSON はNDJSONを読みながら最大件数ごとにメッセージを作成し、結果をresultに追加する
+// サーバーの停止が始まった場合は続きを読まず、読み込み済みのメッセージを書き込んでerrServerStoppingを返す
+// ストリームを読めなくなった場合は、読み込み済みで未作成のメッセージを書き込まずにbatchStoppedErrorを返す
+// 書き込みに失敗した場合、作成済みのメッセージがあれば該当する分を失敗として記録してbatchStoppedErrorを返す
+func (h *MessageHandler) createNDJSON(ctx context.Context, body io.Reader, result *api.MessageBatchResult) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLineSize)
+
+	flush := func(items []batchItem) error {
+		created := result.Created
+		err := h.createBatch(ctx, items, result)
+		if err == nil || created == 0 {
+			return err
+		}
+		slog.ErrorContext(ctx, "Failed to write message batch", slog.Int("index", items[0].index), slog.String("error", err.Error()))
+		for i := range items {
+			if items[i].err == nil {
+				items[i].err = errors.New("failed to write message")
+				items[i].written = true
+			}
+		}
+		appendBatchResults(items, result)
+		return &batchStoppedError{reason: "failed to write messages; processing stopped"}
+	}
+
+	stopping := graceful.Stopping(ctx)
+	var items []batchItem
+	index := 0
+	for scanner.Scan() {
+		select {
+		case <-stopping:
+			if err := flush(items); err != nil {
+				return err
+			}
+			return errServerStopping
+		default:
+		}
+
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		item := batchItem{index: index}
+		var body api.MessageCreate
+		if err := json.Unmarshal(line, &body); err != nil {
+			item.err = fmt.Errorf("invalid json: %w", err)
+		} else {
+			item.msg, item.err = toMessage(body)
+		}
+		items = append(items, item)
+		index++
+
+		if len(items) == maxBatchSize {
+			if err := flush(items); err != nil {
+				return err
+			}
+			items = items[:0]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return &batchStoppedError{reason: fmt.Sprintf("failed to read message %d: %v", index, err)}
+	}
+	return flush(items)
+}
+
+// createBatch は検証を通過したメッセージをまとめて作成し、結果をresultに追加する
+// 書き込みがエラーになった場合はresultを変更しない
+func (h *MessageHandler) createBatch(ctx context.Context, items []batchItem, result *api.MessageBatchResult) error {
+	var valid []*message.Message
+	var validIndexes []int
+	for i := range items {
+		if items[i].err == nil {
+			items[i].err = items[i].msg.Validate()
+		}
+		if items[i].err == nil {
+			valid = append(valid, items[i].msg)
+			validIndexes = append(validIndexes, i)
+		}
+	}
+
+	if len(valid) > 0 {
+		errs, err := h.repo.CreateMany(ctx, valid)
+		if err != nil {
+			return err
+		}
+		for j, i := range validIndexes {
+			items[i].err = errs[j]
+			items[i].written = true
+		}
+	}
+
+	appendBatchResults(items, result)
+	return nil
+}
+
+// appendBatchResults は各メッセージの結果をresultに追加する
+// 書き込みで発生した重複・上限超過以外のエラーはストレージの失敗として扱う
+func appendBatchResults(items []batchItem, result *api.MessageBatchResult) {
+	for _, item := range items {
+		itemResult := api.MessageBatchItemResult{Index: item.index}
+		if item.msg != nil {
+			uid := item.msg.UID
+			itemResult.Uid = &uid
+		}
+
+		switch {
+		case item.err == nil:
+			itemResult.Status = api.Created
+			result.Created++
+		case errors.Is(item.err, message.ErrDuplicateUID):
+			itemResult.Status = api.Duplicate
+			result.Duplicate++
+		case errors.Is(item.err, tenant.ErrQuotaExceeded):
+			itemResult.Status = api.QuotaExceeded
+			result.QuotaExceeded++
+		case item.written:
+			itemResult.Status = api.Failed
+			result.Failed++
+		default:
+			itemResult.Status = api.Invalid
+			result.Invalid++
+		}
+		if item.err != nil {
+			reason := item.err.Error()
+			itemResult.Error = &reason
+		}
+
+		result.Results = append(result.Results, itemResult)
+	}
+}
@@ -0,0 +1,350 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/infrastructure/graceful"
+	"message-service/pkg/api"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func createTestMessageCreate(uid string) api.MessageCreate {
+	return api.MessageCreate{
+		Uid:       uid,
+		SentAt:    time.Now(),
+		Sender:    "test-sender",
+		ChannelId: "test-channel",
+		Content:   "test message",
+	}
+}
+
+func TestMessageHandler_PostApiMessagesBatch_JSON(t *testing.T) {
+	invalid := createTestMessageCreate("invalid-uid")
+	invalid.Content = ""
+	invalidExpiry := createTestMessageCreate("invalid-expiry-uid")
+	invalidExpiry.TtlSeconds = int64Ptr(0)
+
+	tests := []struct {
+		name           string
+		messages       []api.MessageCreate
+		mockSetup      func(*mockMessageRepository)
+		expectedError  bool
+		expectPartial  bool
+		expectedResult *api.MessageBatchResult
+		expectedStatus []api.MessageBatchItemResultStatus
+	}{
+		{
+			name:     "正常系：作成・重複・不正が混在",
+			messages: []api.MessageCreate{createTestMessageCreate("uid-1"), invalid, createTestMessageCreate("uid-1")},
+			mockSetup: func(m *mockMessageRepository) {
+				m.On("CreateMany", mock.Anything, mock.MatchedBy(func(msgs []*message.Message) bool {
+					return len(msgs) == 2
+				})).Return([]error{nil, message.ErrDuplicateUID}, nil)
+			},
+			expectedResult: &api.MessageBatchResult{Created: 1, Duplicate: 1, Invalid: 1},
+			expectedStatus: []api.MessageBatchItemResultStatus{api.Created, api.Invalid, api.Duplicate},
+		},
+		{
+			name:     "正常系：テナントの上限を超えたメッセージは上限超過として扱う",
+			messages: []api.MessageCreate{createTestMessageCreate("uid-1"), createTestMessageCreate("uid-2")},
+			mockSetup: func(m *mockMessageRepository) {
+				m.On("CreateMany", mock.Anything, mock.Anything).Return([]error{nil, tenant.ErrQuotaExceeded}, nil)
+			},
+			expectedResult: &api.MessageBatchResult{Created: 1, QuotaExceeded: 1},
+			expectedStatus: []api.MessageBatchItemResultStatus{api.Created, api.QuotaExceeded},
+		},
+		{
+			name:     "正常系：書き込めなかったメッセージは失敗として207で返す",
+			messages: []api.MessageCreate{createTestMessageCreate("uid-1"), createTestMessageCreate("uid-2")},
+			mockSetup: func(m *mockMessageRepository) {
+				m.On("CreateMany", mock.Anything, mock.Anything).Return([]error{nil, errors.New("write error")}, nil)
+			},
+			expectPartial:  true,
+			expectedResult: &api.MessageBatchResult{Created: 1, Failed: 1},
+			expectedStatus: []api.MessageBatchItemResultStatus{api.Created, api.Failed},
+		},
+		{
+			name:           "正常系：全件が不正な場合はリポジトリを呼ばない",
+			messages:       []api.MessageCreate{invalid},
+			mockSetup:      func(m *mockMessageRepository) {},
+			expectedResult: &api.MessageBatchResult{Invalid: 1},
+			expectedStatus: []api.MessageBatchItemResultStatus{api.Invalid},
+		},
+		{
+			name:           "正常系：有効期限の指定が不正なメッセージは不正として扱う",
+			messages:       []api.MessageCreate{invalidExpiry},
+			mockSetup:      func(m *mockMessageRepository) {},
+			expectedResult: &api.MessageBatchResult{Invalid: 1},
+			expectedStatus: []api.MessageBatchItemResultStatus{api.Invalid},
+		},
+		{
+			name: "異常系：最大件数を超過",
+			messages: func() []api.MessageCreate {
+				msgs := make([]api.MessageCreate, maxBatchSize+1)
+				for i := range msgs {
+					msgs[i] = createTestMessageCreate(fmt.Sprintf("uid-%d", i))
+				}
+				return msgs
+			}(),
+			mockSetup:     func(m *mockMessageRepository) {},
+			expectedError: true,
+		},
+		{
+			name:     "異常系：データベースエラー",
+			messages: []api.MessageCreate{createTestMessageCreate("uid-1")},
+			mockSetup: func(m *mockMessageRepository) {
+				m.On("CreateMany", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mockMessageRepository)
+			tt.mockSetup(mockRepo)
+			handler := NewMessageHandler(mockRepo)
+
+			resp, err := handler.PostApiMessagesBatch(context.Background(), api.PostApiMessagesBatchRequestObject{
+				JSONBody: &api.PostApiMessagesBatchJSONRequestBody{Messages: tt.messages},
+			})
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				var result api.MessageBatchResult
+				if tt.expectPartial {
+					partial, ok := resp.(api.PostApiMessagesBatch207JSONResponse)
+					assert.True(t, ok)
+					result = api.MessageBatchResult(partial)
+				} else {
+					ok200, ok := resp.(api.PostApiMessagesBatch200JSONResponse)
+					assert.True(t, ok)
+					result = api.MessageBatchResult(ok200)
+				}
+				assert.Equal(t, tt.expectedResult.Created, result.Created)
+				assert.Equal(t, tt.expectedResult.Duplicate, result.Duplicate)
+				assert.Equal(t, tt.expectedResult.Invalid, result.Invalid)
+				assert.Equal(t, tt.expectedResult.QuotaExceeded, result.QuotaExceeded)
+				assert.Equal(t, tt.expectedResult.Failed, result.Failed)
+				for i, status := range tt.expectedStatus {
+					assert.Equal(t, i, result.Results[i].Index)
+					assert.Equal(t, status, result.Results[i].Status)
+					assert.Equal(t, status != api.Created, result.Results[i].Error != nil)
+				}
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageHandler_PostApiMessagesBatch_NDJSON(t *testing.T) {
+	t.Run("正常系：行ごとに結果を返す", func(t *testing.T) {
+		body := strings.Join([]string{
+			`{"uid":"uid-1","sent_at":"2024-01-01T00:00:00Z","sender":"s","channel_id":"c","content":"hello"}`,
+			``,
+			`{not json}`,
+			`{"uid":"uid-2","sent_at":"2024-01-01T00:00:00Z","sender":"s","channel_id":"c"}`,
+		}, "\n")
+
+		mockRepo := new(mockMessageRepository)
+		mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(msgs []*message.Message) bool {
+			return len(msgs) == 1 && msgs[0].UID == "uid-1"
+		})).Return([]error{nil}, nil)
+		handler := NewMessageHandler(mockRepo)
+
+		resp, err := handler.PostApiMessagesBatch(context.Background(), api.PostApiMessagesBatchRequestObject{
+			Body: strings.NewReader(body),
+		})
+
+		assert.NoError(t, err)
+		result := resp.(api.PostApiMessagesBatch200JSONResponse)
+		assert.Equal(t, 1, result.Created)
+		assert.Equal(t, 2, result.Invalid)
+		assert.Len(t, result.Results, 3)
+		assert.Nil(t, result.Results[1].Uid)
+		assert.Contains(t, *result.Results[1].Error, "invalid json")
+		assert.Equal(t, message.ErrContentRequired.Error(), *result.Results[2].Error)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系：最大件数ごとに分割して書き込む", func(t *testing.T) {
+		var lines []string
+		for i := 0; i < maxBatchSize+1; i++ {
+			lines = append(lines, fmt.Sprintf(`{"uid":"uid-%d","sent_at":"2024-01-01T00:00:00Z","sender":"s","channel_id":"c","content":"x"}`, i))
+		}
+
+		mockRepo := new(mockMessageRepository)
+		mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(msgs []*message.Message) bool {
+			return len(msgs) == maxBatchSize
+		})).Return(make([]error, maxBatchSize), nil).Once()
+		mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(msgs []*message.Message) bool {
+			return len(msgs) == 1
+		})).Return(make([]error, 1), nil).Once()
+		handler := NewMessageHandler(mockRepo)
+
+		resp, err := handler.PostApiMessagesBatch(context.Background(), api.PostApiMessagesBatchRequestObject{
+			Body: strings.NewReader(strings.Join(lines, "\n")),
+		})
+
+		assert.NoError(t, err)
+		result := resp.(api.PostApiMessagesBatch200JSONResponse)
+		assert.Equal(t, maxBatchSize+1, result.Created)
+		assert.Equal(t, maxBatchSize, result.Results[maxBatchSize].Index)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMessageHandler_PostApiMessagesBatch_NDJSONStopped(t *testing.T) {
+	// newLines はn件のメッセージのNDJSONの行を返す
+	newLines := func(n int) []string {
+		lines := make([]string, n)
+		for i := range lines {
+			lines[i] = fmt.Sprintf(`{"uid":"uid-%d","sent_at":"2024-01-01T00:00:00Z","sender":"s","channel_id":"c","content":"x"}`, i)
+		}
+		return lines
+	}
+	tooLong := `{"uid":"too-long","content":"` + strings.Repeat("x", maxNDJSONLineSize) + `"}`
+
+	t.Run("正常系：作成済みの分の後で書き込みに失敗した場合は207で処理した分の結果を返す", func(t *testing.T) {
+		mockRepo := new(mockMessageRepository)
+		mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(make([]error, maxBatchSize), nil).Once()
+		mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil, errors.New("database error")).Once()
+		handler := NewMessageHandler(mockRepo)
+
+		resp, err := handler.PostApiMessagesBatch(context.Background(), api.PostApiMessagesBatchRequestObject{
+			Body: strings.NewReader(strings.Join(newLines(maxBatchSize+2), "\n")),
+		})
+
+		assert.NoError(t, err)
+		result, ok := resp.(api.PostApiMessagesBatch207JSONResponse)
+		assert.True(t, ok)
+		assert.Equal(t, maxBatchSize, result.Created)
+		assert.Equal(t, 2, result.Failed)
+		assert.Len(t, result.Results, maxBatchSize+2)
+		assert.Equal(t, api.Failed, result.Results[maxBatchSize].Status)
+		assert.NotNil(t, result.Error)
+		assert.NotContains(t, *result.Error, "database error")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系：作成済みの分の後で読み込めなくなった場合は207で処理した分の結果を返す", func(t *testing.T) {
+		mockRepo := new(mockMessageRepository)
+		mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(make([]error, maxBatchSize), nil).Once()
+		handler := NewMessageHandler(mockRepo)
+
+		// 読み込めなくなる前に読んだ未作成のメッセージは書き込まない
+		lines := append(newLines(maxBatchSize+1), tooLong)
+		resp, err := handler.PostApiMessagesBatch(context.Background(), api.PostApiMessagesBatchRequestObject{
+			Body: strings.NewReader(strings.Join(lines, "\n")),
+		})
+
+		assert.NoError(t, err)
+		result, ok := resp.(api.PostApiMessagesBatch207JSONResponse)
+		assert.True(t, ok)
+		assert.Equal(t, maxBatchSize, result.Created)
+		assert.Len(t, result.Results, maxBatchSize)
+		assert.NotNil(t, result.Error)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系：作成前に書き込みに失敗した場合はエラーを返す", func(t *testing.T) {
+		mockRepo := new(mockMessageRepository)
+		mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+		handler := NewMessageHandler(mockRepo)
+
+		resp, err := handler.PostApiMessagesBatch(context.Background(), api.PostApiMessagesBatchRequestObject{
+			Body: strings.NewReader(strings.Join(newLines(1), "\n")),
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("異常系：作成前に読み込めなくなった場合は不正なリクエストとして扱う", func(t *testing.T) {
+		mockRepo := new(mockMessageRepository)
+		handler := NewMessageHandler(mockRepo)
+
+		resp, err := handler.PostApiMessagesBatch(context.Background(), api.PostApiMessagesBatchRequestObject{
+			Body: strings.NewReader(strings.Join(append(newLines(1), tooLong), "\n")),
+		})
+
+		assert.Error(t, err)
+		assert.IsType(t, api.PostApiMessagesBatch400Response{}, resp)
+		mockRepo.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
+	})
+}
+
+// readerFunc は読み込みのたびに関数を呼ぶio.Reader
+type readerFunc func(p []byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) {
+	return f(p)
+}
+
+func TestMessageHandler_PostApiMessagesBatch_NDJSONShutdown(t *testing.T) {
+	line := func(uid string) string {
+		return fmt.Sprintf(`{"uid":"%s","sent_at":"2024-01-01T00:00:00Z","sender":"s","channel_id":"c","content":"x"}`+"\n", uid)
+	}
+
+	t.Run("正常系：停止が始まったら続きを読まずに読み込み済みの分を書き込んで207を返す", func(t *testing.T) {
+		stopping := make(chan struct{})
+		ctx := graceful.WithStopping(context.Background(), stopping)
+		// 2行を読んだ後の読み込みで停止が始まる
+		body := io.MultiReader(strings.NewReader(line("uid-1")+line("uid-2")), readerFunc(func(p []byte) (int, error) {
+			close(stopping)
+			return copy(p, line("uid-3")), io.EOF
+		}))
+
+		mockRepo := new(mockMessageRepository)
+		mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(msgs []*message.Message) bool {
+			return len(msgs) == 2
+		})).Return(make([]error, 2), nil).Once()
+		handler := NewMessageHandler(mockRepo)
+
+		resp, err := handler.PostApiMessagesBatch(ctx, api.PostApiMessagesBatchRequestObject{Body: body})
+
+		assert.NoError(t, err)
+		result, ok := resp.(api.PostApiMessagesBatch207JSONResponse)
+		assert.True(t, ok)
+		assert.Equal(t, 2, result.Created)
+		assert.Len(t, result.Results, 2)
+		assert.NotNil(t, result.Error)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系：読み込み前に停止が始まった場合は503を返す", func(t *testing.T) {
+		stopping := make(chan struct{})
+		close(stopping)
+		ctx := graceful.WithStopping(context.Background(), stopping)
+		mockRepo := new(mockMessageRepository)
+		handler := NewMessageHandler(mockRepo)
+
+		resp, err := handler.PostApiMessagesBatch(ctx, api.PostApiMessagesBatchRequestObject{
+			Body: strings.NewReader(line("uid-1")),
+		})
+
+		assert.Error(t, err)
+		assert.IsType(t, api.PostApiMessagesBatch503Response{}, resp)
+		mockRepo.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
+	})
+}
+
+func TestMessageHandler_PostApiMessagesBatch_UnsupportedContentType(t *testing.T) {
+	handler := NewMessageHandler(new(mockMessageRepository))
+
+	resp, err := handler.PostApiMessagesBatch(context.Background(), api.PostApiMessagesBatchRequestObject{})
+
+	assert.Error(t, err)
+	assert.IsType(t, api.PostApiMessagesBatch400Response{}, resp)
+}
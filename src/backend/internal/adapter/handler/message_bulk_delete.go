@@ -0,0 +1,305 @@
+package handler
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"message-service/internal/domain/audit"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/infrastructure/middleware"
+	"message-service/pkg/api"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrConfirmationTokenMismatch は確認トークンが現在の検索条件・件数と一致しない場合のエラー
+var ErrConfirmationTokenMismatch = errors.New("confirmation token does not match the current criteria")
+
+// ErrConfirmationTokenExpired は確認トークンの有効期限が切れている場合のエラー
+var ErrConfirmationTokenExpired = errors.New("confirmation token has expired")
+
+// DefaultConfirmationTTL は確認トークンの既定の有効期間
+const DefaultConfirmationTTL = 10 * time.Minute
+
+// BulkDeleteHandler は検索条件によるメッセージの一括削除を扱う
+// 削除前のドライランで件数を確認させ、発行した確認トークンを伴う場合のみ削除を実行する
+// 確認トークンはサーバーの秘密鍵による署名のため、秘密鍵を知らない利用者は偽造できない
+type BulkDeleteHandler struct {
+	repo      message.Repository
+	auditRepo audit.Repository
+	secret    []byte
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+// NewBulkDeleteHandler は確認トークンをsecretで署名し、ttlの間有効とするハンドラーを作成する
+// 複数のレプリカで動かす場合は全てのレプリカに同じsecretを設定すること
+func NewBulkDeleteHandler(repo message.Repository, auditRepo audit.Repository, secret []byte, ttl time.Duration) *BulkDeleteHandler {
+	if len(secret) == 0 {
+		panic("confirmation secret is required")
+	}
+	if ttl <= 0 {
+		ttl = DefaultConfirmationTTL
+	}
+	return &BulkDeleteHandler{repo: repo, auditRepo: auditRepo, secret: secret, ttl: ttl, now: time.Now}
+}
+
+// NewConfirmationSecret は確認トークンの署名に使うランダムな秘密鍵を生成する
+// 秘密鍵が設定されていない場合や、プロセス内で確認と削除を続けて行う管理コマンドで利用する
+func NewConfirmationSecret() []byte {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(fmt.Sprintf("failed to generate confirmation secret: %v", err))
+	}
+	return secret
+}
+
+func (h *BulkDeleteHandler) PostApiMessagesBulkDelete(ctx context.Context, req api.PostApiMessagesBulkDeleteRequestObject) (api.PostApiMessagesBulkDeleteResponseObject, error) {
+	criteria := message.SearchCriteria{
+		ChannelIDs:     deref(req.Body.ChannelId),
+		Senders:        deref(req.Body.Sender),
+		ExcludeSenders: deref(req.Body.ExcludeSender),
+		FromDate:       req.Body.FromDate,
+		ToDate:         req.Body.ToDate,
+	}
+
+	if req.Body.DryRun == nil || *req.Body.DryRun {
+		matched, confirmation, err := h.Preview(ctx, criteria)
+		if err != nil {
+			return bulkDeleteError(err)
+		}
+		return api.PostApiMessagesBulkDelete200JSONResponse{
+			DryRun:            true,
+			Matched:           matched,
+			ConfirmationToken: &confirmation,
+		}, nil
+	}
+
+	var confirmation string
+	if req.Body.ConfirmationToken != nil {
+		confirmation = *req.Body.ConfirmationToken
+	}
+	matched, deleted, err := h.Delete(ctx, criteria, confirmation)
+	if err != nil {
+		return bulkDeleteError(err)
+	}
+
+	return api.PostApiMessagesBulkDelete200JSONResponse{
+		DryRun:  false,
+		Matched: matched,
+		Deleted: &deleted,
+	}, nil
+}
+
+// bulkDeleteError はエラーを一括削除のレスポンスに変換する
+func bulkDeleteError(err error) (api.PostApiMessagesBulkDeleteResponseObject, error) {
+	switch {
+	case errors.Is(err, message.ErrCriteriaRequired), errors.Is(err, message.ErrTooManyFilterValues):
+		return api.PostApiMessagesBulkDelete400Response{}, err
+	case errors.Is(err, ErrConfirmationTokenMismatch), errors.Is(err, ErrConfirmationTokenExpired):
+		return api.PostApiMessagesBulkDelete409Response{}, err
+	default:
+		return nil, err
+	}
+}
+
+// Preview は削除対象の件数と、削除の実行に必要な確認トークンを返す
+// 削除の対象はこの時点までに作成されたメッセージに限られ、以降に作成されたメッセージは削除しない
+func (h *BulkDeleteHandler) Preview(ctx context.Context, criteria message.SearchCriteria) (int64, string, error) {
+	// 条件なしで全件が削除されることを防ぐ
+	if criteria.IsEmpty() {
+		return 0, "", message.ErrCriteriaRequired
+	}
+	if err := criteria.Validate(); err != nil {
+		return 0, "", err
+	}
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+
+	now := h.now()
+	// MongoDBは作成日時をミリ秒で保持するため、ミリ秒に切り上げて直前に作成されたメッセージも対象に含める
+	cutoff := now.UTC().Truncate(time.Millisecond).Add(time.Millisecond)
+	criteria.CreatedBefore = &cutoff
+	matched, err := h.repo.Count(ctx, criteria)
+	if err != nil {
+		return 0, "", err
+	}
+	return matched, h.sign(tenantID, operatorTokenID(ctx), criteria, matched, now.Add(h.ttl)), nil
+}
+
+// Delete は確認トークンが現在の条件・件数と一致する場合にメッセージを削除し、監査ログに記録する
+// 削除の対象はドライランの時点までに作成されたメッセージに限る
+// 削除対象の件数と削除した件数を返す
+// 管理コマンドからも利用される。トークンで認証されていない場合、監査ログのトークンIDは空になる
+func (h *BulkDeleteHandler) Delete(ctx context.Context, criteria message.SearchCriteria, confirmation string) (int64, int64, error) {
+	if criteria.IsEmpty() {
+		return 0, 0, message.ErrCriteriaRequired
+	}
+	if err := criteria.Validate(); err != nil {
+		return 0, 0, err
+	}
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	cutoff, expiresAt, ok := parseConfirmationToken(confirmation)
+	if !ok {
+		return 0, 0, ErrConfirmationTokenMismatch
+	}
+	criteria.CreatedBefore = &cutoff
+	matched, err := h.repo.Count(ctx, criteria)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// ドライラン以降に条件や件数が変わっていれば削除しない
+	expected := h.sign(tenantID, operatorTokenID(ctx), criteria, matched, expiresAt)
+	if subtle.ConstantTimeCompare([]byte(confirmation), []byte(expected)) != 1 {
+		return matched, 0, ErrConfirmationTokenMismatch
+	}
+	// 署名を確認してから有効期限を判定し、改ざんされた期限で延長できないようにする
+	if !h.now().Before(expiresAt) {
+		return matched, 0, ErrConfirmationTokenExpired
+	}
+
+	deleted, err := h.repo.DeleteMany(ctx, criteria)
+	if err != nil {
+		return matched, 0, err
+	}
+
+	if err := h.auditRepo.Create(ctx, &audit.Log{
+		Action:   audit.ActionMessageBulkDelete,
+		TokenID:  operatorTokenID(ctx),
+		Criteria: criteriaFields(criteria),
+		Affected: deleted,
+	}); err != nil {
+		return matched, deleted, fmt.Errorf("deleted %d messages but failed to write audit log: %w", deleted, err)
+	}
+	return matched, deleted, nil
+}
+
+// operatorTokenID は操作を行ったトークンのIDを返す
+func operatorTokenID(ctx context.Context) string {
+	if tkn := middleware.TokenFromContext(ctx); tkn != nil {
+		return tkn.ID.Hex()
+	}
+	return ""
+}
+
+// confirmationPayload は確認トークンで署名する内容
+// JSONで符号化するため、値に区切り文字が含まれていても別の内容と同じ署名にはならない
+type confirmationPayload struct {
+	TenantID       string   `json:"tenant_id"`
+	TokenID        string   `json:"token_id"`
+	ChannelIDs     []string `json:"channel_id,omitempty"`
+	Senders        []string `json:"sender,omitempty"`
+	SenderPrefix   *string  `json:"sender_prefix,omitempty"`
+	ExcludeSenders []string `json:"exclude_sender,omitempty"`
+	IsReply        *bool    `json:"is_reply,omitempty"`
+	HasThread      *bool    `json:"has_thread,omitempty"`
+	FromDate       string   `json:"from_date,omitempty"`
+	ToDate         string   `json:"to_date,omitempty"`
+	CreatedBefore  int64    `json:"created_before"`
+	ExpiresAt      int64    `json:"expires_at"`
+	Matched        int64    `json:"matched"`
+}
+
+// sign はテナント・操作者・検索条件・件数・有効期限から確認トークンを生成する
+// トークンは「対象の作成日時の上限.有効期限.署名」の形式で、日時はUnixミリ秒で表す
+// サーバー側に状態を持たず、同じ条件で件数が変わっていない場合のみ同じ値になる
+func (h *BulkDeleteHandler) sign(tenantID, tokenID string, criteria message.SearchCriteria, matched int64, expiresAt time.Time) string {
+	payload, err := json.Marshal(confirmationPayload{
+		TenantID:       tenantID,
+		TokenID:        tokenID,
+		ChannelIDs:     criteria.ChannelIDs,
+		Senders:        criteria.Senders,
+		SenderPrefix:   criteria.SenderPrefix,
+		ExcludeSenders: criteria.ExcludeSenders,
+		IsReply:        criteria.IsReply,
+		HasThread:      criteria.HasThread,
+		FromDate:       formatCriteriaTime(criteria.FromDate),
+		ToDate:         formatCriteriaTime(criteria.ToDate),
+		CreatedBefore:  criteria.CreatedBefore.UnixMilli(),
+		ExpiresAt:      expiresAt.UnixMilli(),
+		Matched:        matched,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode confirmation payload: %v", err))
+	}
+
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write(payload)
+	prefix := strconv.FormatInt(criteria.CreatedBefore.UnixMilli(), 10) + "." + strconv.FormatInt(expiresAt.UnixMilli(), 10)
+	return prefix + "." + hex.EncodeToString(mac.Sum(nil))
+}
+
+// parseConfirmationToken は確認トークンから対象の作成日時の上限と有効期限を取り出す
+// 署名は検証しないため、呼び出し側で再生成した値と比較すること
+func parseConfirmationToken(confirmation string) (time.Time, time.Time, bool) {
+	parts := strings.Split(confirmation, ".")
+	if len(parts) != 3 {
+		return time.Time{}, time.Time{}, false
+	}
+	cutoff, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return time.UnixMilli(cutoff).UTC(), time.UnixMilli(expiresAt), true
+}
+
+// criteriaFields は指定された検索条件を文字列のマップに変換する
+// 複数の値はカンマ区切りで連結する
+func criteriaFields(criteria message.SearchCriteria) map[string]string {
+	fields := make(map[string]string)
+	if len(criteria.ChannelIDs) > 0 {
+		fields["channel_id"] = strings.Join(criteria.ChannelIDs, ",")
+	}
+	if len(criteria.Senders) > 0 {
+		fields["sender"] = strings.Join(criteria.Senders, ",")
+	}
+	if criteria.SenderPrefix != nil {
+		fields["sender_prefix"] = *criteria.SenderPrefix
+	}
+	if len(criteria.ExcludeSenders) > 0 {
+		fields["exclude_sender"] = strings.Join(criteria.ExcludeSenders, ",")
+	}
+	if criteria.IsReply != nil {
+		fields["is_reply"] = strconv.FormatBool(*criteria.IsReply)
+	}
+	if criteria.HasThread != nil {
+		fields["has_thread"] = strconv.FormatBool(*criteria.HasThread)
+	}
+	if criteria.FromDate != nil {
+		fields["from_date"] = formatCriteriaTime(criteria.FromDate)
+	}
+	if criteria.ToDate != nil {
+		fields["to_date"] = formatCriteriaTime(criteria.ToDate)
+	}
+	if criteria.CreatedBefore != nil {
+		fields["created_before"] = formatCriteriaTime(criteria.CreatedBefore)
+	}
+	return fields
+}
+
+// formatCriteriaTime は条件の日時をUTCの文字列にする。指定されていない場合は空文字を返す
+func formatCriteriaTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
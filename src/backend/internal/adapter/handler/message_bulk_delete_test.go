@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"message-service/internal/domain/audit"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/domain/token"
+	"message-service/internal/infrastructure/middleware"
+	"message-service/pkg/api"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// testConfirmationKey はテスト用の確認トークンの署名鍵
+var testConfirmationKey = []byte("test-confirmation-key")
+
+func TestBulkDeleteHandler_PostApiMessagesBulkDelete(t *testing.T) {
+	tkn := &token.Token{ID: primitive.NewObjectID(), TenantID: tenant.DefaultID}
+	ctx := middleware.ContextWithToken(context.Background(), tkn)
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 123456789, time.UTC)
+	cutoff := now.Truncate(time.Millisecond).Add(time.Millisecond)
+	channelID := "spam-channel"
+	channelIDs := []string{channelID}
+	tooManySenders := make([]string, message.MaxFilterValues+1)
+	fromDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// 削除の対象はドライランの時点までに作成されたメッセージに限られる
+	criteria := message.SearchCriteria{ChannelIDs: []string{channelID}, FromDate: &fromDate, CreatedBefore: &cutoff}
+
+	signer := NewBulkDeleteHandler(nil, nil, testConfirmationKey, time.Minute)
+	validToken := signer.sign(tenant.DefaultID, tkn.ID.Hex(), criteria, 3, now.Add(time.Minute))
+	staleToken := signer.sign(tenant.DefaultID, tkn.ID.Hex(), criteria, 2, now.Add(time.Minute))
+	// 有効期限だけを書き換えたトークン
+	tamperedToken := strings.Replace(validToken, strconv.FormatInt(now.Add(time.Minute).UnixMilli(), 10), strconv.FormatInt(now.Add(time.Hour).UnixMilli(), 10), 1)
+	otherKeyToken := NewBulkDeleteHandler(nil, nil, []byte("other-key"), time.Minute).sign(tenant.DefaultID, tkn.ID.Hex(), criteria, 3, now.Add(time.Minute))
+	dryRun := true
+	execute := false
+
+	tests := []struct {
+		name string
+		body api.MessageBulkDelete
+		// elapsed はドライランから削除の実行までの経過時間
+		elapsed        time.Duration
+		mockSetup      func(*mockMessageRepository, *mockAuditRepository)
+		expectedError  error
+		expectedResult *api.MessageBulkDeleteResult
+		// expectedMatched は確認トークンを比較せずに件数のみを確認する場合の件数
+		expectedMatched int64
+	}{
+		{
+			name: "正常系：ドライランは件数と確認トークンを返す",
+			body: api.MessageBulkDelete{ChannelId: &channelIDs, FromDate: &fromDate, DryRun: &dryRun},
+			mockSetup: func(m *mockMessageRepository, a *mockAuditRepository) {
+				m.On("Count", mock.Anything, criteria).Return(int64(3), nil)
+			},
+			expectedResult: &api.MessageBulkDeleteResult{DryRun: true, Matched: 3, ConfirmationToken: &validToken},
+		},
+		{
+			name: "正常系：dry_run未指定はドライランとして扱う",
+			body: api.MessageBulkDelete{ChannelId: &channelIDs, FromDate: &fromDate},
+			mockSetup: func(m *mockMessageRepository, a *mockAuditRepository) {
+				m.On("Count", mock.Anything, criteria).Return(int64(3), nil)
+			},
+			expectedResult: &api.MessageBulkDeleteResult{DryRun: true, Matched: 3, ConfirmationToken: &validToken},
+		},
+		{
+			name:    "正常系：確認トークン付きでドライランの時点までに作成されたメッセージを削除し監査ログを記録",
+			body:    api.MessageBulkDelete{ChannelId: &channelIDs, FromDate: &fromDate, DryRun: &execute, ConfirmationToken: &validToken},
+			elapsed: 30 * time.Second,
+			mockSetup: func(m *mockMessageRepository, a *mockAuditRepository) {
+				m.On("Count", mock.Anything, criteria).Return(int64(3), nil)
+				m.On("DeleteMany", mock.Anything, criteria).Return(int64(3), nil)
+				a.On("Create", mock.Anything, mock.MatchedBy(func(log *audit.Log) bool {
+					return log.Action == audit.ActionMessageBulkDelete &&
+						log.TokenID == tkn.ID.Hex() &&
+						log.Affected == 3 &&
+						log.Criteria["channel_id"] == channelID &&
+						log.Criteria["from_date"] == "2024-01-01T00:00:00Z" &&
+						log.Criteria["created_before"] == "2024-06-01T12:00:00.124Z"
+				})).Return(nil)
+			},
+			expectedResult: &api.MessageBulkDeleteResult{DryRun: false, Matched: 3, Deleted: int64Ptr(3)},
+		},
+		{
+			name: "正常系：検索と同じく複数のチャンネルと除外する送信者を指定する",
+			body: api.MessageBulkDelete{ChannelId: &[]string{"channel-1", "channel-2"}, ExcludeSender: &[]string{"bot"}},
+			mockSetup: func(m *mockMessageRepository, a *mockAuditRepository) {
+				m.On("Count", mock.Anything, mock.MatchedBy(func(c message.SearchCriteria) bool {
+					return assert.ObjectsAreEqual([]string{"channel-1", "channel-2"}, c.ChannelIDs) &&
+						assert.ObjectsAreEqual([]string{"bot"}, c.ExcludeSenders)
+				})).Return(int64(2), nil)
+			},
+			expectedMatched: 2,
+		},
+		{
+			name:          "異常系：指定できる値の数を超える",
+			body:          api.MessageBulkDelete{Sender: &tooManySenders},
+			mockSetup:     func(m *mockMessageRepository, a *mockAuditRepository) {},
+			expectedError: message.SearchCriteria{Senders: tooManySenders}.Validate(),
+		},
+		{
+			name:          "異常系：検索条件が未指定",
+			body:          api.MessageBulkDelete{DryRun: &dryRun},
+			mockSetup:     func(m *mockMessageRepository, a *mockAuditRepository) {},
+			expectedError: message.ErrCriteriaRequired,
+		},
+		{
+			name:          "異常系：確認トークンが未指定",
+			body:          api.MessageBulkDelete{ChannelId: &channelIDs, FromDate: &fromDate, DryRun: &execute},
+			mockSetup:     func(m *mockMessageRepository, a *mockAuditRepository) {},
+			expectedError: ErrConfirmationTokenMismatch,
+		},
+		{
+			name: "異常系：ドライラン後に件数が変わった",
+			body: api.MessageBulkDelete{ChannelId: &channelIDs, FromDate: &fromDate, DryRun: &execute, ConfirmationToken: &staleToken},
+			mockSetup: func(m *mockMessageRepository, a *mockAuditRepository) {
+				m.On("Count", mock.Anything, criteria).Return(int64(3), nil)
+			},
+			expectedError: ErrConfirmationTokenMismatch,
+		},
+		{
+			name: "異常系：別の署名鍵で生成された確認トークン",
+			body: api.MessageBulkDelete{ChannelId: &channelIDs, FromDate: &fromDate, DryRun: &execute, ConfirmationToken: &otherKeyToken},
+			mockSetup: func(m *mockMessageRepository, a *mockAuditRepository) {
+				m.On("Count", mock.Anything, criteria).Return(int64(3), nil)
+			},
+			expectedError: ErrConfirmationTokenMismatch,
+		},
+		{
+			name:    "異常系：有効期限を書き換えた確認トークン",
+			body:    api.MessageBulkDelete{ChannelId: &channelIDs, FromDate: &fromDate, DryRun: &execute, ConfirmationToken: &tamperedToken},
+			elapsed: 2 * time.Minute,
+			mockSetup: func(m *mockMessageRepository, a *mockAuditRepository) {
+				m.On("Count", mock.Anything, criteria).Return(int64(3), nil)
+			},
+			expectedError: ErrConfirmationTokenMismatch,
+		},
+		{
+			name:    "異常系：有効期限切れの確認トークン",
+			body:    api.MessageBulkDelete{ChannelId: &channelIDs, FromDate: &fromDate, DryRun: &execute, ConfirmationToken: &validToken},
+			elapsed: time.Minute,
+			mockSetup: func(m *mockMessageRepository, a *mockAuditRepository) {
+				m.On("Count", mock.Anything, criteria).Return(int64(3), nil)
+			},
+			expectedError: ErrConfirmationTokenExpired,
+		},
+		{
+			name: "異常系：件数取得でデータベースエラー",
+			body: api.MessageBulkDelete{ChannelId: &channelIDs, FromDate: &fromDate},
+			mockSetup: func(m *mockMessageRepository, a *mockAuditRepository) {
+				m.On("Count", mock.Anything, criteria).Return(int64(0), errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+		{
+			name: "異常系：監査ログの記録に失敗",
+			body: api.MessageBulkDelete{ChannelId: &channelIDs, FromDate: &fromDate, DryRun: &execute, ConfirmationToken: &validToken},
+			mockSetup: func(m *mockMessageRepository, a *mockAuditRepository) {
+				m.On("Count", mock.Anything, criteria).Return(int64(3), nil)
+				m.On("DeleteMany", mock.Anything, criteria).Return(int64(3), nil)
+				a.On("Create", mock.Anything, mock.Anything).Return(errors.New("audit error"))
+			},
+			expectedError: errors.New("deleted 3 messages but failed to write audit log: audit error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mockMessageRepository)
+			mockAudit := new(mockAuditRepository)
+			tt.mockSetup(mockRepo, mockAudit)
+			handler := NewBulkDeleteHandler(mockRepo, mockAudit, testConfirmationKey, time.Minute)
+			handler.now = func() time.Time { return now.Add(tt.elapsed) }
+
+			body := tt.body
+			resp, err := handler.PostApiMessagesBulkDelete(ctx, api.PostApiMessagesBulkDeleteRequestObject{Body: &body})
+
+			switch {
+			case tt.expectedError != nil:
+				assert.EqualError(t, err, tt.expectedError.Error())
+			case tt.expectedResult == nil:
+				assert.NoError(t, err)
+				if result, ok := resp.(api.PostApiMessagesBulkDelete200JSONResponse); assert.True(t, ok) {
+					assert.Equal(t, tt.expectedMatched, result.Matched)
+					assert.NotNil(t, result.ConfirmationToken)
+				}
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, api.PostApiMessagesBulkDelete200JSONResponse(*tt.expectedResult), resp)
+			}
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBulkDeleteHandler_sign(t *testing.T) {
+	channelID := "channel"
+	otherChannelID := "other"
+	cutoff := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	otherCutoff := cutoff.Add(time.Second)
+	expiresAt := cutoff.Add(time.Minute)
+	h := NewBulkDeleteHandler(nil, nil, testConfirmationKey, time.Minute)
+	criteria := message.SearchCriteria{ChannelIDs: []string{channelID}, CreatedBefore: &cutoff}
+	base := h.sign("tenant", "token-id", criteria, 10, expiresAt)
+
+	assert.Equal(t, base, h.sign("tenant", "token-id", criteria, 10, expiresAt))
+	assert.NotEqual(t, base, h.sign("tenant", "other-token-id", criteria, 10, expiresAt))
+	assert.NotEqual(t, base, h.sign("other-tenant", "token-id", criteria, 10, expiresAt))
+	// 区切り文字を含む値で、別の条件の組み合わせと同じ署名にならない
+	assert.NotEqual(t,
+		h.sign("tenant", "token-id", message.SearchCriteria{ChannelIDs: []string{"a,b"}, CreatedBefore: &cutoff}, 10, expiresAt),
+		h.sign("tenant", "token-id", message.SearchCriteria{ChannelIDs: []string{"a", "b"}, CreatedBefore: &cutoff}, 10, expiresAt))
+	assert.NotEqual(t,
+		h.sign("tenant", "token-id", message.SearchCriteria{ChannelIDs: []string{"a\nb"}, CreatedBefore: &cutoff}, 10, expiresAt),
+		h.sign("tenant", "token-id", message.SearchCriteria{ChannelIDs: []string{"a"}, Senders: []string{"b"}, CreatedBefore: &cutoff}, 10, expiresAt))
+	assert.NotEqual(t, base, h.sign("tenant", "token-id", message.SearchCriteria{ChannelIDs: []string{otherChannelID}, CreatedBefore: &cutoff}, 10, expiresAt))
+	assert.NotEqual(t, base, h.sign("tenant", "token-id", message.SearchCriteria{Senders: []string{channelID}, CreatedBefore: &cutoff}, 10, expiresAt))
+	assert.NotEqual(t, base, h.sign("tenant", "token-id", message.SearchCriteria{ChannelIDs: []string{channelID}, CreatedBefore: &otherCutoff}, 10, expiresAt))
+	assert.NotEqual(t, base, h.sign("tenant", "token-id", criteria, 11, expiresAt))
+	assert.NotEqual(t, base, h.sign("tenant", "token-id", criteria, 10, expiresAt.Add(time.Second)))
+	assert.NotEqual(t, base, NewBulkDeleteHandler(nil, nil, []byte("other-key"), time.Minute).sign("tenant", "token-id", criteria, 10, expiresAt))
+
+	parsedCutoff, parsedExpiresAt, ok := parseConfirmationToken(base)
+	assert.True(t, ok)
+	assert.True(t, cutoff.Equal(parsedCutoff))
+	assert.True(t, expiresAt.Equal(parsedExpiresAt))
+
+	for _, invalid := range []string{"", "abc", "1.2", "a.2.sig", "1.b.sig"} {
+		_, _, ok := parseConfirmationToken(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
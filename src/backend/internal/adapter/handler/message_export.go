@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"message-service/internal/domain/message"
+	"message-service/pkg/api"
+	"net/http"
+	"time"
+)
+
+// exportCSVHeader はCSVエクスポートのヘッダー行
+var exportCSVHeader = []string{"uid", "sent_at", "sender", "channel_id", "content", "created_at", "updated_at", "expires_at", "reply_to"}
+
+var exportContentTypes = map[api.GetApiMessagesExportParamsFormat]string{
+	api.Ndjson: "application/x-ndjson",
+	api.Csv:    "text/csv",
+	api.Json:   "application/json",
+}
+
+func (h *MessageHandler) GetApiMessagesExport(ctx context.Context, req api.GetApiMessagesExportRequestObject) (api.GetApiMessagesExportResponseObject, error) {
+	format := api.Ndjson
+	if req.Params.Format != nil {
+		format = *req.Params.Format
+	}
+	if _, ok := exportContentTypes[format]; !ok {
+		return api.GetApiMessagesExport400Response{}, fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	criteria, err := searchCriteria(api.GetApiMessagesSearchParams{
+		ChannelId:     req.Params.ChannelId,
+		Sender:        req.Params.Sender,
+		SenderPrefix:  req.Params.SenderPrefix,
+		ExcludeSender: req.Params.ExcludeSender,
+		IsReply:       req.Params.IsReply,
+		HasThread:     req.Params.HasThread,
+		FromDate:      req.Params.FromDate,
+		ToDate:        req.Params.ToDate,
+	})
+	if err != nil {
+		return api.GetApiMessagesExport400Response{}, err
+	}
+
+	return messageExportResponse{
+		ctx:      ctx,
+		repo:     h.repo,
+		criteria: criteria,
+		format:   format,
+		gzip:     req.Params.Gzip != nil && *req.Params.Gzip,
+	}, nil
+}
+
+// messageExportResponse はカーソルから読み出したメッセージをそのままレスポンスに書き込む
+// 生成コードのレスポンス型は本文を一度メモリに保持するため、独自に実装している
+type messageExportResponse struct {
+	ctx      context.Context
+	repo     message.Repository
+	criteria message.SearchCriteria
+	format   api.GetApiMessagesExportParamsFormat
+	gzip     bool
+}
+
+func (r messageExportResponse) VisitGetApiMessagesExportResponse(w http.ResponseWriter) error {
+	contentType := exportContentTypes[r.format]
+	filename := "messages." + string(r.format)
+	if r.gzip {
+		contentType = "application/gzip"
+		filename += ".gz"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	// ヘッダー送信後はステータスを変更できないため、途中で失敗した場合は
+	// JSONの閉じ括弧やgzipのトレーラーを書かずに終了し、不完全な出力であることを示す
+	var gz *gzip.Writer
+	out := bufio.NewWriter(w)
+	var dst io.Writer = out
+	if r.gzip {
+		gz = gzip.NewWriter(out)
+		dst = gz
+	}
+
+	if err := WriteExport(r.ctx, dst, r.repo, r.criteria, r.format); err != nil {
+		out.Flush()
+		return err
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return err
+		}
+	}
+	return out.Flush()
+}
+
+// WriteExport は条件に一致するメッセージを指定された形式でdstに書き込む
+// 全件をメモリに保持せず、リポジトリから読み出しながら書き込む。管理コマンドからも利用される
+func WriteExport(ctx context.Context, dst io.Writer, repo message.Repository, criteria message.SearchCriteria, format api.GetApiMessagesExportParamsFormat) error {
+	switch format {
+	case api.Csv:
+		w := csv.NewWriter(dst)
+		if err := w.Write(exportCSVHeader); err != nil {
+			return err
+		}
+		err := repo.Iterate(ctx, criteria, func(msg *message.Message) error {
+			return w.Write([]string{
+				msg.UID,
+				msg.SentAt.Format(time.RFC3339Nano),
+				msg.Sender,
+				msg.ChannelID,
+				msg.Content,
+				msg.CreatedAt.Format(time.RFC3339Nano),
+				msg.UpdatedAt.Format(time.RFC3339Nano),
+				formatOptionalTime(msg.ExpiresAt),
+				msg.ReplyTo,
+			})
+		})
+		w.Flush()
+		if err != nil {
+			return err
+		}
+		return w.Error()
+
+	case api.Json:
+		if _, err := io.WriteString(dst, "["); err != nil {
+			return err
+		}
+		first := true
+		err := repo.Iterate(ctx, criteria, func(msg *message.Message) error {
+			if !first {
+				if _, err := io.WriteString(dst, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+			b, err := json.Marshal(toAPIMessage(msg))
+			if err != nil {
+				return err
+			}
+			_, err = dst.Write(b)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(dst, "]\n")
+		return err
+
+	default:
+		enc := json.NewEncoder(dst)
+		return repo.Iterate(ctx, criteria, func(msg *message.Message) error {
+			return enc.Encode(toAPIMessage(msg))
+		})
+	}
+}
+
+// toAPIMessage はドメインのメッセージをAPIのレスポンス形式に変換する
+func toAPIMessage(msg *message.Message) api.Message {
+	return api.Message{
+		ChannelId: &msg.ChannelID,
+		Content:   &msg.Content,
+		CreatedAt: &msg.CreatedAt,
+		Sender:    &msg.Sender,
+		SentAt:    &msg.SentAt,
+		Uid:       &msg.UID,
+		UpdatedAt: &msg.UpdatedAt,
+		ExpiresAt: msg.ExpiresAt,
+		ReplyTo:   optionalString(msg.ReplyTo),
+	}
+}
+
+// optionalString は空文字をnilとして返す
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// formatOptionalTime は日時を文字列に変換する。nilの場合は空文字を返す
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
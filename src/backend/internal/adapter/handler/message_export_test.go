@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"message-service/internal/domain/message"
+	"message-service/pkg/api"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func exportTestMessages() []message.Message {
+	sentAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []message.Message{
+		{UID: "msg1", SentAt: sentAt, Sender: "sender", ChannelID: "channel", Content: "hello"},
+		{UID: "msg2", SentAt: sentAt.Add(time.Minute), Sender: "sender", ChannelID: "channel", Content: "hello, \"world\"\nline2"},
+	}
+}
+
+func TestMessageHandler_GetApiMessagesExport(t *testing.T) {
+	channelIDs := []string{"channel", "other"}
+	prefix := "bot-"
+	excluded := []string{"bot-noisy"}
+	yes := true
+	csvFormat := api.Csv
+	jsonFormat := api.Json
+	ndjsonFormat := api.Ndjson
+	enabled := true
+
+	tests := []struct {
+		name                string
+		params              api.GetApiMessagesExportParams
+		expectedCriteria    message.SearchCriteria
+		expectedContentType string
+		expectedFilename    string
+		verify              func(t *testing.T, body string)
+	}{
+		{
+			name:                "正常系：デフォルトはNDJSON",
+			params:              api.GetApiMessagesExportParams{ChannelId: &channelIDs},
+			expectedCriteria:    message.SearchCriteria{ChannelIDs: channelIDs},
+			expectedContentType: "application/x-ndjson",
+			expectedFilename:    "messages.ndjson",
+			verify: func(t *testing.T, body string) {
+				lines := strings.Split(strings.TrimSpace(body), "\n")
+				assert.Len(t, lines, 2)
+				var msg api.Message
+				assert.NoError(t, json.Unmarshal([]byte(lines[1]), &msg))
+				assert.Equal(t, "msg2", *msg.Uid)
+			},
+		},
+		{
+			name: "正常系：CSV形式で検索と同じ条件を指定",
+			params: api.GetApiMessagesExportParams{
+				Format:        &csvFormat,
+				SenderPrefix:  &prefix,
+				ExcludeSender: &excluded,
+				IsReply:       &yes,
+				HasThread:     &yes,
+			},
+			expectedCriteria:    message.SearchCriteria{SenderPrefix: &prefix, ExcludeSenders: excluded, IsReply: &yes, HasThread: &yes},
+			expectedContentType: "text/csv",
+			expectedFilename:    "messages.csv",
+			verify: func(t *testing.T, body string) {
+				records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+				assert.NoError(t, err)
+				assert.Len(t, records, 3)
+				assert.Equal(t, exportCSVHeader, records[0])
+				assert.Equal(t, "2024-01-01T00:00:00Z", records[1][1])
+				assert.Equal(t, "hello, \"world\"\nline2", records[2][4])
+			},
+		},
+		{
+			name:                "正常系：JSON形式",
+			params:              api.GetApiMessagesExportParams{Format: &jsonFormat},
+			expectedContentType: "application/json",
+			expectedFilename:    "messages.json",
+			verify: func(t *testing.T, body string) {
+				var msgs []api.Message
+				assert.NoError(t, json.Unmarshal([]byte(body), &msgs))
+				assert.Len(t, msgs, 2)
+				assert.Equal(t, "msg1", *msgs[0].Uid)
+			},
+		},
+		{
+			name:                "正常系：gzip圧縮",
+			params:              api.GetApiMessagesExportParams{Format: &ndjsonFormat, Gzip: &enabled},
+			expectedContentType: "application/gzip",
+			expectedFilename:    "messages.ndjson.gz",
+			verify: func(t *testing.T, body string) {
+				gz, err := gzip.NewReader(strings.NewReader(body))
+				assert.NoError(t, err)
+				decoded, err := io.ReadAll(gz)
+				assert.NoError(t, err)
+				assert.Len(t, strings.Split(strings.TrimSpace(string(decoded)), "\n"), 2)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mockMessageRepository)
+			mockRepo.On("Iterate", mock.Anything, tt.expectedCriteria).Return(exportTestMessages(), nil)
+			handler := NewMessageHandler(mockRepo)
+
+			resp, err := handler.GetApiMessagesExport(context.Background(), api.GetApiMessagesExportRequestObject{Params: tt.params})
+			assert.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			assert.NoError(t, resp.VisitGetApiMessagesExportResponse(rec))
+			assert.Equal(t, 200, rec.Code)
+			assert.Equal(t, tt.expectedContentType, rec.Header().Get("Content-Type"))
+			assert.Contains(t, rec.Header().Get("Content-Disposition"), tt.expectedFilename)
+			tt.verify(t, rec.Body.String())
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageHandler_GetApiMessagesExport_UnsupportedFormat(t *testing.T) {
+	format := api.GetApiMessagesExportParamsFormat("xml")
+	handler := NewMessageHandler(new(mockMessageRepository))
+
+	resp, err := handler.GetApiMessagesExport(context.Background(), api.GetApiMessagesExportRequestObject{
+		Params: api.GetApiMessagesExportParams{Format: &format},
+	})
+
+	assert.Error(t, err)
+	assert.IsType(t, api.GetApiMessagesExport400Response{}, resp)
+}
+
+func TestMessageHandler_GetApiMessagesExport_TooManyFilterValues(t *testing.T) {
+	channelIDs := make([]string, message.MaxFilterValues+1)
+	handler := NewMessageHandler(new(mockMessageRepository))
+
+	resp, err := handler.GetApiMessagesExport(context.Background(), api.GetApiMessagesExportRequestObject{
+		Params: api.GetApiMessagesExportParams{ChannelId: &channelIDs},
+	})
+
+	assert.ErrorIs(t, err, message.ErrTooManyFilterValues)
+	assert.IsType(t, api.GetApiMessagesExport400Response{}, resp)
+}
+
+func TestMessageHandler_GetApiMessagesExport_IterateError(t *testing.T) {
+	format := api.Json
+	mockRepo := new(mockMessageRepository)
+	mockRepo.On("Iterate", mock.Anything, mock.Anything).Return(exportTestMessages(), errors.New("cursor error"))
+	handler := NewMessageHandler(mockRepo)
+
+	resp, err := handler.GetApiMessagesExport(context.Background(), api.GetApiMessagesExportRequestObject{
+		Params: api.GetApiMessagesExportParams{Format: &format},
+	})
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	err = resp.VisitGetApiMessagesExportResponse(rec)
+
+	assert.EqualError(t, err, "cursor error")
+	// 途中で失敗した場合は閉じ括弧を書かず、不完全なJSONであることを示す
+	assert.False(t, json.Valid(rec.Body.Bytes()))
+	assert.True(t, strings.HasPrefix(rec.Body.String(), "["))
+}
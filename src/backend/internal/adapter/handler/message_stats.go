@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"message-service/internal/domain/message"
+	"message-service/pkg/api"
+	"strings"
+	"time"
+)
+
+// statsCacheControl は統計レスポンスのキャッシュ指定
+// 集計結果はトークンごとに異なり得るため共有キャッシュには載せない
+const statsCacheControl = "private, max-age=60"
+
+func (h *MessageHandler) GetApiStatsMessages(ctx context.Context, req api.GetApiStatsMessagesRequestObject) (api.GetApiStatsMessagesResponseObject, error) {
+	loc := time.UTC
+	if req.Params.Tz != nil && *req.Params.Tz != "" {
+		l, err := time.LoadLocation(*req.Params.Tz)
+		if err != nil {
+			return api.GetApiStatsMessages400Response{}, fmt.Errorf("invalid timezone: %w", err)
+		}
+		loc = l
+	}
+
+	query := message.StatsQuery{
+		Criteria: message.SearchCriteria{
+			ChannelIDs:     deref(req.Params.ChannelId),
+			Senders:        deref(req.Params.Sender),
+			ExcludeSenders: deref(req.Params.ExcludeSender),
+			FromDate:       req.Params.FromDate,
+			ToDate:         req.Params.ToDate,
+		},
+		GroupBy:  message.GroupBy(req.Params.GroupBy),
+		Location: loc,
+	}
+	if err := query.Validate(); err != nil {
+		return api.GetApiStatsMessages400Response{}, err
+	}
+
+	buckets, err := h.repo.Stats(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	body := api.MessageStats{
+		GroupBy:  api.MessageStatsGroupBy(query.GroupBy),
+		Timezone: loc.String(),
+		Buckets:  make([]api.MessageStatsBucket, len(buckets)),
+	}
+	for i, b := range buckets {
+		body.Buckets[i] = api.MessageStatsBucket{Key: b.Key, Count: b.Count, Senders: b.Senders}
+	}
+
+	etag, err := statsETag(body)
+	if err != nil {
+		return nil, err
+	}
+	if req.Params.IfNoneMatch != nil && etagMatches(*req.Params.IfNoneMatch, etag) {
+		return api.GetApiStatsMessages304Response{
+			Headers: api.GetApiStatsMessages304ResponseHeaders{CacheControl: statsCacheControl, ETag: etag},
+		}, nil
+	}
+
+	return api.GetApiStatsMessages200JSONResponse{
+		Body:    body,
+		Headers: api.GetApiStatsMessages200ResponseHeaders{CacheControl: statsCacheControl, ETag: etag},
+	}, nil
+}
+
+// statsETag は集計結果の内容からETagを生成する
+// 集計結果が変わらない限り同じ値になるため、クライアントは条件付きリクエストで再取得を省ける
+func statsETag(body api.MessageStats) (string, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// etagMatches はIf-None-Matchヘッダーの値がETagに一致するかを判定する
+// カンマ区切りの複数指定、弱い比較(W/)、"*"に対応する
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"message-service/internal/domain/message"
+	"message-service/pkg/api"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMessageHandler_GetApiStatsMessages(t *testing.T) {
+	channelID := "channel"
+	tokyo := "Asia/Tokyo"
+	invalidTz := "Mars/Olympus"
+	tooManySenders := make([]string, message.MaxFilterValues+1)
+	buckets := []message.StatsBucket{
+		{Key: "alice", Count: 3, Senders: 1},
+		{Key: "bob", Count: 1, Senders: 1},
+	}
+
+	tests := []struct {
+		name     string
+		params   api.GetApiStatsMessagesParams
+		mockFn   func(*mockMessageRepository)
+		verify   func(t *testing.T, resp api.GetApiStatsMessagesResponseObject)
+		wantErr  bool
+		wantResp api.GetApiStatsMessagesResponseObject
+	}{
+		{
+			name:   "正常系：送信者単位の集計",
+			params: api.GetApiStatsMessagesParams{GroupBy: api.GetApiStatsMessagesParamsGroupBySender, ChannelId: &[]string{channelID}},
+			mockFn: func(m *mockMessageRepository) {
+				m.On("Stats", mock.Anything, mock.MatchedBy(func(q message.StatsQuery) bool {
+					return q.GroupBy == message.GroupBySender && assert.ObjectsAreEqual([]string{channelID}, q.Criteria.ChannelIDs) && q.TimeZone().String() == "UTC"
+				})).Return(buckets, nil)
+			},
+			verify: func(t *testing.T, resp api.GetApiStatsMessagesResponseObject) {
+				ok, isOK := resp.(api.GetApiStatsMessages200JSONResponse)
+				assert.True(t, isOK)
+				assert.Equal(t, api.MessageStatsGroupBy("sender"), ok.Body.GroupBy)
+				assert.Equal(t, "UTC", ok.Body.Timezone)
+				assert.Equal(t, []api.MessageStatsBucket{
+					{Key: "alice", Count: 3, Senders: 1},
+					{Key: "bob", Count: 1, Senders: 1},
+				}, ok.Body.Buckets)
+				assert.Equal(t, statsCacheControl, ok.Headers.CacheControl)
+				assert.NotEmpty(t, ok.Headers.ETag)
+			},
+		},
+		{
+			name:   "正常系：タイムゾーンを指定した日単位の集計",
+			params: api.GetApiStatsMessagesParams{GroupBy: api.GetApiStatsMessagesParamsGroupByDay, Tz: &tokyo},
+			mockFn: func(m *mockMessageRepository) {
+				m.On("Stats", mock.Anything, mock.MatchedBy(func(q message.StatsQuery) bool {
+					return q.GroupBy == message.GroupByDay && q.TimeZone().String() == tokyo
+				})).Return(nil, nil)
+			},
+			verify: func(t *testing.T, resp api.GetApiStatsMessagesResponseObject) {
+				ok, isOK := resp.(api.GetApiStatsMessages200JSONResponse)
+				assert.True(t, isOK)
+				assert.Equal(t, tokyo, ok.Body.Timezone)
+				assert.NotNil(t, ok.Body.Buckets)
+				assert.Empty(t, ok.Body.Buckets)
+			},
+		},
+		{
+			name: "正常系：検索と同じく複数のチャンネルと除外する送信者を指定する",
+			params: api.GetApiStatsMessagesParams{
+				GroupBy:       api.GetApiStatsMessagesParamsGroupByChannel,
+				ChannelId:     &[]string{"channel-1", "channel-2"},
+				ExcludeSender: &[]string{"bot"},
+			},
+			mockFn: func(m *mockMessageRepository) {
+				m.On("Stats", mock.Anything, mock.MatchedBy(func(q message.StatsQuery) bool {
+					return assert.ObjectsAreEqual([]string{"channel-1", "channel-2"}, q.Criteria.ChannelIDs) &&
+						assert.ObjectsAreEqual([]string{"bot"}, q.Criteria.ExcludeSenders)
+				})).Return(nil, nil)
+			},
+			verify: func(t *testing.T, resp api.GetApiStatsMessagesResponseObject) {
+				assert.IsType(t, api.GetApiStatsMessages200JSONResponse{}, resp)
+			},
+		},
+		{
+			name:     "異常系：指定できる値の数を超える",
+			params:   api.GetApiStatsMessagesParams{GroupBy: api.GetApiStatsMessagesParamsGroupByChannel, Sender: &tooManySenders},
+			mockFn:   func(m *mockMessageRepository) {},
+			wantErr:  true,
+			wantResp: api.GetApiStatsMessages400Response{},
+		},
+		{
+			name:     "異常系：不正なタイムゾーン",
+			params:   api.GetApiStatsMessagesParams{GroupBy: api.GetApiStatsMessagesParamsGroupByDay, Tz: &invalidTz},
+			mockFn:   func(m *mockMessageRepository) {},
+			wantErr:  true,
+			wantResp: api.GetApiStatsMessages400Response{},
+		},
+		{
+			name:     "異常系：不正な集計単位",
+			params:   api.GetApiStatsMessagesParams{GroupBy: "week"},
+			mockFn:   func(m *mockMessageRepository) {},
+			wantErr:  true,
+			wantResp: api.GetApiStatsMessages400Response{},
+		},
+		{
+			name:   "異常系：リポジトリエラー",
+			params: api.GetApiStatsMessagesParams{GroupBy: api.GetApiStatsMessagesParamsGroupByChannel},
+			mockFn: func(m *mockMessageRepository) {
+				m.On("Stats", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mockMessageRepository)
+			tt.mockFn(mockRepo)
+			h := NewMessageHandler(mockRepo)
+
+			resp, err := h.GetApiStatsMessages(context.Background(), api.GetApiStatsMessagesRequestObject{Params: tt.params})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantResp, resp)
+			} else {
+				assert.NoError(t, err)
+				tt.verify(t, resp)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageHandler_GetApiStatsMessages_NotModified(t *testing.T) {
+	mockRepo := new(mockMessageRepository)
+	mockRepo.On("Stats", mock.Anything, mock.Anything).Return([]message.StatsBucket{{Key: "channel", Count: 2, Senders: 1}}, nil)
+	h := NewMessageHandler(mockRepo)
+	params := api.GetApiStatsMessagesParams{GroupBy: api.GetApiStatsMessagesParamsGroupByChannel}
+
+	first, err := h.GetApiStatsMessages(context.Background(), api.GetApiStatsMessagesRequestObject{Params: params})
+	assert.NoError(t, err)
+	etag := first.(api.GetApiStatsMessages200JSONResponse).Headers.ETag
+
+	tests := []struct {
+		name            string
+		ifNoneMatch     string
+		wantNotModified bool
+	}{
+		{name: "正常系：ETagが一致", ifNoneMatch: etag, wantNotModified: true},
+		{name: "正常系：弱いETagと複数指定", ifNoneMatch: `"other", W/` + etag, wantNotModified: true},
+		{name: "正常系：ワイルドカード", ifNoneMatch: "*", wantNotModified: true},
+		{name: "正常系：ETagが不一致", ifNoneMatch: `"other"`, wantNotModified: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := params
+			p.IfNoneMatch = &tt.ifNoneMatch
+
+			resp, err := h.GetApiStatsMessages(context.Background(), api.GetApiStatsMessagesRequestObject{Params: p})
+
+			assert.NoError(t, err)
+			if tt.wantNotModified {
+				notModified, ok := resp.(api.GetApiStatsMessages304Response)
+				assert.True(t, ok)
+				assert.Equal(t, etag, notModified.Headers.ETag)
+			} else {
+				_, ok := resp.(api.GetApiStatsMessages200JSONResponse)
+				assert.True(t, ok)
+			}
+		})
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
 	"message-service/pkg/api"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // テストヘルパー関数
@@ -45,6 +47,8 @@ func createTestSearchRequest(params api.GetApiMessagesSearchParams) api.GetApiMe
 }
 
 func TestMessageHandler_PostApiMessages(t *testing.T) {
+	parentUID, selfUID := "parent-uid", "test-uid"
+
 	tests := []struct {
 		name          string
 		request       api.PostApiMessagesRequestObject
@@ -71,10 +75,7 @@ func TestMessageHandler_PostApiMessages(t *testing.T) {
 				ChannelId: "test-channel",
 				Content:   "",
 			}),
-			mockSetup: func(m *mockMessageRepository) {
-				m.On("Create", mock.Anything, mock.AnythingOfType("*message.Message")).
-					Return(errors.New("content is required"))
-			},
+			mockSetup:     func(m *mockMessageRepository) {},
 			expectedError: true,
 			expectedCode:  400,
 			errorMessage:  "content is required",
@@ -96,6 +97,98 @@ func TestMessageHandler_PostApiMessages(t *testing.T) {
 			expectedCode:  409,
 			errorMessage:  "duplicate uid",
 		},
+		{
+			name:    "異常系：テナントのメッセージ数の上限を超える",
+			request: createTestPostRequest(nil),
+			mockSetup: func(m *mockMessageRepository) {
+				m.On("Create", mock.Anything, mock.AnythingOfType("*message.Message")).Return(tenant.ErrQuotaExceeded)
+			},
+			expectedError: true,
+			expectedCode:  403,
+			errorMessage:  "tenant quota exceeded",
+		},
+		{
+			name: "正常系：有効期限を秒数で指定",
+			request: createTestPostRequest(&api.PostApiMessagesJSONRequestBody{
+				Uid:        "test-uid",
+				SentAt:     time.Now(),
+				Sender:     "test-sender",
+				ChannelId:  "test-channel",
+				Content:    "test message",
+				TtlSeconds: int64Ptr(60),
+			}),
+			mockSetup: func(m *mockMessageRepository) {
+				m.On("Create", mock.Anything, mock.MatchedBy(func(msg *message.Message) bool {
+					return msg.ExpiresAt != nil && time.Until(*msg.ExpiresAt) > 50*time.Second
+				})).Return(nil)
+			},
+			expectedError: false,
+			expectedCode:  201,
+		},
+		{
+			name: "正常系：返信先を指定",
+			request: createTestPostRequest(&api.PostApiMessagesJSONRequestBody{
+				Uid:       "test-uid",
+				SentAt:    time.Now(),
+				Sender:    "test-sender",
+				ChannelId: "test-channel",
+				Content:   "test message",
+				ReplyTo:   &parentUID,
+			}),
+			mockSetup: func(m *mockMessageRepository) {
+				m.On("Create", mock.Anything, mock.MatchedBy(func(msg *message.Message) bool {
+					return msg.ReplyTo == "parent-uid"
+				})).Return(nil)
+			},
+			expectedError: false,
+			expectedCode:  201,
+		},
+		{
+			name: "異常系：自身への返信",
+			request: createTestPostRequest(&api.PostApiMessagesJSONRequestBody{
+				Uid:       "test-uid",
+				SentAt:    time.Now(),
+				Sender:    "test-sender",
+				ChannelId: "test-channel",
+				Content:   "test message",
+				ReplyTo:   &selfUID,
+			}),
+			mockSetup:     func(m *mockMessageRepository) {},
+			expectedError: true,
+			expectedCode:  400,
+			errorMessage:  "reply_to cannot refer to the message itself",
+		},
+		{
+			name: "異常系：有効期限の日時と秒数を両方指定",
+			request: createTestPostRequest(&api.PostApiMessagesJSONRequestBody{
+				Uid:        "test-uid",
+				SentAt:     time.Now(),
+				Sender:     "test-sender",
+				ChannelId:  "test-channel",
+				Content:    "test message",
+				ExpiresAt:  timePtr(time.Now().Add(time.Hour)),
+				TtlSeconds: int64Ptr(60),
+			}),
+			mockSetup:     func(m *mockMessageRepository) {},
+			expectedError: true,
+			expectedCode:  400,
+			errorMessage:  "cannot be specified together",
+		},
+		{
+			name: "異常系：有効期限が過去",
+			request: createTestPostRequest(&api.PostApiMessagesJSONRequestBody{
+				Uid:       "test-uid",
+				SentAt:    time.Now(),
+				Sender:    "test-sender",
+				ChannelId: "test-channel",
+				Content:   "test message",
+				ExpiresAt: timePtr(time.Now().Add(-time.Hour)),
+			}),
+			mockSetup:     func(m *mockMessageRepository) {},
+			expectedError: true,
+			expectedCode:  400,
+			errorMessage:  "must be in the future",
+		},
 	}
 
 	for _, tt := range tests {
@@ -111,6 +204,9 @@ func TestMessageHandler_PostApiMessages(t *testing.T) {
 				if tt.errorMessage != "" {
 					assert.Contains(t, err.Error(), tt.errorMessage)
 				}
+				if tt.expectedCode == 403 {
+					assert.IsType(t, api.PostApiMessages403Response{}, resp)
+				}
 			} else {
 				assert.NoError(t, err)
 				response, ok := resp.(api.PostApiMessages201JSONResponse)
@@ -118,6 +214,8 @@ func TestMessageHandler_PostApiMessages(t *testing.T) {
 				assert.Equal(t, tt.request.Body.Uid, *response.Uid)
 				assert.Equal(t, tt.request.Body.ChannelId, *response.ChannelId)
 				assert.Equal(t, tt.request.Body.Content, *response.Content)
+				assert.Equal(t, tt.request.Body.TtlSeconds != nil || tt.request.Body.ExpiresAt != nil, response.ExpiresAt != nil)
+				assert.Equal(t, tt.request.Body.ReplyTo, response.ReplyTo)
 			}
 			mockRepo.AssertExpectations(t)
 		})
@@ -138,12 +236,12 @@ func TestMessageHandler_GetApiMessagesSearch(t *testing.T) {
 		{
 			name: "正常系：検索結果あり",
 			request: createTestSearchRequest(api.GetApiMessagesSearchParams{
-				ChannelId: stringPtr("test-channel"),
+				ChannelId: &[]string{"test-channel"},
 				FromDate:  &testTime,
 				ToDate:    &futureTime,
 			}),
 			mockSetup: func(m *mockMessageRepository) {
-				m.On("Search", mock.Anything, mock.AnythingOfType("message.SearchCriteria")).
+				m.On("Search", mock.Anything, mock.AnythingOfType("message.SearchCriteria"), message.SearchOptions{}).
 					Return([]message.Message{*createTestMessage()}, nil)
 			},
 			expectedError: false,
@@ -152,10 +250,10 @@ func TestMessageHandler_GetApiMessagesSearch(t *testing.T) {
 		{
 			name: "正常系：日付範囲指定なしの検索",
 			request: createTestSearchRequest(api.GetApiMessagesSearchParams{
-				ChannelId: stringPtr("test-channel"),
+				ChannelId: &[]string{"test-channel"},
 			}),
 			mockSetup: func(m *mockMessageRepository) {
-				m.On("Search", mock.Anything, mock.AnythingOfType("message.SearchCriteria")).
+				m.On("Search", mock.Anything, mock.AnythingOfType("message.SearchCriteria"), message.SearchOptions{}).
 					Return([]message.Message{*createTestMessage()}, nil)
 			},
 			expectedError: false,
@@ -164,12 +262,12 @@ func TestMessageHandler_GetApiMessagesSearch(t *testing.T) {
 		{
 			name: "異常系：無効な日付範囲",
 			request: createTestSearchRequest(api.GetApiMessagesSearchParams{
-				ChannelId: stringPtr("test-channel"),
+				ChannelId: &[]string{"test-channel"},
 				FromDate:  &futureTime,
 				ToDate:    &testTime,
 			}),
 			mockSetup: func(m *mockMessageRepository) {
-				m.On("Search", mock.Anything, mock.AnythingOfType("message.SearchCriteria")).
+				m.On("Search", mock.Anything, mock.AnythingOfType("message.SearchCriteria"), message.SearchOptions{}).
 					Return(nil, errors.New("invalid date range"))
 			},
 			expectedError: true,
@@ -195,12 +293,246 @@ func TestMessageHandler_GetApiMessagesSearch(t *testing.T) {
 				assert.NoError(t, err)
 				response, ok := resp.(api.GetApiMessagesSearch200JSONResponse)
 				assert.True(t, ok)
-				assert.Len(t, response, tt.expectedLen)
+				assert.Len(t, response.Body, tt.expectedLen)
 				if tt.expectedLen > 0 {
-					assert.NotEmpty(t, response[0].Uid)
-					assert.NotEmpty(t, response[0].ChannelId)
-					assert.NotEmpty(t, response[0].Content)
+					assert.NotEmpty(t, response.Body[0].Uid)
+					assert.NotEmpty(t, response.Body[0].ChannelId)
+					assert.NotEmpty(t, response.Body[0].Content)
 				}
+				// limitを指定しない場合は続きのカーソルを返さない
+				assert.Empty(t, response.Headers.XNextCursor)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageHandler_GetApiMessagesSearch_Filters(t *testing.T) {
+	prefix := "bot-"
+
+	t.Run("正常系：複数指定・前方一致・除外の条件を検索条件に変換する", func(t *testing.T) {
+		mockRepo := new(mockMessageRepository)
+		mockRepo.On("Search", mock.Anything, message.SearchCriteria{
+			ChannelIDs:     []string{"general", "random"},
+			Senders:        []string{"alice"},
+			SenderPrefix:   &prefix,
+			ExcludeSenders: []string{"bot-spam"},
+		}, message.SearchOptions{}).Return([]message.Message{}, nil)
+		handler := NewMessageHandler(mockRepo)
+
+		_, err := handler.GetApiMessagesSearch(context.Background(), createTestSearchRequest(api.GetApiMessagesSearchParams{
+			ChannelId:     &[]string{"general", "random"},
+			Sender:        &[]string{"alice"},
+			SenderPrefix:  &prefix,
+			ExcludeSender: &[]string{"bot-spam"},
+		}))
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系：返信かどうか・返信の有無の条件を検索条件に変換する", func(t *testing.T) {
+		isReply, hasThread := false, true
+		mockRepo := new(mockMessageRepository)
+		mockRepo.On("Search", mock.Anything, message.SearchCriteria{
+			IsReply:   &isReply,
+			HasThread: &hasThread,
+		}, message.SearchOptions{}).Return([]message.Message{}, nil)
+		handler := NewMessageHandler(mockRepo)
+
+		_, err := handler.GetApiMessagesSearch(context.Background(), createTestSearchRequest(api.GetApiMessagesSearchParams{
+			IsReply:   &isReply,
+			HasThread: &hasThread,
+		}))
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系：指定された値が多すぎる", func(t *testing.T) {
+		mockRepo := new(mockMessageRepository)
+		handler := NewMessageHandler(mockRepo)
+		channelIDs := make([]string, message.MaxFilterValues+1)
+
+		resp, err := handler.GetApiMessagesSearch(context.Background(), createTestSearchRequest(api.GetApiMessagesSearchParams{
+			ChannelId: &channelIDs,
+		}))
+
+		assert.ErrorIs(t, err, message.ErrTooManyFilterValues)
+		assert.Equal(t, api.GetApiMessagesSearch400Response{}, resp)
+		mockRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestMessageHandler_GetApiMessagesSearch_Options(t *testing.T) {
+	msg := createTestMessage()
+	desc := api.MinusSentAt
+	invalidSort := api.GetApiMessagesSearchParamsSort("-created_at")
+
+	tests := []struct {
+		name          string
+		params        api.GetApiMessagesSearchParams
+		mockSetup     func(*mockMessageRepository)
+		expectedError error
+		verify        func(t *testing.T, response api.GetApiMessagesSearch200JSONResponse)
+	}{
+		{
+			name:   "正常系：並び順をリポジトリに渡す",
+			params: api.GetApiMessagesSearchParams{Sort: &desc},
+			mockSetup: func(m *mockMessageRepository) {
+				m.On("Search", mock.Anything, mock.Anything, message.SearchOptions{Sort: message.SortSentAtDesc}).
+					Return([]message.Message{*msg}, nil)
+			},
+			verify: func(t *testing.T, response api.GetApiMessagesSearch200JSONResponse) {
+				assert.Equal(t, toAPIMessage(msg), response.Body[0])
+			},
+		},
+		{
+			name:   "正常系：指定したフィールドのみを返す",
+			params: api.GetApiMessagesSearchParams{Fields: &[]string{"uid", "sender"}},
+			mockSetup: func(m *mockMessageRepository) {
+				m.On("Search", mock.Anything, mock.Anything, message.SearchOptions{Fields: []string{"uid", "sender"}}).
+					Return([]message.Message{*msg}, nil)
+			},
+			verify: func(t *testing.T, response api.GetApiMessagesSearch200JSONResponse) {
+				assert.Equal(t, api.Message{Uid: &msg.UID, Sender: &msg.Sender}, response.Body[0])
+			},
+		},
+		{
+			name:          "異常系：不正な並び順",
+			params:        api.GetApiMessagesSearchParams{Sort: &invalidSort},
+			mockSetup:     func(m *mockMessageRepository) {},
+			expectedError: message.ErrInvalidSort,
+		},
+		{
+			name:          "異常系：不正なフィールド",
+			params:        api.GetApiMessagesSearchParams{Fields: &[]string{"uid", "password"}},
+			mockSetup:     func(m *mockMessageRepository) {},
+			expectedError: message.ErrInvalidField,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mockMessageRepository)
+			tt.mockSetup(mockRepo)
+			handler := NewMessageHandler(mockRepo)
+
+			resp, err := handler.GetApiMessagesSearch(context.Background(), createTestSearchRequest(tt.params))
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Equal(t, api.GetApiMessagesSearch400Response{}, resp)
+			} else {
+				assert.NoError(t, err)
+				response, ok := resp.(api.GetApiMessagesSearch200JSONResponse)
+				assert.True(t, ok)
+				assert.Len(t, response.Body, 1)
+				tt.verify(t, response)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageHandler_GetApiMessagesSearch_Pages(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newMessages := func(n int) []message.Message {
+		msgs := make([]message.Message, n)
+		for i := range msgs {
+			msgs[i] = *createTestMessage()
+			msgs[i].ID = primitive.NewObjectID()
+			msgs[i].SentAt = base.Add(time.Duration(i) * time.Minute)
+		}
+		return msgs
+	}
+	msgs := newMessages(3)
+	after := message.SortOrder("").CursorAfter(&msgs[0])
+	cursor := after.String()
+	descCursor := message.SortSentAtDesc.CursorAfter(&msgs[0]).String()
+	invalidCursor := "not-a-cursor"
+	limit2 := 2
+	limit0 := 0
+	limitTooLarge := message.MaxSearchLimit + 1
+
+	tests := []struct {
+		name           string
+		params         api.GetApiMessagesSearchParams
+		mockSetup      func(*mockMessageRepository)
+		expectedLen    int
+		expectedCursor string
+		expectedError  error
+	}{
+		{
+			name:   "正常系：続きがある場合は最後のメッセージの次を指すカーソルを返す",
+			params: api.GetApiMessagesSearchParams{Limit: &limit2},
+			mockSetup: func(m *mockMessageRepository) {
+				// 続きの有無を判定するため1件多く取得する
+				m.On("Search", mock.Anything, mock.Anything, message.SearchOptions{Limit: 3}).Return(msgs, nil)
+			},
+			expectedLen:    2,
+			expectedCursor: message.SortOrder("").CursorAfter(&msgs[1]).String(),
+		},
+		{
+			name:   "正常系：最後のページではカーソルを返さない",
+			params: api.GetApiMessagesSearchParams{Limit: &limit2, Cursor: &cursor},
+			mockSetup: func(m *mockMessageRepository) {
+				m.On("Search", mock.Anything, mock.Anything, message.SearchOptions{Limit: 3, After: after}).Return(msgs[1:], nil)
+			},
+			expectedLen: 2,
+		},
+		{
+			name:   "正常系：フィールドを指定しても並び順のキーを取得する",
+			params: api.GetApiMessagesSearchParams{Limit: &limit2, Fields: &[]string{"uid"}},
+			mockSetup: func(m *mockMessageRepository) {
+				m.On("Search", mock.Anything, mock.Anything, message.SearchOptions{Limit: 3, Fields: []string{"uid", "sent_at"}}).Return(msgs, nil)
+			},
+			expectedLen:    2,
+			expectedCursor: message.SortOrder("").CursorAfter(&msgs[1]).String(),
+		},
+		{
+			name:          "異常系：件数が0",
+			params:        api.GetApiMessagesSearchParams{Limit: &limit0},
+			mockSetup:     func(m *mockMessageRepository) {},
+			expectedError: message.ErrInvalidLimit,
+		},
+		{
+			name:          "異常系：件数が上限を超える",
+			params:        api.GetApiMessagesSearchParams{Limit: &limitTooLarge},
+			mockSetup:     func(m *mockMessageRepository) {},
+			expectedError: message.ErrInvalidLimit,
+		},
+		{
+			name:          "異常系：不正なカーソル",
+			params:        api.GetApiMessagesSearchParams{Limit: &limit2, Cursor: &invalidCursor},
+			mockSetup:     func(m *mockMessageRepository) {},
+			expectedError: message.ErrInvalidCursor,
+		},
+		{
+			name:          "異常系：並び順の異なるカーソル",
+			params:        api.GetApiMessagesSearchParams{Limit: &limit2, Cursor: &descCursor},
+			mockSetup:     func(m *mockMessageRepository) {},
+			expectedError: message.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mockMessageRepository)
+			tt.mockSetup(mockRepo)
+			handler := NewMessageHandler(mockRepo)
+
+			resp, err := handler.GetApiMessagesSearch(context.Background(), createTestSearchRequest(tt.params))
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Equal(t, api.GetApiMessagesSearch400Response{}, resp)
+			} else {
+				assert.NoError(t, err)
+				response, ok := resp.(api.GetApiMessagesSearch200JSONResponse)
+				assert.True(t, ok)
+				assert.Len(t, response.Body, tt.expectedLen)
+				assert.Equal(t, tt.expectedCursor, response.Headers.XNextCursor)
 			}
 			mockRepo.AssertExpectations(t)
 		})
@@ -274,6 +606,92 @@ func TestMessageHandler_DeleteApiMessagesUid(t *testing.T) {
 	}
 }
 
-func stringPtr(s string) *string {
-	return &s
+func TestMessageHandler_GetApiMessagesUidContext(t *testing.T) {
+	target := createTestMessage()
+	older := []message.Message{{UID: "older", ChannelID: target.ChannelID, SentAt: target.SentAt.Add(-time.Minute)}}
+	newer := []message.Message{{UID: "newer", ChannelID: target.ChannelID, SentAt: target.SentAt.Add(time.Minute)}}
+
+	tests := []struct {
+		name         string
+		params       api.GetApiMessagesUidContextParams
+		mockSetup    func(*mockMessageRepository)
+		expectedResp interface{}
+		expectError  bool
+	}{
+		{
+			name:   "正常系：既定の件数で前後のメッセージを取得",
+			params: api.GetApiMessagesUidContextParams{},
+			mockSetup: func(m *mockMessageRepository) {
+				m.On("FindByUID", mock.Anything, "test-uid").Return(target, nil)
+				m.On("Around", mock.Anything, target, message.DefaultAroundLimit, message.DefaultAroundLimit).Return(older, newer, nil)
+			},
+			expectedResp: api.GetApiMessagesUidContext200JSONResponse{
+				Before:  toAPIMessages(older),
+				Message: toAPIMessage(target),
+				After:   toAPIMessages(newer),
+			},
+		},
+		{
+			name:   "正常系：件数を指定し、前後が無い場合は空配列",
+			params: api.GetApiMessagesUidContextParams{Before: intPtr(0), After: intPtr(5)},
+			mockSetup: func(m *mockMessageRepository) {
+				m.On("FindByUID", mock.Anything, "test-uid").Return(target, nil)
+				m.On("Around", mock.Anything, target, 0, 5).Return(nil, nil, nil)
+			},
+			expectedResp: api.GetApiMessagesUidContext200JSONResponse{
+				Before:  []api.Message{},
+				Message: toAPIMessage(target),
+				After:   []api.Message{},
+			},
+		},
+		{
+			name:         "正常系：存在しないメッセージ",
+			params:       api.GetApiMessagesUidContextParams{},
+			mockSetup:    func(m *mockMessageRepository) { m.On("FindByUID", mock.Anything, "test-uid").Return(nil, nil) },
+			expectedResp: api.GetApiMessagesUidContext404Response{},
+		},
+		{
+			name:         "異常系：件数が上限を超える",
+			params:       api.GetApiMessagesUidContextParams{Before: intPtr(message.MaxAroundLimit + 1)},
+			mockSetup:    func(m *mockMessageRepository) {},
+			expectedResp: api.GetApiMessagesUidContext400Response{},
+			expectError:  true,
+		},
+		{
+			name:   "異常系：前後の取得でエラー",
+			params: api.GetApiMessagesUidContextParams{},
+			mockSetup: func(m *mockMessageRepository) {
+				m.On("FindByUID", mock.Anything, "test-uid").Return(target, nil)
+				m.On("Around", mock.Anything, target, mock.Anything, mock.Anything).Return(nil, nil, errors.New("db error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mockMessageRepository)
+			tt.mockSetup(mockRepo)
+			handler := NewMessageHandler(mockRepo)
+
+			resp, err := handler.GetApiMessagesUidContext(context.Background(), api.GetApiMessagesUidContextRequestObject{
+				Uid:    "test-uid",
+				Params: tt.params,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.expectedResp != nil {
+				assert.Equal(t, tt.expectedResp, resp)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
 }
@@ -2,7 +2,9 @@ package handler
 
 import (
 	"context"
+	"message-service/internal/domain/audit"
 	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
 	"message-service/internal/domain/token"
 
 	"github.com/stretchr/testify/mock"
@@ -18,19 +20,69 @@ func (m *mockMessageRepository) Create(ctx context.Context, msg *message.Message
 	return args.Error(0)
 }
 
+func (m *mockMessageRepository) CreateMany(ctx context.Context, msgs []*message.Message) ([]error, error) {
+	args := m.Called(ctx, msgs)
+	if errs, ok := args.Get(0).([]error); ok {
+		return errs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *mockMessageRepository) Delete(ctx context.Context, uid string) error {
 	args := m.Called(ctx, uid)
 	return args.Error(0)
 }
 
-func (m *mockMessageRepository) Search(ctx context.Context, criteria message.SearchCriteria) ([]message.Message, error) {
-	args := m.Called(ctx, criteria)
+func (m *mockMessageRepository) Search(ctx context.Context, criteria message.SearchCriteria, opts message.SearchOptions) ([]message.Message, error) {
+	args := m.Called(ctx, criteria, opts)
 	if msgs, ok := args.Get(0).([]message.Message); ok {
 		return msgs, args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
+func (m *mockMessageRepository) Iterate(ctx context.Context, criteria message.SearchCriteria, fn func(*message.Message) error) error {
+	args := m.Called(ctx, criteria)
+	if msgs, ok := args.Get(0).([]message.Message); ok {
+		for i := range msgs {
+			if err := fn(&msgs[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *mockMessageRepository) Count(ctx context.Context, criteria message.SearchCriteria) (int64, error) {
+	args := m.Called(ctx, criteria)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockMessageRepository) CountUpTo(ctx context.Context, criteria message.SearchCriteria, limit int64) (int64, error) {
+	args := m.Called(ctx, criteria, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockMessageRepository) DeleteMany(ctx context.Context, criteria message.SearchCriteria) (int64, error) {
+	args := m.Called(ctx, criteria)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockMessageRepository) Around(ctx context.Context, target *message.Message, before, after int) ([]message.Message, []message.Message, error) {
+	args := m.Called(ctx, target, before, after)
+	older, _ := args.Get(0).([]message.Message)
+	newer, _ := args.Get(1).([]message.Message)
+	return older, newer, args.Error(2)
+}
+
+func (m *mockMessageRepository) Stats(ctx context.Context, query message.StatsQuery) ([]message.StatsBucket, error) {
+	args := m.Called(ctx, query)
+	if buckets, ok := args.Get(0).([]message.StatsBucket); ok {
+		return buckets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *mockMessageRepository) FindByUID(ctx context.Context, uid string) (*message.Message, error) {
 	args := m.Called(ctx, uid)
 	if msg, ok := args.Get(0).(*message.Message); ok {
@@ -39,6 +91,16 @@ func (m *mockMessageRepository) FindByUID(ctx context.Context, uid string) (*mes
 	return nil, args.Error(1)
 }
 
+// mockAuditRepository は監査ログリポジトリのモック
+type mockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *mockAuditRepository) Create(ctx context.Context, log *audit.Log) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
 // mockTokenRepository はトークンリポジトリのモック
 type mockTokenRepository struct {
 	mock.Mock
@@ -62,6 +124,11 @@ func (m *mockTokenRepository) List(ctx context.Context) ([]token.Token, error) {
 	return nil, args.Error(1)
 }
 
+func (m *mockTokenRepository) CountUpTo(ctx context.Context, limit int64) (int64, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *mockTokenRepository) FindByID(ctx context.Context, id string) (*token.Token, error) {
 	args := m.Called(ctx, id)
 	if t, ok := args.Get(0).(*token.Token); ok {
@@ -77,3 +144,44 @@ func (m *mockTokenRepository) FindByToken(ctx context.Context, tokenStr string)
 	}
 	return nil, args.Error(1)
 }
+
+// mockTenantRepository はテナントリポジトリのモック
+type mockTenantRepository struct {
+	mock.Mock
+}
+
+func (m *mockTenantRepository) Create(ctx context.Context, t *tenant.Tenant) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *mockTenantRepository) Update(ctx context.Context, t *tenant.Tenant) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *mockTenantRepository) Get(ctx context.Context, id string) (*tenant.Tenant, error) {
+	args := m.Called(ctx, id)
+	if t, ok := args.Get(0).(*tenant.Tenant); ok {
+		return t, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockTenantRepository) List(ctx context.Context) ([]tenant.Tenant, error) {
+	args := m.Called(ctx)
+	if tenants, ok := args.Get(0).([]tenant.Tenant); ok {
+		return tenants, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockTenantRepository) Reserve(ctx context.Context, id string, resource tenant.Resource, n int64) (int64, error) {
+	args := m.Called(ctx, id, resource, n)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockTenantRepository) Release(ctx context.Context, id string, resource tenant.Resource, n int64) error {
+	args := m.Called(ctx, id, resource, n)
+	return args.Error(0)
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/domain/token"
+	"message-service/internal/infrastructure/middleware"
+	"message-service/pkg/api"
+)
+
+var (
+	// ErrAdminRequired は管理用でないトークン、または他のテナントの管理用トークンで管理用の操作を行おうとした場合のエラー
+	ErrAdminRequired = errors.New("admin token required")
+	// ErrSuperAdminRequired は全てのテナントを管理できるトークン以外でテナントの作成や更新を行おうとした場合のエラー
+	ErrSuperAdminRequired = errors.New("super admin token required")
+)
+
+// TenantHandler はテナントの管理を扱う
+// テナントの一覧・作成・更新は全てのテナントを管理できるトークンでのみ行える
+// テナントの取得とトークンの発行は、そのテナントの管理用トークンでも行える
+type TenantHandler struct {
+	repo         tenant.Repository
+	messageRepo  message.Repository
+	tokenRepo    token.Repository
+	tokenHandler *TokenHandler
+}
+
+func NewTenantHandler(repo tenant.Repository, messageRepo message.Repository, tokenRepo token.Repository, tokenHandler *TokenHandler) *TenantHandler {
+	return &TenantHandler{repo: repo, messageRepo: messageRepo, tokenRepo: tokenRepo, tokenHandler: tokenHandler}
+}
+
+// isAdmin はリクエストが管理用のトークンで認証されているかを判定する
+// 管理用のトークンが管理できるのは、トークンが属するテナントのみ
+func isAdmin(ctx context.Context) bool {
+	tkn := middleware.TokenFromContext(ctx)
+	return tkn != nil && (tkn.Admin || tkn.SuperAdmin)
+}
+
+// isSuperAdmin はリクエストが全てのテナントを管理できるトークンで認証されているかを判定する
+func isSuperAdmin(ctx context.Context) bool {
+	tkn := middleware.TokenFromContext(ctx)
+	return tkn != nil && tkn.SuperAdmin
+}
+
+// canManageTenant はリクエストのトークンで指定されたテナントを管理できるかを判定する
+func canManageTenant(ctx context.Context, id string) bool {
+	if isSuperAdmin(ctx) {
+		return true
+	}
+	tkn := middleware.TokenFromContext(ctx)
+	return isAdmin(ctx) && tkn.TenantID == id
+}
+
+func (h *TenantHandler) GetApiTenants(ctx context.Context, req api.GetApiTenantsRequestObject) (api.GetApiTenantsResponseObject, error) {
+	if !isSuperAdmin(ctx) {
+		return api.GetApiTenants403Response{}, ErrSuperAdminRequired
+	}
+
+	tenants, err := h.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(api.GetApiTenants200JSONResponse, len(tenants))
+	for i := range tenants {
+		response[i] = toAPITenant(&tenants[i])
+	}
+	return response, nil
+}
+
+func (h *TenantHandler) PostApiTenants(ctx context.Context, req api.PostApiTenantsRequestObject) (api.PostApiTenantsResponseObject, error) {
+	if !isSuperAdmin(ctx) {
+		return api.PostApiTenants403Response{}, ErrSuperAdminRequired
+	}
+
+	t := &tenant.Tenant{ID: req.Body.Id, Name: req.Body.Name}
+	if req.Body.Quota != nil {
+		applyQuota(&t.Quota, *req.Body.Quota)
+	}
+	if err := t.Validate(); err != nil {
+		return api.PostApiTenants400Response{}, err
+	}
+
+	if err := h.repo.Create(ctx, t); err != nil {
+		if errors.Is(err, tenant.ErrDuplicateTenant) {
+			return api.PostApiTenants409Response{}, err
+		}
+		return nil, err
+	}
+	return api.PostApiTenants201JSONResponse(toAPITenant(t)), nil
+}
+
+// GetApiTenantsId はテナントと、そのテナントが保持している有効なメッセージとトークンの件数を返す
+func (h *TenantHandler) GetApiTenantsId(ctx context.Context, req api.GetApiTenantsIdRequestObject) (api.GetApiTenantsIdResponseObject, error) {
+	// 他のテナントの存在を推測できないよう、存在の確認より先に判定する
+	if !canManageTenant(ctx, req.Id) {
+		return api.GetApiTenantsId403Response{}, ErrAdminRequired
+	}
+
+	t, err := h.repo.Get(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return api.GetApiTenantsId404Response{}, nil
+	}
+
+	// 件数は対象のテナントに切り替えて数える
+	tenantCtx := tenant.WithID(ctx, t.ID)
+	messages, err := h.messageRepo.Count(tenantCtx, message.SearchCriteria{})
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := h.tokenRepo.List(tenantCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toAPITenant(t)
+	response.Usage = &api.TenantUsage{Messages: messages, Tokens: int64(len(tokens))}
+	return api.GetApiTenantsId200JSONResponse(response), nil
+}
+
+// PatchApiTenantsId は指定された項目のみを更新する
+func (h *TenantHandler) PatchApiTenantsId(ctx context.Context, req api.PatchApiTenantsIdRequestObject) (api.PatchApiTenantsIdResponseObject, error) {
+	if !isSuperAdmin(ctx) {
+		return api.PatchApiTenantsId403Response{}, ErrSuperAdminRequired
+	}
+
+	t, err := h.repo.Get(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return api.PatchApiTenantsId404Response{}, nil
+	}
+
+	if req.Body.Name != nil {
+		t.Name = *req.Body.Name
+	}
+	if req.Body.Quota != nil {
+		applyQuota(&t.Quota, *req.Body.Quota)
+	}
+	if err := t.Validate(); err != nil {
+		return api.PatchApiTenantsId400Response{}, err
+	}
+
+	if err := h.repo.Update(ctx, t); err != nil {
+		return nil, err
+	}
+	return api.PatchApiTenantsId200JSONResponse(toAPITenant(t)), nil
+}
+
+// PostApiTenantsIdTokens は指定されたテナントにトークンを発行する
+// 作成したばかりのテナントに最初のトークンを発行するために使う
+func (h *TenantHandler) PostApiTenantsIdTokens(ctx context.Context, req api.PostApiTenantsIdTokensRequestObject) (api.PostApiTenantsIdTokensResponseObject, error) {
+	// 他のテナントの存在を推測できないよう、存在の確認より先に判定する
+	if !canManageTenant(ctx, req.Id) {
+		return api.PostApiTenantsIdTokens403Response{}, ErrAdminRequired
+	}
+
+	t, err := h.repo.Get(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return api.PostApiTenantsIdTokens404Response{}, nil
+	}
+
+	admin := req.Body.Admin != nil && *req.Body.Admin
+
+	tkn, err := h.tokenHandler.Issue(tenant.WithID(ctx, t.ID), req.Body.Name, h.tokenHandler.ttl(req.Body.ExpiresIn), admin)
+	if errors.Is(err, tenant.ErrQuotaExceeded) {
+		return api.PostApiTenantsIdTokens403Response{}, err
+	}
+	if err != nil {
+		return api.PostApiTenantsIdTokens400Response{}, err
+	}
+	return api.PostApiTenantsIdTokens201JSONResponse(toTokenResponse(tkn)), nil
+}
+
+// applyQuota はリクエストで指定された上限のみを反映する
+func applyQuota(quota *tenant.Quota, req api.TenantQuota) {
+	if req.MaxMessages != nil {
+		quota.MaxMessages = *req.MaxMessages
+	}
+	if req.MaxTokens != nil {
+		quota.MaxTokens = *req.MaxTokens
+	}
+}
+
+func toAPITenant(t *tenant.Tenant) api.Tenant {
+	maxMessages, maxTokens := t.Quota.MaxMessages, t.Quota.MaxTokens
+	createdAt, updatedAt := t.CreatedAt, t.UpdatedAt
+	return api.Tenant{
+		Id:   t.ID,
+		Name: t.Name,
+		Quota: api.TenantQuota{
+			MaxMessages: &maxMessages,
+			MaxTokens:   &maxTokens,
+		},
+		CreatedAt: &createdAt,
+		UpdatedAt: &updatedAt,
+	}
+}
@@ -0,0 +1,470 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/domain/token"
+	"message-service/internal/infrastructure/middleware"
+	"message-service/pkg/api"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// テストヘルパー関数
+func createTestTenant() *tenant.Tenant {
+	now := time.Now()
+	return &tenant.Tenant{
+		ID:        "acme",
+		Name:      "Acme",
+		Quota:     tenant.Quota{MaxMessages: 100, MaxTokens: 5},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// superAdminContext は全てのテナントを管理できるトークンで認証されたコンテキストを返す
+func superAdminContext() context.Context {
+	return middleware.ContextWithToken(context.Background(), &token.Token{TenantID: tenant.DefaultID, Admin: true, SuperAdmin: true})
+}
+
+// inTenant はコンテキストのテナントが指定されたものかを判定するmatcherを返す
+func inTenant(id string) interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		got, err := tenant.FromContext(ctx)
+		return err == nil && got == id
+	})
+}
+
+// tenantAdminContext は指定されたテナントの管理用のトークンで認証されたコンテキストを返す
+func tenantAdminContext(id string) context.Context {
+	return middleware.ContextWithToken(context.Background(), &token.Token{TenantID: id, Admin: true})
+}
+
+// memberContext は管理用でないトークンで認証されたコンテキストを返す
+func memberContext() context.Context {
+	return middleware.ContextWithToken(context.Background(), &token.Token{TenantID: tenant.DefaultID})
+}
+
+func TestTenantHandler_RequireAdmin(t *testing.T) {
+	handler := NewTenantHandler(new(mockTenantRepository), new(mockMessageRepository), new(mockTokenRepository), NewTokenHandler(new(mockTokenRepository), testTokenTTL))
+	ctx := memberContext()
+
+	tests := []struct {
+		name    string
+		call    func() (interface{}, error)
+		want    interface{}
+		wantErr error
+	}{
+		{
+			name: "異常系：一覧の取得",
+			call: func() (interface{}, error) {
+				return handler.GetApiTenants(ctx, api.GetApiTenantsRequestObject{})
+			},
+			want:    api.GetApiTenants403Response{},
+			wantErr: ErrSuperAdminRequired,
+		},
+		{
+			name: "異常系：作成",
+			call: func() (interface{}, error) {
+				return handler.PostApiTenants(ctx, api.PostApiTenantsRequestObject{Body: &api.TenantCreate{Id: "acme", Name: "Acme"}})
+			},
+			want:    api.PostApiTenants403Response{},
+			wantErr: ErrSuperAdminRequired,
+		},
+		{
+			name: "異常系：取得",
+			call: func() (interface{}, error) {
+				return handler.GetApiTenantsId(ctx, api.GetApiTenantsIdRequestObject{Id: tenant.DefaultID})
+			},
+			want:    api.GetApiTenantsId403Response{},
+			wantErr: ErrAdminRequired,
+		},
+		{
+			name: "異常系：更新",
+			call: func() (interface{}, error) {
+				return handler.PatchApiTenantsId(ctx, api.PatchApiTenantsIdRequestObject{Id: "acme", Body: &api.TenantUpdate{}})
+			},
+			want:    api.PatchApiTenantsId403Response{},
+			wantErr: ErrSuperAdminRequired,
+		},
+		{
+			name: "異常系：トークンの発行",
+			call: func() (interface{}, error) {
+				return handler.PostApiTenantsIdTokens(ctx, api.PostApiTenantsIdTokensRequestObject{Id: tenant.DefaultID, Body: &api.TokenCreate{Name: "ci"}})
+			},
+			want:    api.PostApiTenantsIdTokens403Response{},
+			wantErr: ErrAdminRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := tt.call()
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.IsType(t, tt.want, resp)
+		})
+	}
+}
+
+func TestTenantHandler_TenantAdmin(t *testing.T) {
+	// team-aの管理用トークンは自身のテナントのみを管理でき、他のテナントやテナントの作成・更新は扱えない
+	ctx := tenantAdminContext("team-a")
+	teamA := &tenant.Tenant{ID: "team-a", Name: "Team A"}
+	inTeamA := inTenant("team-a")
+
+	tests := []struct {
+		name      string
+		mockSetup func(*mockTenantRepository, *mockMessageRepository, *mockTokenRepository)
+		call      func(*TenantHandler) (interface{}, error)
+		want      interface{}
+		wantErr   error
+	}{
+		{
+			name: "正常系：自身のテナントを取得できる",
+			mockSetup: func(tr *mockTenantRepository, mr *mockMessageRepository, kr *mockTokenRepository) {
+				tr.On("Get", mock.Anything, "team-a").Return(teamA, nil)
+				mr.On("Count", inTeamA, message.SearchCriteria{}).Return(int64(1), nil)
+				kr.On("List", inTeamA).Return([]token.Token{}, nil)
+			},
+			call: func(h *TenantHandler) (interface{}, error) {
+				return h.GetApiTenantsId(ctx, api.GetApiTenantsIdRequestObject{Id: "team-a"})
+			},
+			want: api.GetApiTenantsId200JSONResponse{},
+		},
+		{
+			name: "正常系：自身のテナントにトークンを発行できる",
+			mockSetup: func(tr *mockTenantRepository, mr *mockMessageRepository, kr *mockTokenRepository) {
+				tr.On("Get", mock.Anything, "team-a").Return(teamA, nil)
+				kr.On("Create", inTeamA, mock.MatchedBy(func(tkn *token.Token) bool {
+					return !tkn.SuperAdmin
+				})).Return(nil)
+			},
+			call: func(h *TenantHandler) (interface{}, error) {
+				return h.PostApiTenantsIdTokens(ctx, api.PostApiTenantsIdTokensRequestObject{Id: "team-a", Body: &api.TokenCreate{Name: "ci"}})
+			},
+			want: api.PostApiTenantsIdTokens201JSONResponse{},
+		},
+		{
+			name:      "異常系：他のテナントは取得できない",
+			mockSetup: func(*mockTenantRepository, *mockMessageRepository, *mockTokenRepository) {},
+			call: func(h *TenantHandler) (interface{}, error) {
+				return h.GetApiTenantsId(ctx, api.GetApiTenantsIdRequestObject{Id: "team-b"})
+			},
+			want:    api.GetApiTenantsId403Response{},
+			wantErr: ErrAdminRequired,
+		},
+		{
+			name:      "異常系：他のテナントにトークンを発行できない",
+			mockSetup: func(*mockTenantRepository, *mockMessageRepository, *mockTokenRepository) {},
+			call: func(h *TenantHandler) (interface{}, error) {
+				return h.PostApiTenantsIdTokens(ctx, api.PostApiTenantsIdTokensRequestObject{Id: "team-b", Body: &api.TokenCreate{Name: "ci"}})
+			},
+			want:    api.PostApiTenantsIdTokens403Response{},
+			wantErr: ErrAdminRequired,
+		},
+		{
+			name:      "異常系：一覧は取得できない",
+			mockSetup: func(*mockTenantRepository, *mockMessageRepository, *mockTokenRepository) {},
+			call: func(h *TenantHandler) (interface{}, error) {
+				return h.GetApiTenants(ctx, api.GetApiTenantsRequestObject{})
+			},
+			want:    api.GetApiTenants403Response{},
+			wantErr: ErrSuperAdminRequired,
+		},
+		{
+			name:      "異常系：テナントを作成できない",
+			mockSetup: func(*mockTenantRepository, *mockMessageRepository, *mockTokenRepository) {},
+			call: func(h *TenantHandler) (interface{}, error) {
+				return h.PostApiTenants(ctx, api.PostApiTenantsRequestObject{Body: &api.TenantCreate{Id: "team-b", Name: "Team B"}})
+			},
+			want:    api.PostApiTenants403Response{},
+			wantErr: ErrSuperAdminRequired,
+		},
+		{
+			name:      "異常系：自身のテナントの上限も変更できない",
+			mockSetup: func(*mockTenantRepository, *mockMessageRepository, *mockTokenRepository) {},
+			call: func(h *TenantHandler) (interface{}, error) {
+				return h.PatchApiTenantsId(ctx, api.PatchApiTenantsIdRequestObject{Id: "team-a", Body: &api.TenantUpdate{}})
+			},
+			want:    api.PatchApiTenantsId403Response{},
+			wantErr: ErrSuperAdminRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantRepo, messageRepo, tokenRepo := new(mockTenantRepository), new(mockMessageRepository), new(mockTokenRepository)
+			tt.mockSetup(tenantRepo, messageRepo, tokenRepo)
+			handler := NewTenantHandler(tenantRepo, messageRepo, tokenRepo, NewTokenHandler(tokenRepo, testTokenTTL))
+
+			resp, err := tt.call(handler)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.IsType(t, tt.want, resp)
+			tenantRepo.AssertExpectations(t)
+			messageRepo.AssertExpectations(t)
+			tokenRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTenantHandler_GetApiTenants(t *testing.T) {
+	tenantRepo := new(mockTenantRepository)
+	tenantRepo.On("List", mock.Anything).Return([]tenant.Tenant{{ID: tenant.DefaultID, Name: "Default"}, *createTestTenant()}, nil)
+	handler := NewTenantHandler(tenantRepo, new(mockMessageRepository), new(mockTokenRepository), nil)
+
+	resp, err := handler.GetApiTenants(superAdminContext(), api.GetApiTenantsRequestObject{})
+
+	assert.NoError(t, err)
+	tenants, ok := resp.(api.GetApiTenants200JSONResponse)
+	if assert.True(t, ok) && assert.Len(t, tenants, 2) {
+		assert.Equal(t, "acme", tenants[1].Id)
+		assert.Equal(t, int64(100), *tenants[1].Quota.MaxMessages)
+		assert.Equal(t, int64(5), *tenants[1].Quota.MaxTokens)
+	}
+	tenantRepo.AssertExpectations(t)
+}
+
+func TestTenantHandler_PostApiTenants(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          api.TenantCreate
+		mockSetup     func(*mockTenantRepository)
+		expectedType  interface{}
+		expectedError error
+	}{
+		{
+			name: "正常系：上限を指定してテナントを作成",
+			body: api.TenantCreate{Id: "acme", Name: "Acme", Quota: &api.TenantQuota{MaxMessages: int64Ptr(100)}},
+			mockSetup: func(m *mockTenantRepository) {
+				m.On("Create", mock.Anything, mock.MatchedBy(func(t *tenant.Tenant) bool {
+					return t.ID == "acme" && t.Quota.MaxMessages == 100 && t.Quota.MaxTokens == 0
+				})).Return(nil)
+			},
+			expectedType: api.PostApiTenants201JSONResponse{},
+		},
+		{
+			name:          "異常系：不正なテナントID",
+			body:          api.TenantCreate{Id: "Acme Corp", Name: "Acme"},
+			mockSetup:     func(m *mockTenantRepository) {},
+			expectedType:  api.PostApiTenants400Response{},
+			expectedError: tenant.ErrInvalidID,
+		},
+		{
+			name:          "異常系：負の上限",
+			body:          api.TenantCreate{Id: "acme", Name: "Acme", Quota: &api.TenantQuota{MaxTokens: int64Ptr(-1)}},
+			mockSetup:     func(m *mockTenantRepository) {},
+			expectedType:  api.PostApiTenants400Response{},
+			expectedError: tenant.ErrInvalidQuota,
+		},
+		{
+			name: "異常系：既に存在するテナント",
+			body: api.TenantCreate{Id: "acme", Name: "Acme"},
+			mockSetup: func(m *mockTenantRepository) {
+				m.On("Create", mock.Anything, mock.Anything).Return(tenant.ErrDuplicateTenant)
+			},
+			expectedType:  api.PostApiTenants409Response{},
+			expectedError: tenant.ErrDuplicateTenant,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantRepo := new(mockTenantRepository)
+			tt.mockSetup(tenantRepo)
+			handler := NewTenantHandler(tenantRepo, new(mockMessageRepository), new(mockTokenRepository), nil)
+
+			resp, err := handler.PostApiTenants(superAdminContext(), api.PostApiTenantsRequestObject{Body: &tt.body})
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.IsType(t, tt.expectedType, resp)
+			tenantRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTenantHandler_GetApiTenantsId(t *testing.T) {
+	// 件数は対象のテナントのコンテキストで数える
+	inAcme := inTenant("acme")
+
+	tests := []struct {
+		name         string
+		mockSetup    func(*mockTenantRepository, *mockMessageRepository, *mockTokenRepository)
+		expectedType interface{}
+		expectError  bool
+	}{
+		{
+			name: "正常系：使用量を含めて返す",
+			mockSetup: func(tr *mockTenantRepository, mr *mockMessageRepository, kr *mockTokenRepository) {
+				tr.On("Get", mock.Anything, "acme").Return(createTestTenant(), nil)
+				mr.On("Count", inAcme, message.SearchCriteria{}).Return(int64(42), nil)
+				kr.On("List", inAcme).Return([]token.Token{*createTestToken(), *createTestToken()}, nil)
+			},
+			expectedType: api.GetApiTenantsId200JSONResponse{},
+		},
+		{
+			name: "異常系：存在しないテナント",
+			mockSetup: func(tr *mockTenantRepository, mr *mockMessageRepository, kr *mockTokenRepository) {
+				tr.On("Get", mock.Anything, "acme").Return(nil, nil)
+			},
+			expectedType: api.GetApiTenantsId404Response{},
+		},
+		{
+			name: "異常系：件数の取得でエラー",
+			mockSetup: func(tr *mockTenantRepository, mr *mockMessageRepository, kr *mockTokenRepository) {
+				tr.On("Get", mock.Anything, "acme").Return(createTestTenant(), nil)
+				mr.On("Count", inAcme, message.SearchCriteria{}).Return(int64(0), errors.New("database error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantRepo, messageRepo, tokenRepo := new(mockTenantRepository), new(mockMessageRepository), new(mockTokenRepository)
+			tt.mockSetup(tenantRepo, messageRepo, tokenRepo)
+			handler := NewTenantHandler(tenantRepo, messageRepo, tokenRepo, nil)
+
+			resp, err := handler.GetApiTenantsId(superAdminContext(), api.GetApiTenantsIdRequestObject{Id: "acme"})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.IsType(t, tt.expectedType, resp)
+			}
+			if got, ok := resp.(api.GetApiTenantsId200JSONResponse); ok {
+				assert.Equal(t, &api.TenantUsage{Messages: 42, Tokens: 2}, got.Usage)
+			}
+			tenantRepo.AssertExpectations(t)
+			messageRepo.AssertExpectations(t)
+			tokenRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTenantHandler_PatchApiTenantsId(t *testing.T) {
+	name := "Acme Inc."
+
+	tests := []struct {
+		name          string
+		body          api.TenantUpdate
+		mockSetup     func(*mockTenantRepository)
+		expectedType  interface{}
+		expectedError error
+	}{
+		{
+			name: "正常系：指定した項目のみ更新する",
+			body: api.TenantUpdate{Name: &name, Quota: &api.TenantQuota{MaxTokens: int64Ptr(0)}},
+			mockSetup: func(m *mockTenantRepository) {
+				m.On("Get", mock.Anything, "acme").Return(createTestTenant(), nil)
+				m.On("Update", mock.Anything, mock.MatchedBy(func(t *tenant.Tenant) bool {
+					return t.Name == name && t.Quota.MaxMessages == 100 && t.Quota.MaxTokens == 0
+				})).Return(nil)
+			},
+			expectedType: api.PatchApiTenantsId200JSONResponse{},
+		},
+		{
+			name: "異常系：負の上限",
+			body: api.TenantUpdate{Quota: &api.TenantQuota{MaxMessages: int64Ptr(-5)}},
+			mockSetup: func(m *mockTenantRepository) {
+				m.On("Get", mock.Anything, "acme").Return(createTestTenant(), nil)
+			},
+			expectedType:  api.PatchApiTenantsId400Response{},
+			expectedError: tenant.ErrInvalidQuota,
+		},
+		{
+			name: "異常系：存在しないテナント",
+			body: api.TenantUpdate{Name: &name},
+			mockSetup: func(m *mockTenantRepository) {
+				m.On("Get", mock.Anything, "acme").Return(nil, nil)
+			},
+			expectedType: api.PatchApiTenantsId404Response{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantRepo := new(mockTenantRepository)
+			tt.mockSetup(tenantRepo)
+			handler := NewTenantHandler(tenantRepo, new(mockMessageRepository), new(mockTokenRepository), nil)
+
+			resp, err := handler.PatchApiTenantsId(superAdminContext(), api.PatchApiTenantsIdRequestObject{Id: "acme", Body: &tt.body})
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.IsType(t, tt.expectedType, resp)
+			tenantRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTenantHandler_PostApiTenantsIdTokens(t *testing.T) {
+	admin := true
+
+	tests := []struct {
+		name          string
+		mockSetup     func(*mockTenantRepository, *mockTokenRepository)
+		expectedType  interface{}
+		expectedError error
+	}{
+		{
+			name: "正常系：対象のテナントにトークンを発行する",
+			mockSetup: func(tr *mockTenantRepository, kr *mockTokenRepository) {
+				tr.On("Get", mock.Anything, "acme").Return(createTestTenant(), nil)
+				kr.On("Create", inTenant("acme"), mock.MatchedBy(func(tkn *token.Token) bool {
+					return tkn.Admin
+				})).Run(func(args mock.Arguments) {
+					tkn := args.Get(1).(*token.Token)
+					tkn.ID = primitive.NewObjectID()
+					tkn.TenantID = "acme"
+				}).Return(nil)
+			},
+			expectedType: api.PostApiTenantsIdTokens201JSONResponse{},
+		},
+		{
+			name: "異常系：存在しないテナント",
+			mockSetup: func(tr *mockTenantRepository, kr *mockTokenRepository) {
+				tr.On("Get", mock.Anything, "acme").Return(nil, nil)
+			},
+			expectedType: api.PostApiTenantsIdTokens404Response{},
+		},
+		{
+			name: "異常系：テナントのトークン数の上限を超える",
+			mockSetup: func(tr *mockTenantRepository, kr *mockTokenRepository) {
+				tr.On("Get", mock.Anything, "acme").Return(createTestTenant(), nil)
+				kr.On("Create", mock.Anything, mock.Anything).Return(tenant.ErrQuotaExceeded)
+			},
+			expectedType:  api.PostApiTenantsIdTokens403Response{},
+			expectedError: tenant.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantRepo, tokenRepo := new(mockTenantRepository), new(mockTokenRepository)
+			tt.mockSetup(tenantRepo, tokenRepo)
+			handler := NewTenantHandler(tenantRepo, new(mockMessageRepository), tokenRepo, NewTokenHandler(tokenRepo, testTokenTTL))
+
+			resp, err := handler.PostApiTenantsIdTokens(superAdminContext(), api.PostApiTenantsIdTokensRequestObject{
+				Id:   "acme",
+				Body: &api.TokenCreate{Name: "ci", Admin: &admin},
+			})
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.IsType(t, tt.expectedType, resp)
+			if created, ok := resp.(api.PostApiTenantsIdTokens201JSONResponse); ok {
+				assert.Equal(t, "acme", *created.TenantId)
+				assert.True(t, *created.Admin)
+			}
+			tenantRepo.AssertExpectations(t)
+			tokenRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -4,58 +4,89 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
+	"message-service/internal/domain/tenant"
 	"message-service/internal/domain/token"
 	"message-service/pkg/api"
 	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type TokenHandler struct {
 	repo token.Repository
+	// defaultTTL は有効期限が指定されなかったトークンの有効期間
+	defaultTTL time.Duration
 }
 
 // テスト用にrand.Readをモック可能にする
 var randRead = rand.Read
 
-func NewTokenHandler(repo token.Repository) *TokenHandler {
-	return &TokenHandler{repo: repo}
+func NewTokenHandler(repo token.Repository, defaultTTL time.Duration) *TokenHandler {
+	return &TokenHandler{repo: repo, defaultTTL: defaultTTL}
 }
 
 func (h *TokenHandler) PostApiTokens(ctx context.Context, req api.PostApiTokensRequestObject) (api.PostApiTokensResponseObject, error) {
-	// トークンの生成
-	tokenBytes := make([]byte, 32)
-	if _, err := randRead(tokenBytes); err != nil {
+	// 管理用のトークンは管理用のトークンでのみ発行できる
+	admin := req.Body.Admin != nil && *req.Body.Admin
+	if admin && !isAdmin(ctx) {
+		return api.PostApiTokens403Response{}, ErrAdminRequired
+	}
+
+	tkn, err := h.Issue(ctx, req.Body.Name, h.ttl(req.Body.ExpiresIn), admin)
+	if errors.Is(err, tenant.ErrQuotaExceeded) {
+		return api.PostApiTokens403Response{}, err
+	}
+	if err != nil {
 		return api.PostApiTokens400Response{}, err
 	}
-	tokenString := base64.URLEncoding.EncodeToString(tokenBytes)
 
-	// 有効期限の設定
-	expiresIn := 2592000 // デフォルト30日
-	if req.Body.ExpiresIn != nil {
-		expiresIn = *req.Body.ExpiresIn
+	return api.PostApiTokens201JSONResponse(toTokenResponse(tkn)), nil
+}
+
+// ttl は秒数で指定された有効期間を返す。指定されなかった場合は既定の有効期間とする
+func (h *TokenHandler) ttl(expiresIn *int) time.Duration {
+	if expiresIn == nil {
+		return h.defaultTTL
 	}
-	expiresAt := time.Now().Add(time.Duration(expiresIn) * time.Second)
+	return time.Duration(*expiresIn) * time.Second
+}
+
+// Issue はランダムなトークン文字列を生成し、ttlの間有効なトークンをコンテキストのテナントに作成する
+// adminの場合はそのテナントを管理できるトークンとする
+// 管理コマンドからも利用される
+func (h *TokenHandler) Issue(ctx context.Context, name string, ttl time.Duration, admin bool) (*token.Token, error) {
+	return h.issue(ctx, &token.Token{Name: name, Admin: admin}, ttl)
+}
+
+// IssueSuperAdmin は全てのテナントを管理できるトークンをコンテキストのテナントに作成する
+// APIからは発行できないため、管理コマンドからのみ利用される
+func (h *TokenHandler) IssueSuperAdmin(ctx context.Context, name string, ttl time.Duration) (*token.Token, error) {
+	return h.issue(ctx, &token.Token{Name: name, Admin: true, SuperAdmin: true}, ttl)
+}
 
-	tkn := &token.Token{
-		Token:     tokenString,
-		Name:      req.Body.Name,
-		ExpiresAt: expiresAt,
+func (h *TokenHandler) issue(ctx context.Context, tkn *token.Token, ttl time.Duration) (*token.Token, error) {
+	// トークンの生成
+	tokenBytes := make([]byte, 32)
+	if _, err := randRead(tokenBytes); err != nil {
+		return nil, err
 	}
+	tkn.Token = base64.URLEncoding.EncodeToString(tokenBytes)
+	tkn.ExpiresAt = time.Now().Add(ttl)
 
 	if err := h.repo.Create(ctx, tkn); err != nil {
-		return api.PostApiTokens400Response{}, err
+		return nil, err
 	}
-
-	// IDをstring型に変換
-	idStr := tkn.ID.Hex()
-	return api.PostApiTokens201JSONResponse{
-		Id:        &idStr,
-		Token:     &tkn.Token,
-		Name:      &tkn.Name,
-		ExpiresAt: &tkn.ExpiresAt,
-	}, nil
+	return tkn, nil
 }
 
+// GetApiTokens はテナントのトークンの一覧を返す
+// トークン文字列は発行時にのみ返し、一覧には含めない
 func (h *TokenHandler) GetApiTokens(ctx context.Context, req api.GetApiTokensRequestObject) (api.GetApiTokensResponseObject, error) {
+	if !isAdmin(ctx) {
+		return api.GetApiTokens403Response{}, ErrAdminRequired
+	}
+
 	tokens, err := h.repo.List(ctx)
 	if err != nil {
 		return nil, err
@@ -65,21 +96,55 @@ func (h *TokenHandler) GetApiTokens(ctx context.Context, req api.GetApiTokensReq
 	for i, tkn := range tokens {
 		idStr := tkn.ID.Hex()
 		response[i] = api.Token{
-			Id:        &idStr,
-			Token:     &tkn.Token,
-			Name:      &tkn.Name,
-			ExpiresAt: &tkn.ExpiresAt,
-			CreatedAt: &tkn.CreatedAt,
-			UpdatedAt: &tkn.UpdatedAt,
+			Id:         &idStr,
+			Name:       &tkn.Name,
+			TenantId:   &tkn.TenantID,
+			Admin:      &tkn.Admin,
+			SuperAdmin: &tkn.SuperAdmin,
+			ExpiresAt:  &tkn.ExpiresAt,
+			CreatedAt:  &tkn.CreatedAt,
+			UpdatedAt:  &tkn.UpdatedAt,
 		}
 	}
 
 	return response, nil
 }
 
+// DeleteApiTokensId はトークンを無効化する
+// 管理用のトークンでのみ行え、全てのテナントを管理できるトークンはそのトークン同士でのみ無効化できる
 func (h *TokenHandler) DeleteApiTokensId(ctx context.Context, req api.DeleteApiTokensIdRequestObject) (api.DeleteApiTokensIdResponseObject, error) {
+	if !isAdmin(ctx) {
+		return api.DeleteApiTokensId403Response{}, ErrAdminRequired
+	}
+
+	tkn, err := h.repo.FindByID(ctx, req.Id)
+	if err != nil {
+		return api.DeleteApiTokensId404Response{}, err
+	}
+	if tkn == nil {
+		return api.DeleteApiTokensId404Response{}, mongo.ErrNoDocuments
+	}
+	if tkn.SuperAdmin && !isSuperAdmin(ctx) {
+		return api.DeleteApiTokensId403Response{}, ErrSuperAdminRequired
+	}
+
 	if err := h.repo.Delete(ctx, req.Id); err != nil {
 		return api.DeleteApiTokensId404Response{}, err
 	}
 	return api.DeleteApiTokensId204Response{}, nil
 }
+
+// toTokenResponse は作成したトークンをレスポンスの形式に変換する
+func toTokenResponse(tkn *token.Token) api.TokenResponse {
+	// IDをstring型に変換
+	idStr := tkn.ID.Hex()
+	return api.TokenResponse{
+		Id:         &idStr,
+		Token:      &tkn.Token,
+		Name:       &tkn.Name,
+		TenantId:   &tkn.TenantID,
+		Admin:      &tkn.Admin,
+		SuperAdmin: &tkn.SuperAdmin,
+		ExpiresAt:  &tkn.ExpiresAt,
+	}
+}
@@ -2,8 +2,11 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"message-service/internal/domain/tenant"
 	"message-service/internal/domain/token"
+	"message-service/internal/infrastructure/middleware"
 	"message-service/pkg/api"
 	"testing"
 	"time"
@@ -11,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // テストヘルパー関数
@@ -26,6 +30,9 @@ func createTestToken() *token.Token {
 	}
 }
 
+// testTokenTTL はテストで使用するトークンの既定の有効期間
+const testTokenTTL = 24 * time.Hour
+
 func createTestTokenRequest(body *api.PostApiTokensJSONRequestBody) api.PostApiTokensRequestObject {
 	if body == nil {
 		body = &api.PostApiTokensJSONRequestBody{
@@ -123,7 +130,7 @@ func TestTokenHandler_PostApiTokens(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(mockTokenRepository)
 			tt.mockSetup(mockRepo)
-			handler := NewTokenHandler(mockRepo)
+			handler := NewTokenHandler(mockRepo, testTokenTTL)
 
 			// rand.Read()のモック
 			if tt.randReadErr != nil {
@@ -153,6 +160,8 @@ func TestTokenHandler_PostApiTokens(t *testing.T) {
 				if tt.request.Body.ExpiresIn != nil {
 					expectedExpiry := time.Now().Add(time.Duration(*tt.request.Body.ExpiresIn) * time.Second)
 					assert.WithinDuration(t, expectedExpiry, *response.ExpiresAt, time.Second)
+				} else {
+					assert.WithinDuration(t, time.Now().Add(testTokenTTL), *response.ExpiresAt, time.Second)
 				}
 			}
 			mockRepo.AssertExpectations(t)
@@ -160,39 +169,130 @@ func TestTokenHandler_PostApiTokens(t *testing.T) {
 	}
 }
 
+func TestTokenHandler_PostApiTokens_AdminAndQuota(t *testing.T) {
+	adminCtx := middleware.ContextWithToken(context.Background(), &token.Token{TenantID: "acme", Admin: true})
+	memberCtx := middleware.ContextWithToken(context.Background(), &token.Token{TenantID: "acme"})
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		admin         bool
+		createErr     error
+		expectCreate  bool
+		expectedType  interface{}
+		expectedError error
+	}{
+		{
+			name:         "正常系：管理用のトークンは管理用のトークンを発行できる",
+			ctx:          adminCtx,
+			admin:        true,
+			expectCreate: true,
+			expectedType: api.PostApiTokens201JSONResponse{},
+		},
+		{
+			name:          "異常系：管理用でないトークンは管理用のトークンを発行できない",
+			ctx:           memberCtx,
+			admin:         true,
+			expectedType:  api.PostApiTokens403Response{},
+			expectedError: ErrAdminRequired,
+		},
+		{
+			name:          "異常系：認証なしでは管理用のトークンを発行できない",
+			ctx:           context.Background(),
+			admin:         true,
+			expectedType:  api.PostApiTokens403Response{},
+			expectedError: ErrAdminRequired,
+		},
+		{
+			name:          "異常系：テナントのトークン数の上限を超える",
+			ctx:           memberCtx,
+			createErr:     tenant.ErrQuotaExceeded,
+			expectCreate:  true,
+			expectedType:  api.PostApiTokens403Response{},
+			expectedError: tenant.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mockTokenRepository)
+			if tt.expectCreate {
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(tkn *token.Token) bool {
+					return tkn.Admin == tt.admin
+				})).Return(tt.createErr)
+			}
+			handler := NewTokenHandler(mockRepo, testTokenTTL)
+
+			resp, err := handler.PostApiTokens(tt.ctx, createTestTokenRequest(&api.PostApiTokensJSONRequestBody{
+				Name:  "test-token",
+				Admin: &tt.admin,
+			}))
+
+			assert.IsType(t, tt.expectedType, resp)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, *resp.(api.PostApiTokens201JSONResponse).Admin)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestTokenHandler_GetApiTokens(t *testing.T) {
+	adminCtx := middleware.ContextWithToken(context.Background(), &token.Token{TenantID: "acme", Admin: true})
+	memberCtx := middleware.ContextWithToken(context.Background(), &token.Token{TenantID: "acme"})
+
 	tests := []struct {
 		name          string
+		ctx           context.Context
 		mockSetup     func(*mockTokenRepository)
-		expectedError bool
-		expectedLen   int
+		expectedType  interface{}
+		expectedError error
 		errorMessage  string
+		expectedLen   int
 	}{
 		{
 			name: "正常系：有効なトークン一覧取得",
+			ctx:  adminCtx,
 			mockSetup: func(m *mockTokenRepository) {
 				tokens := []token.Token{*createTestToken(), *createTestToken()}
 				m.On("List", mock.Anything).Return(tokens, nil)
 			},
-			expectedError: false,
-			expectedLen:   2,
+			expectedType: api.GetApiTokens200JSONResponse{},
+			expectedLen:  2,
 		},
 		{
 			name: "正常系：トークンが存在しない",
+			ctx:  adminCtx,
 			mockSetup: func(m *mockTokenRepository) {
 				m.On("List", mock.Anything).Return([]token.Token{}, nil)
 			},
-			expectedError: false,
-			expectedLen:   0,
+			expectedType: api.GetApiTokens200JSONResponse{},
+			expectedLen:  0,
+		},
+		{
+			name:          "異常系：管理用でないトークンは一覧を取得できない",
+			ctx:           memberCtx,
+			mockSetup:     func(m *mockTokenRepository) {},
+			expectedType:  api.GetApiTokens403Response{},
+			expectedError: ErrAdminRequired,
+		},
+		{
+			name:          "異常系：認証なしでは一覧を取得できない",
+			ctx:           context.Background(),
+			mockSetup:     func(m *mockTokenRepository) {},
+			expectedType:  api.GetApiTokens403Response{},
+			expectedError: ErrAdminRequired,
 		},
 		{
 			name: "異常系：データベースエラー",
+			ctx:  adminCtx,
 			mockSetup: func(m *mockTokenRepository) {
 				m.On("List", mock.Anything).Return(nil, errors.New("database error"))
 			},
-			expectedError: true,
-			expectedLen:   0,
-			errorMessage:  "database error",
+			errorMessage: "database error",
 		},
 	}
 
@@ -200,30 +300,33 @@ func TestTokenHandler_GetApiTokens(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(mockTokenRepository)
 			tt.mockSetup(mockRepo)
-			handler := NewTokenHandler(mockRepo)
+			handler := NewTokenHandler(mockRepo, testTokenTTL)
 
-			resp, err := handler.GetApiTokens(context.Background(), api.GetApiTokensRequestObject{})
+			resp, err := handler.GetApiTokens(tt.ctx, api.GetApiTokensRequestObject{})
 
-			if tt.expectedError {
-				assert.Error(t, err)
-				if tt.errorMessage != "" {
-					assert.Contains(t, err.Error(), tt.errorMessage)
-				}
+			switch {
+			case tt.expectedError != nil:
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.IsType(t, tt.expectedType, resp)
+			case tt.errorMessage != "":
+				assert.ErrorContains(t, err, tt.errorMessage)
 				assert.Nil(t, resp)
-			} else {
+			default:
 				assert.NoError(t, err)
 				response, ok := resp.(api.GetApiTokens200JSONResponse)
 				assert.True(t, ok)
 				assert.Len(t, response, tt.expectedLen)
-				if tt.expectedLen > 0 {
-					for _, token := range response {
-						assert.NotEmpty(t, token.Token)
-						assert.NotEmpty(t, token.Name)
-						assert.NotNil(t, token.ExpiresAt)
-						assert.True(t, token.ExpiresAt.After(time.Now()))
-					}
+				for _, tkn := range response {
+					assert.NotEmpty(t, tkn.Id)
+					assert.NotEmpty(t, tkn.Name)
+					assert.NotNil(t, tkn.ExpiresAt)
+					assert.True(t, tkn.ExpiresAt.After(time.Now()))
 				}
 			}
+
+			// トークン文字列はどの場合もレスポンスに含めない
+			body, _ := json.Marshal(resp)
+			assert.NotContains(t, string(body), "test-token\"")
 			mockRepo.AssertExpectations(t)
 		})
 	}
@@ -231,56 +334,107 @@ func TestTokenHandler_GetApiTokens(t *testing.T) {
 
 func TestTokenHandler_DeleteApiTokensId(t *testing.T) {
 	validObjectID := primitive.NewObjectID().Hex()
+	superAdminCtx := middleware.ContextWithToken(context.Background(), &token.Token{TenantID: "acme", Admin: true, SuperAdmin: true})
+	adminCtx := middleware.ContextWithToken(context.Background(), &token.Token{TenantID: "acme", Admin: true})
+	memberCtx := middleware.ContextWithToken(context.Background(), &token.Token{TenantID: "acme"})
 
 	tests := []struct {
 		name          string
+		ctx           context.Context
 		id            string
 		mockSetup     func(*mockTokenRepository)
-		expectedError bool
-		expectedCode  int
+		expectedType  interface{}
+		expectedError error
 		errorMessage  string
 	}{
 		{
 			name: "正常系：トークン削除成功",
+			ctx:  adminCtx,
 			id:   validObjectID,
 			mockSetup: func(m *mockTokenRepository) {
+				m.On("FindByID", mock.Anything, validObjectID).Return(createTestToken(), nil)
 				m.On("Delete", mock.Anything, validObjectID).Return(nil)
 			},
-			expectedError: false,
-			expectedCode:  204,
+			expectedType: api.DeleteApiTokensId204Response{},
+		},
+		{
+			name: "正常系：管理用のトークンは管理用のトークンを削除できる",
+			ctx:  adminCtx,
+			id:   validObjectID,
+			mockSetup: func(m *mockTokenRepository) {
+				m.On("FindByID", mock.Anything, validObjectID).Return(&token.Token{TenantID: "acme", Admin: true}, nil)
+				m.On("Delete", mock.Anything, validObjectID).Return(nil)
+			},
+			expectedType: api.DeleteApiTokensId204Response{},
+		},
+		{
+			name: "正常系：全てのテナントを管理できるトークンは同じ権限のトークンを削除できる",
+			ctx:  superAdminCtx,
+			id:   validObjectID,
+			mockSetup: func(m *mockTokenRepository) {
+				m.On("FindByID", mock.Anything, validObjectID).Return(&token.Token{TenantID: "acme", Admin: true, SuperAdmin: true}, nil)
+				m.On("Delete", mock.Anything, validObjectID).Return(nil)
+			},
+			expectedType: api.DeleteApiTokensId204Response{},
+		},
+		{
+			name:          "異常系：管理用でないトークンは削除できない",
+			ctx:           memberCtx,
+			id:            validObjectID,
+			mockSetup:     func(m *mockTokenRepository) {},
+			expectedType:  api.DeleteApiTokensId403Response{},
+			expectedError: ErrAdminRequired,
+		},
+		{
+			name:          "異常系：認証なしでは削除できない",
+			ctx:           context.Background(),
+			id:            validObjectID,
+			mockSetup:     func(m *mockTokenRepository) {},
+			expectedType:  api.DeleteApiTokensId403Response{},
+			expectedError: ErrAdminRequired,
+		},
+		{
+			name: "異常系：管理用のトークンは全てのテナントを管理できるトークンを削除できない",
+			ctx:  adminCtx,
+			id:   validObjectID,
+			mockSetup: func(m *mockTokenRepository) {
+				m.On("FindByID", mock.Anything, validObjectID).Return(&token.Token{TenantID: "acme", Admin: true, SuperAdmin: true}, nil)
+			},
+			expectedType:  api.DeleteApiTokensId403Response{},
+			expectedError: ErrSuperAdminRequired,
 		},
 		{
 			name: "異常系：存在しないトークン",
+			ctx:  adminCtx,
 			id:   validObjectID,
 			mockSetup: func(m *mockTokenRepository) {
-				m.On("Delete", mock.Anything, validObjectID).
-					Return(errors.New("token not found"))
+				m.On("FindByID", mock.Anything, validObjectID).Return(nil, nil)
 			},
-			expectedError: true,
-			expectedCode:  404,
-			errorMessage:  "token not found",
+			expectedType:  api.DeleteApiTokensId404Response{},
+			expectedError: mongo.ErrNoDocuments,
 		},
 		{
 			name: "異常系：不正なObjectID形式",
+			ctx:  adminCtx,
 			id:   "invalid-id",
 			mockSetup: func(m *mockTokenRepository) {
-				m.On("Delete", mock.Anything, "invalid-id").
-					Return(errors.New("invalid object id format"))
+				m.On("FindByID", mock.Anything, "invalid-id").
+					Return(nil, errors.New("invalid object id format"))
 			},
-			expectedError: true,
-			expectedCode:  404,
-			errorMessage:  "invalid object id format",
+			expectedType: api.DeleteApiTokensId404Response{},
+			errorMessage: "invalid object id format",
 		},
 		{
 			name: "異常系：データベースエラー",
+			ctx:  adminCtx,
 			id:   validObjectID,
 			mockSetup: func(m *mockTokenRepository) {
+				m.On("FindByID", mock.Anything, validObjectID).Return(createTestToken(), nil)
 				m.On("Delete", mock.Anything, validObjectID).
 					Return(errors.New("database error"))
 			},
-			expectedError: true,
-			expectedCode:  404,
-			errorMessage:  "database error",
+			expectedType: api.DeleteApiTokensId404Response{},
+			errorMessage: "database error",
 		},
 	}
 
@@ -288,23 +442,20 @@ func TestTokenHandler_DeleteApiTokensId(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(mockTokenRepository)
 			tt.mockSetup(mockRepo)
-			handler := NewTokenHandler(mockRepo)
+			handler := NewTokenHandler(mockRepo, testTokenTTL)
 
-			resp, err := handler.DeleteApiTokensId(context.Background(), api.DeleteApiTokensIdRequestObject{
+			resp, err := handler.DeleteApiTokensId(tt.ctx, api.DeleteApiTokensIdRequestObject{
 				Id: tt.id,
 			})
 
-			if tt.expectedError {
-				assert.Error(t, err)
-				if tt.errorMessage != "" {
-					assert.Contains(t, err.Error(), tt.errorMessage)
-				}
-				_, ok := resp.(api.DeleteApiTokensId404Response)
-				assert.True(t, ok)
-			} else {
+			assert.IsType(t, tt.expectedType, resp)
+			switch {
+			case tt.expectedError != nil:
+				assert.ErrorIs(t, err, tt.expectedError)
+			case tt.errorMessage != "":
+				assert.ErrorContains(t, err, tt.errorMessage)
+			default:
 				assert.NoError(t, err)
-				_, ok := resp.(api.DeleteApiTokensId204Response)
-				assert.True(t, ok)
 			}
 			mockRepo.AssertExpectations(t)
 		})
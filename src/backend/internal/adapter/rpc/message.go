@@ -0,0 +1,135 @@
+package rpc
+
+import (
+	"context"
+	"message-service/internal/adapter/handler"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/infrastructure/broker"
+	"message-service/pkg/api"
+	messagev1 "message-service/pkg/pb/message/v1"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MessageServer はMessageServiceのgRPC実装
+// 処理はREST APIと同じMessageHandlerに委譲する
+type MessageServer struct {
+	messagev1.UnimplementedMessageServiceServer
+	handler *handler.MessageHandler
+	events  message.EventSubscriber
+}
+
+// NewMessageServer はMessageServerを作成する
+// eventsがnilの場合、WatchMessagesはUnimplementedを返す
+func NewMessageServer(repo message.Repository, events message.EventSubscriber) *MessageServer {
+	return &MessageServer{handler: handler.NewMessageHandler(repo), events: events}
+}
+
+func (s *MessageServer) CreateMessage(ctx context.Context, req *messagev1.CreateMessageRequest) (*messagev1.CreateMessageResponse, error) {
+	resp, err := s.handler.PostApiMessages(ctx, api.PostApiMessagesRequestObject{
+		Body: &api.PostApiMessagesJSONRequestBody{
+			Uid:        req.GetUid(),
+			SentAt:     req.GetSentAt().AsTime(),
+			Sender:     req.GetSender(),
+			ChannelId:  req.GetChannelId(),
+			Content:    req.GetContent(),
+			ExpiresAt:  toTimePtr(req.GetExpiresAt()),
+			TtlSeconds: req.TtlSeconds,
+			ReplyTo:    toStringPtr(req.GetReplyTo()),
+		},
+	})
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	created, ok := resp.(api.PostApiMessages201JSONResponse)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "Invalid request")
+	}
+	return &messagev1.CreateMessageResponse{Message: toProtoMessage(api.Message(created))}, nil
+}
+
+// searchPageSize はSearchMessagesが1回の検索で取得するメッセージ数
+const searchPageSize = 500
+
+// SearchMessages は検索結果をページ単位で取得しながらストリームで返す
+// 結果の全件をメモリに保持しないよう、カーソルで次のページを取得する前に送信する
+func (s *MessageServer) SearchMessages(req *messagev1.SearchMessagesRequest, stream grpc.ServerStreamingServer[messagev1.SearchMessagesResponse]) error {
+	ctx := stream.Context()
+	limit := searchPageSize
+	params := api.GetApiMessagesSearchParams{
+		ChannelId: toSlicePtr(req.ChannelId),
+		Sender:    toSlicePtr(req.Sender),
+		FromDate:  toTimePtr(req.GetFromDate()),
+		ToDate:    toTimePtr(req.GetToDate()),
+		IsReply:   req.IsReply,
+		HasThread: req.HasThread,
+		Limit:     &limit,
+	}
+
+	for {
+		resp, err := s.handler.GetApiMessagesSearch(ctx, api.GetApiMessagesSearchRequestObject{Params: params})
+		if err != nil {
+			return toStatusError(ctx, err)
+		}
+		page, ok := resp.(api.GetApiMessagesSearch200JSONResponse)
+		if !ok {
+			return status.Error(codes.Internal, "Unexpected search response")
+		}
+
+		for _, msg := range page.Body {
+			if err := stream.Send(&messagev1.SearchMessagesResponse{Message: toProtoMessage(msg)}); err != nil {
+				return err
+			}
+		}
+		if page.Headers.XNextCursor == "" {
+			return nil
+		}
+		params.Cursor = &page.Headers.XNextCursor
+	}
+}
+
+func (s *MessageServer) DeleteMessage(ctx context.Context, req *messagev1.DeleteMessageRequest) (*messagev1.DeleteMessageResponse, error) {
+	if _, err := s.handler.DeleteApiMessagesUid(ctx, api.DeleteApiMessagesUidRequestObject{Uid: req.GetUid()}); err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+	return &messagev1.DeleteMessageResponse{}, nil
+}
+
+// WatchMessages はトークンのテナントで発生したメッセージの変更をストリームで返す
+// 受信が追いつかない場合のイベントは破棄される。サーバーの停止時はUnavailableで終了する
+func (s *MessageServer) WatchMessages(req *messagev1.WatchMessagesRequest, stream grpc.ServerStreamingServer[messagev1.WatchMessagesResponse]) error {
+	if s.events == nil {
+		return status.Error(codes.Unimplemented, "Message events are not available with this storage")
+	}
+
+	ctx := stream.Context()
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return toStatusError(ctx, err)
+	}
+	inTenant := broker.ForTenant(tenantID)
+	channelIDs := req.GetChannelIds()
+	events, unsubscribe := s.events.Subscribe(func(event message.Event) bool {
+		return inTenant(event) && (len(channelIDs) == 0 || slices.Contains(channelIDs, event.Message.ChannelID))
+	})
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return status.Error(codes.Unavailable, "Server is shutting down")
+			}
+			if err := stream.Send(toProtoEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
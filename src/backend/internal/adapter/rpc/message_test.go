@@ -0,0 +1,276 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/token"
+	"message-service/internal/infrastructure/broker"
+	messagev1 "message-service/pkg/pb/message/v1"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newAuthorizedTokenRepository は"valid-token"を有効とするトークンリポジトリのモック
+func newAuthorizedTokenRepository() *mockTokenRepository {
+	tokenRepo := new(mockTokenRepository)
+	tokenRepo.On("FindByToken", mock.Anything, "valid-token").
+		Return(&token.Token{Token: "valid-token", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	return tokenRepo
+}
+
+func TestMessageServer_CreateMessage(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		mockSetup    func(*mockMessageRepository)
+		expectedCode codes.Code
+	}{
+		{
+			name: "正常系：メッセージ作成成功",
+			mockSetup: func(m *mockMessageRepository) {
+				m.On("Create", mock.Anything, mock.MatchedBy(func(msg *message.Message) bool {
+					return msg.UID == "test-uid" && msg.SentAt.Equal(now) && msg.ReplyTo == "parent-uid"
+				})).Return(nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name: "異常系：重複するUID",
+			mockSetup: func(m *mockMessageRepository) {
+				m.On("Create", mock.Anything, mock.Anything).
+					Return(mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}})
+			},
+			expectedCode: codes.AlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messageRepo := new(mockMessageRepository)
+			tt.mockSetup(messageRepo)
+			client := messagev1.NewMessageServiceClient(newTestClient(t, messageRepo, newAuthorizedTokenRepository()))
+
+			resp, err := client.CreateMessage(authContext("valid-token"), &messagev1.CreateMessageRequest{
+				Uid:       "test-uid",
+				SentAt:    timestamppb.New(now),
+				Sender:    "test-sender",
+				ChannelId: "test-channel",
+				Content:   "test message",
+				ReplyTo:   "parent-uid",
+			})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK {
+				assert.Equal(t, "test-uid", resp.GetMessage().GetUid())
+				assert.Equal(t, "test-channel", resp.GetMessage().GetChannelId())
+				assert.Equal(t, "parent-uid", resp.GetMessage().GetReplyTo())
+			}
+			messageRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageServer_CreateMessage_Invalid(t *testing.T) {
+	messageRepo := new(mockMessageRepository)
+	client := messagev1.NewMessageServiceClient(newTestClient(t, messageRepo, newAuthorizedTokenRepository()))
+
+	_, err := client.CreateMessage(authContext("valid-token"), &messagev1.CreateMessageRequest{
+		Uid:       "test-uid",
+		SentAt:    timestamppb.Now(),
+		Sender:    "test-sender",
+		ChannelId: "test-channel",
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	messageRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestMessageServer_CreateMessage_Unauthenticated(t *testing.T) {
+	tokenRepo := new(mockTokenRepository)
+	tokenRepo.On("FindByToken", mock.Anything, "unknown").Return(nil, nil)
+	client := messagev1.NewMessageServiceClient(newTestClient(t, new(mockMessageRepository), tokenRepo))
+
+	_, err := client.CreateMessage(authContext("unknown"), &messagev1.CreateMessageRequest{Uid: "test-uid"})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestMessageServer_SearchMessages(t *testing.T) {
+	now := time.Now()
+	channelID := "test-channel"
+
+	t.Run("正常系：検索結果をストリームで返す", func(t *testing.T) {
+		messageRepo := new(mockMessageRepository)
+		messageRepo.On("Search", mock.Anything, mock.MatchedBy(func(c message.SearchCriteria) bool {
+			return assert.ObjectsAreEqual([]string{channelID}, c.ChannelIDs) && c.FromDate != nil && c.ToDate == nil &&
+				c.IsReply == nil && c.HasThread != nil && *c.HasThread
+		}), message.SearchOptions{Limit: searchPageSize + 1}).Return([]message.Message{
+			{UID: "msg1", ChannelID: channelID, SentAt: now},
+			{UID: "msg2", ChannelID: channelID, SentAt: now.Add(time.Second)},
+		}, nil)
+		client := messagev1.NewMessageServiceClient(newTestClient(t, messageRepo, newAuthorizedTokenRepository()))
+
+		stream, err := client.SearchMessages(authContext("valid-token"), &messagev1.SearchMessagesRequest{
+			ChannelId: &channelID,
+			FromDate:  timestamppb.New(now.Add(-time.Hour)),
+			HasThread: proto.Bool(true),
+		})
+		assert.NoError(t, err)
+
+		var uids []string
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			uids = append(uids, resp.GetMessage().GetUid())
+		}
+
+		assert.Equal(t, []string{"msg1", "msg2"}, uids)
+		messageRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系：カーソルで次のページを取得して続けて返す", func(t *testing.T) {
+		// 1ページ目は続きがあることを示すため、ページの件数より1件多く返す
+		firstPage := make([]message.Message, searchPageSize+1)
+		for i := range firstPage {
+			firstPage[i] = message.Message{ID: primitive.NewObjectID(), UID: fmt.Sprintf("msg%d", i), ChannelID: channelID, SentAt: now}
+		}
+		lastOfFirstPage := firstPage[searchPageSize-1]
+		messageRepo := new(mockMessageRepository)
+		messageRepo.On("Search", mock.Anything, mock.Anything, mock.MatchedBy(func(opts message.SearchOptions) bool {
+			return opts.After == nil
+		})).Return(firstPage, nil).Once()
+		messageRepo.On("Search", mock.Anything, mock.Anything, mock.MatchedBy(func(opts message.SearchOptions) bool {
+			return opts.After != nil && opts.After.ID == lastOfFirstPage.ID
+		})).Return([]message.Message{firstPage[searchPageSize]}, nil).Once()
+		client := messagev1.NewMessageServiceClient(newTestClient(t, messageRepo, newAuthorizedTokenRepository()))
+
+		stream, err := client.SearchMessages(authContext("valid-token"), &messagev1.SearchMessagesRequest{ChannelId: &channelID})
+		assert.NoError(t, err)
+
+		var count int
+		var lastUID string
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			count++
+			lastUID = resp.GetMessage().GetUid()
+		}
+
+		assert.Equal(t, searchPageSize+1, count)
+		assert.Equal(t, fmt.Sprintf("msg%d", searchPageSize), lastUID)
+		messageRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系：データベースエラー", func(t *testing.T) {
+		messageRepo := new(mockMessageRepository)
+		messageRepo.On("Search", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+		client := messagev1.NewMessageServiceClient(newTestClient(t, messageRepo, newAuthorizedTokenRepository()))
+
+		stream, err := client.SearchMessages(authContext("valid-token"), &messagev1.SearchMessagesRequest{})
+		assert.NoError(t, err)
+
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestMessageServer_DeleteMessage(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode codes.Code
+	}{
+		{name: "正常系：メッセージ削除成功", err: nil, expectedCode: codes.OK},
+		{name: "異常系：存在しないメッセージ", err: mongo.ErrNoDocuments, expectedCode: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messageRepo := new(mockMessageRepository)
+			messageRepo.On("Delete", mock.Anything, "test-uid").Return(tt.err)
+			client := messagev1.NewMessageServiceClient(newTestClient(t, messageRepo, newAuthorizedTokenRepository()))
+
+			_, err := client.DeleteMessage(authContext("valid-token"), &messagev1.DeleteMessageRequest{Uid: "test-uid"})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			messageRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageServer_WatchMessages(t *testing.T) {
+	newEvent := func(tenantID, channelID, uid string) message.Event {
+		return message.Event{
+			Type:       message.EventCreated,
+			Message:    message.Message{TenantID: tenantID, UID: uid, ChannelID: channelID},
+			OccurredAt: time.Now(),
+		}
+	}
+	newReplyEvent := func(tenantID, channelID, uid, replyTo string) message.Event {
+		event := newEvent(tenantID, channelID, uid)
+		event.Message.ReplyTo = replyTo
+		return event
+	}
+
+	t.Run("正常系：テナントとチャンネルに一致する変更のみ返す", func(t *testing.T) {
+		tokenRepo := new(mockTokenRepository)
+		tokenRepo.On("FindByToken", mock.Anything, "valid-token").
+			Return(&token.Token{Token: "valid-token", TenantID: "team-a", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+		events := broker.NewBroker()
+		client := messagev1.NewMessageServiceClient(newTestClientWithEvents(t, new(mockMessageRepository), tokenRepo, events))
+		ctx, cancel := context.WithCancel(authContext("valid-token"))
+		defer cancel()
+
+		stream, err := client.WatchMessages(ctx, &messagev1.WatchMessagesRequest{ChannelIds: []string{"general"}})
+		assert.NoError(t, err)
+		assert.Eventually(t, func() bool { return events.SubscriberCount() == 1 }, time.Second, time.Millisecond)
+
+		for _, event := range []message.Event{
+			newEvent("team-b", "general", "other-tenant"),
+			newEvent("team-a", "random", "other-channel"),
+			newReplyEvent("team-a", "general", "msg1", "parent-uid"),
+		} {
+			assert.NoError(t, events.Publish(context.Background(), event))
+		}
+
+		resp, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, messagev1.MessageEventType_MESSAGE_EVENT_TYPE_CREATED, resp.GetType())
+		assert.Equal(t, "msg1", resp.GetMessage().GetUid())
+		assert.Equal(t, "parent-uid", resp.GetMessage().GetReplyTo())
+
+		// 停止時は購読が閉じられ、クライアントに再接続を促す
+		events.Close()
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("異常系：イベントの購読元が無い", func(t *testing.T) {
+		client := messagev1.NewMessageServiceClient(newTestClient(t, new(mockMessageRepository), newAuthorizedTokenRepository()))
+
+		stream, err := client.WatchMessages(authContext("valid-token"), &messagev1.WatchMessagesRequest{})
+		assert.NoError(t, err)
+
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
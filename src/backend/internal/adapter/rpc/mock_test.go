@@ -0,0 +1,179 @@
+package rpc
+
+import (
+	"context"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/token"
+	"message-service/internal/infrastructure/middleware"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// mockMessageRepository はメッセージリポジトリのモック
+type mockMessageRepository struct {
+	mock.Mock
+}
+
+func (m *mockMessageRepository) Create(ctx context.Context, msg *message.Message) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
+
+func (m *mockMessageRepository) CreateMany(ctx context.Context, msgs []*message.Message) ([]error, error) {
+	args := m.Called(ctx, msgs)
+	if errs, ok := args.Get(0).([]error); ok {
+		return errs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockMessageRepository) Delete(ctx context.Context, uid string) error {
+	args := m.Called(ctx, uid)
+	return args.Error(0)
+}
+
+func (m *mockMessageRepository) Search(ctx context.Context, criteria message.SearchCriteria, opts message.SearchOptions) ([]message.Message, error) {
+	args := m.Called(ctx, criteria, opts)
+	if msgs, ok := args.Get(0).([]message.Message); ok {
+		return msgs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockMessageRepository) Iterate(ctx context.Context, criteria message.SearchCriteria, fn func(*message.Message) error) error {
+	args := m.Called(ctx, criteria)
+	if msgs, ok := args.Get(0).([]message.Message); ok {
+		for i := range msgs {
+			if err := fn(&msgs[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *mockMessageRepository) Count(ctx context.Context, criteria message.SearchCriteria) (int64, error) {
+	args := m.Called(ctx, criteria)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockMessageRepository) CountUpTo(ctx context.Context, criteria message.SearchCriteria, limit int64) (int64, error) {
+	args := m.Called(ctx, criteria, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockMessageRepository) DeleteMany(ctx context.Context, criteria message.SearchCriteria) (int64, error) {
+	args := m.Called(ctx, criteria)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockMessageRepository) Around(ctx context.Context, target *message.Message, before, after int) ([]message.Message, []message.Message, error) {
+	args := m.Called(ctx, target, before, after)
+	older, _ := args.Get(0).([]message.Message)
+	newer, _ := args.Get(1).([]message.Message)
+	return older, newer, args.Error(2)
+}
+
+func (m *mockMessageRepository) Stats(ctx context.Context, query message.StatsQuery) ([]message.StatsBucket, error) {
+	args := m.Called(ctx, query)
+	if buckets, ok := args.Get(0).([]message.StatsBucket); ok {
+		return buckets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockMessageRepository) FindByUID(ctx context.Context, uid string) (*message.Message, error) {
+	args := m.Called(ctx, uid)
+	if msg, ok := args.Get(0).(*message.Message); ok {
+		return msg, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockTokenRepository はトークンリポジトリのモック
+type mockTokenRepository struct {
+	mock.Mock
+}
+
+func (m *mockTokenRepository) Create(ctx context.Context, t *token.Token) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *mockTokenRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockTokenRepository) List(ctx context.Context) ([]token.Token, error) {
+	args := m.Called(ctx)
+	if tokens, ok := args.Get(0).([]token.Token); ok {
+		return tokens, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockTokenRepository) CountUpTo(ctx context.Context, limit int64) (int64, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockTokenRepository) FindByID(ctx context.Context, id string) (*token.Token, error) {
+	args := m.Called(ctx, id)
+	if t, ok := args.Get(0).(*token.Token); ok {
+		return t, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockTokenRepository) FindByToken(ctx context.Context, tokenStr string) (*token.Token, error) {
+	args := m.Called(ctx, tokenStr)
+	if t, ok := args.Get(0).(*token.Token); ok {
+		return t, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// newTestClient はbufconn上でgRPCサーバーを起動し、接続を返す
+func newTestClient(t *testing.T, messageRepo message.Repository, tokenRepo token.Repository) *grpc.ClientConn {
+	return newTestClientWithEvents(t, messageRepo, tokenRepo, nil)
+}
+
+// newTestClientWithEvents はWatchMessagesの購読元を指定してnewTestClientと同様に接続を返す
+func newTestClientWithEvents(t *testing.T, messageRepo message.Repository, tokenRepo token.Repository, events message.EventSubscriber) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	authMiddleware := middleware.NewAuthMiddleware(tokenRepo)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(authMiddleware.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(authMiddleware.StreamServerInterceptor()),
+	)
+	Register(server, messageRepo, tokenRepo, events, 24*time.Hour)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// authContext はBearerトークンを付与したコンテキストを返す
+func authContext(tokenString string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+tokenString)
+}
@@ -0,0 +1,136 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"message-service/internal/adapter/handler"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/domain/token"
+	"message-service/pkg/api"
+	messagev1 "message-service/pkg/pb/message/v1"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Register はgRPCサーバーに各サービスを登録する
+// eventsはWatchMessagesで配信するイベントの購読元で、イベントの配信元が無いストレージではnilとする
+// tokenTTLは有効期限が指定されなかったトークンの有効期間
+func Register(s grpc.ServiceRegistrar, messageRepo message.Repository, tokenRepo token.Repository, events message.EventSubscriber, tokenTTL time.Duration) {
+	messagev1.RegisterMessageServiceServer(s, NewMessageServer(messageRepo, events))
+	messagev1.RegisterTokenServiceServer(s, NewTokenServer(tokenRepo, tokenTTL))
+}
+
+// toStatusError はリポジトリのエラーをgRPCのステータスに変換する
+// 想定外のエラーはドライバーなどの内部の情報を含むため、ログに記録してクライアントには詳細を返さない
+func toStatusError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Error(codes.NotFound, "Not found")
+	case errors.Is(err, message.ErrDuplicateUID), mongo.IsDuplicateKeyError(err):
+		return status.Error(codes.AlreadyExists, "Already exists")
+	case message.IsValidationError(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, tenant.ErrNoTenant):
+		return status.Error(codes.Unauthenticated, "Authentication required")
+	case errors.Is(err, tenant.ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, handler.ErrAdminRequired), errors.Is(err, handler.ErrSuperAdminRequired):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, primitive.ErrInvalidHex):
+		return status.Error(codes.InvalidArgument, "Invalid ID")
+	default:
+		slog.ErrorContext(ctx, "Unexpected error in gRPC handler", slog.String("error", err.Error()))
+		return status.Error(codes.Internal, "Internal error")
+	}
+}
+
+func toProtoMessage(msg api.Message) *messagev1.Message {
+	return &messagev1.Message{
+		Uid:       deref(msg.Uid),
+		SentAt:    toTimestamp(msg.SentAt),
+		Sender:    deref(msg.Sender),
+		ChannelId: deref(msg.ChannelId),
+		Content:   deref(msg.Content),
+		CreatedAt: toTimestamp(msg.CreatedAt),
+		UpdatedAt: toTimestamp(msg.UpdatedAt),
+		ExpiresAt: toTimestamp(msg.ExpiresAt),
+		ReplyTo:   deref(msg.ReplyTo),
+	}
+}
+
+// toProtoEvent はドメインイベントをWatchMessagesのレスポンスに変換する
+func toProtoEvent(event message.Event) *messagev1.WatchMessagesResponse {
+	eventType := messagev1.MessageEventType_MESSAGE_EVENT_TYPE_UNSPECIFIED
+	switch event.Type {
+	case message.EventCreated:
+		eventType = messagev1.MessageEventType_MESSAGE_EVENT_TYPE_CREATED
+	case message.EventUpdated:
+		eventType = messagev1.MessageEventType_MESSAGE_EVENT_TYPE_UPDATED
+	case message.EventDeleted:
+		eventType = messagev1.MessageEventType_MESSAGE_EVENT_TYPE_DELETED
+	}
+
+	msg := event.Message
+	return &messagev1.WatchMessagesResponse{
+		Type: eventType,
+		Message: &messagev1.Message{
+			Uid:       msg.UID,
+			SentAt:    timestamppb.New(msg.SentAt),
+			Sender:    msg.Sender,
+			ChannelId: msg.ChannelID,
+			Content:   msg.Content,
+			CreatedAt: timestamppb.New(msg.CreatedAt),
+			UpdatedAt: timestamppb.New(msg.UpdatedAt),
+			ExpiresAt: toTimestamp(msg.ExpiresAt),
+			ReplyTo:   msg.ReplyTo,
+		},
+		OccurredAt: timestamppb.New(event.OccurredAt),
+	}
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func toTimePtr(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// toSlicePtr は単一の検索条件を複数指定の形式に変換する
+func toSlicePtr(v *string) *[]string {
+	if v == nil {
+		return nil
+	}
+	return &[]string{*v}
+}
+
+// toStringPtr は空文字を指定なし(nil)として変換する
+func toStringPtr(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+func deref[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+	return *v
+}
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"message-service/internal/adapter/handler"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/pkg/api"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestToStatusError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode codes.Code
+	}{
+		{name: "存在しない", err: mongo.ErrNoDocuments, expectedCode: codes.NotFound},
+		{name: "重複", err: mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}}, expectedCode: codes.AlreadyExists},
+		{name: "重複するUID", err: message.ErrDuplicateUID, expectedCode: codes.AlreadyExists},
+		{name: "必須項目の不足", err: message.ErrContentRequired, expectedCode: codes.InvalidArgument},
+		{name: "テナントの上限超過", err: tenant.ErrQuotaExceeded, expectedCode: codes.ResourceExhausted},
+		{name: "管理用のトークンが必要", err: handler.ErrAdminRequired, expectedCode: codes.PermissionDenied},
+		{name: "運用者用のトークンが必要", err: handler.ErrSuperAdminRequired, expectedCode: codes.PermissionDenied},
+		{name: "その他のエラー", err: errors.New("database error"), expectedCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedCode, status.Code(toStatusError(context.Background(), tt.err)))
+		})
+	}
+
+	t.Run("想定外のエラーの内容はクライアントに返さない", func(t *testing.T) {
+		err := toStatusError(context.Background(), errors.New("connection to mongodb:27017 refused"))
+
+		assert.Equal(t, "Internal error", status.Convert(err).Message())
+	})
+}
+
+func TestToProtoMessage(t *testing.T) {
+	now := time.Now()
+	uid := "test-uid"
+
+	msg := toProtoMessage(api.Message{Uid: &uid, SentAt: &now})
+
+	assert.Equal(t, uid, msg.GetUid())
+	assert.True(t, msg.GetSentAt().AsTime().Equal(now))
+	assert.Empty(t, msg.GetSender())
+	assert.Nil(t, msg.GetCreatedAt())
+}
+
+func TestToTimePtr(t *testing.T) {
+	now := time.Now()
+
+	assert.Nil(t, toTimePtr(nil))
+	assert.True(t, toTimePtr(timestamppb.New(now)).Equal(now))
+}
@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"message-service/internal/adapter/handler"
+	"message-service/internal/domain/token"
+	"message-service/pkg/api"
+	messagev1 "message-service/pkg/pb/message/v1"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TokenServer はTokenServiceのgRPC実装
+// 処理はREST APIと同じTokenHandlerに委譲する
+type TokenServer struct {
+	messagev1.UnimplementedTokenServiceServer
+	handler *handler.TokenHandler
+}
+
+func NewTokenServer(repo token.Repository, defaultTTL time.Duration) *TokenServer {
+	return &TokenServer{handler: handler.NewTokenHandler(repo, defaultTTL)}
+}
+
+func (s *TokenServer) CreateToken(ctx context.Context, req *messagev1.CreateTokenRequest) (*messagev1.CreateTokenResponse, error) {
+	body := &api.PostApiTokensJSONRequestBody{Name: req.GetName()}
+	if req.ExpiresIn != nil {
+		expiresIn := int(req.GetExpiresIn())
+		body.ExpiresIn = &expiresIn
+	}
+
+	resp, err := s.handler.PostApiTokens(ctx, api.PostApiTokensRequestObject{Body: body})
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	created, ok := resp.(api.PostApiTokens201JSONResponse)
+	if !ok {
+		return nil, status.Error(codes.Internal, "Unexpected token response")
+	}
+	return &messagev1.CreateTokenResponse{Token: &messagev1.Token{
+		Id:        deref(created.Id),
+		Token:     deref(created.Token),
+		Name:      deref(created.Name),
+		ExpiresAt: toTimestamp(created.ExpiresAt),
+	}}, nil
+}
+
+func (s *TokenServer) ListTokens(ctx context.Context, req *messagev1.ListTokensRequest) (*messagev1.ListTokensResponse, error) {
+	resp, err := s.handler.GetApiTokens(ctx, api.GetApiTokensRequestObject{})
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	tokens, ok := resp.(api.GetApiTokens200JSONResponse)
+	if !ok {
+		return nil, status.Error(codes.Internal, "Unexpected token response")
+	}
+	result := make([]*messagev1.Token, len(tokens))
+	for i, tkn := range tokens {
+		result[i] = &messagev1.Token{
+			Id:        deref(tkn.Id),
+			Name:      deref(tkn.Name),
+			ExpiresAt: toTimestamp(tkn.ExpiresAt),
+			CreatedAt: toTimestamp(tkn.CreatedAt),
+			UpdatedAt: toTimestamp(tkn.UpdatedAt),
+		}
+	}
+	return &messagev1.ListTokensResponse{Tokens: result}, nil
+}
+
+func (s *TokenServer) DeleteToken(ctx context.Context, req *messagev1.DeleteTokenRequest) (*messagev1.DeleteTokenResponse, error) {
+	if _, err := s.handler.DeleteApiTokensId(ctx, api.DeleteApiTokensIdRequestObject{Id: req.GetId()}); err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+	return &messagev1.DeleteTokenResponse{}, nil
+}
@@ -0,0 +1,127 @@
+package rpc
+
+import (
+	"context"
+	"message-service/internal/domain/token"
+	messagev1 "message-service/pkg/pb/message/v1"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTokenServer_CreateToken(t *testing.T) {
+	t.Run("正常系：認証したトークンのテナントに発行", func(t *testing.T) {
+		tokenRepo := newAuthorizedTokenRepository()
+		tokenRepo.On("Create", mock.Anything, mock.MatchedBy(func(tkn *token.Token) bool {
+			return tkn.Name == "test-token" && time.Until(tkn.ExpiresAt) <= time.Hour
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*token.Token).ID = primitive.NewObjectID()
+		}).Return(nil)
+		client := messagev1.NewTokenServiceClient(newTestClient(t, new(mockMessageRepository), tokenRepo))
+
+		expiresIn := int32(3600)
+		resp, err := client.CreateToken(authContext("valid-token"), &messagev1.CreateTokenRequest{
+			Name:      "test-token",
+			ExpiresIn: &expiresIn,
+		})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, resp.GetToken().GetId())
+		assert.NotEmpty(t, resp.GetToken().GetToken())
+		assert.Equal(t, "test-token", resp.GetToken().GetName())
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系：認証なしではトークンを発行できない", func(t *testing.T) {
+		tokenRepo := new(mockTokenRepository)
+		client := messagev1.NewTokenServiceClient(newTestClient(t, new(mockMessageRepository), tokenRepo))
+
+		_, err := client.CreateToken(context.Background(), &messagev1.CreateTokenRequest{Name: "test-token"})
+
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+// newAdminTokenRepository は"admin-token"を管理用のトークンとして認証するモックを返す
+func newAdminTokenRepository() *mockTokenRepository {
+	tokenRepo := newAuthorizedTokenRepository()
+	tokenRepo.On("FindByToken", mock.Anything, "admin-token").
+		Return(&token.Token{Token: "admin-token", Admin: true, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	return tokenRepo
+}
+
+func TestTokenServer_ListTokens(t *testing.T) {
+	t.Run("正常系：トークン文字列を含めずに一覧を返す", func(t *testing.T) {
+		tokenRepo := newAdminTokenRepository()
+		tokenRepo.On("List", mock.Anything).Return([]token.Token{
+			{ID: primitive.NewObjectID(), Token: "secret-1", Name: "token-1", ExpiresAt: time.Now().Add(time.Hour)},
+			{ID: primitive.NewObjectID(), Token: "secret-2", Name: "token-2", ExpiresAt: time.Now().Add(time.Hour)},
+		}, nil)
+		client := messagev1.NewTokenServiceClient(newTestClient(t, new(mockMessageRepository), tokenRepo))
+
+		resp, err := client.ListTokens(authContext("admin-token"), &messagev1.ListTokensRequest{})
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.GetTokens(), 2)
+		assert.Equal(t, "token-1", resp.GetTokens()[0].GetName())
+		assert.NotNil(t, resp.GetTokens()[0].GetExpiresAt())
+		for _, tkn := range resp.GetTokens() {
+			assert.Empty(t, tkn.GetToken())
+		}
+	})
+
+	t.Run("異常系：管理用でないトークンは一覧を取得できない", func(t *testing.T) {
+		tokenRepo := newAuthorizedTokenRepository()
+		client := messagev1.NewTokenServiceClient(newTestClient(t, new(mockMessageRepository), tokenRepo))
+
+		_, err := client.ListTokens(authContext("valid-token"), &messagev1.ListTokensRequest{})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		tokenRepo.AssertNotCalled(t, "List", mock.Anything)
+	})
+}
+
+func TestTokenServer_DeleteToken(t *testing.T) {
+	tests := []struct {
+		name         string
+		auth         string
+		findErr      error
+		err          error
+		expectDelete bool
+		expectedCode codes.Code
+	}{
+		{name: "正常系：トークン無効化成功", auth: "admin-token", expectDelete: true, expectedCode: codes.OK},
+		{name: "異常系：存在しないトークン", auth: "admin-token", err: mongo.ErrNoDocuments, expectDelete: true, expectedCode: codes.NotFound},
+		{name: "異常系：不正なID", auth: "admin-token", findErr: primitive.ErrInvalidHex, expectedCode: codes.InvalidArgument},
+		{name: "異常系：管理用でないトークンは無効化できない", auth: "valid-token", expectedCode: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenRepo := newAdminTokenRepository()
+			if tt.findErr != nil {
+				tokenRepo.On("FindByID", mock.Anything, "token-id").Return(nil, tt.findErr)
+			} else {
+				tokenRepo.On("FindByID", mock.Anything, "token-id").Return(&token.Token{Name: "token"}, nil)
+			}
+			if tt.expectDelete {
+				tokenRepo.On("Delete", mock.Anything, "token-id").Return(tt.err)
+			}
+			client := messagev1.NewTokenServiceClient(newTestClient(t, new(mockMessageRepository), tokenRepo))
+
+			_, err := client.DeleteToken(authContext(tt.auth), &messagev1.DeleteTokenRequest{Id: "token-id"})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if !tt.expectDelete {
+				tokenRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
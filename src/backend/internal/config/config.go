@@ -0,0 +1,449 @@
+// Package config はサービスの設定を読み込む
+// 既定値、YAMLファイル、環境変数、コマンドライン引数の順に読み込み、後のものほど優先する
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ストレージの種類
+const (
+	StorageMongoDB  = "mongodb"
+	StorageMemory   = "memory"
+	StorageSQLite   = "sqlite"
+	StoragePostgres = "postgres"
+)
+
+// トレースのエクスポーター
+const (
+	TracingExporterNone   = "none"
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+)
+
+// ログの形式
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+// redacted は秘匿情報を置き換える文字列
+const redacted = "REDACTED"
+
+// MinBootstrapTokenLength はtoken.bootstrap_tokenに設定できるトークン文字列の最小の長さ
+const MinBootstrapTokenLength = 16
+
+// Config はサービス全体の設定
+type Config struct {
+	Server     ServerConfig     `yaml:"server"`
+	Storage    StorageConfig    `yaml:"storage"`
+	MongoDB    MongoDBConfig    `yaml:"mongodb"`
+	Token      TokenConfig      `yaml:"token"`
+	BulkDelete BulkDeleteConfig `yaml:"bulk_delete"`
+	Tracing    TracingConfig    `yaml:"tracing"`
+	Log        LogConfig        `yaml:"log"`
+}
+
+// ServerConfig はHTTPとgRPCのサーバーの設定
+type ServerConfig struct {
+	HTTPAddress string `yaml:"http_address"`
+	GRPCAddress string `yaml:"grpc_address"`
+	// MetricsAddress はメトリクスを公開する待ち受けアドレス。認証なしで公開するためAPIとは分ける
+	// 空の場合はメトリクスを公開しない
+	MetricsAddress string `yaml:"metrics_address"`
+	// ShutdownDelay は停止の開始から新しいリクエストの受け付けを止めるまでの時間
+	// この間は/readyzが失敗し、ロードバランサーが振り分け先から外すのを待つ
+	ShutdownDelay time.Duration `yaml:"shutdown_delay"`
+	// ShutdownTimeout は停止時に処理中のリクエストやストリームの完了を待つ時間
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+// StorageConfig は永続化先の設定
+type StorageConfig struct {
+	// Type はmongodb、memory、sqlite、postgresのいずれか
+	Type string `yaml:"type"`
+	// DatabaseURL はsqlite・postgresの接続先
+	DatabaseURL string `yaml:"database_url"`
+	// ExpirySweepInterval はTTLインデックスを持たないストレージで期限切れのデータを削除する間隔
+	ExpirySweepInterval time.Duration `yaml:"expiry_sweep_interval"`
+}
+
+// MongoDBConfig はMongoDBの接続設定
+type MongoDBConfig struct {
+	URI                    string        `yaml:"uri"`
+	Name                   string        `yaml:"name"`
+	Timeout                time.Duration `yaml:"timeout"`
+	ServerSelectionTimeout time.Duration `yaml:"server_selection_timeout"`
+	// SkipIndexSync は起動時にインデックスを作成せず、差分の報告のみ行うかどうか
+	SkipIndexSync bool `yaml:"skip_index_sync"`
+}
+
+// TokenConfig はアクセストークンの設定
+type TokenConfig struct {
+	// DefaultTTL は有効期限が指定されなかったトークンの有効期間
+	DefaultTTL time.Duration `yaml:"default_ttl"`
+	// BootstrapToken は起動時に既定のテナントへ作成する運用者用トークンの文字列
+	// 管理コマンドでトークンを作成できないインメモリストレージでのみ設定できる
+	BootstrapToken string `yaml:"bootstrap_token"`
+}
+
+// BulkDeleteConfig はメッセージの一括削除の設定
+type BulkDeleteConfig struct {
+	// ConfirmationKey は確認トークンの署名鍵。複数のレプリカでは同じ値を設定する
+	// 空の場合は起動ごとにランダムな値を生成するため、発行したレプリカでのみ有効になる
+	ConfirmationKey string `yaml:"confirmation_key"`
+	// ConfirmationTTL は確認トークンの有効期間
+	ConfirmationTTL time.Duration `yaml:"confirmation_ttl"`
+}
+
+// TracingConfig は分散トレーシングの設定
+type TracingConfig struct {
+	// Exporter はnone、otlp、stdoutのいずれか
+	Exporter string `yaml:"exporter"`
+	// OTLPEndpoint はOTLP/HTTPでスパンを送信するURL
+	OTLPEndpoint string `yaml:"otlp_endpoint"`
+	// SampleRatio は呼び出し元がサンプリングを決めていないトレースを記録する割合（0〜1）
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// LogConfig はログ出力の設定
+type LogConfig struct {
+	// Level はdebug、info、warn、errorのいずれか
+	Level string `yaml:"level"`
+	// Format はjsonまたはtext
+	Format string `yaml:"format"`
+}
+
+// Default は既定値の設定を返す
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			HTTPAddress:     ":8080",
+			GRPCAddress:     ":9090",
+			MetricsAddress:  ":9091",
+			ShutdownDelay:   5 * time.Second,
+			ShutdownTimeout: 30 * time.Second,
+		},
+		Storage: StorageConfig{
+			Type:                StorageMongoDB,
+			ExpirySweepInterval: time.Minute,
+		},
+		MongoDB: MongoDBConfig{
+			Timeout:                10 * time.Second,
+			ServerSelectionTimeout: 5 * time.Second,
+		},
+		Token: TokenConfig{
+			DefaultTTL: 30 * 24 * time.Hour,
+		},
+		BulkDelete: BulkDeleteConfig{
+			ConfirmationTTL: 10 * time.Minute,
+		},
+		Tracing: TracingConfig{
+			Exporter:     TracingExporterNone,
+			OTLPEndpoint: "http://localhost:4318/v1/traces",
+			SampleRatio:  1,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: LogFormatJSON,
+		},
+	}
+}
+
+// binding は設定項目と環境変数・コマンドライン引数の対応
+type binding struct {
+	env   string
+	flag  string
+	usage string
+	// target は値を設定するフィールドを返す。*string、*bool、*float64、*time.Durationのいずれか
+	target func(c *Config) interface{}
+}
+
+var bindings = []binding{
+	{env: "SERVER_ADDRESS", flag: "http-address", usage: "HTTPサーバーの待ち受けアドレス",
+		target: func(c *Config) interface{} { return &c.Server.HTTPAddress }},
+	{env: "GRPC_ADDRESS", flag: "grpc-address", usage: "gRPCサーバーの待ち受けアドレス",
+		target: func(c *Config) interface{} { return &c.Server.GRPCAddress }},
+	{env: "METRICS_ADDRESS", flag: "metrics-address", usage: "メトリクスの待ち受けアドレス（空の場合は公開しない）",
+		target: func(c *Config) interface{} { return &c.Server.MetricsAddress }},
+	{env: "SHUTDOWN_DELAY", flag: "shutdown-delay", usage: "停止の開始から受け付けを止めるまでの時間",
+		target: func(c *Config) interface{} { return &c.Server.ShutdownDelay }},
+	{env: "SHUTDOWN_TIMEOUT", flag: "shutdown-timeout", usage: "停止時に処理中のリクエストの完了を待つ時間",
+		target: func(c *Config) interface{} { return &c.Server.ShutdownTimeout }},
+	{env: "STORAGE", flag: "storage", usage: "ストレージの種類 (mongodb, memory, sqlite, postgres)",
+		target: func(c *Config) interface{} { return &c.Storage.Type }},
+	{env: "DATABASE_URL", flag: "database-url", usage: "sqlite・postgresの接続先",
+		target: func(c *Config) interface{} { return &c.Storage.DatabaseURL }},
+	{env: "EXPIRY_SWEEP_INTERVAL", flag: "expiry-sweep-interval", usage: "期限切れのデータを削除する間隔",
+		target: func(c *Config) interface{} { return &c.Storage.ExpirySweepInterval }},
+	{env: "MONGODB_URI", flag: "mongodb-uri", usage: "MongoDBの接続URI",
+		target: func(c *Config) interface{} { return &c.MongoDB.URI }},
+	{env: "MONGODB_NAME", flag: "mongodb-name", usage: "MongoDBのデータベース名",
+		target: func(c *Config) interface{} { return &c.MongoDB.Name }},
+	{env: "MONGODB_TIMEOUT", flag: "mongodb-timeout", usage: "MongoDBの操作のタイムアウト",
+		target: func(c *Config) interface{} { return &c.MongoDB.Timeout }},
+	{env: "MONGODB_SERVER_SELECTION_TIMEOUT", flag: "mongodb-server-selection-timeout", usage: "MongoDBのサーバー選択のタイムアウト",
+		target: func(c *Config) interface{} { return &c.MongoDB.ServerSelectionTimeout }},
+	{env: "MONGODB_SKIP_INDEX_SYNC", flag: "skip-index-sync", usage: "起動時にMongoDBのインデックスを作成せず、差分の報告のみ行う",
+		target: func(c *Config) interface{} { return &c.MongoDB.SkipIndexSync }},
+	{env: "TOKEN_DEFAULT_TTL", flag: "token-default-ttl", usage: "有効期限が指定されなかったトークンの有効期間",
+		target: func(c *Config) interface{} { return &c.Token.DefaultTTL }},
+	{env: "TOKEN_BOOTSTRAP", flag: "token-bootstrap", usage: "インメモリストレージで起動時に作成する運用者用トークンの文字列",
+		target: func(c *Config) interface{} { return &c.Token.BootstrapToken }},
+	{env: "BULK_DELETE_CONFIRMATION_KEY", flag: "bulk-delete-confirmation-key", usage: "一括削除の確認トークンの署名鍵",
+		target: func(c *Config) interface{} { return &c.BulkDelete.ConfirmationKey }},
+	{env: "BULK_DELETE_CONFIRMATION_TTL", flag: "bulk-delete-confirmation-ttl", usage: "一括削除の確認トークンの有効期間",
+		target: func(c *Config) interface{} { return &c.BulkDelete.ConfirmationTTL }},
+	{env: "TRACING_EXPORTER", flag: "tracing-exporter", usage: "トレースのエクスポーター (none, otlp, stdout)",
+		target: func(c *Config) interface{} { return &c.Tracing.Exporter }},
+	{env: "TRACING_OTLP_ENDPOINT", flag: "tracing-otlp-endpoint", usage: "OTLP/HTTPでスパンを送信するURL",
+		target: func(c *Config) interface{} { return &c.Tracing.OTLPEndpoint }},
+	{env: "TRACING_SAMPLE_RATIO", flag: "tracing-sample-ratio", usage: "トレースを記録する割合 (0〜1)",
+		target: func(c *Config) interface{} { return &c.Tracing.SampleRatio }},
+	{env: "LOG_LEVEL", flag: "log-level", usage: "出力するログの最低レベル (debug, info, warn, error)",
+		target: func(c *Config) interface{} { return &c.Log.Level }},
+	{env: "LOG_FORMAT", flag: "log-format", usage: "ログの形式 (json, text)",
+		target: func(c *Config) interface{} { return &c.Log.Format }},
+}
+
+// Load はコマンドライン引数と環境変数から設定を読み込み、検証する
+// 設定ファイルは--configまたは環境変数CONFIG_FILEで指定する
+// 戻り値の[]stringはフラグ以外の引数（サブコマンドなど）
+func Load(args []string, lookupEnv func(string) (string, bool)) (*Config, []string, error) {
+	fs := flag.NewFlagSet("message-service", flag.ContinueOnError)
+	configFile := fs.String("config", "", "YAML形式の設定ファイル (環境変数CONFIG_FILE)")
+
+	// フラグは環境変数より優先するため、値を控えておき最後に適用する
+	type flagValue struct {
+		binding binding
+		value   string
+	}
+	var flagValues []flagValue
+	for _, b := range bindings {
+		b := b
+		record := func(s string) error {
+			flagValues = append(flagValues, flagValue{binding: b, value: s})
+			return nil
+		}
+		if _, ok := b.target(&Config{}).(*bool); ok {
+			fs.BoolFunc(b.flag, b.usage, record)
+		} else {
+			fs.Func(b.flag, b.usage, record)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	cfg := Default()
+
+	path := *configFile
+	if path == "" {
+		path, _ = lookupEnv("CONFIG_FILE")
+	}
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open config file: %w", err)
+		}
+		defer f.Close()
+		if err := decodeYAML(f, &cfg); err != nil {
+			return nil, nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+		}
+	}
+
+	// 空の環境変数は未設定として扱う
+	for _, b := range bindings {
+		if value, ok := lookupEnv(b.env); ok && value != "" {
+			if err := setValue(b.target(&cfg), value); err != nil {
+				return nil, nil, fmt.Errorf("invalid %s: %w", b.env, err)
+			}
+		}
+	}
+	for _, fv := range flagValues {
+		if err := setValue(fv.binding.target(&cfg), fv.value); err != nil {
+			return nil, nil, fmt.Errorf("invalid --%s: %w", fv.binding.flag, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return &cfg, fs.Args(), nil
+}
+
+// decodeYAML はYAMLを設定に読み込む。未知のキーはエラーとする
+func decodeYAML(r io.Reader, cfg *Config) error {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// setValue は文字列の値をフィールドの型に変換して設定する
+func setValue(target interface{}, value string) error {
+	switch p := target.(type) {
+	case *string:
+		*p = value
+	case *bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*p = b
+	case *float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*p = f
+	case *time.Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*p = d
+	default:
+		return fmt.Errorf("unsupported config type %T", target)
+	}
+	return nil
+}
+
+// Validate は設定値を検証し、問題を全てまとめて返す
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Server.HTTPAddress == "" {
+		errs = append(errs, errors.New("server.http_address is required"))
+	}
+	if c.Server.GRPCAddress == "" {
+		errs = append(errs, errors.New("server.grpc_address is required"))
+	}
+	if c.Server.ShutdownDelay < 0 {
+		errs = append(errs, errors.New("server.shutdown_delay must not be negative"))
+	}
+	if c.Server.MetricsAddress != "" && (c.Server.MetricsAddress == c.Server.HTTPAddress || c.Server.MetricsAddress == c.Server.GRPCAddress) {
+		errs = append(errs, errors.New("server.metrics_address must differ from server.http_address and server.grpc_address"))
+	}
+
+	switch c.Storage.Type {
+	case StorageMongoDB:
+		if c.MongoDB.URI == "" {
+			errs = append(errs, errors.New("mongodb.uri is required for mongodb storage"))
+		}
+		if c.MongoDB.Name == "" {
+			errs = append(errs, errors.New("mongodb.name is required for mongodb storage"))
+		}
+	case StorageSQLite, StoragePostgres:
+		if c.Storage.DatabaseURL == "" {
+			errs = append(errs, fmt.Errorf("storage.database_url is required for %s storage", c.Storage.Type))
+		}
+	case StorageMemory:
+	default:
+		errs = append(errs, fmt.Errorf("storage.type must be one of mongodb, memory, sqlite or postgres (got %q)", c.Storage.Type))
+	}
+
+	if c.Token.BootstrapToken != "" {
+		if c.Storage.Type != StorageMemory {
+			errs = append(errs, errors.New("token.bootstrap_token is only supported for memory storage"))
+		}
+		if len(c.Token.BootstrapToken) < MinBootstrapTokenLength {
+			errs = append(errs, fmt.Errorf("token.bootstrap_token must be at least %d characters", MinBootstrapTokenLength))
+		}
+	}
+
+	switch c.Tracing.Exporter {
+	case TracingExporterOTLP:
+		if u, err := url.Parse(c.Tracing.OTLPEndpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("tracing.otlp_endpoint must be an absolute URL (got %q)", c.Tracing.OTLPEndpoint))
+		}
+	case TracingExporterNone, TracingExporterStdout:
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter must be one of none, otlp or stdout (got %q)", c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, errors.New("tracing.sample_ratio must be between 0 and 1"))
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Log.Level)); err != nil {
+		errs = append(errs, fmt.Errorf("log.level must be one of debug, info, warn or error (got %q)", c.Log.Level))
+	}
+	switch c.Log.Format {
+	case LogFormatJSON, LogFormatText:
+	default:
+		errs = append(errs, fmt.Errorf("log.format must be json or text (got %q)", c.Log.Format))
+	}
+
+	for name, d := range map[string]time.Duration{
+		"server.shutdown_timeout":          c.Server.ShutdownTimeout,
+		"storage.expiry_sweep_interval":    c.Storage.ExpirySweepInterval,
+		"mongodb.timeout":                  c.MongoDB.Timeout,
+		"mongodb.server_selection_timeout": c.MongoDB.ServerSelectionTimeout,
+		"token.default_ttl":                c.Token.DefaultTTL,
+		"bulk_delete.confirmation_ttl":     c.BulkDelete.ConfirmationTTL,
+	} {
+		if d <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Redacted は接続文字列に含まれるパスワードや署名鍵を伏せた設定を返す
+func (c Config) Redacted() Config {
+	if c.BulkDelete.ConfirmationKey != "" {
+		c.BulkDelete.ConfirmationKey = redacted
+	}
+	if c.Token.BootstrapToken != "" {
+		c.Token.BootstrapToken = redacted
+	}
+	c.MongoDB.URI = redactDSN(c.MongoDB.URI)
+	c.Storage.DatabaseURL = redactDSN(c.Storage.DatabaseURL)
+	c.Tracing.OTLPEndpoint = redactDSN(c.Tracing.OTLPEndpoint)
+	return c
+}
+
+// String は秘匿情報を伏せた設定をYAML形式で返す
+func (c Config) String() string {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(c.Redacted()); err != nil {
+		return fmt.Sprintf("<invalid config: %v>", err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// dsnPassword はkey=value形式の接続文字列のパスワード
+var dsnPassword = regexp.MustCompile(`(?i)(password=)('[^']*'|\S+)`)
+
+// redactDSN はURL形式またはkey=value形式の接続文字列のパスワードを伏せる
+func redactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+		q := u.Query()
+		if q.Has("password") {
+			q.Set("password", redacted)
+			u.RawQuery = q.Encode()
+		}
+		return u.String()
+	}
+	return dsnPassword.ReplaceAllString(dsn, "${1}"+redacted)
+}
@@ -0,0 +1,25 @@
+package audit
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Action は監査対象の操作の種類
+type Action string
+
+const (
+	ActionMessageBulkDelete Action = "message.bulk_delete"
+)
+
+// Log は破壊的な操作の実行記録
+type Log struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID  string             `bson:"tenant_id"`
+	Action    Action             `bson:"action"`
+	TokenID   string             `bson:"token_id,omitempty"` // 操作を行ったトークンのID
+	Criteria  map[string]string  `bson:"criteria"`           // 操作対象を絞り込んだ条件
+	Affected  int64              `bson:"affected"`           // 操作によって変更された件数
+	CreatedAt time.Time          `bson:"created_at"`
+}
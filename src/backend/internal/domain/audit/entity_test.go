@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLog_BSONMapping(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	log := &Log{
+		ID:        primitive.NewObjectID(),
+		Action:    ActionMessageBulkDelete,
+		TokenID:   "token-id",
+		Criteria:  map[string]string{"channel_id": "channel-1"},
+		Affected:  3,
+		CreatedAt: now,
+	}
+
+	data, err := bson.Marshal(log)
+	assert.NoError(t, err)
+
+	var doc bson.M
+	assert.NoError(t, bson.Unmarshal(data, &doc))
+	assert.Equal(t, "message.bulk_delete", doc["action"])
+	assert.Equal(t, "token-id", doc["token_id"])
+	assert.Equal(t, int64(3), doc["affected"])
+
+	var decoded Log
+	assert.NoError(t, bson.Unmarshal(data, &decoded))
+	assert.Equal(t, *log, decoded)
+}
@@ -0,0 +1,7 @@
+package audit
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, log *Log) error
+}
@@ -0,0 +1,33 @@
+package message
+
+import (
+	"bytes"
+	"errors"
+)
+
+const (
+	// DefaultAroundLimit は前後それぞれに取得するメッセージ数の既定値
+	DefaultAroundLimit = 20
+	// MaxAroundLimit は前後それぞれに取得できるメッセージ数の上限
+	MaxAroundLimit = 100
+)
+
+// ErrInvalidAroundLimit は前後に取得するメッセージ数が範囲外の場合のエラー
+var ErrInvalidAroundLimit = errors.New("before and after must be between 0 and 100")
+
+// ValidateAroundLimits は前後に取得するメッセージ数を検証する
+func ValidateAroundLimits(before, after int) error {
+	if before < 0 || before > MaxAroundLimit || after < 0 || after > MaxAroundLimit {
+		return ErrInvalidAroundLimit
+	}
+	return nil
+}
+
+// Precedes はメッセージの並び順でaがbより前かどうかを判定する
+// 送信日時の昇順に並べ、送信日時が同じ場合はIDの昇順とする
+func Precedes(a, b *Message) bool {
+	if !a.SentAt.Equal(b.SentAt) {
+		return a.SentAt.Before(b.SentAt)
+	}
+	return bytes.Compare(a.ID[:], b.ID[:]) < 0
+}
@@ -0,0 +1,57 @@
+package message
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestValidateAroundLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   int
+		after    int
+		expected error
+	}{
+		{name: "正常系：既定値", before: DefaultAroundLimit, after: DefaultAroundLimit},
+		{name: "正常系：0件", before: 0, after: 0},
+		{name: "正常系：上限", before: MaxAroundLimit, after: MaxAroundLimit},
+		{name: "異常系：負の値", before: -1, after: 0, expected: ErrInvalidAroundLimit},
+		{name: "異常系：上限を超える", before: 0, after: MaxAroundLimit + 1, expected: ErrInvalidAroundLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAroundLimits(tt.before, tt.after)
+			assert.Equal(t, tt.expected, err)
+			assert.Equal(t, tt.expected != nil, IsValidationError(err))
+		})
+	}
+}
+
+func TestPrecedes(t *testing.T) {
+	sentAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	id1, _ := primitive.ObjectIDFromHex("000000000000000000000001")
+	id2, _ := primitive.ObjectIDFromHex("000000000000000000000002")
+
+	tests := []struct {
+		name     string
+		a        Message
+		b        Message
+		expected bool
+	}{
+		{name: "送信日時が前", a: Message{ID: id2, SentAt: sentAt}, b: Message{ID: id1, SentAt: sentAt.Add(time.Second)}, expected: true},
+		{name: "送信日時が後", a: Message{ID: id1, SentAt: sentAt.Add(time.Second)}, b: Message{ID: id2, SentAt: sentAt}, expected: false},
+		{name: "同時刻はIDが小さい方が前", a: Message{ID: id1, SentAt: sentAt}, b: Message{ID: id2, SentAt: sentAt}, expected: true},
+		{name: "同時刻でIDが大きい", a: Message{ID: id2, SentAt: sentAt}, b: Message{ID: id1, SentAt: sentAt}, expected: false},
+		{name: "同一のメッセージ", a: Message{ID: id1, SentAt: sentAt}, b: Message{ID: id1, SentAt: sentAt}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Precedes(&tt.a, &tt.b))
+		})
+	}
+}
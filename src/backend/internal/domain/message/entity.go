@@ -1,13 +1,41 @@
 package message
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var (
+	// ErrDuplicateUID は同じUIDの有効なメッセージが既に存在する場合のエラー
+	ErrDuplicateUID = errors.New("duplicate uid")
+
+	ErrUIDRequired       = errors.New("uid is required")
+	ErrSentAtRequired    = errors.New("sent_at is required")
+	ErrSenderRequired    = errors.New("sender is required")
+	ErrChannelIDRequired = errors.New("channel_id is required")
+	ErrContentRequired   = errors.New("content is required")
+
+	// ErrExpiryConflict はexpires_atとttl_secondsが同時に指定された場合のエラー
+	ErrExpiryConflict = errors.New("expires_at and ttl_seconds cannot be specified together")
+	// ErrInvalidTTL はttl_secondsが正の値でない場合のエラー
+	ErrInvalidTTL = errors.New("ttl_seconds must be positive")
+	// ErrExpiresAtInPast はexpires_atが現在時刻以前の場合のエラー
+	ErrExpiresAtInPast = errors.New("expires_at must be in the future")
+	// ErrReplyToSelf はreply_toに自身のUIDが指定された場合のエラー
+	ErrReplyToSelf = errors.New("reply_to cannot refer to the message itself")
+
+	// ErrCriteriaRequired は一括操作で検索条件が1つも指定されていない場合のエラー
+	ErrCriteriaRequired = errors.New("at least one search criterion is required")
+)
+
 type Message struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID  string             `bson:"tenant_id"`
 	UID       string             `bson:"uid"`
 	SentAt    time.Time          `bson:"sent_at"`
 	Sender    string             `bson:"sender"`
@@ -16,11 +44,131 @@ type Message struct {
 	CreatedAt time.Time          `bson:"created_at"`
 	UpdatedAt time.Time          `bson:"updated_at"`
 	DeletedAt *time.Time         `bson:"deleted_at,omitempty"`
+	// ExpiresAt を過ぎたメッセージは検索対象外となり、後から物理削除される
+	ExpiresAt *time.Time `bson:"expires_at,omitempty"`
+	// ReplyTo は返信先のメッセージのUID。空の場合は返信ではない
+	// 返信先が後から登録される場合もあるため、返信先の存在は確認しない
+	ReplyTo string `bson:"reply_to,omitempty"`
+}
+
+// IsExpired は指定時刻の時点で有効期限を過ぎているかどうかを判定する
+func (m *Message) IsExpired(now time.Time) bool {
+	return m.ExpiresAt != nil && !m.ExpiresAt.After(now)
+}
+
+// ResolveExpiry は有効期限の日時または秒数から、メッセージの有効期限を求める
+// どちらも指定されていない場合は期限なし(nil)を返す
+func ResolveExpiry(expiresAt *time.Time, ttlSeconds *int64, now time.Time) (*time.Time, error) {
+	switch {
+	case expiresAt != nil && ttlSeconds != nil:
+		return nil, ErrExpiryConflict
+	case ttlSeconds != nil:
+		if *ttlSeconds <= 0 {
+			return nil, ErrInvalidTTL
+		}
+		t := now.Add(time.Duration(*ttlSeconds) * time.Second)
+		return &t, nil
+	case expiresAt != nil:
+		if !expiresAt.After(now) {
+			return nil, ErrExpiresAtInPast
+		}
+		t := *expiresAt
+		return &t, nil
+	}
+	return nil, nil
+}
+
+// Validate は作成時に必須となる項目を検証する
+func (m *Message) Validate() error {
+	switch {
+	case m.UID == "":
+		return ErrUIDRequired
+	case m.SentAt.IsZero():
+		return ErrSentAtRequired
+	case m.Sender == "":
+		return ErrSenderRequired
+	case m.ChannelID == "":
+		return ErrChannelIDRequired
+	case m.Content == "":
+		return ErrContentRequired
+	case m.ReplyTo != "" && m.ReplyTo == m.UID:
+		return ErrReplyToSelf
+	}
+	return nil
 }
 
+// IsValidationError はリクエストの内容が不正であることを示すエラーかどうかを判定する
+func IsValidationError(err error) bool {
+	for _, target := range []error{
+		ErrUIDRequired, ErrSentAtRequired, ErrSenderRequired, ErrChannelIDRequired, ErrContentRequired,
+		ErrExpiryConflict, ErrInvalidTTL, ErrExpiresAtInPast, ErrReplyToSelf, ErrInvalidAroundLimit,
+		ErrInvalidSort, ErrInvalidField, ErrTooManyFilterValues, ErrInvalidLimit, ErrInvalidCursor,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxFilterValues は複数の値を指定できる検索条件の値の上限
+const MaxFilterValues = 100
+
+// ErrTooManyFilterValues は検索条件に指定された値が多すぎる場合のエラー
+var ErrTooManyFilterValues = errors.New("too many values in search criteria")
+
+// SearchCriteria は検索条件。指定された条件は全て満たすものを対象とする
 type SearchCriteria struct {
-	ChannelID *string
-	Sender    *string
-	FromDate  *time.Time
-	ToDate    *time.Time
+	FromDate *time.Time
+	ToDate   *time.Time
+	// ChannelIDs が指定された場合、いずれかのチャンネルのメッセージを対象とする
+	ChannelIDs []string
+	// Senders が指定された場合、いずれかの送信者のメッセージを対象とする
+	Senders []string
+	// SenderPrefix は送信者の前方一致の条件
+	SenderPrefix *string
+	// ExcludeSenders に含まれる送信者のメッセージは対象外とする
+	ExcludeSenders []string
+	// IsReply が指定された場合、返信であるか(true)、返信でないか(false)で絞り込む
+	IsReply *bool
+	// HasThread が指定された場合、有効な返信があるか(true)、無いか(false)で絞り込む
+	// 削除済みや有効期限切れの返信は数えない
+	HasThread *bool
+	// CreatedBefore が指定された場合、この日時より前に作成されたメッセージのみを対象とする
+	// 一括削除でドライランの時点の対象に限定するための条件で、IsEmptyでは考慮しない
+	CreatedBefore *time.Time
+}
+
+// IsEmpty は検索条件が1つも指定されていないかどうかを判定する
+func (c SearchCriteria) IsEmpty() bool {
+	return c.FromDate == nil && c.ToDate == nil && len(c.ChannelIDs) == 0 && len(c.Senders) == 0 &&
+		c.SenderPrefix == nil && len(c.ExcludeSenders) == 0 && c.IsReply == nil && c.HasThread == nil
+}
+
+// Validate は複数の値を指定する条件が上限を超えていないかを検証する
+func (c SearchCriteria) Validate() error {
+	for _, values := range [][]string{c.ChannelIDs, c.Senders, c.ExcludeSenders} {
+		if len(values) > MaxFilterValues {
+			return fmt.Errorf("%w: at most %d values are allowed", ErrTooManyFilterValues, MaxFilterValues)
+		}
+	}
+	return nil
+}
+
+// Matches はメッセージが検索条件を満たすかどうかを判定する
+// 削除済みや有効期限切れかどうかは判定しない
+// HasThreadは他のメッセージとの関係で決まるため、呼び出し側で判定する
+func (c SearchCriteria) Matches(msg *Message) bool {
+	switch {
+	case c.FromDate != nil && msg.SentAt.Before(*c.FromDate),
+		c.ToDate != nil && msg.SentAt.After(*c.ToDate),
+		len(c.ChannelIDs) > 0 && !slices.Contains(c.ChannelIDs, msg.ChannelID),
+		len(c.Senders) > 0 && !slices.Contains(c.Senders, msg.Sender),
+		c.SenderPrefix != nil && !strings.HasPrefix(msg.Sender, *c.SenderPrefix),
+		slices.Contains(c.ExcludeSenders, msg.Sender),
+		c.IsReply != nil && *c.IsReply != (msg.ReplyTo != ""),
+		c.CreatedBefore != nil && !msg.CreatedAt.Before(*c.CreatedBefore):
+		return false
+	}
+	return true
 }
@@ -103,24 +103,24 @@ func TestSearchCriteria_Validation(t *testing.T) {
 			{
 				name: "有効な完全な検索条件",
 				criteria: SearchCriteria{
-					ChannelID: strPtr("channel-1"),
-					Sender:    strPtr("user1"),
-					FromDate:  timePtr(time.Now().Add(-24 * time.Hour)),
-					ToDate:    timePtr(time.Now()),
+					ChannelIDs: []string{"channel-1"},
+					Senders:    []string{"user1"},
+					FromDate:   timePtr(time.Now().Add(-24 * time.Hour)),
+					ToDate:     timePtr(time.Now()),
 				},
 				isValid: true,
 			},
 			{
 				name: "チャンネルIDのみの検索条件",
 				criteria: SearchCriteria{
-					ChannelID: strPtr("channel-1"),
+					ChannelIDs: []string{"channel-1"},
 				},
 				isValid: true,
 			},
 			{
 				name: "送信者のみの検索条件",
 				criteria: SearchCriteria{
-					Sender: strPtr("user1"),
+					Senders: []string{"user1"},
 				},
 				isValid: true,
 			},
@@ -159,10 +159,191 @@ func TestSearchCriteria_Validation(t *testing.T) {
 }
 
 // ヘルパー関数
-func strPtr(s string) *string {
-	return &s
-}
-
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestMessage_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*Message)
+		expected error
+	}{
+		{name: "有効なメッセージ", modify: func(m *Message) {}, expected: nil},
+		{name: "UIDが空", modify: func(m *Message) { m.UID = "" }, expected: ErrUIDRequired},
+		{name: "送信日時が空", modify: func(m *Message) { m.SentAt = time.Time{} }, expected: ErrSentAtRequired},
+		{name: "送信者が空", modify: func(m *Message) { m.Sender = "" }, expected: ErrSenderRequired},
+		{name: "チャンネルIDが空", modify: func(m *Message) { m.ChannelID = "" }, expected: ErrChannelIDRequired},
+		{name: "Contentが空", modify: func(m *Message) { m.Content = "" }, expected: ErrContentRequired},
+		{name: "他のメッセージへの返信", modify: func(m *Message) { m.ReplyTo = "parent" }, expected: nil},
+		{name: "自身への返信", modify: func(m *Message) { m.ReplyTo = m.UID }, expected: ErrReplyToSelf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := createTestMessage(t)
+			tt.modify(msg)
+			err := msg.Validate()
+			assert.Equal(t, tt.expected, err)
+			assert.Equal(t, tt.expected != nil, IsValidationError(err))
+		})
+	}
+}
+
+func TestSearchCriteria_IsEmpty(t *testing.T) {
+	channelID := "channel-1"
+	sender := "user1"
+	isReply := false
+
+	tests := []struct {
+		name     string
+		criteria SearchCriteria
+		expected bool
+	}{
+		{name: "条件なし", criteria: SearchCriteria{}, expected: true},
+		{name: "開始日時を指定", criteria: SearchCriteria{FromDate: timePtr(time.Now())}, expected: false},
+		{name: "終了日時を指定", criteria: SearchCriteria{ToDate: timePtr(time.Now())}, expected: false},
+		{name: "複数のチャンネルIDを指定", criteria: SearchCriteria{ChannelIDs: []string{channelID}}, expected: false},
+		{name: "複数の送信者を指定", criteria: SearchCriteria{Senders: []string{sender}}, expected: false},
+		{name: "送信者の前方一致を指定", criteria: SearchCriteria{SenderPrefix: &sender}, expected: false},
+		{name: "除外する送信者を指定", criteria: SearchCriteria{ExcludeSenders: []string{sender}}, expected: false},
+		{name: "返信かどうかを指定", criteria: SearchCriteria{IsReply: &isReply}, expected: false},
+		{name: "返信の有無を指定", criteria: SearchCriteria{HasThread: &isReply}, expected: false},
+		{name: "作成日時の上限のみは条件なしとして扱う", criteria: SearchCriteria{CreatedBefore: timePtr(time.Now())}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.criteria.IsEmpty())
+		})
+	}
+}
+
+func TestSearchCriteria_Validate(t *testing.T) {
+	tooMany := make([]string, MaxFilterValues+1)
+
+	tests := []struct {
+		name     string
+		criteria SearchCriteria
+		expected error
+	}{
+		{name: "正常系：条件なし", criteria: SearchCriteria{}},
+		{name: "正常系：上限までの値", criteria: SearchCriteria{ChannelIDs: make([]string, MaxFilterValues)}},
+		{name: "異常系：チャンネルIDが多すぎる", criteria: SearchCriteria{ChannelIDs: tooMany}, expected: ErrTooManyFilterValues},
+		{name: "異常系：除外する送信者が多すぎる", criteria: SearchCriteria{ExcludeSenders: tooMany}, expected: ErrTooManyFilterValues},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.criteria.Validate()
+			if tt.expected != nil {
+				assert.ErrorIs(t, err, tt.expected)
+				assert.True(t, IsValidationError(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSearchCriteria_Matches(t *testing.T) {
+	sentAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	msg := &Message{ChannelID: "general", Sender: "bot-alerts", SentAt: sentAt, CreatedAt: sentAt}
+	general := "general"
+	random := "random"
+	botPrefix := "bot-"
+	userPrefix := "user-"
+	reply := &Message{ChannelID: "general", Sender: "alice", SentAt: sentAt, ReplyTo: "parent"}
+	yes, no := true, false
+
+	tests := []struct {
+		name     string
+		msg      *Message
+		criteria SearchCriteria
+		expected bool
+	}{
+		{name: "条件なし", criteria: SearchCriteria{}, expected: true},
+		{name: "チャンネルIDが一致", criteria: SearchCriteria{ChannelIDs: []string{general}}, expected: true},
+		{name: "チャンネルIDが不一致", criteria: SearchCriteria{ChannelIDs: []string{random}}, expected: false},
+		{name: "いずれかのチャンネルIDに一致", criteria: SearchCriteria{ChannelIDs: []string{"random", "general"}}, expected: true},
+		{name: "どのチャンネルIDにも不一致", criteria: SearchCriteria{ChannelIDs: []string{"random", "dev"}}, expected: false},
+		{name: "いずれかの送信者に一致", criteria: SearchCriteria{Senders: []string{"alice", "bot-alerts"}}, expected: true},
+		{name: "送信者が前方一致", criteria: SearchCriteria{SenderPrefix: &botPrefix}, expected: true},
+		{name: "送信者が前方一致しない", criteria: SearchCriteria{SenderPrefix: &userPrefix}, expected: false},
+		{name: "除外する送信者", criteria: SearchCriteria{SenderPrefix: &botPrefix, ExcludeSenders: []string{"bot-alerts"}}, expected: false},
+		{name: "除外する送信者に含まれない", criteria: SearchCriteria{ExcludeSenders: []string{"alice"}}, expected: true},
+		{name: "期間外", criteria: SearchCriteria{FromDate: timePtr(sentAt.Add(time.Second))}, expected: false},
+		{name: "期間の境界を含む", criteria: SearchCriteria{FromDate: &sentAt, ToDate: &sentAt}, expected: true},
+		{name: "返信ではない", criteria: SearchCriteria{IsReply: &no}, expected: true},
+		{name: "返信のみを対象", criteria: SearchCriteria{IsReply: &yes}, expected: false},
+		{name: "返信に一致", msg: reply, criteria: SearchCriteria{IsReply: &yes}, expected: true},
+		{name: "返信を除外", msg: reply, criteria: SearchCriteria{IsReply: &no}, expected: false},
+		{name: "作成日時の上限より前", criteria: SearchCriteria{CreatedBefore: timePtr(sentAt.Add(time.Millisecond))}, expected: true},
+		{name: "作成日時の上限は含まない", criteria: SearchCriteria{CreatedBefore: &sentAt}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := msg
+			if tt.msg != nil {
+				target = tt.msg
+			}
+			assert.Equal(t, tt.expected, tt.criteria.Matches(target))
+		})
+	}
+}
+
+func TestResolveExpiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ttl := int64(60)
+	zero := int64(0)
+
+	tests := []struct {
+		name       string
+		expiresAt  *time.Time
+		ttlSeconds *int64
+		expected   *time.Time
+		err        error
+	}{
+		{name: "正常系：指定なし", expected: nil},
+		{name: "正常系：秒数を指定", ttlSeconds: &ttl, expected: timePtr(now.Add(time.Minute))},
+		{name: "正常系：日時を指定", expiresAt: timePtr(now.Add(time.Hour)), expected: timePtr(now.Add(time.Hour))},
+		{name: "異常系：両方を指定", expiresAt: timePtr(now.Add(time.Hour)), ttlSeconds: &ttl, err: ErrExpiryConflict},
+		{name: "異常系：秒数が0", ttlSeconds: &zero, err: ErrInvalidTTL},
+		{name: "異常系：日時が現在時刻", expiresAt: timePtr(now), err: ErrExpiresAtInPast},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expiresAt, err := ResolveExpiry(tt.expiresAt, tt.ttlSeconds, now)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.expected, expiresAt)
+			if err != nil {
+				assert.True(t, IsValidationError(err))
+			}
+		})
+	}
+}
+
+func TestMessage_IsExpired(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		expiresAt *time.Time
+		expected  bool
+	}{
+		{name: "有効期限なし", expiresAt: nil, expected: false},
+		{name: "有効期限前", expiresAt: timePtr(now.Add(time.Second)), expected: false},
+		{name: "有効期限ちょうど", expiresAt: timePtr(now), expected: true},
+		{name: "有効期限後", expiresAt: timePtr(now.Add(-time.Second)), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := createTestMessage(t)
+			msg.ExpiresAt = tt.expiresAt
+			assert.Equal(t, tt.expected, msg.IsExpired(now))
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package message
+
+import (
+	"context"
+	"time"
+)
+
+// EventType はメッセージに対する変更の種類
+type EventType string
+
+const (
+	EventCreated EventType = "created"
+	EventUpdated EventType = "updated"
+	EventDeleted EventType = "deleted"
+)
+
+// Event はメッセージの変更を表すドメインイベント
+type Event struct {
+	Type       EventType
+	Message    Message
+	OccurredAt time.Time
+}
+
+// EventPublisher はドメインイベントの配信先を定義するインターフェース
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// EventFilter は購読者が受け取るイベントを選別する関数
+type EventFilter func(event Event) bool
+
+// EventSubscriber はドメインイベントの購読を定義するインターフェース
+// filterに一致するイベントを受け取るチャネルと購読解除関数を返す
+type EventSubscriber interface {
+	Subscribe(filter EventFilter) (<-chan Event, func())
+}
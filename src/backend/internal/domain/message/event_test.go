@@ -0,0 +1,55 @@
+package message
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 記録用のパブリッシャー
+type recordingPublisher struct {
+	events []Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event Event) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+func TestEvent_Creation(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType EventType
+		expected  string
+	}{
+		{name: "作成イベント", eventType: EventCreated, expected: "created"},
+		{name: "更新イベント", eventType: EventUpdated, expected: "updated"},
+		{name: "削除イベント", eventType: EventDeleted, expected: "deleted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := createTestMessage(t)
+			event := Event{
+				Type:       tt.eventType,
+				Message:    *msg,
+				OccurredAt: time.Now(),
+			}
+
+			assert.Equal(t, tt.expected, string(event.Type))
+			assert.Equal(t, msg.UID, event.Message.UID)
+			assert.NotZero(t, event.OccurredAt)
+		})
+	}
+}
+
+func TestEventPublisher_Interface(t *testing.T) {
+	var publisher EventPublisher = &recordingPublisher{}
+
+	err := publisher.Publish(context.Background(), Event{Type: EventCreated, Message: *createTestMessage(t)})
+
+	assert.NoError(t, err)
+	assert.Len(t, publisher.(*recordingPublisher).events, 1)
+}
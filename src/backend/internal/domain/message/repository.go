@@ -4,7 +4,28 @@ import "context"
 
 type Repository interface {
 	Create(ctx context.Context, msg *Message) error
+	// CreateMany は複数のメッセージを作成する
+	// 戻り値のスライスは入力と同じ順序で、作成できなかったメッセージの位置にエラーが入る
+	CreateMany(ctx context.Context, msgs []*Message) ([]error, error)
 	Delete(ctx context.Context, uid string) error
-	Search(ctx context.Context, criteria SearchCriteria) ([]Message, error)
+	// Search は検索条件に一致するメッセージをoptsの並び順で返す
+	Search(ctx context.Context, criteria SearchCriteria, opts SearchOptions) ([]Message, error)
+	// Iterate は検索条件に一致するメッセージを送信日時順に1件ずつfnへ渡す
+	// 結果をメモリに溜めないため、大量件数のエクスポートに利用する
+	// fnがエラーを返した場合はその時点で打ち切り、そのエラーを返す
+	Iterate(ctx context.Context, criteria SearchCriteria, fn func(*Message) error) error
 	FindByUID(ctx context.Context, uid string) (*Message, error)
+	// Count は検索条件に一致する削除されていないメッセージの件数を返す
+	Count(ctx context.Context, criteria SearchCriteria) (int64, error)
+	// CountUpTo はCountと同じ条件でlimit件まで数える。limit件以上ある場合はlimitを返す
+	// 上限の確認など、全件を数える必要がない場合に使う
+	CountUpTo(ctx context.Context, criteria SearchCriteria, limit int64) (int64, error)
+	// DeleteMany は検索条件に一致するメッセージをまとめて論理削除し、削除した件数を返す
+	DeleteMany(ctx context.Context, criteria SearchCriteria) (int64, error)
+	// Around は対象のメッセージと同じチャンネルで、直前のbefore件と直後のafter件を返す
+	// どちらも送信日時の昇順で、送信日時が同じメッセージはIDの昇順とする
+	Around(ctx context.Context, target *Message, before, after int) (older []Message, newer []Message, err error)
+	// Stats は検索条件に一致するメッセージを集計単位ごとに数える
+	// 並び順はStatsAccumulator.Bucketsと同じとする
+	Stats(ctx context.Context, query StatsQuery) ([]StatsBucket, error)
 }
@@ -0,0 +1,178 @@
+package message
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SortOrder は検索結果の並び順
+// 先頭に"-"が付くものは降順とし、同じ値のメッセージはIDで並べる
+type SortOrder string
+
+const (
+	SortSentAtAsc    SortOrder = "sent_at"
+	SortSentAtDesc   SortOrder = "-sent_at"
+	SortCreatedAtAsc SortOrder = "created_at"
+)
+
+// ErrInvalidSort は並び順の指定が不正な場合のエラー
+var ErrInvalidSort = errors.New("sort must be one of sent_at, -sent_at or created_at")
+
+// ErrInvalidField は取得するフィールドの指定が不正な場合のエラー
+var ErrInvalidField = errors.New("unknown field")
+
+// MaxSearchLimit は1回の検索で取得できるメッセージ数の上限
+const MaxSearchLimit = 1000
+
+// ErrInvalidLimit は取得するメッセージ数が範囲外の場合のエラー
+var ErrInvalidLimit = errors.New("limit must be between 1 and 1000")
+
+// ErrInvalidCursor はカーソルの形式が不正な場合や、並び順が検索と異なる場合のエラー
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// searchFields は検索結果で取得を指定できるフィールド
+var searchFields = map[string]bool{
+	"uid":        true,
+	"sent_at":    true,
+	"sender":     true,
+	"channel_id": true,
+	"content":    true,
+	"created_at": true,
+	"updated_at": true,
+	"expires_at": true,
+	"reply_to":   true,
+}
+
+// SearchOptions は検索結果の並び順と取得するフィールドの指定
+type SearchOptions struct {
+	// Sort が空の場合は送信日時の昇順
+	Sort SortOrder
+	// Fields が空の場合は全てのフィールドを取得する
+	// 指定されていないフィールドは、実装によってはゼロ値で返る
+	Fields []string
+	// Limit が0より大きい場合は先頭からLimit件までを返す
+	Limit int
+	// After が指定された場合は、並び順でカーソルの位置より後のメッセージのみを返す
+	After *SearchCursor
+}
+
+// Validate は並び順とフィールドの指定を検証する
+func (o SearchOptions) Validate() error {
+	switch o.Sort {
+	case "", SortSentAtAsc, SortSentAtDesc, SortCreatedAtAsc:
+	default:
+		return ErrInvalidSort
+	}
+	for _, field := range o.Fields {
+		if !searchFields[field] {
+			return fmt.Errorf("%w: %q", ErrInvalidField, field)
+		}
+	}
+	if o.Limit < 0 || o.Limit > MaxSearchLimit {
+		return ErrInvalidLimit
+	}
+	// 並び順が異なるとカーソルの位置の意味が変わる
+	if o.After != nil && o.After.Sort != o.Sort.orDefault() {
+		return fmt.Errorf("%w: cursor was issued for sort %q", ErrInvalidCursor, o.After.Sort)
+	}
+	return nil
+}
+
+// orDefault は空の並び順を既定の送信日時の昇順に置き換える
+func (s SortOrder) orDefault() SortOrder {
+	if s == "" {
+		return SortSentAtAsc
+	}
+	return s
+}
+
+// Field は並び順のキーとなるフィールド名を返す
+func (s SortOrder) Field() string {
+	if s == SortCreatedAtAsc {
+		return "created_at"
+	}
+	return "sent_at"
+}
+
+// Descending は降順かどうかを返す
+func (s SortOrder) Descending() bool {
+	return s == SortSentAtDesc
+}
+
+// key はメッセージの並び順のキーの値を返す
+func (s SortOrder) key(msg *Message) time.Time {
+	if s == SortCreatedAtAsc {
+		return msg.CreatedAt
+	}
+	return msg.SentAt
+}
+
+// Less は並び順でaがbより前かどうかを判定する
+func (s SortOrder) Less(a, b *Message) bool {
+	switch s {
+	case SortSentAtDesc:
+		return Precedes(b, a)
+	case SortCreatedAtAsc:
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return bytes.Compare(a.ID[:], b.ID[:]) < 0
+	}
+	return Precedes(a, b)
+}
+
+// SearchCursor は検索結果の続きを取得するための位置
+// 並び順のキーの値とIDの組で表し、位置のメッセージが削除されても続きを取得できる
+type SearchCursor struct {
+	Sort  SortOrder
+	Value time.Time
+	ID    primitive.ObjectID
+}
+
+// CursorAfter は並び順でmsgの次から取得するためのカーソルを返す
+func (s SortOrder) CursorAfter(msg *Message) *SearchCursor {
+	return &SearchCursor{Sort: s.orDefault(), Value: s.key(msg), ID: msg.ID}
+}
+
+// Precedes はカーソルの位置がmsgより前かどうかを判定する
+func (c *SearchCursor) Precedes(msg *Message) bool {
+	pivot := &Message{ID: c.ID, SentAt: c.Value, CreatedAt: c.Value}
+	return c.Sort.Less(pivot, msg)
+}
+
+// String はカーソルをクエリパラメーターに使える文字列に変換する
+func (c *SearchCursor) String() string {
+	raw := strings.Join([]string{string(c.Sort), c.Value.UTC().Format(time.RFC3339Nano), c.ID.Hex()}, "|")
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseSearchCursor はStringで変換したカーソルを復元する
+func ParseSearchCursor(s string) (*SearchCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 {
+		return nil, ErrInvalidCursor
+	}
+	sort := SortOrder(parts[0])
+	if err := (SearchOptions{Sort: sort}).Validate(); err != nil || sort == "" {
+		return nil, ErrInvalidCursor
+	}
+	value, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	id, err := primitive.ObjectIDFromHex(parts[2])
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &SearchCursor{Sort: sort, Value: value, ID: id}, nil
+}
@@ -0,0 +1,118 @@
+package message
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSearchOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     SearchOptions
+		expected error
+	}{
+		{name: "正常系：指定なし", opts: SearchOptions{}},
+		{name: "正常系：送信日時の降順", opts: SearchOptions{Sort: SortSentAtDesc}},
+		{name: "正常系：作成日時の昇順とフィールド指定", opts: SearchOptions{Sort: SortCreatedAtAsc, Fields: []string{"uid", "sender", "sent_at"}}},
+		{name: "異常系：不正な並び順", opts: SearchOptions{Sort: "-created_at"}, expected: ErrInvalidSort},
+		{name: "異常系：不正なフィールド", opts: SearchOptions{Fields: []string{"uid", "deleted_at"}}, expected: ErrInvalidField},
+		{name: "正常系：件数と既定の並び順のカーソル", opts: SearchOptions{Limit: MaxSearchLimit, After: &SearchCursor{Sort: SortSentAtAsc}}},
+		{name: "異常系：件数が上限を超える", opts: SearchOptions{Limit: MaxSearchLimit + 1}, expected: ErrInvalidLimit},
+		{name: "異常系：件数が負", opts: SearchOptions{Limit: -1}, expected: ErrInvalidLimit},
+		{name: "異常系：並び順の異なるカーソル", opts: SearchOptions{Sort: SortCreatedAtAsc, After: &SearchCursor{Sort: SortSentAtAsc}}, expected: ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.expected != nil {
+				assert.ErrorIs(t, err, tt.expected)
+				assert.True(t, IsValidationError(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSortOrder_Less(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	id1, _ := primitive.ObjectIDFromHex("000000000000000000000001")
+	id2, _ := primitive.ObjectIDFromHex("000000000000000000000002")
+	// aは送信日時が前で作成日時が後
+	a := &Message{ID: id1, SentAt: base, CreatedAt: base.Add(time.Hour)}
+	b := &Message{ID: id2, SentAt: base.Add(time.Minute), CreatedAt: base}
+
+	tests := []struct {
+		name     string
+		sort     SortOrder
+		x, y     *Message
+		expected bool
+	}{
+		{name: "既定は送信日時の昇順", sort: "", x: a, y: b, expected: true},
+		{name: "送信日時の昇順", sort: SortSentAtAsc, x: a, y: b, expected: true},
+		{name: "送信日時の降順", sort: SortSentAtDesc, x: b, y: a, expected: true},
+		{name: "作成日時の昇順", sort: SortCreatedAtAsc, x: b, y: a, expected: true},
+		{name: "作成日時が同じ場合はIDの昇順", sort: SortCreatedAtAsc, x: &Message{ID: id1, CreatedAt: base}, y: &Message{ID: id2, CreatedAt: base}, expected: true},
+		{name: "降順で送信日時が同じ場合はIDの降順", sort: SortSentAtDesc, x: &Message{ID: id2, SentAt: base}, y: &Message{ID: id1, SentAt: base}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.sort.Less(tt.x, tt.y))
+			assert.False(t, tt.sort.Less(tt.y, tt.x))
+		})
+	}
+}
+
+func TestSearchCursor(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 123456789, time.UTC)
+	id1, _ := primitive.ObjectIDFromHex("000000000000000000000001")
+	id2, _ := primitive.ObjectIDFromHex("000000000000000000000002")
+	msg := &Message{ID: id1, SentAt: base, CreatedAt: base.Add(time.Hour)}
+
+	t.Run("正常系：文字列に変換して復元できる", func(t *testing.T) {
+		for _, sort := range []SortOrder{"", SortSentAtAsc, SortSentAtDesc, SortCreatedAtAsc} {
+			cursor := sort.CursorAfter(msg)
+
+			parsed, err := ParseSearchCursor(cursor.String())
+
+			assert.NoError(t, err)
+			assert.Equal(t, cursor, parsed)
+			assert.Equal(t, sort.orDefault(), parsed.Sort)
+		}
+	})
+
+	t.Run("正常系：並び順のキーを位置とする", func(t *testing.T) {
+		assert.Equal(t, base, SortSentAtDesc.CursorAfter(msg).Value)
+		assert.Equal(t, base.Add(time.Hour), SortCreatedAtAsc.CursorAfter(msg).Value)
+	})
+
+	t.Run("正常系：並び順で位置より後のメッセージのみが続きになる", func(t *testing.T) {
+		sameTime := &Message{ID: id2, SentAt: base}
+		later := &Message{ID: id1, SentAt: base.Add(time.Second)}
+
+		assert.False(t, SortSentAtAsc.CursorAfter(msg).Precedes(msg))
+		assert.True(t, SortSentAtAsc.CursorAfter(msg).Precedes(sameTime))
+		assert.True(t, SortSentAtAsc.CursorAfter(msg).Precedes(later))
+		assert.False(t, SortSentAtDesc.CursorAfter(msg).Precedes(sameTime))
+		assert.False(t, SortSentAtDesc.CursorAfter(msg).Precedes(later))
+	})
+
+	t.Run("異常系：不正な文字列", func(t *testing.T) {
+		for _, s := range []string{
+			"not base64!",
+			base64.RawURLEncoding.EncodeToString([]byte("sent_at|2024-01-01T00:00:00Z")),
+			base64.RawURLEncoding.EncodeToString([]byte("-created_at|2024-01-01T00:00:00Z|000000000000000000000001")),
+			base64.RawURLEncoding.EncodeToString([]byte("sent_at|yesterday|000000000000000000000001")),
+			base64.RawURLEncoding.EncodeToString([]byte("sent_at|2024-01-01T00:00:00Z|xyz")),
+		} {
+			_, err := ParseSearchCursor(s)
+			assert.ErrorIs(t, err, ErrInvalidCursor, s)
+		}
+	})
+}
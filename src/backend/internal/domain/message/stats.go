@@ -0,0 +1,146 @@
+package message
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// GroupBy は統計の集計単位
+type GroupBy string
+
+const (
+	GroupByChannel GroupBy = "channel"
+	GroupBySender  GroupBy = "sender"
+	GroupByHour    GroupBy = "hour"
+	GroupByDay     GroupBy = "day"
+)
+
+// ErrInvalidGroupBy は集計単位が不正な場合のエラー
+var ErrInvalidGroupBy = errors.New("group_by must be one of channel, sender, hour or day")
+
+// IsTime は時間単位の集計かどうかを判定する
+func (g GroupBy) IsTime() bool {
+	return g == GroupByHour || g == GroupByDay
+}
+
+// StatsQuery は統計の集計条件
+type StatsQuery struct {
+	Criteria SearchCriteria
+	GroupBy  GroupBy
+	// Location は時・日の境界を決めるタイムゾーン。nilの場合はUTC
+	Location *time.Location
+}
+
+// Validate は集計条件を検証する
+func (q StatsQuery) Validate() error {
+	if err := q.Criteria.Validate(); err != nil {
+		return err
+	}
+	switch q.GroupBy {
+	case GroupByChannel, GroupBySender, GroupByHour, GroupByDay:
+		return nil
+	}
+	return ErrInvalidGroupBy
+}
+
+// TimeZone は集計に使うタイムゾーンを返す
+func (q StatsQuery) TimeZone() *time.Location {
+	if q.Location == nil {
+		return time.UTC
+	}
+	return q.Location
+}
+
+// Truncate は日時を集計期間の開始時刻に切り捨てる
+// 夏時間の切り替えがあっても期間の境界がタイムゾーン上の正時・0時になるよう、暦の上で切り捨てる
+func (q StatsQuery) Truncate(t time.Time) time.Time {
+	local := t.In(q.TimeZone())
+	switch q.GroupBy {
+	case GroupByHour:
+		return time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), 0, 0, 0, local.Location())
+	case GroupByDay:
+		return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	}
+	return local
+}
+
+// PeriodKey は集計期間の開始時刻をキーの文字列に変換する
+func (q StatsQuery) PeriodKey(start time.Time) string {
+	return start.In(q.TimeZone()).Format(time.RFC3339)
+}
+
+// StatsBucket は集計単位ごとの件数
+type StatsBucket struct {
+	// Key はチャンネルID、送信者、または集計期間の開始時刻(RFC3339)
+	Key   string
+	Count int64
+	// Senders はメッセージを送信した送信者の数
+	Senders int64
+}
+
+// StatsAccumulator はメッセージを1件ずつ受け取って集計する
+// 集計をデータベースで行えないストレージの実装で使う
+type StatsAccumulator struct {
+	query   StatsQuery
+	buckets map[string]*accumulatedBucket
+}
+
+type accumulatedBucket struct {
+	start   time.Time
+	count   int64
+	senders map[string]struct{}
+}
+
+func NewStatsAccumulator(query StatsQuery) *StatsAccumulator {
+	return &StatsAccumulator{query: query, buckets: make(map[string]*accumulatedBucket)}
+}
+
+// Add はメッセージを集計に加える
+func (a *StatsAccumulator) Add(msg *Message) {
+	var key string
+	var start time.Time
+	switch a.query.GroupBy {
+	case GroupByChannel:
+		key = msg.ChannelID
+	case GroupBySender:
+		key = msg.Sender
+	default:
+		start = a.query.Truncate(msg.SentAt)
+		key = a.query.PeriodKey(start)
+	}
+
+	bucket, ok := a.buckets[key]
+	if !ok {
+		bucket = &accumulatedBucket{start: start, senders: make(map[string]struct{})}
+		a.buckets[key] = bucket
+	}
+	bucket.count++
+	bucket.senders[msg.Sender] = struct{}{}
+}
+
+// Buckets は集計結果を返す
+// 時間単位の集計は期間の昇順、それ以外は件数の降順（同数の場合はキーの昇順）に並べる
+func (a *StatsAccumulator) Buckets() []StatsBucket {
+	keys := make([]string, 0, len(a.buckets))
+	for key := range a.buckets {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		bi, bj := a.buckets[keys[i]], a.buckets[keys[j]]
+		if a.query.GroupBy.IsTime() {
+			return bi.start.Before(bj.start)
+		}
+		if bi.count != bj.count {
+			return bi.count > bj.count
+		}
+		return keys[i] < keys[j]
+	})
+
+	result := make([]StatsBucket, len(keys))
+	for i, key := range keys {
+		bucket := a.buckets[key]
+		result[i] = StatsBucket{Key: key, Count: bucket.count, Senders: int64(len(bucket.senders))}
+	}
+	return result
+}
@@ -0,0 +1,131 @@
+package message
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsQuery_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		groupBy  GroupBy
+		expected error
+	}{
+		{name: "チャンネル", groupBy: GroupByChannel},
+		{name: "送信者", groupBy: GroupBySender},
+		{name: "時", groupBy: GroupByHour},
+		{name: "日", groupBy: GroupByDay},
+		{name: "未指定", groupBy: "", expected: ErrInvalidGroupBy},
+		{name: "不正な値", groupBy: "week", expected: ErrInvalidGroupBy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, StatsQuery{GroupBy: tt.groupBy}.Validate())
+		})
+	}
+}
+
+func TestStatsQuery_Truncate(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	tests := []struct {
+		name     string
+		query    StatsQuery
+		input    time.Time
+		expected string
+	}{
+		{
+			name:     "時単位（UTC）",
+			query:    StatsQuery{GroupBy: GroupByHour},
+			input:    time.Date(2024, 1, 1, 10, 30, 15, 0, time.UTC),
+			expected: "2024-01-01T10:00:00Z",
+		},
+		{
+			name:     "日単位はタイムゾーンの0時で区切る",
+			query:    StatsQuery{GroupBy: GroupByDay, Location: tokyo},
+			input:    time.Date(2024, 1, 1, 15, 30, 0, 0, time.UTC),
+			expected: "2024-01-02T00:00:00+09:00",
+		},
+		{
+			name:     "夏時間の開始日",
+			query:    StatsQuery{GroupBy: GroupByDay, Location: newYork},
+			input:    time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC),
+			expected: "2024-03-10T00:00:00-05:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.query.PeriodKey(tt.query.Truncate(tt.input)))
+		})
+	}
+}
+
+func TestStatsAccumulator(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	base := time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC)
+	messages := []*Message{
+		{ChannelID: "general", Sender: "alice", SentAt: base},
+		{ChannelID: "general", Sender: "bob", SentAt: base.Add(30 * time.Minute)},
+		{ChannelID: "general", Sender: "alice", SentAt: base.Add(2 * time.Hour)},
+		{ChannelID: "random", Sender: "carol", SentAt: base.Add(3 * time.Hour)},
+		{ChannelID: "dev", Sender: "carol", SentAt: base.Add(4 * time.Hour)},
+	}
+
+	tests := []struct {
+		name     string
+		query    StatsQuery
+		expected []StatsBucket
+	}{
+		{
+			name:  "チャンネル単位は件数の降順、同数はキーの昇順",
+			query: StatsQuery{GroupBy: GroupByChannel},
+			expected: []StatsBucket{
+				{Key: "general", Count: 3, Senders: 2},
+				{Key: "dev", Count: 1, Senders: 1},
+				{Key: "random", Count: 1, Senders: 1},
+			},
+		},
+		{
+			name:  "送信者単位",
+			query: StatsQuery{GroupBy: GroupBySender},
+			expected: []StatsBucket{
+				{Key: "alice", Count: 2, Senders: 1},
+				{Key: "carol", Count: 2, Senders: 1},
+				{Key: "bob", Count: 1, Senders: 1},
+			},
+		},
+		{
+			name:  "時単位は期間の昇順",
+			query: StatsQuery{GroupBy: GroupByHour},
+			expected: []StatsBucket{
+				{Key: "2024-01-01T14:00:00Z", Count: 2, Senders: 2},
+				{Key: "2024-01-01T16:00:00Z", Count: 1, Senders: 1},
+				{Key: "2024-01-01T17:00:00Z", Count: 1, Senders: 1},
+				{Key: "2024-01-01T18:00:00Z", Count: 1, Senders: 1},
+			},
+		},
+		{
+			name:  "日単位はタイムゾーンで区切る",
+			query: StatsQuery{GroupBy: GroupByDay, Location: tokyo},
+			expected: []StatsBucket{
+				{Key: "2024-01-01T00:00:00+09:00", Count: 2, Senders: 2},
+				{Key: "2024-01-02T00:00:00+09:00", Count: 3, Senders: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acc := NewStatsAccumulator(tt.query)
+			for _, msg := range messages {
+				acc.Add(msg)
+			}
+			assert.Equal(t, tt.expected, acc.Buckets())
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoTenant はテナントが設定されていないコンテキストでデータを読み書きしようとした場合のエラー
+// 認証を経ていない操作が既定のテナントのデータに触れないよう、既定のテナントとはみなさない
+var ErrNoTenant = errors.New("tenant is not set in context")
+
+type contextKey struct{}
+
+// WithID は操作の対象とするテナントをコンテキストに設定する
+// リポジトリはこのテナントのデータのみを読み書きする
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext はコンテキストに設定されたテナントIDを返す
+// 設定されていない場合はErrNoTenantを返す
+func FromContext(ctx context.Context) (string, error) {
+	if id, ok := ctx.Value(contextKey{}).(string); ok && id != "" {
+		return id, nil
+	}
+	return "", ErrNoTenant
+}
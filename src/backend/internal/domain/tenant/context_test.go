@@ -0,0 +1,30 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		expected      string
+		expectedError error
+	}{
+		{name: "正常系：設定したテナント", ctx: WithID(context.Background(), "team-a"), expected: "team-a"},
+		{name: "異常系：設定されていない場合は既定のテナントとみなさない", ctx: context.Background(), expectedError: ErrNoTenant},
+		{name: "異常系：空のID", ctx: WithID(context.Background(), ""), expectedError: ErrNoTenant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := FromContext(tt.ctx)
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package tenant
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"time"
+)
+
+// DefaultID は既定のテナントのID
+// テナントを導入する前に作成されたデータはこのテナントに属する
+const DefaultID = "default"
+
+var (
+	// ErrInvalidID はテナントIDの形式が不正な場合のエラー
+	ErrInvalidID = errors.New("tenant id must be 1-63 lowercase letters, digits or hyphens and start with a letter or digit")
+	// ErrNameRequired はテナント名が空の場合のエラー
+	ErrNameRequired = errors.New("tenant name is required")
+	// ErrInvalidQuota は上限に負の値が指定された場合のエラー
+	ErrInvalidQuota = errors.New("quota must not be negative")
+	// ErrDuplicateTenant は同じIDのテナントが既に存在する場合のエラー
+	ErrDuplicateTenant = errors.New("duplicate tenant")
+	// ErrQuotaExceeded はテナントの上限を超えて作成しようとした場合のエラー
+	ErrQuotaExceeded = errors.New("tenant quota exceeded")
+)
+
+// Resource は上限を設ける対象の種類
+type Resource string
+
+const (
+	ResourceMessages Resource = "messages"
+	ResourceTokens   Resource = "tokens"
+)
+
+// ReservationTimeout は作成中の予約を有効とみなす期間
+// 作成中にプロセスが停止して解放されなかった予約は、最後の予約からこの期間を過ぎると数えない
+const ReservationTimeout = time.Minute
+
+// idPattern はテナントIDの形式。URLのパスやインデックスのキーにそのまま使えるものに限る
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// Tenant はトークンとデータを共有するチームの単位
+// メッセージとトークンは必ず1つのテナントに属し、他のテナントからは参照できない
+type Tenant struct {
+	ID        string    `bson:"_id"`
+	Name      string    `bson:"name"`
+	Quota     Quota     `bson:"quota"`
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// Quota はテナントが保持できるデータの上限。0は無制限を表す
+type Quota struct {
+	// MaxMessages は削除されていない有効なメッセージの上限
+	MaxMessages int64 `bson:"max_messages"`
+	// MaxTokens は削除されていない有効なトークンの上限
+	MaxTokens int64 `bson:"max_tokens"`
+}
+
+// ValidateID はテナントIDの形式を検証する
+func ValidateID(id string) error {
+	if !idPattern.MatchString(id) {
+		return ErrInvalidID
+	}
+	return nil
+}
+
+// Validate は作成時と更新時に必須となる項目を検証する
+func (t *Tenant) Validate() error {
+	if err := ValidateID(t.ID); err != nil {
+		return err
+	}
+	if t.Name == "" {
+		return ErrNameRequired
+	}
+	return t.Quota.Validate()
+}
+
+// Validate は上限が負の値でないことを検証する
+func (q Quota) Validate() error {
+	if q.MaxMessages < 0 || q.MaxTokens < 0 {
+		return ErrInvalidQuota
+	}
+	return nil
+}
+
+// Limit は対象の種類の上限を返す
+func (q Quota) Limit(resource Resource) int64 {
+	switch resource {
+	case ResourceMessages:
+		return q.MaxMessages
+	case ResourceTokens:
+		return q.MaxTokens
+	}
+	return 0
+}
+
+// RemainingMessages は使用中の件数から、あと何件のメッセージを作成できるかを返す
+func (q Quota) RemainingMessages(used int64) int64 {
+	return remaining(q.MaxMessages, used)
+}
+
+// RemainingTokens は使用中の件数から、あと何件のトークンを作成できるかを返す
+func (q Quota) RemainingTokens(used int64) int64 {
+	return remaining(q.MaxTokens, used)
+}
+
+func remaining(limit, used int64) int64 {
+	if limit == 0 {
+		return math.MaxInt64
+	}
+	return max(limit-used, 0)
+}
+
+// IsValidationError はリクエストの内容が不正であることを示すエラーかどうかを判定する
+func IsValidationError(err error) bool {
+	return errors.Is(err, ErrInvalidID) || errors.Is(err, ErrNameRequired) || errors.Is(err, ErrInvalidQuota)
+}
@@ -0,0 +1,98 @@
+package tenant
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenant_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		tenant        Tenant
+		expectedError error
+	}{
+		{
+			name:   "正常系：上限を指定しない",
+			tenant: Tenant{ID: "team-a", Name: "Team A"},
+		},
+		{
+			name:   "正常系：63文字のID",
+			tenant: Tenant{ID: strings.Repeat("a", 63), Name: "Long", Quota: Quota{MaxMessages: 10, MaxTokens: 1}},
+		},
+		{
+			name:          "異常系：IDが空",
+			tenant:        Tenant{Name: "Team A"},
+			expectedError: ErrInvalidID,
+		},
+		{
+			name:          "異常系：IDが64文字",
+			tenant:        Tenant{ID: strings.Repeat("a", 64), Name: "Long"},
+			expectedError: ErrInvalidID,
+		},
+		{
+			name:          "異常系：IDに大文字を含む",
+			tenant:        Tenant{ID: "Team-A", Name: "Team A"},
+			expectedError: ErrInvalidID,
+		},
+		{
+			name:          "異常系：IDがハイフンで始まる",
+			tenant:        Tenant{ID: "-team", Name: "Team A"},
+			expectedError: ErrInvalidID,
+		},
+		{
+			name:          "異常系：名前が空",
+			tenant:        Tenant{ID: "team-a"},
+			expectedError: ErrNameRequired,
+		},
+		{
+			name:          "異常系：上限が負の値",
+			tenant:        Tenant{ID: "team-a", Name: "Team A", Quota: Quota{MaxTokens: -1}},
+			expectedError: ErrInvalidQuota,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tenant.Validate()
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.True(t, IsValidationError(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestQuota_Remaining(t *testing.T) {
+	tests := []struct {
+		name     string
+		quota    Quota
+		used     int64
+		expected int64
+	}{
+		{name: "正常系：0は無制限", quota: Quota{}, used: 1000, expected: math.MaxInt64},
+		{name: "正常系：上限までの残り", quota: Quota{MaxMessages: 10, MaxTokens: 10}, used: 3, expected: 7},
+		{name: "正常系：上限に達している", quota: Quota{MaxMessages: 10, MaxTokens: 10}, used: 10, expected: 0},
+		{name: "正常系：上限を下げて超過している場合も0", quota: Quota{MaxMessages: 5, MaxTokens: 5}, used: 8, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.quota.RemainingMessages(tt.used))
+			assert.Equal(t, tt.expected, tt.quota.RemainingTokens(tt.used))
+		})
+	}
+}
+
+func TestQuota_Limit(t *testing.T) {
+	quota := Quota{MaxMessages: 100, MaxTokens: 2}
+
+	assert.Equal(t, int64(100), quota.Limit(ResourceMessages))
+	assert.Equal(t, int64(2), quota.Limit(ResourceTokens))
+	assert.Zero(t, quota.Limit(Resource("unknown")))
+}
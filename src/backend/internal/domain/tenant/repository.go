@@ -0,0 +1,20 @@
+package tenant
+
+import "context"
+
+type Repository interface {
+	// Create はテナントを作成する。同じIDのテナントが存在する場合はErrDuplicateTenantを返す
+	Create(ctx context.Context, tenant *Tenant) error
+	// Update はテナントの名前と上限を更新する。存在しない場合はmongo.ErrNoDocumentsを返す
+	Update(ctx context.Context, tenant *Tenant) error
+	// Get はテナントを返す。存在しない場合はnilを返す
+	Get(ctx context.Context, id string) (*Tenant, error)
+	// List は全てのテナントをIDの昇順で返す
+	List(ctx context.Context) ([]Tenant, error)
+	// Reserve はテナントで作成中の件数をn件増やし、他の作成中の分も含めた件数を返す
+	// 上限の確認と作成の間に他の作成が割り込んでも上限を超えないよう、作成の前に予約する
+	// 最後の予約からReservationTimeoutを過ぎた予約は解放されなかったものとして数えない
+	Reserve(ctx context.Context, id string, resource Resource, n int64) (int64, error)
+	// Release はReserveで予約した件数を戻す
+	Release(ctx context.Context, id string, resource Resource, n int64) error
+}
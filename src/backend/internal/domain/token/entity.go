@@ -1,17 +1,27 @@
 package token
 
 import (
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// ErrDuplicateToken は同じトークン文字列の有効なトークンが既に存在する場合のエラー
+var ErrDuplicateToken = errors.New("duplicate token")
+
 type Token struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty"`
-	Token     string             `bson:"token"`
-	Name      string             `bson:"name"`
-	ExpiresAt time.Time          `bson:"expires_at"`
-	CreatedAt time.Time          `bson:"created_at"`
-	UpdatedAt time.Time          `bson:"updated_at"`
-	DeletedAt *time.Time         `bson:"deleted_at,omitempty"`
+	ID       primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID string             `bson:"tenant_id"`
+	Token    string             `bson:"token"`
+	Name     string             `bson:"name"`
+	// Admin のトークンは所属するテナントの使用量の確認や、そのテナントへの管理用トークンの発行ができる
+	Admin bool `bson:"admin,omitempty"`
+	// SuperAdmin のトークンはテナントの作成や上限の変更など、全てのテナントを管理できる
+	// 運用者向けのため、管理コマンドからのみ発行できる
+	SuperAdmin bool       `bson:"super_admin,omitempty"`
+	ExpiresAt  time.Time  `bson:"expires_at"`
+	CreatedAt  time.Time  `bson:"created_at"`
+	UpdatedAt  time.Time  `bson:"updated_at"`
+	DeletedAt  *time.Time `bson:"deleted_at,omitempty"`
 }
@@ -9,6 +9,8 @@ type Repository interface {
 	Create(ctx context.Context, token *Token) error
 	Delete(ctx context.Context, id string) error // ObjectID.Hex()の文字列を受け取る
 	List(ctx context.Context) ([]Token, error)
+	// CountUpTo はListが返すトークンをlimit件まで数える。limit件以上ある場合はlimitを返す
+	CountUpTo(ctx context.Context, limit int64) (int64, error)
 	FindByID(ctx context.Context, id string) (*Token, error)
 	FindByToken(ctx context.Context, tokenString string) (*Token, error)
 }
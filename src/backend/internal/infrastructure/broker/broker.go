@@ -0,0 +1,106 @@
+package broker
+
+import (
+	"context"
+	"message-service/internal/domain/message"
+	"sync"
+)
+
+// subscriberBufferSize は購読者ごとのイベントバッファ数
+const subscriberBufferSize = 64
+
+// Filter は購読者が受け取るイベントを選別する関数
+type Filter = message.EventFilter
+
+// ForTenant はテナントのメッセージのイベントのみを受け取るフィルターを返す
+// イベントは全てのテナントのものが配信されるため、購読者はテナントで絞り込むこと
+func ForTenant(tenantID string) Filter {
+	return func(event message.Event) bool {
+		return event.Message.TenantID == tenantID
+	}
+}
+
+type subscription struct {
+	events chan message.Event
+	filter Filter
+}
+
+// Broker はプロセス内の購読者にドメインイベントを配信する
+// 受信が追いつかない購読者へのイベントは破棄し、配信元をブロックしない
+type Broker struct {
+	mu          sync.RWMutex
+	nextID      int
+	subscribers map[int]*subscription
+	closed      bool
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers: make(map[int]*subscription),
+	}
+}
+
+// Publish はmessage.EventPublisherの実装
+func (b *Broker) Publish(ctx context.Context, event message.Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subscribers {
+		if sub.filter != nil && !sub.filter(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe はイベントを受け取るチャネルと購読解除関数を返す
+// チャネルは購読を解除するかBrokerを閉じると閉じられる。閉じた後の購読は閉じたチャネルを返す
+func (b *Broker) Subscribe(filter Filter) (<-chan message.Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	events := make(chan message.Event, subscriberBufferSize)
+	if b.closed {
+		close(events)
+		return events, func() {}
+	}
+
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = &subscription{events: events, filter: filter}
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		// Closeで既に閉じられている場合や二重に呼ばれた場合は何もしない
+		if sub, ok := b.subscribers[id]; ok {
+			delete(b.subscribers, id)
+			close(sub.events)
+		}
+	}
+	return events, unsubscribe
+}
+
+// Close は全ての購読のチャネルを閉じ、以降の配信を破棄する
+// サーバーの停止時に、イベントを待っているストリームを終わらせるために使う
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for id, sub := range b.subscribers {
+		delete(b.subscribers, id)
+		close(sub.events)
+	}
+}
+
+// SubscriberCount は現在の購読者数を返す
+func (b *Broker) SubscriberCount() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers)
+}
@@ -0,0 +1,124 @@
+package broker
+
+import (
+	"context"
+	"message-service/internal/domain/message"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestEvent(channelID string) message.Event {
+	return message.Event{
+		Type: message.EventCreated,
+		Message: message.Message{
+			UID:       "test-uid",
+			ChannelID: channelID,
+		},
+	}
+}
+
+func TestBroker_PublishSubscribe(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    Filter
+		channelID string
+		delivered bool
+	}{
+		{
+			name:      "正常系：フィルタなしで受信",
+			filter:    nil,
+			channelID: "channel-1",
+			delivered: true,
+		},
+		{
+			name: "正常系：フィルタに一致するイベントを受信",
+			filter: func(event message.Event) bool {
+				return event.Message.ChannelID == "channel-1"
+			},
+			channelID: "channel-1",
+			delivered: true,
+		},
+		{
+			name:      "正常系：他のテナントのイベントは受信しない",
+			filter:    ForTenant("team-a"),
+			channelID: "channel-1",
+			delivered: false,
+		},
+		{
+			name: "正常系：フィルタに一致しないイベントは受信しない",
+			filter: func(event message.Event) bool {
+				return event.Message.ChannelID == "channel-1"
+			},
+			channelID: "channel-2",
+			delivered: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBroker()
+			events, unsubscribe := b.Subscribe(tt.filter)
+			defer unsubscribe()
+
+			err := b.Publish(context.Background(), createTestEvent(tt.channelID))
+			assert.NoError(t, err)
+
+			select {
+			case event := <-events:
+				assert.True(t, tt.delivered)
+				assert.Equal(t, tt.channelID, event.Message.ChannelID)
+			default:
+				assert.False(t, tt.delivered)
+			}
+		})
+	}
+}
+
+func TestBroker_Unsubscribe(t *testing.T) {
+	b := NewBroker()
+	events, unsubscribe := b.Subscribe(nil)
+	assert.Equal(t, 1, b.SubscriberCount())
+
+	unsubscribe()
+	// 二重呼び出しでもパニックしない
+	assert.NotPanics(t, unsubscribe)
+
+	assert.Equal(t, 0, b.SubscriberCount())
+	_, ok := <-events
+	assert.False(t, ok, "channel should be closed")
+}
+
+func TestBroker_SlowSubscriber(t *testing.T) {
+	b := NewBroker()
+	events, unsubscribe := b.Subscribe(nil)
+	defer unsubscribe()
+
+	// バッファを超えて配信してもブロックしない
+	for i := 0; i < subscriberBufferSize+10; i++ {
+		assert.NoError(t, b.Publish(context.Background(), createTestEvent("channel-1")))
+	}
+
+	assert.Len(t, events, subscriberBufferSize)
+}
+
+func TestBroker_Close(t *testing.T) {
+	b := NewBroker()
+	events, unsubscribe := b.Subscribe(nil)
+
+	b.Close()
+
+	_, ok := <-events
+	assert.False(t, ok, "channel should be closed")
+	assert.Equal(t, 0, b.SubscriberCount())
+	// 閉じた後の購読解除と配信はパニックしない
+	assert.NotPanics(t, unsubscribe)
+	assert.NoError(t, b.Publish(context.Background(), createTestEvent("channel-1")))
+
+	// 閉じた後の購読は閉じたチャネルを返す
+	events, unsubscribe = b.Subscribe(nil)
+	defer unsubscribe()
+	_, ok = <-events
+	assert.False(t, ok, "channel should be closed")
+	assert.Equal(t, 0, b.SubscriberCount())
+}
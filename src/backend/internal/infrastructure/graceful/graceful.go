@@ -0,0 +1,154 @@
+// Package graceful はサーバーを起動し、停止シグナルを受けた時に処理中のリクエストを待ってから停止する
+package graceful
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Server は段階的に停止できるサーバー
+type Server interface {
+	// Serve は停止されるまでリクエストを受け付ける
+	Serve() error
+	// Shutdown は新しいリクエストの受け付けを止め、処理中のリクエストの完了を待つ
+	Shutdown(ctx context.Context) error
+	// Close は処理中のリクエストを待たずに停止する
+	Close() error
+}
+
+// Run は全てのサーバーを起動し、ctxがキャンセルされるか、いずれかのサーバーが異常終了するまで待つ
+// ctxがキャンセルされた場合は、ロードバランサーが振り分けを止めるまでdelayの間リクエストの受け付けを続ける
+// その後、全てのサーバーを並行してShutdownし、timeoutまでに完了しないものはCloseで強制的に停止する
+// サーバーの異常終了とShutdownのエラーのうち、最初のものを返す
+func Run(ctx context.Context, delay, timeout time.Duration, servers ...Server) error {
+	serveErrs := make(chan error, len(servers))
+	for _, s := range servers {
+		s := s
+		go func() { serveErrs <- s.Serve() }()
+	}
+
+	var serveErr error
+	select {
+	case <-ctx.Done():
+		if delay > 0 {
+			slog.Info("Draining before shutdown", slog.String("delay", delay.String()))
+			select {
+			case <-time.After(delay):
+			case serveErr = <-serveErrs:
+				slog.Error("Server stopped unexpectedly while draining", slog.String("error", serveErr.Error()))
+			}
+		}
+		slog.Info("Shutting down servers", slog.String("timeout", timeout.String()))
+	case serveErr = <-serveErrs:
+		slog.Error("Server stopped unexpectedly, shutting down others", slog.String("error", serveErr.Error()))
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	errs := make([]error, len(servers))
+	var wg sync.WaitGroup
+	for i, s := range servers {
+		wg.Add(1)
+		go func(i int, s Server) {
+			defer wg.Done()
+			if err := s.Shutdown(shutdownCtx); err != nil {
+				if errors.Is(err, context.DeadlineExceeded) {
+					slog.Warn("Shutdown timed out, closing remaining connections")
+				}
+				errs[i] = errors.Join(err, s.Close())
+			}
+		}(i, s)
+	}
+	wg.Wait()
+
+	return errors.Join(append([]error{serveErr}, errs...)...)
+}
+
+// stoppingKey は停止の開始時に閉じられるチャネルをコンテキストに格納するキー
+type stoppingKey struct{}
+
+// WithStopping は停止の開始時に閉じられるチャネルをコンテキストに設定する
+// http.ServerのBaseContextで設定すると、リクエストのコンテキストから参照できる
+func WithStopping(ctx context.Context, stopping <-chan struct{}) context.Context {
+	return context.WithValue(ctx, stoppingKey{}, stopping)
+}
+
+// Stopping は停止の開始時に閉じられるチャネルを返す
+// 長く続くリクエストは、これを見て区切りの良いところで処理を終える
+// 設定されていない場合は閉じられることのないnilを返す
+func Stopping(ctx context.Context) <-chan struct{} {
+	stopping, _ := ctx.Value(stoppingKey{}).(<-chan struct{})
+	return stopping
+}
+
+// HTTPServer はhttp.ServerをServerとして扱う
+type HTTPServer struct {
+	server   *http.Server
+	listener net.Listener
+}
+
+func NewHTTPServer(server *http.Server, listener net.Listener) *HTTPServer {
+	return &HTTPServer{server: server, listener: listener}
+}
+
+func (s *HTTPServer) Serve() error {
+	if err := s.server.Serve(s.listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *HTTPServer) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+func (s *HTTPServer) Close() error {
+	return s.server.Close()
+}
+
+// GRPCServer はgrpc.ServerをServerとして扱う
+type GRPCServer struct {
+	server   *grpc.Server
+	listener net.Listener
+}
+
+func NewGRPCServer(server *grpc.Server, listener net.Listener) *GRPCServer {
+	return &GRPCServer{server: server, listener: listener}
+}
+
+func (s *GRPCServer) Serve() error {
+	if err := s.server.Serve(s.listener); !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown は処理中のRPC（ストリームを含む）の完了を待つ
+// GracefulStopはタイムアウトを受け取らないため、ctxの期限を過ぎた場合はエラーを返す
+func (s *GRPCServer) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *GRPCServer) Close() error {
+	s.server.Stop()
+	return nil
+}
@@ -0,0 +1,277 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// テスト用のServer
+type mockServer struct {
+	mu          sync.Mutex
+	serveErr    error
+	shutdownErr error
+	stopped     chan struct{}
+	shutdowns   int
+	closes      int
+}
+
+func newMockServer(serveErr, shutdownErr error) *mockServer {
+	return &mockServer{serveErr: serveErr, shutdownErr: shutdownErr, stopped: make(chan struct{})}
+}
+
+func (m *mockServer) Serve() error {
+	if m.serveErr != nil {
+		return m.serveErr
+	}
+	<-m.stopped
+	return nil
+}
+
+func (m *mockServer) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.shutdowns++
+	if m.shutdownErr == nil && m.serveErr == nil {
+		close(m.stopped)
+	}
+	return m.shutdownErr
+}
+
+func (m *mockServer) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closes++
+	return nil
+}
+
+func TestRun(t *testing.T) {
+	serveErr := errors.New("listen error")
+
+	tests := []struct {
+		name              string
+		servers           []*mockServer
+		cancel            bool
+		expectedErr       error
+		expectedCloses    []int
+		expectedShutdowns []int
+	}{
+		{
+			name:              "正常系：キャンセルで全てのサーバーを停止する",
+			servers:           []*mockServer{newMockServer(nil, nil), newMockServer(nil, nil)},
+			cancel:            true,
+			expectedCloses:    []int{0, 0},
+			expectedShutdowns: []int{1, 1},
+		},
+		{
+			name:              "異常系：異常終了したサーバーがあれば他のサーバーも停止する",
+			servers:           []*mockServer{newMockServer(serveErr, nil), newMockServer(nil, nil)},
+			expectedErr:       serveErr,
+			expectedCloses:    []int{0, 0},
+			expectedShutdowns: []int{1, 1},
+		},
+		{
+			name:              "異常系：Shutdownに失敗したサーバーは強制的に停止する",
+			servers:           []*mockServer{newMockServer(nil, context.DeadlineExceeded), newMockServer(nil, nil)},
+			cancel:            true,
+			expectedErr:       context.DeadlineExceeded,
+			expectedCloses:    []int{1, 0},
+			expectedShutdowns: []int{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+
+			servers := make([]Server, len(tt.servers))
+			for i, s := range tt.servers {
+				servers[i] = s
+			}
+			err := Run(ctx, 0, time.Second, servers...)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			for i, s := range tt.servers {
+				assert.Equal(t, tt.expectedShutdowns[i], s.shutdowns)
+				assert.Equal(t, tt.expectedCloses[i], s.closes)
+			}
+		})
+	}
+}
+
+// startBlockingHTTPServer はreleaseが閉じられるかリクエストがキャンセルされるまで応答しないHTTPサーバーを用意する
+func startBlockingHTTPServer(t *testing.T, release <-chan struct{}) (*HTTPServer, string, <-chan struct{}) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		select {
+		case <-release:
+			io.WriteString(w, "done")
+		case <-r.Context().Done():
+		}
+	})
+	return NewHTTPServer(&http.Server{Handler: handler}, listener), "http://" + listener.Addr().String(), started
+}
+
+func TestRun_HTTPServer(t *testing.T) {
+	t.Run("正常系：処理中のリクエストの完了を待って停止する", func(t *testing.T) {
+		release := make(chan struct{})
+		server, url, started := startBlockingHTTPServer(t, release)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		runErr := make(chan error, 1)
+		go func() { runErr <- Run(ctx, 0, 5*time.Second, server) }()
+
+		type result struct {
+			body string
+			err  error
+		}
+		results := make(chan result, 1)
+		go func() {
+			resp, err := http.Get(url)
+			if err != nil {
+				results <- result{err: err}
+				return
+			}
+			defer resp.Body.Close()
+			b, err := io.ReadAll(resp.Body)
+			results <- result{body: string(b), err: err}
+		}()
+
+		<-started
+		cancel()
+
+		// 停止処理の開始後は新しい接続を受け付けない
+		assert.Eventually(t, func() bool {
+			_, err := http.Get(url)
+			return err != nil
+		}, time.Second, 10*time.Millisecond)
+
+		close(release)
+		res := <-results
+		assert.NoError(t, res.err)
+		assert.Equal(t, "done", res.body)
+		assert.NoError(t, <-runErr)
+	})
+
+	t.Run("異常系：タイムアウトまでに完了しないリクエストは打ち切る", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		server, url, started := startBlockingHTTPServer(t, release)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		runErr := make(chan error, 1)
+		go func() { runErr <- Run(ctx, 0, 100*time.Millisecond, server) }()
+
+		reqErr := make(chan error, 1)
+		go func() {
+			resp, err := http.Get(url)
+			if err == nil {
+				_, err = io.ReadAll(resp.Body)
+				resp.Body.Close()
+			}
+			reqErr <- err
+		}()
+
+		<-started
+		cancel()
+
+		assert.ErrorIs(t, <-runErr, context.DeadlineExceeded)
+		assert.Error(t, <-reqErr)
+	})
+}
+
+func TestRun_GRPCServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewGRPCServer(grpc.NewServer(), listener)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- Run(ctx, 0, time.Second, server) }()
+	cancel()
+
+	assert.NoError(t, <-runErr)
+	_, err = net.Dial("tcp", listener.Addr().String())
+	assert.Error(t, err)
+}
+
+func TestRun_Delay(t *testing.T) {
+	t.Run("正常系：停止の開始後もdelayの間は受け付けを続けてから停止する", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		server := NewHTTPServer(&http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})}, listener)
+		url := "http://" + listener.Addr().String()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		runErr := make(chan error, 1)
+		go func() { runErr <- Run(ctx, 200*time.Millisecond, time.Second, server) }()
+		// サーバーが受け付けを始めるまで待つ
+		assert.Eventually(t, func() bool {
+			resp, err := http.Get(url)
+			if err == nil {
+				resp.Body.Close()
+			}
+			return err == nil
+		}, time.Second, 10*time.Millisecond)
+		cancel()
+
+		resp, err := http.Get(url)
+		assert.NoError(t, err)
+		if err == nil {
+			resp.Body.Close()
+		}
+		assert.NoError(t, <-runErr)
+	})
+
+	t.Run("異常系：サーバーが異常終了した場合は待たずに停止する", func(t *testing.T) {
+		serveErr := errors.New("listen error")
+		servers := []Server{newMockServer(serveErr, nil), newMockServer(nil, nil)}
+
+		start := time.Now()
+		err := Run(context.Background(), time.Minute, time.Second, servers...)
+
+		assert.ErrorIs(t, err, serveErr)
+		assert.Less(t, time.Since(start), time.Minute)
+	})
+}
+
+func TestStopping(t *testing.T) {
+	assert.Nil(t, Stopping(context.Background()))
+
+	stopping := make(chan struct{})
+	ctx := WithStopping(context.Background(), stopping)
+	close(stopping)
+
+	select {
+	case <-Stopping(ctx):
+	default:
+		t.Fatal("stopping channel is not closed")
+	}
+}
@@ -0,0 +1,150 @@
+// Package health はプロセスの死活確認と、依存先を含めたリクエスト受け付け可否の確認を提供する
+package health
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// LivenessPath はプロセスが応答できるかを返すエンドポイント
+	LivenessPath = "/healthz"
+	// ReadinessPath は依存先を含めてリクエストを受け付けられるかを返すエンドポイント
+	ReadinessPath = "/readyz"
+
+	// DefaultCheckTimeout は1つのチェックに許容する時間
+	DefaultCheckTimeout = 2 * time.Second
+)
+
+// チェックの状態
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+// CheckFunc は依存先の状態を確認し、リクエストを受け付けられない場合はエラーを返す
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult は1つのチェックの結果
+// 所要時間とエラーの詳細は接続先などの内部の情報を含み得るため、レスポンスには含めずログにのみ出力する
+type CheckResult struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"-"`
+	Error     string  `json:"-"`
+}
+
+// Report はレディネスの確認結果
+type Report struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker は登録されたチェックを実行する
+type Checker struct {
+	timeout time.Duration
+	checks  []check
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Add はチェックを登録する。結果は登録順に並ぶ
+func (c *Checker) Add(name string, fn CheckFunc) {
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+// Check は全てのチェックを並行して実行する
+// 1つでも失敗するかタイムアウトした場合、全体の状態はunavailableになる
+func (c *Checker) Check(ctx context.Context) Report {
+	report := Report{Status: StatusOK, Checks: make([]CheckResult, len(c.checks))}
+
+	var wg sync.WaitGroup
+	for i, chk := range c.checks {
+		wg.Add(1)
+		go func(i int, chk check) {
+			defer wg.Done()
+			report.Checks[i] = c.run(ctx, chk)
+		}(i, chk)
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != StatusOK {
+			report.Status = StatusUnavailable
+		}
+	}
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, chk check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// チェックがctxを無視して応答しない場合もタイムアウトで打ち切る
+	errCh := make(chan error, 1)
+	start := time.Now()
+	go func() { errCh <- chk.fn(ctx) }()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{
+		Name:      chk.name,
+		Status:    StatusOK,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusUnavailable
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Register はルーターにヘルスチェックのエンドポイントを登録する
+// 認証を必要としないよう、認証ミドルウェアでLivenessPathとReadinessPathを除外すること
+func Register(router gin.IRouter, checker *Checker) {
+	liveness := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": StatusOK})
+	}
+	readiness := func(c *gin.Context) {
+		ctx := c.Request.Context()
+		report := checker.Check(ctx)
+		for _, result := range report.Checks {
+			if result.Status != StatusOK {
+				slog.WarnContext(ctx, "Readiness check failed",
+					"check", result.Name,
+					"error", result.Error,
+					"latency_ms", result.LatencyMS,
+				)
+			}
+		}
+		code := http.StatusOK
+		if report.Status != StatusOK {
+			code = http.StatusServiceUnavailable
+		}
+		// プローブの結果が中間のキャッシュに残らないようにする
+		c.Header("Cache-Control", "no-store")
+		c.JSON(code, report)
+	}
+
+	router.GET(LivenessPath, liveness)
+	router.HEAD(LivenessPath, liveness)
+	router.GET(ReadinessPath, readiness)
+	router.HEAD(ReadinessPath, readiness)
+}
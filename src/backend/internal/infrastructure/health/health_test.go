@@ -0,0 +1,150 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Check(t *testing.T) {
+	ok := func(ctx context.Context) error { return nil }
+	failing := func(ctx context.Context) error { return errors.New("connection refused") }
+	// ctxを無視して応答しないチェック
+	hanging := func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}
+
+	tests := []struct {
+		name             string
+		checks           map[string]CheckFunc
+		order            []string
+		expectedStatus   string
+		expectedStatuses []string
+		expectedErrors   []string
+	}{
+		{
+			name:           "正常系：チェックが無い場合は受け付け可能",
+			expectedStatus: StatusOK,
+		},
+		{
+			name:             "正常系：全てのチェックが成功",
+			checks:           map[string]CheckFunc{"mongodb": ok, "indexes": ok},
+			order:            []string{"mongodb", "indexes"},
+			expectedStatus:   StatusOK,
+			expectedStatuses: []string{StatusOK, StatusOK},
+			expectedErrors:   []string{"", ""},
+		},
+		{
+			name:             "異常系：失敗したチェックがある",
+			checks:           map[string]CheckFunc{"mongodb": failing, "indexes": ok},
+			order:            []string{"mongodb", "indexes"},
+			expectedStatus:   StatusUnavailable,
+			expectedStatuses: []string{StatusUnavailable, StatusOK},
+			expectedErrors:   []string{"connection refused", ""},
+		},
+		{
+			name:             "異常系：応答しないチェックはタイムアウトで打ち切る",
+			checks:           map[string]CheckFunc{"mongodb": hanging},
+			order:            []string{"mongodb"},
+			expectedStatus:   StatusUnavailable,
+			expectedStatuses: []string{StatusUnavailable},
+			expectedErrors:   []string{context.DeadlineExceeded.Error()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker(50 * time.Millisecond)
+			for _, name := range tt.order {
+				checker.Add(name, tt.checks[name])
+			}
+
+			start := time.Now()
+			report := checker.Check(context.Background())
+
+			assert.Less(t, time.Since(start), 500*time.Millisecond)
+			assert.Equal(t, tt.expectedStatus, report.Status)
+			assert.Len(t, report.Checks, len(tt.order))
+			for i, result := range report.Checks {
+				assert.Equal(t, tt.order[i], result.Name)
+				assert.Equal(t, tt.expectedStatuses[i], result.Status)
+				assert.Equal(t, tt.expectedErrors[i], result.Error)
+				assert.GreaterOrEqual(t, result.LatencyMS, 0.0)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		path           string
+		check          CheckFunc
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "正常系：ライブネスは依存先に関わらず成功する",
+			path:           LivenessPath,
+			check:          func(ctx context.Context) error { return errors.New("down") },
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"ok"}`,
+		},
+		{
+			name:           "正常系：レディネスの成功",
+			path:           ReadinessPath,
+			check:          func(ctx context.Context) error { return nil },
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系：レディネスの失敗は503を返す",
+			path:           ReadinessPath,
+			check:          func(ctx context.Context) error { return errors.New("down") },
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker(time.Second)
+			checker.Add("mongodb", tt.check)
+			router := gin.New()
+			Register(router, checker)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+				return
+			}
+
+			var report struct {
+				Status string                   `json:"status"`
+				Checks []map[string]interface{} `json:"checks"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+			assert.Len(t, report.Checks, 1)
+			// 依存先のエラーや所要時間は返さず、名前と状態のみを返す
+			expectedStatus := StatusOK
+			if tt.expectedStatus != http.StatusOK {
+				expectedStatus = StatusUnavailable
+			}
+			assert.Equal(t, expectedStatus, report.Status)
+			assert.Equal(t, map[string]interface{}{"name": "mongodb", "status": expectedStatus}, report.Checks[0])
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		})
+	}
+}
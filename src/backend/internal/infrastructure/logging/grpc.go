@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDMetadataKey はgRPCでリクエストIDを受け渡すメタデータのキー
+const requestIDMetadataKey = "x-request-id"
+
+// UnaryServerInterceptor はgRPCのunary呼び出しにリクエストIDを付与し、呼び出しごとに記録する
+// トークンIDを記録するため、認証のインターセプターより後に登録する
+func UnaryServerInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = withGRPCRequestID(ctx, info.FullMethod)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, RequestIDFromContext(ctx)))
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, logger, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor はgRPCのストリーミング呼び出しにリクエストIDを付与し、呼び出しごとに記録する
+func StreamServerInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := withGRPCRequestID(ss.Context(), info.FullMethod)
+		_ = ss.SetHeader(metadata.Pairs(requestIDMetadataKey, RequestIDFromContext(ctx)))
+
+		start := time.Now()
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, logger, start, err)
+		return err
+	}
+}
+
+// withGRPCRequestID はメタデータのリクエストIDを引き継ぐか新たに発行し、メソッド名とあわせてコンテキストに格納する
+func withGRPCRequestID(ctx context.Context, method string) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDMetadataKey); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	if !validRequestID(id) {
+		id = uuid.NewString()
+	}
+	return ContextWithOperation(ContextWithRequestID(ctx, id), method)
+}
+
+// logCall はgRPCの呼び出しの結果を記録する。サーバー側の障害を示すコードはerrorで記録する
+func logCall(ctx context.Context, logger *slog.Logger, start time.Time, err error) {
+	code := status.Code(err)
+	attrs := []slog.Attr{
+		slog.String("code", code.String()),
+		slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+	}
+	level := slog.LevelInfo
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		level = slog.LevelWarn
+		switch code {
+		case codes.Internal, codes.Unknown, codes.Unavailable, codes.DataLoss, codes.DeadlineExceeded:
+			level = slog.LevelError
+		}
+	}
+	logger.LogAttrs(ctx, level, "gRPC call", attrs...)
+}
+
+// contextStream はリクエストIDを格納したコンテキストを返すServerStream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"message-service/internal/config"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	const method = "/message.v1.MessageService/CreateMessage"
+
+	tests := []struct {
+		name          string
+		requestID     string
+		handlerErr    error
+		expectedLevel string
+		expectedCode  string
+	}{
+		{name: "正常系：受け取ったリクエストIDを引き継ぐ", requestID: "client-request-1", expectedLevel: "INFO", expectedCode: "OK"},
+		{name: "正常系：リクエストIDが無い場合は発行する", expectedLevel: "INFO", expectedCode: "OK"},
+		{name: "異常系：クライアントの誤りはwarnで記録する", handlerErr: status.Error(codes.InvalidArgument, "invalid"), expectedLevel: "WARN", expectedCode: "InvalidArgument"},
+		{name: "異常系：サーバーの障害はerrorで記録する", handlerErr: status.Error(codes.Internal, "db down"), expectedLevel: "ERROR", expectedCode: "Internal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			interceptor := UnaryServerInterceptor(New(&buf, config.LogConfig{Level: "info", Format: config.LogFormatJSON}))
+
+			ctx := context.Background()
+			if tt.requestID != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(requestIDMetadataKey, tt.requestID))
+			}
+
+			var handlerID string
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				handlerID = RequestIDFromContext(ctx)
+				return nil, tt.handlerErr
+			})
+			assert.Equal(t, tt.handlerErr, err)
+
+			if tt.requestID != "" {
+				assert.Equal(t, tt.requestID, handlerID)
+			} else {
+				_, parseErr := uuid.Parse(handlerID)
+				assert.NoError(t, parseErr)
+			}
+
+			var line map[string]interface{}
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+			assert.Equal(t, "gRPC call", line["msg"])
+			assert.Equal(t, tt.expectedLevel, line["level"])
+			assert.Equal(t, tt.expectedCode, line["code"])
+			assert.Equal(t, handlerID, line[RequestIDKey])
+			assert.Equal(t, method, line[OperationKey])
+		})
+	}
+}
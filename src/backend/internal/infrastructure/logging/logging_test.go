@@ -0,0 +1,111 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"message-service/internal/config"
+	"message-service/internal/domain/token"
+	"message-service/internal/infrastructure/middleware"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name           string
+		cfg            config.LogConfig
+		expectedOutput []string
+		expectedEmpty  bool
+	}{
+		{
+			name:           "正常系：JSON形式",
+			cfg:            config.LogConfig{Level: "info", Format: config.LogFormatJSON},
+			expectedOutput: []string{`"level":"INFO"`, `"msg":"hello"`},
+		},
+		{
+			name:           "正常系：テキスト形式",
+			cfg:            config.LogConfig{Level: "info", Format: config.LogFormatText},
+			expectedOutput: []string{"level=INFO", "msg=hello"},
+		},
+		{
+			name:          "正常系：レベル未満のログは出力しない",
+			cfg:           config.LogConfig{Level: "warn", Format: config.LogFormatJSON},
+			expectedEmpty: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			New(&buf, tt.cfg).Info("hello")
+
+			if tt.expectedEmpty {
+				assert.Empty(t, buf.String())
+				return
+			}
+			for _, expected := range tt.expectedOutput {
+				assert.Contains(t, buf.String(), expected)
+			}
+		})
+	}
+}
+
+func TestContextHandler(t *testing.T) {
+	tkn := &token.Token{ID: primitive.NewObjectID(), TenantID: "team-a", Token: "secret-token"}
+	traceID := trace.TraceID{0x4b, 0xf9, 0x2f, 0x35}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected map[string]interface{}
+	}{
+		{
+			name:     "正常系：コンテキストに情報が無い場合は付与しない",
+			ctx:      context.Background(),
+			expected: map[string]interface{}{},
+		},
+		{
+			name: "正常系：リクエストID、トークンID、テナントID、オペレーションID、トレースIDを付与する",
+			ctx: trace.ContextWithSpanContext(
+				ContextWithOperation(
+					middleware.ContextWithToken(ContextWithRequestID(context.Background(), "req-1"), tkn),
+					"GetApiMessagesSearch",
+				),
+				trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: trace.SpanID{1}}),
+			),
+			expected: map[string]interface{}{
+				RequestIDKey: "req-1",
+				TokenIDKey:   tkn.ID.Hex(),
+				TenantIDKey:  "team-a",
+				OperationKey: "GetApiMessagesSearch",
+				TraceIDKey:   traceID.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := New(&buf, config.LogConfig{Level: "info", Format: config.LogFormatJSON})
+
+			logger.With("component", "test").InfoContext(tt.ctx, "hello")
+
+			var line map[string]interface{}
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+			assert.Equal(t, "test", line["component"])
+			for _, key := range []string{RequestIDKey, TokenIDKey, TenantIDKey, OperationKey, TraceIDKey} {
+				if expected, ok := tt.expected[key]; ok {
+					assert.Equal(t, expected, line[key])
+				} else {
+					assert.NotContains(t, line, key)
+				}
+			}
+			// トークンの文字列は記録しない
+			assert.NotContains(t, buf.String(), tkn.Token)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"message-service/pkg/api"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader はリクエストIDを受け渡すヘッダー
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength は受け付けるリクエストIDの最大長
+const maxRequestIDLength = 128
+
+// RequestID はX-Request-IDヘッダーのリクエストIDを引き継ぐか新たに発行し、レスポンスヘッダーに返す
+// リクエストIDはリクエストのコンテキストに格納され、ハンドラーからリポジトリまで引き継がれる
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(ContextWithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// validRequestID は呼び出し元から受け取ったリクエストIDをそのまま使えるかを返す
+// ログやヘッダーを壊さないよう、空白と制御文字を含まない印字可能なASCIIのみ受け付ける
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// AccessLog はリクエストごとにメソッド、パス、ステータスコード、処理時間を記録する
+// 5xxはerror、4xxはwarn、それ以外はinfoで記録する。クエリ文字列は記録しない
+func AccessLog(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int("bytes", c.Writer.Size()),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", strings.Join(c.Errors.Errors(), "; ")))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		// ハンドラーが差し替えたコンテキストから、トークンIDとオペレーションIDを取り出す
+		logger.LogAttrs(c.Request.Context(), level, "HTTP request", attrs...)
+	}
+}
+
+// Recovery はハンドラーのpanicを500として返し、スタックトレースを記録する
+func Recovery(logger *slog.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		logger.ErrorContext(c.Request.Context(), "Panic recovered",
+			slog.Any("error", recovered),
+			slog.String("stack", string(debug.Stack())),
+		)
+		c.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
+
+// StrictMiddleware はストリクトハンドラーのオペレーションIDをリクエストのコンテキストに格納する
+func StrictMiddleware() api.StrictMiddlewareFunc {
+	return func(f api.StrictHandlerFunc, operationID string) api.StrictHandlerFunc {
+		return func(c *gin.Context, request interface{}) (interface{}, error) {
+			c.Request = c.Request.WithContext(ContextWithOperation(c.Request.Context(), operationID))
+			return f(c, request)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"message-service/internal/config"
+	"message-service/internal/domain/token"
+	"message-service/internal/infrastructure/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		header     string
+		expectKeep bool
+	}{
+		{name: "正常系：受け取ったリクエストIDを引き継ぐ", header: "client-request-1", expectKeep: true},
+		{name: "正常系：リクエストIDが無い場合は発行する"},
+		{name: "異常系：空白を含むリクエストIDは発行し直す", header: "bad id"},
+		{name: "異常系：長すぎるリクエストIDは発行し直す", header: strings.Repeat("a", maxRequestIDLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inContext string
+			router := gin.New()
+			router.Use(RequestID())
+			router.GET("/", func(c *gin.Context) {
+				inContext = RequestIDFromContext(c.Request.Context())
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			router.ServeHTTP(w, req)
+
+			id := w.Header().Get(RequestIDHeader)
+			assert.Equal(t, id, inContext)
+			if tt.expectKeep {
+				assert.Equal(t, tt.header, id)
+			} else {
+				_, err := uuid.Parse(id)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tkn := &token.Token{ID: primitive.NewObjectID(), Token: "secret-token"}
+
+	tests := []struct {
+		name          string
+		path          string
+		handler       gin.HandlerFunc
+		expectedLevel string
+		expected      map[string]interface{}
+	}{
+		{
+			name: "正常系：リクエストの情報を記録する",
+			path: "/api/messages?q=secret",
+			handler: func(c *gin.Context) {
+				c.Request = c.Request.WithContext(middleware.ContextWithToken(c.Request.Context(), tkn))
+				c.String(http.StatusOK, "ok")
+			},
+			expectedLevel: "INFO",
+			expected: map[string]interface{}{
+				"method":     "GET",
+				"path":       "/api/messages",
+				"route":      "/api/messages",
+				"status":     float64(http.StatusOK),
+				"bytes":      float64(2),
+				RequestIDKey: "req-1",
+				TokenIDKey:   tkn.ID.Hex(),
+			},
+		},
+		{
+			name:          "正常系：4xxはwarnで記録する",
+			path:          "/api/messages",
+			handler:       func(c *gin.Context) { c.Status(http.StatusNotFound) },
+			expectedLevel: "WARN",
+			expected:      map[string]interface{}{"status": float64(http.StatusNotFound)},
+		},
+		{
+			name: "異常系：5xxはエラーとあわせてerrorで記録する",
+			path: "/api/messages",
+			handler: func(c *gin.Context) {
+				c.Error(errors.New("connection refused"))
+				c.Status(http.StatusInternalServerError)
+			},
+			expectedLevel: "ERROR",
+			expected: map[string]interface{}{
+				"status": float64(http.StatusInternalServerError),
+				"error":  "connection refused",
+			},
+		},
+		{
+			name:          "異常系：panicは500として記録する",
+			path:          "/api/messages",
+			handler:       func(c *gin.Context) { panic("unexpected") },
+			expectedLevel: "ERROR",
+			expected:      map[string]interface{}{"status": float64(http.StatusInternalServerError)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := New(&buf, config.LogConfig{Level: "info", Format: config.LogFormatJSON})
+			router := gin.New()
+			router.Use(RequestID(), AccessLog(logger), Recovery(logger))
+			router.GET("/api/messages", tt.handler)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set(RequestIDHeader, "req-1")
+			router.ServeHTTP(w, req)
+
+			// 最後の行がアクセスログ
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			var line map[string]interface{}
+			assert.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &line))
+			assert.Equal(t, "HTTP request", line["msg"])
+			assert.Equal(t, tt.expectedLevel, line["level"])
+			assert.Equal(t, "req-1", line[RequestIDKey])
+			for key, expected := range tt.expected {
+				assert.Equal(t, expected, line[key], key)
+			}
+			assert.NotContains(t, buf.String(), "secret")
+		})
+	}
+}
+
+func TestStrictMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+	var operation string
+	handler := StrictMiddleware()(func(c *gin.Context, request interface{}) (interface{}, error) {
+		operation = OperationFromContext(c.Request.Context())
+		return nil, nil
+	}, "GetApiTokens")
+	_, _ = handler(c, nil)
+
+	assert.Equal(t, "GetApiTokens", operation)
+	// ハンドラーの終了後もアクセスログから参照できる
+	assert.Equal(t, "GetApiTokens", OperationFromContext(c.Request.Context()))
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"message-service/internal/domain/audit"
+	"message-service/internal/domain/tenant"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditRepository はaudit.Repositoryのインメモリ実装
+type AuditRepository struct {
+	mu   sync.RWMutex
+	logs []audit.Log
+}
+
+func NewAuditRepository() *AuditRepository {
+	return &AuditRepository{}
+}
+
+func (r *AuditRepository) Create(ctx context.Context, log *audit.Log) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if log.CreatedAt.IsZero() {
+		log.CreatedAt = time.Now()
+	}
+	log.ID = primitive.NewObjectID()
+	log.TenantID = tenantID
+
+	stored := *log
+	stored.Criteria = make(map[string]string, len(log.Criteria))
+	for k, v := range log.Criteria {
+		stored.Criteria[k] = v
+	}
+	r.logs = append(r.logs, stored)
+	return nil
+}
+
+// Logs は記録された監査ログを記録順に返す
+func (r *AuditRepository) Logs() []audit.Log {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	logs := make([]audit.Log, len(r.logs))
+	copy(logs, r.logs)
+	return logs
+}
@@ -0,0 +1,26 @@
+package memory
+
+import (
+	"context"
+	"message-service/internal/domain/audit"
+	"message-service/internal/domain/tenant"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditRepository_Create(t *testing.T) {
+	repo := NewAuditRepository()
+	criteria := map[string]string{"channel_id": "test-channel"}
+	log := &audit.Log{Action: audit.ActionMessageBulkDelete, Criteria: criteria, Affected: 2}
+
+	assert.NoError(t, repo.Create(tenant.WithID(context.Background(), tenant.DefaultID), log))
+	criteria["channel_id"] = "changed"
+
+	logs := repo.Logs()
+	assert.Len(t, logs, 1)
+	assert.False(t, logs[0].ID.IsZero())
+	assert.NotZero(t, logs[0].CreatedAt)
+	assert.Equal(t, int64(2), logs[0].Affected)
+	assert.Equal(t, "test-channel", logs[0].Criteria["channel_id"])
+}
@@ -0,0 +1,323 @@
+// Package memory はリポジトリのインメモリ実装を提供する
+// データはプロセス内にのみ保持されるため、デモやローカル開発、テストでの利用を想定している
+package memory
+
+import (
+	"context"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MessageRepository はmessage.Repositoryのインメモリ実装
+// MongoDB実装と同様に、削除は論理削除とし、削除されていないメッセージのUIDはテナント内で一意とする
+// 全ての操作はコンテキストのテナントのメッセージのみを対象とする
+type MessageRepository struct {
+	mu       sync.RWMutex
+	messages []*message.Message
+}
+
+func NewMessageRepository() message.Repository {
+	return &MessageRepository{}
+}
+
+func (r *MessageRepository) Create(ctx context.Context, msg *message.Message) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	msg.CreatedAt = now
+	msg.UpdatedAt = now
+	msg.TenantID = tenantID
+	return r.insert(msg)
+}
+
+func (r *MessageRepository) CreateMany(ctx context.Context, msgs []*message.Message) ([]error, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	errs := make([]error, len(msgs))
+	for i, msg := range msgs {
+		msg.CreatedAt = now
+		msg.UpdatedAt = now
+		msg.TenantID = tenantID
+		errs[i] = r.insert(msg)
+	}
+	return errs, nil
+}
+
+// insert はUIDの重複を確認してメッセージを追加する。呼び出し側でロックを取得すること
+// 他の実装のユニークインデックスと同様に、削除されるまでは有効期限切れのメッセージもUIDを占有する
+func (r *MessageRepository) insert(msg *message.Message) error {
+	for _, m := range r.messages {
+		if m.TenantID == msg.TenantID && m.UID == msg.UID && m.DeletedAt == nil {
+			return message.ErrDuplicateUID
+		}
+	}
+	if msg.ID.IsZero() {
+		msg.ID = primitive.NewObjectID()
+	}
+	r.messages = append(r.messages, cloneMessage(msg))
+	return nil
+}
+
+func (r *MessageRepository) Delete(ctx context.Context, uid string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	msg := r.findActive(tenantID, uid, now)
+	if msg == nil {
+		return mongo.ErrNoDocuments
+	}
+	msg.DeletedAt = &now
+	msg.UpdatedAt = now
+	return nil
+}
+
+// Search は指定された並び順で返す。取得するフィールドの指定は無視し、全てのフィールドを返す
+func (r *MessageRepository) Search(ctx context.Context, criteria message.SearchCriteria, opts message.SearchOptions) ([]message.Message, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := r.match(tenantID, criteria)
+	sort.Slice(matched, func(i, j int) bool {
+		return opts.Sort.Less(matched[i], matched[j])
+	})
+	messages := make([]message.Message, 0, len(matched))
+	for _, msg := range matched {
+		if opts.After != nil && !opts.After.Precedes(msg) {
+			continue
+		}
+		if opts.Limit > 0 && len(messages) == opts.Limit {
+			break
+		}
+		messages = append(messages, *cloneMessage(msg))
+	}
+	return messages, nil
+}
+
+func (r *MessageRepository) Iterate(ctx context.Context, criteria message.SearchCriteria, fn func(*message.Message) error) error {
+	// コールバック中にロックを保持しないよう、対象をコピーしてから渡す
+	messages, err := r.Search(ctx, criteria, message.SearchOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range messages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(&messages[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MessageRepository) FindByUID(ctx context.Context, uid string) (*message.Message, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	msg := r.findActive(tenantID, uid, time.Now())
+	if msg == nil {
+		return nil, nil
+	}
+	return cloneMessage(msg), nil
+}
+
+func (r *MessageRepository) Count(ctx context.Context, criteria message.SearchCriteria) (int64, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return int64(len(r.match(tenantID, criteria))), nil
+}
+
+func (r *MessageRepository) CountUpTo(ctx context.Context, criteria message.SearchCriteria, limit int64) (int64, error) {
+	count, err := r.Count(ctx, criteria)
+	if err != nil {
+		return 0, err
+	}
+	return min(count, limit), nil
+}
+
+func (r *MessageRepository) DeleteMany(ctx context.Context, criteria message.SearchCriteria) (int64, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	matched := r.match(tenantID, criteria)
+	now := time.Now()
+	for _, msg := range matched {
+		deletedAt := now
+		msg.DeletedAt = &deletedAt
+		msg.UpdatedAt = now
+	}
+	return int64(len(matched)), nil
+}
+
+func (r *MessageRepository) Around(ctx context.Context, target *message.Message, before, after int) ([]message.Message, []message.Message, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var older, newer []message.Message
+	for _, msg := range r.match(tenantID, message.SearchCriteria{ChannelIDs: []string{target.ChannelID}}) {
+		switch {
+		case message.Precedes(msg, target):
+			older = append(older, *cloneMessage(msg))
+		case message.Precedes(target, msg):
+			newer = append(newer, *cloneMessage(msg))
+		}
+	}
+
+	// 対象に近いものから指定件数を残す
+	if len(older) > before {
+		older = older[len(older)-before:]
+	}
+	if len(newer) > after {
+		newer = newer[:after]
+	}
+	return older, newer, nil
+}
+
+func (r *MessageRepository) Stats(ctx context.Context, query message.StatsQuery) ([]message.StatsBucket, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	acc := message.NewStatsAccumulator(query)
+	for _, msg := range r.match(tenantID, query.Criteria) {
+		acc.Add(msg)
+	}
+	return acc.Buckets(), nil
+}
+
+// DeleteExpired は有効期限を過ぎたメッセージを物理削除し、削除した件数を返す
+// 定期的な掃除のための操作のため、全てのテナントを対象とする
+func (r *MessageRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.messages[:0]
+	for _, msg := range r.messages {
+		if !msg.IsExpired(now) {
+			kept = append(kept, msg)
+		}
+	}
+	deleted := int64(len(r.messages) - len(kept))
+	clear(r.messages[len(kept):])
+	r.messages = kept
+	return deleted, nil
+}
+
+// findActive はテナントの削除されておらず有効期限内の指定UIDのメッセージを返す。呼び出し側でロックを取得すること
+func (r *MessageRepository) findActive(tenantID, uid string, now time.Time) *message.Message {
+	for _, msg := range r.messages {
+		if msg.TenantID == tenantID && msg.UID == uid && msg.DeletedAt == nil && !msg.IsExpired(now) {
+			return msg
+		}
+	}
+	return nil
+}
+
+// match はテナントのメッセージのうち、検索条件に一致する削除されておらず有効期限内のものを送信日時の昇順で返す
+// 送信日時が同じメッセージはIDの昇順とする
+// 呼び出し側でロックを取得すること
+func (r *MessageRepository) match(tenantID string, criteria message.SearchCriteria) []*message.Message {
+	now := time.Now()
+	var threads map[string]bool
+	if criteria.HasThread != nil {
+		threads = r.threads(tenantID, now)
+	}
+	var matched []*message.Message
+	for _, msg := range r.messages {
+		if msg.TenantID != tenantID || msg.DeletedAt != nil || msg.IsExpired(now) || !criteria.Matches(msg) {
+			continue
+		}
+		if criteria.HasThread != nil && *criteria.HasThread != threads[msg.UID] {
+			continue
+		}
+		matched = append(matched, msg)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return message.Precedes(matched[i], matched[j])
+	})
+	return matched
+}
+
+// threads はテナントの有効な返信の返信先UIDの集合を返す。呼び出し側でロックを取得すること
+func (r *MessageRepository) threads(tenantID string, now time.Time) map[string]bool {
+	threads := make(map[string]bool)
+	for _, msg := range r.messages {
+		if msg.TenantID == tenantID && msg.ReplyTo != "" && msg.DeletedAt == nil && !msg.IsExpired(now) {
+			threads[msg.ReplyTo] = true
+		}
+	}
+	return threads
+}
+
+// cloneMessage は保持しているメッセージが呼び出し側から変更されないようにコピーする
+func cloneMessage(msg *message.Message) *message.Message {
+	clone := *msg
+	if msg.DeletedAt != nil {
+		deletedAt := *msg.DeletedAt
+		clone.DeletedAt = &deletedAt
+	}
+	if msg.ExpiresAt != nil {
+		expiresAt := *msg.ExpiresAt
+		clone.ExpiresAt = &expiresAt
+	}
+	return &clone
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/repotest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMessage(uid string, sentAt time.Time) *message.Message {
+	return &message.Message{
+		UID:       uid,
+		SentAt:    sentAt,
+		Sender:    "test-sender",
+		ChannelID: "test-channel",
+		Content:   "test message",
+	}
+}
+
+func TestMessageRepository_Conformance(t *testing.T) {
+	repotest.RunMessageRepositorySuite(t, func(t *testing.T) message.Repository {
+		return NewMessageRepository()
+	})
+}
+
+func TestMessageRepository_Isolation(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	repo := NewMessageRepository()
+	msg := newTestMessage("msg1", time.Now())
+	assert.NoError(t, repo.Create(ctx, msg))
+
+	// 保持しているメッセージは呼び出し側の変更の影響を受けない
+	msg.Content = "changed"
+	found, err := repo.FindByUID(ctx, "msg1")
+	assert.NoError(t, err)
+	assert.Equal(t, "test message", found.Content)
+
+	found.Content = "changed"
+	found, err = repo.FindByUID(ctx, "msg1")
+	assert.NoError(t, err)
+	assert.Equal(t, "test message", found.Content)
+}
+
+func TestMessageRepository_Concurrency(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	repo := NewMessageRepository()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_ = repo.Create(ctx, newTestMessage(fmt.Sprintf("msg%d", i%10), time.Now()))
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, _ = repo.Search(ctx, message.SearchCriteria{}, message.SearchOptions{})
+		}()
+	}
+	wg.Wait()
+
+	// 同じUIDは1件しか作成されない
+	count, err := repo.Count(ctx, message.SearchCriteria{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), count)
+}
@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"context"
+	"message-service/internal/domain/tenant"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TenantRepository はtenant.Repositoryのインメモリ実装
+// 他の実装のマイグレーションと同様に、既定のテナントを作成した状態で始まる
+type TenantRepository struct {
+	mu           sync.RWMutex
+	tenants      map[string]tenant.Tenant
+	reservations map[reservationKey]reservation
+}
+
+type reservationKey struct {
+	tenantID string
+	resource tenant.Resource
+}
+
+// reservation は作成中の件数と、予約を有効とみなす期限
+type reservation struct {
+	count     int64
+	expiresAt time.Time
+}
+
+func NewTenantRepository() *TenantRepository {
+	now := time.Now()
+	return &TenantRepository{
+		tenants: map[string]tenant.Tenant{
+			tenant.DefaultID: {ID: tenant.DefaultID, Name: "Default", CreatedAt: now, UpdatedAt: now},
+		},
+		reservations: make(map[reservationKey]reservation),
+	}
+}
+
+func (r *TenantRepository) Create(ctx context.Context, t *tenant.Tenant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tenants[t.ID]; ok {
+		return tenant.ErrDuplicateTenant
+	}
+	now := time.Now()
+	t.CreatedAt = now
+	t.UpdatedAt = now
+	r.tenants[t.ID] = *t
+	return nil
+}
+
+func (r *TenantRepository) Update(ctx context.Context, t *tenant.Tenant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tenants[t.ID]
+	if !ok {
+		return mongo.ErrNoDocuments
+	}
+	stored.Name = t.Name
+	stored.Quota = t.Quota
+	stored.UpdatedAt = time.Now()
+	r.tenants[t.ID] = stored
+	*t = stored
+	return nil
+}
+
+func (r *TenantRepository) Get(ctx context.Context, id string) (*tenant.Tenant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if t, ok := r.tenants[id]; ok {
+		return &t, nil
+	}
+	return nil, nil
+}
+
+func (r *TenantRepository) List(ctx context.Context) ([]tenant.Tenant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tenants := make([]tenant.Tenant, 0, len(r.tenants))
+	for _, t := range r.tenants {
+		tenants = append(tenants, t)
+	}
+	sort.Slice(tenants, func(i, j int) bool {
+		return tenants[i].ID < tenants[j].ID
+	})
+	return tenants, nil
+}
+
+func (r *TenantRepository) Reserve(ctx context.Context, id string, resource tenant.Resource, n int64) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := reservationKey{tenantID: id, resource: resource}
+	now := time.Now()
+	res := r.reservations[key]
+	if !res.expiresAt.After(now) {
+		res.count = 0
+	}
+	res.count += n
+	res.expiresAt = now.Add(tenant.ReservationTimeout)
+	r.reservations[key] = res
+	return res.count, nil
+}
+
+func (r *TenantRepository) Release(ctx context.Context, id string, resource tenant.Resource, n int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := reservationKey{tenantID: id, resource: resource}
+	res, ok := r.reservations[key]
+	if !ok {
+		return nil
+	}
+	res.count = max(res.count-n, 0)
+	r.reservations[key] = res
+	return nil
+}
@@ -0,0 +1,174 @@
+package memory
+
+import (
+	"context"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/domain/token"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TokenRepository はtoken.Repositoryのインメモリ実装
+// MongoDB実装と同様に、削除は論理削除とし、期限切れのトークンは一覧と認証の対象外とする
+// 認証に使うFindByTokenと定期的な掃除を除き、コンテキストのテナントのトークンのみを対象とする
+type TokenRepository struct {
+	mu     sync.RWMutex
+	tokens []*token.Token
+}
+
+func NewTokenRepository() *TokenRepository {
+	return &TokenRepository{}
+}
+
+func (r *TokenRepository) Create(ctx context.Context, tkn *token.Token) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range r.tokens {
+		if t.Token == tkn.Token && t.DeletedAt == nil {
+			return token.ErrDuplicateToken
+		}
+	}
+
+	now := time.Now()
+	tkn.CreatedAt = now
+	tkn.UpdatedAt = now
+	tkn.ID = primitive.NewObjectID()
+	tkn.TenantID = tenantID
+	r.tokens = append(r.tokens, cloneToken(tkn))
+	return nil
+}
+
+func (r *TokenRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tkn := r.find(func(t *token.Token) bool { return t.TenantID == tenantID && t.ID == objectID })
+	if tkn == nil {
+		return mongo.ErrNoDocuments
+	}
+	now := time.Now()
+	tkn.DeletedAt = &now
+	tkn.UpdatedAt = now
+	return nil
+}
+
+func (r *TokenRepository) List(ctx context.Context) ([]token.Token, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	var tokens []token.Token
+	for _, t := range r.tokens {
+		if t.TenantID == tenantID && t.DeletedAt == nil && t.ExpiresAt.After(now) {
+			tokens = append(tokens, *cloneToken(t))
+		}
+	}
+
+	sort.SliceStable(tokens, func(i, j int) bool {
+		return tokens[i].CreatedAt.After(tokens[j].CreatedAt)
+	})
+	return tokens, nil
+}
+
+func (r *TokenRepository) CountUpTo(ctx context.Context, limit int64) (int64, error) {
+	tokens, err := r.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return min(int64(len(tokens)), limit), nil
+}
+
+func (r *TokenRepository) FindByID(ctx context.Context, id string) (*token.Token, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if tkn := r.find(func(t *token.Token) bool { return t.TenantID == tenantID && t.ID == objectID }); tkn != nil {
+		return cloneToken(tkn), nil
+	}
+	return nil, nil
+}
+
+// FindByToken は認証のため、全てのテナントからトークンを探す
+func (r *TokenRepository) FindByToken(ctx context.Context, tokenString string) (*token.Token, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	tkn := r.find(func(t *token.Token) bool {
+		return t.Token == tokenString && t.ExpiresAt.After(now)
+	})
+	if tkn == nil {
+		return nil, nil
+	}
+	return cloneToken(tkn), nil
+}
+
+// DeleteExpired は有効期限を過ぎたトークンを物理削除し、削除した件数を返す
+func (r *TokenRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.tokens[:0]
+	for _, t := range r.tokens {
+		if t.ExpiresAt.After(now) {
+			kept = append(kept, t)
+		}
+	}
+	deleted := int64(len(r.tokens) - len(kept))
+	clear(r.tokens[len(kept):])
+	r.tokens = kept
+	return deleted, nil
+}
+
+// find は削除されていないトークンのうち条件に一致する最初のものを返す。呼び出し側でロックを取得すること
+func (r *TokenRepository) find(match func(*token.Token) bool) *token.Token {
+	for _, t := range r.tokens {
+		if t.DeletedAt == nil && match(t) {
+			return t
+		}
+	}
+	return nil
+}
+
+// cloneToken は保持しているトークンが呼び出し側から変更されないようにコピーする
+func cloneToken(tkn *token.Token) *token.Token {
+	clone := *tkn
+	if tkn.DeletedAt != nil {
+		deletedAt := *tkn.DeletedAt
+		clone.DeletedAt = &deletedAt
+	}
+	return &clone
+}
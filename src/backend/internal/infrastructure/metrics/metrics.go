@@ -0,0 +1,194 @@
+// Package metrics はPrometheus形式のメトリクスを収集し、公開する
+package metrics
+
+import (
+	"message-service/internal/infrastructure/middleware"
+	"message-service/pkg/api"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// Path はメトリクスを公開するエンドポイント
+	Path = "/metrics"
+
+	namespace = "message_service"
+
+	// DefaultChannelLimit はチャンネルごとのメトリクスで個別に記録するテナントとチャンネルの組の上限
+	DefaultChannelLimit = 100
+	// otherLabel は上限を超えたラベルの値をまとめる値
+	otherLabel = "other"
+	// unmatchedOperation はどのルートにも一致しなかったリクエストのoperationラベル
+	unmatchedOperation = "unmatched"
+)
+
+// operationKey はStrictMiddlewareがgin.ContextにオペレーションIDを格納するキー
+const operationKey = "metrics.operation"
+
+// Metrics はサービスのメトリクスを保持する
+type Metrics struct {
+	registry        *prometheus.Registry
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	authAttempts    *prometheus.CounterVec
+	repoDuration    *prometheus.HistogramVec
+	messagesCreated *prometheus.CounterVec
+	channels        *labelLimiter
+}
+
+// New はメトリクスを作成し、GoランタイムとプロセスのメトリクスとあわせてRegistryに登録する
+func New(channelLimit int) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests by operation and status code.",
+		}, []string{"operation", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by operation.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		authAttempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "auth_attempts_total",
+			Help:      "Number of authentication attempts by result.",
+		}, []string{"result"}),
+		repoDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "repository_operation_duration_seconds",
+			Help:      "Storage operation latency by repository method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"storage", "repository", "method", "status"}),
+		messagesCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "messages_created_total",
+			Help:      "Number of messages created by tenant and channel. Channels beyond the limit are counted as \"other\".",
+		}, []string{"tenant_id", "channel_id"}),
+		channels: newLabelLimiter(channelLimit),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.authAttempts,
+		m.repoDuration,
+		m.messagesCreated,
+	)
+	return m
+}
+
+// Handler はPrometheusのテキスト形式でメトリクスを返すハンドラー
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Mux はPathでメトリクスを返すハンドラー
+// 認証を行わないため、APIとは別の公開しないアドレスで待ち受けること
+func (m *Metrics) Mux() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(Path, m.Handler())
+	return mux
+}
+
+// GinMiddleware はリクエスト数と処理時間を記録する
+// 認証で拒否されたリクエストも記録するため、認証ミドルウェアより前に登録する
+// operationラベルはStrictMiddlewareが設定したオペレーションIDで、
+// ストリクトハンドラーに到達しなかったリクエストはルートのパス（一致しない場合はunmatched）とする
+func (m *Metrics) GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		operation := c.GetString(operationKey)
+		if operation == "" {
+			operation = c.FullPath()
+		}
+		if operation == "" {
+			operation = unmatchedOperation
+		}
+		m.requests.WithLabelValues(operation, strconv.Itoa(c.Writer.Status())).Inc()
+		m.requestDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	}
+}
+
+// StrictMiddleware はストリクトハンドラーのオペレーションIDをGinMiddlewareに伝える
+func (m *Metrics) StrictMiddleware() api.StrictMiddlewareFunc {
+	return func(f api.StrictHandlerFunc, operationID string) api.StrictHandlerFunc {
+		return func(c *gin.Context, request interface{}) (interface{}, error) {
+			c.Set(operationKey, operationID)
+			return f(c, request)
+		}
+	}
+}
+
+// ObserveAuth はmiddleware.AuthObserverの実装
+func (m *Metrics) ObserveAuth(result middleware.AuthResult) {
+	m.authAttempts.WithLabelValues(string(result)).Inc()
+}
+
+// RegisterSubscribers はイベントの購読者数をゲージとして登録する
+func (m *Metrics) RegisterSubscribers(count func() int) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "event_subscribers",
+		Help:      "Number of active message event stream subscribers.",
+	}, func() float64 { return float64(count()) }))
+}
+
+// observeRepository はリポジトリのメソッドの処理時間を記録する
+func (m *Metrics) observeRepository(storage, repository, method string, start time.Time, err error) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	m.repoDuration.WithLabelValues(storage, repository, method, status).Observe(time.Since(start).Seconds())
+}
+
+// observeMessageCreated は作成されたメッセージをテナントとチャンネルごとに数える
+// 別のテナントの同じIDのチャンネルとは区別し、上限を超えた組はテナントごとにotherにまとめる
+func (m *Metrics) observeMessageCreated(tenantID, channelID string) {
+	channel := otherLabel
+	if m.channels.allow(tenantID + "\x00" + channelID) {
+		channel = channelID
+	}
+	m.messagesCreated.WithLabelValues(tenantID, channel).Inc()
+}
+
+// labelLimiter はラベルの値の種類を上限までに抑える
+// 最初に現れた上限個の値はそのまま使い、それ以降の新しい値はotherにまとめる
+type labelLimiter struct {
+	mu    sync.Mutex
+	limit int
+	seen  map[string]struct{}
+}
+
+func newLabelLimiter(limit int) *labelLimiter {
+	return &labelLimiter{limit: limit, seen: make(map[string]struct{})}
+}
+
+// allow はvalueを個別のラベルとして記録してよいかを返す
+func (l *labelLimiter) allow(value string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.seen[value]; ok {
+		return true
+	}
+	if len(l.seen) >= l.limit {
+		return false
+	}
+	l.seen[value] = struct{}{}
+	return true
+}
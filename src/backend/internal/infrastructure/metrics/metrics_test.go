@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"message-service/internal/infrastructure/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics_GinMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m := New(DefaultChannelLimit)
+	router := gin.New()
+	router.Use(m.GinMiddleware())
+	// ストリクトハンドラーを経由するルート
+	router.GET("/api/messages/:uid", func(c *gin.Context) {
+		handler := m.StrictMiddleware()(func(c *gin.Context, request interface{}) (interface{}, error) {
+			c.Status(http.StatusNotFound)
+			return nil, nil
+		}, "GetApiMessagesUid")
+		_, _ = handler(c, nil)
+	})
+	// ストリクトハンドラーを経由しないルート
+	router.GET("/healthz", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, path := range []string{"/api/messages/a", "/api/messages/b", "/healthz", "/unknown"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	tests := []struct {
+		name      string
+		operation string
+		code      string
+		expected  float64
+	}{
+		{name: "オペレーションIDで集計する", operation: "GetApiMessagesUid", code: "404", expected: 2},
+		{name: "ストリクトハンドラー以外はルートのパスで集計する", operation: "/healthz", code: "200", expected: 1},
+		{name: "一致しないルートはまとめて集計する", operation: "unmatched", code: "404", expected: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, testutil.ToFloat64(m.requests.WithLabelValues(tt.operation, tt.code)))
+		})
+	}
+	assert.Equal(t, 3, testutil.CollectAndCount(m.requestDuration))
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	m := New(DefaultChannelLimit)
+	m.ObserveAuth(middleware.AuthResultOK)
+	m.ObserveAuth(middleware.AuthResultMissing)
+	m.ObserveAuth(middleware.AuthResultMissing)
+	m.RegisterSubscribers(func() int { return 3 })
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path, nil))
+	body, _ := io.ReadAll(w.Body)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+	assert.Contains(t, string(body), `message_service_auth_attempts_total{result="missing"} 2`)
+	assert.Contains(t, string(body), `message_service_auth_attempts_total{result="ok"} 1`)
+	assert.Contains(t, string(body), "message_service_event_subscribers 3")
+	assert.Contains(t, string(body), "go_goroutines")
+}
+
+func TestMetrics_Mux(t *testing.T) {
+	m := New(DefaultChannelLimit)
+	mux := m.Mux()
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// メトリクス以外のパスは公開しない
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/messages", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestLabelLimiter(t *testing.T) {
+	l := newLabelLimiter(2)
+
+	assert.True(t, l.allow("general"))
+	assert.True(t, l.allow("random"))
+	// 上限を超えた新しい値は個別に記録しない
+	assert.False(t, l.allow("news"))
+	// 既に記録している値はそのまま使う
+	assert.True(t, l.allow("general"))
+}
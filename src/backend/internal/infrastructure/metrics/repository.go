@@ -0,0 +1,164 @@
+package metrics
+
+import (
+	"context"
+	"message-service/internal/domain/audit"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/domain/token"
+	"time"
+)
+
+// messageRepository はメッセージリポジトリの各メソッドの処理時間と作成件数を記録する
+type messageRepository struct {
+	next    message.Repository
+	metrics *Metrics
+	storage string
+}
+
+// InstrumentMessageRepository はrepoの処理時間と作成されたメッセージ数を記録するリポジトリを返す
+// storageはラベルに使うストレージの種類
+func (m *Metrics) InstrumentMessageRepository(storage string, repo message.Repository) message.Repository {
+	return &messageRepository{next: repo, metrics: m, storage: storage}
+}
+
+// tenantLabel は操作の対象のテナントIDを返す
+// 作成に成功した場合は必ず設定されているため、取得できない場合は空文字とする
+func tenantLabel(ctx context.Context) string {
+	tenantID, _ := tenant.FromContext(ctx)
+	return tenantID
+}
+
+func (r *messageRepository) observe(method string, start time.Time, err error) {
+	r.metrics.observeRepository(r.storage, "messages", method, start, err)
+}
+
+func (r *messageRepository) Create(ctx context.Context, msg *message.Message) (err error) {
+	defer func(start time.Time) { r.observe("Create", start, err) }(time.Now())
+	if err = r.next.Create(ctx, msg); err == nil {
+		r.metrics.observeMessageCreated(tenantLabel(ctx), msg.ChannelID)
+	}
+	return err
+}
+
+func (r *messageRepository) CreateMany(ctx context.Context, msgs []*message.Message) (errs []error, err error) {
+	defer func(start time.Time) { r.observe("CreateMany", start, err) }(time.Now())
+	errs, err = r.next.CreateMany(ctx, msgs)
+	if err == nil {
+		tenantID := tenantLabel(ctx)
+		for i, msg := range msgs {
+			if i < len(errs) && errs[i] == nil {
+				r.metrics.observeMessageCreated(tenantID, msg.ChannelID)
+			}
+		}
+	}
+	return errs, err
+}
+
+func (r *messageRepository) Delete(ctx context.Context, uid string) (err error) {
+	defer func(start time.Time) { r.observe("Delete", start, err) }(time.Now())
+	return r.next.Delete(ctx, uid)
+}
+
+func (r *messageRepository) Search(ctx context.Context, criteria message.SearchCriteria, opts message.SearchOptions) (msgs []message.Message, err error) {
+	defer func(start time.Time) { r.observe("Search", start, err) }(time.Now())
+	return r.next.Search(ctx, criteria, opts)
+}
+
+func (r *messageRepository) Iterate(ctx context.Context, criteria message.SearchCriteria, fn func(*message.Message) error) (err error) {
+	defer func(start time.Time) { r.observe("Iterate", start, err) }(time.Now())
+	return r.next.Iterate(ctx, criteria, fn)
+}
+
+func (r *messageRepository) FindByUID(ctx context.Context, uid string) (msg *message.Message, err error) {
+	defer func(start time.Time) { r.observe("FindByUID", start, err) }(time.Now())
+	return r.next.FindByUID(ctx, uid)
+}
+
+func (r *messageRepository) Count(ctx context.Context, criteria message.SearchCriteria) (n int64, err error) {
+	defer func(start time.Time) { r.observe("Count", start, err) }(time.Now())
+	return r.next.Count(ctx, criteria)
+}
+
+func (r *messageRepository) CountUpTo(ctx context.Context, criteria message.SearchCriteria, limit int64) (n int64, err error) {
+	defer func(start time.Time) { r.observe("CountUpTo", start, err) }(time.Now())
+	return r.next.CountUpTo(ctx, criteria, limit)
+}
+
+func (r *messageRepository) DeleteMany(ctx context.Context, criteria message.SearchCriteria) (n int64, err error) {
+	defer func(start time.Time) { r.observe("DeleteMany", start, err) }(time.Now())
+	return r.next.DeleteMany(ctx, criteria)
+}
+
+func (r *messageRepository) Around(ctx context.Context, target *message.Message, before, after int) (older []message.Message, newer []message.Message, err error) {
+	defer func(start time.Time) { r.observe("Around", start, err) }(time.Now())
+	return r.next.Around(ctx, target, before, after)
+}
+
+func (r *messageRepository) Stats(ctx context.Context, query message.StatsQuery) (buckets []message.StatsBucket, err error) {
+	defer func(start time.Time) { r.observe("Stats", start, err) }(time.Now())
+	return r.next.Stats(ctx, query)
+}
+
+// tokenRepository はトークンリポジトリの各メソッドの処理時間を記録する
+type tokenRepository struct {
+	next    token.Repository
+	metrics *Metrics
+	storage string
+}
+
+// InstrumentTokenRepository はrepoの処理時間を記録するリポジトリを返す
+func (m *Metrics) InstrumentTokenRepository(storage string, repo token.Repository) token.Repository {
+	return &tokenRepository{next: repo, metrics: m, storage: storage}
+}
+
+func (r *tokenRepository) observe(method string, start time.Time, err error) {
+	r.metrics.observeRepository(r.storage, "tokens", method, start, err)
+}
+
+func (r *tokenRepository) Create(ctx context.Context, tkn *token.Token) (err error) {
+	defer func(start time.Time) { r.observe("Create", start, err) }(time.Now())
+	return r.next.Create(ctx, tkn)
+}
+
+func (r *tokenRepository) Delete(ctx context.Context, id string) (err error) {
+	defer func(start time.Time) { r.observe("Delete", start, err) }(time.Now())
+	return r.next.Delete(ctx, id)
+}
+
+func (r *tokenRepository) List(ctx context.Context) (tokens []token.Token, err error) {
+	defer func(start time.Time) { r.observe("List", start, err) }(time.Now())
+	return r.next.List(ctx)
+}
+
+func (r *tokenRepository) CountUpTo(ctx context.Context, limit int64) (n int64, err error) {
+	defer func(start time.Time) { r.observe("CountUpTo", start, err) }(time.Now())
+	return r.next.CountUpTo(ctx, limit)
+}
+
+func (r *tokenRepository) FindByID(ctx context.Context, id string) (tkn *token.Token, err error) {
+	defer func(start time.Time) { r.observe("FindByID", start, err) }(time.Now())
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *tokenRepository) FindByToken(ctx context.Context, tokenString string) (tkn *token.Token, err error) {
+	defer func(start time.Time) { r.observe("FindByToken", start, err) }(time.Now())
+	return r.next.FindByToken(ctx, tokenString)
+}
+
+// auditRepository は監査ログリポジトリの処理時間を記録する
+type auditRepository struct {
+	next    audit.Repository
+	metrics *Metrics
+	storage string
+}
+
+// InstrumentAuditRepository はrepoの処理時間を記録するリポジトリを返す
+func (m *Metrics) InstrumentAuditRepository(storage string, repo audit.Repository) audit.Repository {
+	return &auditRepository{next: repo, metrics: m, storage: storage}
+}
+
+func (r *auditRepository) Create(ctx context.Context, log *audit.Log) (err error) {
+	defer func(start time.Time) { r.metrics.observeRepository(r.storage, "audit_logs", "Create", start, err) }(time.Now())
+	return r.next.Create(ctx, log)
+}
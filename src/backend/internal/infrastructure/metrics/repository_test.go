@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/infrastructure/memory"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMessage(uid, channelID string) *message.Message {
+	return &message.Message{
+		UID:       uid,
+		SentAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Sender:    "alice",
+		ChannelID: channelID,
+		Content:   "hello",
+	}
+}
+
+func TestInstrumentMessageRepository(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	m := New(2)
+	repo := m.InstrumentMessageRepository("memory", memory.NewMessageRepository())
+
+	assert.NoError(t, repo.Create(ctx, newTestMessage("1", "general")))
+	// 重複したUIDは作成数に含めない
+	assert.Error(t, repo.Create(ctx, newTestMessage("1", "general")))
+	errs, err := repo.CreateMany(ctx, []*message.Message{
+		newTestMessage("2", "random"),
+		newTestMessage("1", "random"),
+		newTestMessage("3", "news"),
+		newTestMessage("4", "sports"),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, errs, 4)
+	_, err = repo.Search(ctx, message.SearchCriteria{}, message.SearchOptions{})
+	assert.NoError(t, err)
+	// 別のテナントの同じIDのチャンネルは区別して数える
+	otherCtx := tenant.WithID(context.Background(), "team-b")
+	assert.NoError(t, repo.Create(otherCtx, newTestMessage("1", "general")))
+
+	tests := []struct {
+		name      string
+		tenantID  string
+		channelID string
+		expected  float64
+	}{
+		{name: "テナントとチャンネルごとに作成数を数える", tenantID: tenant.DefaultID, channelID: "general", expected: 1},
+		{name: "一部が失敗した一括作成は成功分のみ数える", tenantID: tenant.DefaultID, channelID: "random", expected: 1},
+		{name: "上限を超えたチャンネルはテナントごとにotherにまとめる", tenantID: tenant.DefaultID, channelID: "other", expected: 2},
+		{name: "上限を超えた別のテナントのチャンネルもotherにまとめる", tenantID: "team-b", channelID: "other", expected: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, testutil.ToFloat64(m.messagesCreated.WithLabelValues(tt.tenantID, tt.channelID)))
+		})
+	}
+
+	// Create（成功・失敗）、CreateMany、Searchの4系列
+	assert.Equal(t, 4, testutil.CollectAndCount(m.repoDuration))
+	assert.Equal(t, 4, testutil.CollectAndCount(m.messagesCreated))
+}
+
+func TestInstrumentTokenRepository(t *testing.T) {
+	m := New(DefaultChannelLimit)
+	repo := m.InstrumentTokenRepository("memory", memory.NewTokenRepository())
+
+	tkn, err := repo.FindByToken(context.Background(), "unknown")
+
+	assert.NoError(t, err)
+	assert.Nil(t, tkn)
+	assert.Equal(t, 1, testutil.CollectAndCount(m.repoDuration, "message_service_repository_operation_duration_seconds"))
+}
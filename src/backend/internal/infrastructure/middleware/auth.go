@@ -2,15 +2,54 @@
 package middleware
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"message-service/internal/domain/tenant"
 	"message-service/internal/domain/token"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
+var (
+	// ErrMissingToken は認証情報が無い場合のエラー
+	ErrMissingToken = errors.New("authentication required")
+	// ErrMalformedToken は認証情報の形式が不正な場合のエラー
+	ErrMalformedToken = errors.New("invalid authentication format")
+	// ErrInvalidToken はトークンが存在しないか期限切れの場合のエラー
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// AuthResult は認証の結果の分類
+type AuthResult string
+
+const (
+	AuthResultOK        AuthResult = "ok"
+	AuthResultMissing   AuthResult = "missing"
+	AuthResultMalformed AuthResult = "malformed"
+	AuthResultInvalid   AuthResult = "invalid"
+	AuthResultError     AuthResult = "error"
+)
+
+// AuthObserver は認証の結果を受け取る。メトリクスの記録に使う
+type AuthObserver interface {
+	ObserveAuth(result AuthResult)
+}
+
+// tracer は認証のスパンを作成する
+var tracer = otel.Tracer("message-service/internal/infrastructure/middleware")
+
+type tokenContextKey struct{}
+
 type AuthMiddleware struct {
 	tokenRepo token.Repository
+	observer  AuthObserver
 }
 
 func NewAuthMiddleware(tokenRepo token.Repository) *AuthMiddleware {
@@ -19,46 +58,121 @@ func NewAuthMiddleware(tokenRepo token.Repository) *AuthMiddleware {
 	}
 }
 
-func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Skip authentication for POST /api/tokens (token creation)
-		if c.Request.Method == "POST" && c.Request.URL.Path == "/api/tokens" {
-			c.Next()
-			return
-		}
+// WithObserver は認証の結果をobserverに通知するようにする
+func (m *AuthMiddleware) WithObserver(observer AuthObserver) *AuthMiddleware {
+	m.observer = observer
+	return m
+}
 
-		// Get Authorization header
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
-			return
-		}
+// Authenticate はAuthorizationヘッダーの値を検証し、対応するトークンを返す
+// HTTPとgRPCの両方から利用される
+// トークンの照会はスパンで囲み、認証の結果を属性に記録する
+func (m *AuthMiddleware) Authenticate(ctx context.Context, authHeader string) (*token.Token, error) {
+	ctx, span := tracer.Start(ctx, "Authenticate")
+	defer span.End()
 
-		// Extract Bearer token
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid authentication format"})
-			return
-		}
+	tkn, err := m.authenticate(ctx, authHeader)
+	result := authResult(err)
+	span.SetAttributes(attribute.String("auth.result", string(result)))
+	if result == AuthResultError {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	if m.observer != nil {
+		m.observer.ObserveAuth(result)
+	}
+	return tkn, err
+}
 
-		tokenString := parts[1]
+func (m *AuthMiddleware) authenticate(ctx context.Context, authHeader string) (*token.Token, error) {
+	if authHeader == "" {
+		return nil, ErrMissingToken
+	}
 
-		// Validate token
-		tkn, err := m.tokenRepo.FindByToken(c.Request.Context(), tokenString)
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Error occurred during token validation"})
+	// Extract Bearer token
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, ErrMalformedToken
+	}
+
+	// Validate token
+	tkn, err := m.tokenRepo.FindByToken(ctx, parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("token validation: %w", err)
+	}
+
+	if tkn == nil {
+		return nil, ErrInvalidToken
+	}
+
+	return tkn, nil
+}
+
+// authResult はAuthenticateのエラーを認証の結果に分類する
+func authResult(err error) AuthResult {
+	switch {
+	case err == nil:
+		return AuthResultOK
+	case errors.Is(err, ErrMissingToken):
+		return AuthResultMissing
+	case errors.Is(err, ErrMalformedToken):
+		return AuthResultMalformed
+	case errors.Is(err, ErrInvalidToken):
+		return AuthResultInvalid
+	default:
+		return AuthResultError
+	}
+}
+
+// RequireAuth はpublicPathsに一致するパス以外で認証を要求する
+// publicPathsはヘルスチェックなど、メソッドを問わず認証なしで公開するパス
+// トークン作成も認証が必要で、最初のトークンは管理コマンド（token create）で作成する
+func (m *AuthMiddleware) RequireAuth(publicPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if slices.Contains(publicPaths, c.Request.URL.Path) {
+			c.Next()
 			return
 		}
 
-		if tkn == nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		tkn, err := m.Authenticate(c.Request.Context(), c.GetHeader("Authorization"))
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrMissingToken):
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			case errors.Is(err, ErrMalformedToken):
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid authentication format"})
+			case errors.Is(err, ErrInvalidToken):
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			default:
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Error occurred during token validation"})
+			}
 			return
 		}
 
 		// Store token information in context (if needed)
 		c.Set("token", tkn)
 		c.Set("token_id", tkn.ID.Hex())
+		c.Request = c.Request.WithContext(ContextWithToken(c.Request.Context(), tkn))
 
 		c.Next()
 	}
 }
+
+// ContextWithToken は認証済みトークンをコンテキストに格納する
+// 以降の操作はトークンが属するテナントのデータのみを対象とする
+func ContextWithToken(ctx context.Context, tkn *token.Token) context.Context {
+	ctx = tenant.WithID(ctx, tkn.TenantID)
+	return context.WithValue(ctx, tokenContextKey{}, tkn)
+}
+
+// TokenFromContext は認証済みトークンをコンテキストから取り出す
+// gin.ContextとgRPCのコンテキストのどちらにも対応する
+func TokenFromContext(ctx context.Context) *token.Token {
+	if tkn, ok := ctx.Value(tokenContextKey{}).(*token.Token); ok {
+		return tkn
+	}
+	if tkn, ok := ctx.Value("token").(*token.Token); ok {
+		return tkn
+	}
+	return nil
+}
@@ -8,11 +8,18 @@ import (
 	"testing"
 	"time"
 
+	"message-service/internal/domain/tenant"
 	"message-service/internal/domain/token"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type mockTokenRepository struct {
@@ -35,6 +42,10 @@ func (m *mockTokenRepository) List(ctx context.Context) ([]token.Token, error) {
 	return nil, nil
 }
 
+func (m *mockTokenRepository) CountUpTo(ctx context.Context, limit int64) (int64, error) {
+	return 0, nil
+}
+
 func (m *mockTokenRepository) FindByID(ctx context.Context, id string) (*token.Token, error) {
 	return nil, nil
 }
@@ -53,14 +64,49 @@ func TestAuthMiddleware_RequireAuth(t *testing.T) {
 		checkContext   func(*testing.T, *gin.Context)
 	}{
 		{
-			name:   "トークン作成エンドポイントは認証をスキップ",
+			name:   "トークン作成エンドポイントも認証ヘッダーが無ければ401を返す",
 			method: "POST",
 			path:   "/api/tokens",
 			setupMock: func() token.Repository {
 				return &mockTokenRepository{}
 			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"Authentication required"}`,
+		},
+		{
+			name:       "トークン作成エンドポイントは認証ヘッダーがあれば認証する",
+			method:     "POST",
+			path:       "/api/tokens",
+			authHeader: "Bearer token123",
+			setupMock: func() token.Repository {
+				return &mockTokenRepository{
+					findByTokenFunc: func(ctx context.Context, tokenStr string) (*token.Token, error) {
+						return nil, nil
+					},
+				}
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"Invalid token"}`,
+		},
+		{
+			name:   "公開パスは認証をスキップ",
+			method: "GET",
+			path:   "/healthz",
+			setupMock: func() token.Repository {
+				return &mockTokenRepository{}
+			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:   "公開パスの配下は認証が必要",
+			method: "GET",
+			path:   "/healthz/detail",
+			setupMock: func() token.Repository {
+				return &mockTokenRepository{}
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"Authentication required"}`,
+		},
 		{
 			name:   "認証ヘッダーが無い場合はエラー",
 			method: "GET",
@@ -123,6 +169,7 @@ func TestAuthMiddleware_RequireAuth(t *testing.T) {
 						now := time.Now()
 						return &token.Token{
 							ID:        primitive.NewObjectID(),
+							TenantID:  "team-a",
 							Token:     "validToken",
 							Name:      "Test Token",
 							ExpiresAt: now.Add(24 * time.Hour),
@@ -142,6 +189,11 @@ func TestAuthMiddleware_RequireAuth(t *testing.T) {
 				tokenID, exists := c.Get("token_id")
 				assert.True(t, exists, "token_id should be set in context")
 				assert.NotEmpty(t, tokenID, "token_id should not be empty")
+
+				// 以降の操作はトークンのテナントを対象とする
+				tenantID, err := tenant.FromContext(c.Request.Context())
+				assert.NoError(t, err)
+				assert.Equal(t, "team-a", tenantID)
 			},
 		},
 	}
@@ -152,7 +204,7 @@ func TestAuthMiddleware_RequireAuth(t *testing.T) {
 			_, r := gin.CreateTestContext(w)
 
 			authMiddleware := NewAuthMiddleware(tt.setupMock())
-			r.Use(authMiddleware.RequireAuth())
+			r.Use(authMiddleware.RequireAuth("/healthz", "/readyz"))
 
 			r.Handle(tt.method, tt.path, func(c *gin.Context) {
 				if tt.checkContext != nil {
@@ -175,3 +227,157 @@ func TestAuthMiddleware_RequireAuth(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthMiddleware_Authenticate(t *testing.T) {
+	tests := []struct {
+		name        string
+		authHeader  string
+		repo        token.Repository
+		expectedErr error
+	}{
+		{
+			name:        "認証ヘッダーが無い場合",
+			repo:        &mockTokenRepository{},
+			expectedErr: ErrMissingToken,
+		},
+		{
+			name:        "不正な認証フォーマット",
+			authHeader:  "Bearer",
+			repo:        &mockTokenRepository{},
+			expectedErr: ErrMalformedToken,
+		},
+		{
+			name:        "トークンが存在しない場合",
+			authHeader:  "Bearer unknown",
+			repo:        validTokenRepository(),
+			expectedErr: ErrInvalidToken,
+		},
+		{
+			name:       "有効なトークン",
+			authHeader: "Bearer validToken",
+			repo:       validTokenRepository(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tkn, err := NewAuthMiddleware(tt.repo).Authenticate(context.Background(), tt.authHeader)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, tkn)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "validToken", tkn.Token)
+			}
+		})
+	}
+}
+
+func TestTokenFromContext(t *testing.T) {
+	tkn := &token.Token{ID: primitive.NewObjectID()}
+
+	t.Run("ContextWithTokenで格納したトークン", func(t *testing.T) {
+		ctx := ContextWithToken(context.Background(), tkn)
+		assert.Same(t, tkn, TokenFromContext(ctx))
+	})
+
+	t.Run("gin.Contextに格納したトークン", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Set("token", tkn)
+		assert.Same(t, tkn, TokenFromContext(c))
+	})
+
+	t.Run("トークンが無い場合", func(t *testing.T) {
+		assert.Nil(t, TokenFromContext(context.Background()))
+	})
+}
+
+// テスト用のAuthObserver
+type recordingObserver struct {
+	results []AuthResult
+}
+
+func (o *recordingObserver) ObserveAuth(result AuthResult) {
+	o.results = append(o.results, result)
+}
+
+func TestAuthMiddleware_Authenticate_Observer(t *testing.T) {
+	tests := []struct {
+		name       string
+		authHeader string
+		findErr    error
+		expected   AuthResult
+	}{
+		{name: "有効なトークン", authHeader: "Bearer validToken", expected: AuthResultOK},
+		{name: "認証ヘッダーが無い", authHeader: "", expected: AuthResultMissing},
+		{name: "不正な認証フォーマット", authHeader: "Basic token123", expected: AuthResultMalformed},
+		{name: "トークンが存在しない", authHeader: "Bearer unknown", expected: AuthResultInvalid},
+		{name: "リポジトリからのエラー発生", authHeader: "Bearer validToken", findErr: errors.New("repository error"), expected: AuthResultError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := validTokenRepository()
+			if tt.findErr != nil {
+				repo = &mockTokenRepository{
+					findByTokenFunc: func(ctx context.Context, tokenStr string) (*token.Token, error) {
+						return nil, tt.findErr
+					},
+				}
+			}
+			observer := &recordingObserver{}
+			m := NewAuthMiddleware(repo).WithObserver(observer)
+
+			_, _ = m.Authenticate(context.Background(), tt.authHeader)
+
+			assert.Equal(t, []AuthResult{tt.expected}, observer.results)
+		})
+	}
+}
+
+func TestAuthMiddleware_Authenticate_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	tests := []struct {
+		name           string
+		authHeader     string
+		findErr        error
+		expectedResult AuthResult
+		expectedStatus codes.Code
+	}{
+		{name: "正常系：有効なトークン", authHeader: "Bearer validToken", expectedResult: AuthResultOK, expectedStatus: codes.Unset},
+		{name: "正常系：トークンが存在しない場合はエラーとして記録しない", authHeader: "Bearer unknown", expectedResult: AuthResultInvalid, expectedStatus: codes.Unset},
+		{name: "異常系：リポジトリからのエラー発生", authHeader: "Bearer validToken", findErr: errors.New("repository error"), expectedResult: AuthResultError, expectedStatus: codes.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(recorder.Ended())
+			var lookupSpan trace.SpanContext
+			repo := &mockTokenRepository{
+				findByTokenFunc: func(ctx context.Context, tokenStr string) (*token.Token, error) {
+					lookupSpan = trace.SpanContextFromContext(ctx)
+					if tt.findErr != nil {
+						return nil, tt.findErr
+					}
+					return validTokenRepository().FindByToken(ctx, tokenStr)
+				},
+			}
+
+			_, _ = NewAuthMiddleware(repo).Authenticate(context.Background(), tt.authHeader)
+
+			spans := recorder.Ended()
+			if !assert.Len(t, spans, before+1) {
+				return
+			}
+			span := spans[before]
+			assert.Equal(t, "Authenticate", span.Name())
+			// トークンの照会は認証のスパンの中で行われる
+			assert.Equal(t, span.SpanContext().SpanID(), lookupSpan.SpanID())
+			assert.Contains(t, span.Attributes(), attribute.String("auth.result", string(tt.expectedResult)))
+			assert.Equal(t, tt.expectedStatus, span.Status().Code)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"message-service/internal/domain/token"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// テスト用のServerStream
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func validTokenRepository() token.Repository {
+	return &mockTokenRepository{
+		findByTokenFunc: func(ctx context.Context, tokenStr string) (*token.Token, error) {
+			if tokenStr != "validToken" {
+				return nil, nil
+			}
+			return &token.Token{
+				ID:        primitive.NewObjectID(),
+				Token:     "validToken",
+				ExpiresAt: time.Now().Add(time.Hour),
+			}, nil
+		},
+	}
+}
+
+func TestAuthMiddleware_UnaryServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		authHeader   string
+		repo         token.Repository
+		expectedCode codes.Code
+	}{
+		{
+			name:         "公開メソッドは認証をスキップ",
+			method:       "/message.v1.TokenService/CreateToken",
+			repo:         &mockTokenRepository{},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "公開メソッドでも認証メタデータがある場合は認証する",
+			method:       "/message.v1.TokenService/CreateToken",
+			authHeader:   "Bearer validToken",
+			repo:         validTokenRepository(),
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "公開メソッドでも無効なトークンはエラー",
+			method:       "/message.v1.TokenService/CreateToken",
+			authHeader:   "Bearer unknown",
+			repo:         validTokenRepository(),
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "認証メタデータが無い場合はエラー",
+			method:       "/message.v1.MessageService/CreateMessage",
+			repo:         &mockTokenRepository{},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "不正な認証フォーマット",
+			method:       "/message.v1.MessageService/CreateMessage",
+			authHeader:   "Basic token123",
+			repo:         &mockTokenRepository{},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "トークンが存在しない場合",
+			method:       "/message.v1.MessageService/CreateMessage",
+			authHeader:   "Bearer unknown",
+			repo:         validTokenRepository(),
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:       "リポジトリからのエラー発生",
+			method:     "/message.v1.MessageService/CreateMessage",
+			authHeader: "Bearer token123",
+			repo: &mockTokenRepository{
+				findByTokenFunc: func(ctx context.Context, tokenStr string) (*token.Token, error) {
+					return nil, errors.New("repository error")
+				},
+			},
+			expectedCode: codes.Internal,
+		},
+		{
+			name:         "有効なトークン",
+			method:       "/message.v1.MessageService/CreateMessage",
+			authHeader:   "Bearer validToken",
+			repo:         validTokenRepository(),
+			expectedCode: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := NewAuthMiddleware(tt.repo).UnaryServerInterceptor("/message.v1.TokenService/CreateToken")
+
+			ctx := context.Background()
+			if tt.authHeader != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authHeader))
+			}
+
+			var handlerCtx context.Context
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				handlerCtx = ctx
+				return "ok", nil
+			})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK && tt.authHeader != "" {
+				assert.NotNil(t, TokenFromContext(handlerCtx))
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_StreamServerInterceptor(t *testing.T) {
+	interceptor := NewAuthMiddleware(validTokenRepository()).StreamServerInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/message.v1.MessageService/SearchMessages"}
+
+	t.Run("有効なトークン", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer validToken"))
+
+		err := interceptor(nil, &testServerStream{ctx: ctx}, info, func(srv interface{}, stream grpc.ServerStream) error {
+			assert.NotNil(t, TokenFromContext(stream.Context()))
+			return nil
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("認証メタデータが無い場合はエラー", func(t *testing.T) {
+		err := interceptor(nil, &testServerStream{ctx: context.Background()}, info, func(srv interface{}, stream grpc.ServerStream) error {
+			t.Fatal("handler should not be called")
+			return nil
+		})
+
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
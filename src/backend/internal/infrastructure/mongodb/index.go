@@ -3,6 +3,11 @@ package mongodb
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -22,6 +27,7 @@ type Collection interface {
 // IndexView インターフェース
 type IndexView interface {
 	CreateMany(ctx context.Context, models []mongo.IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error)
+	ListSpecifications(ctx context.Context, opts ...*options.ListIndexesOptions) ([]*mongo.IndexSpecification, error)
 }
 
 // MongoDatabase は実際のmongo.Databaseのラッパー
@@ -51,6 +57,10 @@ func (m *MongoIndexView) CreateMany(ctx context.Context, models []mongo.IndexMod
 	return m.view.CreateMany(ctx, models, opts...)
 }
 
+func (m *MongoIndexView) ListSpecifications(ctx context.Context, opts ...*options.ListIndexesOptions) ([]*mongo.IndexSpecification, error) {
+	return m.view.ListSpecifications(ctx, opts...)
+}
+
 // IndexClient はインデックス操作を管理するクライアント
 type IndexClient struct {
 	db *mongo.Database
@@ -73,70 +83,281 @@ func NewDatabase(db interface{}) Database {
 	}
 }
 
-func CreateIndexes(ctx context.Context, db Database) error {
-	// メッセージコレクションのインデックス
-	messageIndexes := []mongo.IndexModel{
-		{
-			Keys: bson.D{
-				{Key: "uid", Value: 1},
-				{Key: "deleted_at", Value: 1},
-			},
-			Options: options.Index().SetUnique(true),
-		},
-		{
-			Keys: bson.D{
-				{Key: "channel_id", Value: 1},
-				{Key: "deleted_at", Value: 1},
-			},
-		},
+// collectionIndexes はコレクションごとに宣言されたインデックス
+type collectionIndexes struct {
+	collection string
+	models     []mongo.IndexModel
+}
+
+// declaredIndexes はサービスが必要とするインデックスの定義
+// 起動時の同期と差分検出はこの定義を正とする
+func declaredIndexes() []collectionIndexes {
+	return []collectionIndexes{
 		{
-			Keys: bson.D{
-				{Key: "sender", Value: 1},
-				{Key: "deleted_at", Value: 1},
+			// メッセージコレクションのインデックス
+			collection: "messages",
+			models: []mongo.IndexModel{
+				{
+					// UIDはテナントごとに一意
+					Keys: bson.D{
+						{Key: "tenant_id", Value: 1},
+						{Key: "uid", Value: 1},
+						{Key: "deleted_at", Value: 1},
+					},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					Keys: bson.D{
+						{Key: "tenant_id", Value: 1},
+						{Key: "channel_id", Value: 1},
+						{Key: "deleted_at", Value: 1},
+					},
+				},
+				{
+					Keys: bson.D{
+						{Key: "tenant_id", Value: 1},
+						{Key: "sender", Value: 1},
+						{Key: "deleted_at", Value: 1},
+					},
+				},
+				{
+					Keys: bson.D{
+						{Key: "tenant_id", Value: 1},
+						{Key: "sent_at", Value: -1},
+						{Key: "deleted_at", Value: 1},
+					},
+				},
+				{
+					// 有効期限を過ぎたメッセージを削除する。expires_atが無いメッセージは対象外
+					Keys:    bson.D{{Key: "expires_at", Value: 1}},
+					Options: options.Index().SetExpireAfterSeconds(0),
+				},
+				{
+					// チャンネル内で指定したメッセージの前後を送信日時とIDの順にたどる
+					Keys: bson.D{
+						{Key: "tenant_id", Value: 1},
+						{Key: "channel_id", Value: 1},
+						{Key: "sent_at", Value: 1},
+						{Key: "_id", Value: 1},
+					},
+				},
+				{
+					// 返信の有無による絞り込みで、返信先のUIDから返信を探す
+					Keys: bson.D{
+						{Key: "tenant_id", Value: 1},
+						{Key: "reply_to", Value: 1},
+						{Key: "deleted_at", Value: 1},
+					},
+				},
+				{
+					// チェンジストリームが使えない場合のポーリングで、更新日時とIDの順に変更をたどる
+					Keys: bson.D{
+						{Key: "updated_at", Value: 1},
+						{Key: "_id", Value: 1},
+					},
+				},
 			},
 		},
 		{
-			Keys: bson.D{
-				{Key: "sent_at", Value: -1},
-				{Key: "deleted_at", Value: 1},
+			// トークンコレクションのインデックス
+			collection: "tokens",
+			models: []mongo.IndexModel{
+				{
+					// 認証では全てのテナントから探すため、トークン文字列はテナントをまたいで一意とする
+					Keys: bson.D{
+						{Key: "token", Value: 1},
+						{Key: "deleted_at", Value: 1},
+					},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					Keys: bson.D{
+						{Key: "tenant_id", Value: 1},
+						{Key: "expires_at", Value: 1},
+						{Key: "deleted_at", Value: 1},
+					},
+				},
+				{
+					Keys: bson.D{
+						{Key: "tenant_id", Value: 1},
+						{Key: "created_at", Value: -1},
+						{Key: "deleted_at", Value: 1},
+					},
+				},
+				{
+					// 有効期限を過ぎたトークンを削除する
+					Keys:    bson.D{{Key: "expires_at", Value: 1}},
+					Options: options.Index().SetExpireAfterSeconds(0),
+				},
 			},
 		},
 	}
+}
 
-	// トークンコレクションのインデックス
-	tokenIndexes := []mongo.IndexModel{
-		{
-			Keys: bson.D{
-				{Key: "token", Value: 1},
-				{Key: "deleted_at", Value: 1},
-			},
-			Options: options.Index().SetUnique(true),
-		},
-		{
-			Keys: bson.D{
-				{Key: "expires_at", Value: 1},
-				{Key: "deleted_at", Value: 1},
-			},
-		},
-		{
-			Keys: bson.D{
-				{Key: "created_at", Value: -1},
-				{Key: "deleted_at", Value: 1},
-			},
-		},
+// CreateIndexes は宣言された全てのインデックスを作成する
+func CreateIndexes(ctx context.Context, db Database) error {
+	for _, declared := range declaredIndexes() {
+		if _, err := db.Collection(declared.collection).Indexes().CreateMany(ctx, declared.models); err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+// IndexReport はコレクションごとの宣言と実際のインデックスの差分
+type IndexReport struct {
+	Collection string
+	// Missing は宣言されているが存在しないインデックス
+	Missing []string
+	// Conflicting はキーは一致するがオプション（unique、TTL）が宣言と異なるインデックス
+	Conflicting []string
+	// Extra は存在するが宣言されていないインデックス
+	Extra []string
+	// Created は同期によって作成されたインデックス
+	Created []string
+}
+
+// HasDrift は宣言と実際のインデックスに差分があるかを返す
+func (r IndexReport) HasDrift() bool {
+	return len(r.Missing) > 0 || len(r.Conflicting) > 0 || len(r.Extra) > 0
+}
+
+// CheckIndexes は宣言と実際のインデックスを比較し、差分を返す
+// インデックスの作成は行わない
+func CheckIndexes(ctx context.Context, db Database) ([]IndexReport, error) {
+	return syncIndexes(ctx, db, false)
+}
 
-	// メッセージインデックスの作成
-	_, err := db.Collection("messages").Indexes().CreateMany(ctx, messageIndexes)
+// SyncIndexes は存在しないインデックスを作成し、差分を返す
+// オプションが異なるインデックスや宣言されていないインデックスは削除・再作成せず、報告のみ行う
+func SyncIndexes(ctx context.Context, db Database) ([]IndexReport, error) {
+	return syncIndexes(ctx, db, true)
+}
+
+// VerifyIndexes は宣言されたインデックスが全て存在するかを確認する
+// オプションの違いや宣言されていないインデックスはクエリの実行を妨げないため、エラーとしない
+func VerifyIndexes(ctx context.Context, db Database) error {
+	reports, err := CheckIndexes(ctx, db)
 	if err != nil {
 		return err
 	}
+	var errs []error
+	for _, report := range reports {
+		if len(report.Missing) > 0 {
+			errs = append(errs, fmt.Errorf("missing indexes on %s: %v", report.Collection, report.Missing))
+		}
+	}
+	return errors.Join(errs...)
+}
 
-	// トークンインデックスの作成
-	_, err = db.Collection("tokens").Indexes().CreateMany(ctx, tokenIndexes)
-	if err != nil {
-		return err
+func syncIndexes(ctx context.Context, db Database, create bool) ([]IndexReport, error) {
+	reports := make([]IndexReport, 0, len(declaredIndexes()))
+	for _, declared := range declaredIndexes() {
+		view := db.Collection(declared.collection).Indexes()
+		specs, err := view.ListSpecifications(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list indexes of %s: %w", declared.collection, err)
+		}
+
+		report := diffIndexes(declared, specs)
+		if create && len(report.Missing) > 0 {
+			var missing []mongo.IndexModel
+			for _, model := range declared.models {
+				if slices.Contains(report.Missing, indexName(model.Keys.(bson.D))) {
+					missing = append(missing, model)
+				}
+			}
+			created, err := view.CreateMany(ctx, missing)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create indexes on %s: %w", declared.collection, err)
+			}
+			report.Created = created
+			report.Missing = nil
+		}
+		reports = append(reports, report)
 	}
+	return reports, nil
+}
 
-	return nil
+// diffIndexes はキー構成で宣言と実際のインデックスを突き合わせる
+func diffIndexes(declared collectionIndexes, specs []*mongo.IndexSpecification) IndexReport {
+	report := IndexReport{Collection: declared.collection}
+
+	actual := make(map[string]*mongo.IndexSpecification, len(specs))
+	for _, spec := range specs {
+		actual[specKeyName(spec.KeysDocument)] = spec
+	}
+
+	known := make(map[string]bool, len(declared.models))
+	for _, model := range declared.models {
+		name := indexName(model.Keys.(bson.D))
+		known[name] = true
+
+		spec, ok := actual[name]
+		if !ok {
+			report.Missing = append(report.Missing, name)
+			continue
+		}
+		if !sameOptions(model.Options, spec) {
+			report.Conflicting = append(report.Conflicting, spec.Name)
+		}
+	}
+
+	for _, spec := range specs {
+		// _idインデックスはMongoDBが自動で作成する
+		if spec.Name == "_id_" || known[specKeyName(spec.KeysDocument)] {
+			continue
+		}
+		report.Extra = append(report.Extra, spec.Name)
+	}
+	return report
+}
+
+// sameOptions はunique・TTLの設定が宣言と一致するかを返す
+func sameOptions(opts *options.IndexOptions, spec *mongo.IndexSpecification) bool {
+	var unique bool
+	var expireAfter *int32
+	if opts != nil {
+		unique = opts.Unique != nil && *opts.Unique
+		expireAfter = opts.ExpireAfterSeconds
+	}
+	if unique != (spec.Unique != nil && *spec.Unique) {
+		return false
+	}
+	if (expireAfter == nil) != (spec.ExpireAfterSeconds == nil) {
+		return false
+	}
+	return expireAfter == nil || *expireAfter == *spec.ExpireAfterSeconds
+}
+
+// indexName はキー構成からMongoDBの既定と同じ形式のインデックス名を生成する
+func indexName(keys bson.D) string {
+	parts := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		parts = append(parts, key.Key, fmt.Sprint(key.Value))
+	}
+	return strings.Join(parts, "_")
+}
+
+// specKeyName は実際のインデックスのキー構成をindexNameと同じ形式に変換する
+// インデックス名は任意に付けられるため、名前ではなくキー構成で比較する
+func specKeyName(keys bson.Raw) string {
+	elems, err := keys.Elements()
+	if err != nil {
+		return ""
+	}
+	parts := make([]string, 0, len(elems)*2)
+	for _, elem := range elems {
+		value := elem.Value()
+		var v string
+		if n, ok := value.AsInt64OK(); ok {
+			v = strconv.FormatInt(n, 10)
+		} else if str, ok := value.StringValueOK(); ok {
+			v = str
+		} else {
+			v = value.String()
+		}
+		parts = append(parts, elem.Key(), v)
+	}
+	return strings.Join(parts, "_")
 }
@@ -21,6 +21,12 @@ func (m *mockIndexView) CreateMany(ctx context.Context, models []mongo.IndexMode
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *mockIndexView) ListSpecifications(ctx context.Context, opts ...*options.ListIndexesOptions) ([]*mongo.IndexSpecification, error) {
+	args := m.Called(ctx)
+	specs, _ := args.Get(0).([]*mongo.IndexSpecification)
+	return specs, args.Error(1)
+}
+
 // モックコレクション
 type mockCollection struct {
 	mock.Mock
@@ -122,31 +128,38 @@ func TestCreateIndexes(t *testing.T) {
 				tokensCol.On("Indexes").Return(tokensIndexView)
 
 				messagesIndexView.On("CreateMany", mock.Anything, mock.MatchedBy(func(models []mongo.IndexModel) bool {
-					return len(models) == 4 // メッセージコレクションのインデックス数
-				})).Return([]string{"index1", "index2", "index3", "index4"}, nil)
+					return len(models) == 8 // メッセージコレクションのインデックス数
+				})).Return([]string{"index1", "index2", "index3", "index4", "index5", "index6", "index7", "index8"}, nil)
 
 				tokensIndexView.On("CreateMany", mock.Anything, mock.MatchedBy(func(models []mongo.IndexModel) bool {
-					return len(models) == 3 // トークンコレクションのインデックス数
-				})).Return([]string{"index1", "index2", "index3"}, nil)
+					return len(models) == 4 // トークンコレクションのインデックス数
+				})).Return([]string{"index1", "index2", "index3", "index4"}, nil)
 
 				return db, messagesCol, tokensCol, messagesIndexView, tokensIndexView
 			},
 			wantErr: false,
 			validateIndex: func(t *testing.T, models []mongo.IndexModel) {
 				// メッセージコレクションのインデックス構造を確認
-				if len(models) == 4 {
-					// UIDとdeleted_atの複合ユニークインデックス
-					assert.Equal(t, bson.D{{Key: "uid", Value: 1}, {Key: "deleted_at", Value: 1}}, models[0].Keys)
+				if len(models) == 8 {
+					// tenant_id、UID、deleted_atの複合ユニークインデックス
+					assert.Equal(t, bson.D{{Key: "tenant_id", Value: 1}, {Key: "uid", Value: 1}, {Key: "deleted_at", Value: 1}}, models[0].Keys)
 					assert.True(t, models[0].Options.Unique != nil && *models[0].Options.Unique)
 
-					// channel_idとdeleted_atの複合インデックス
-					assert.Equal(t, bson.D{{Key: "channel_id", Value: 1}, {Key: "deleted_at", Value: 1}}, models[1].Keys)
+					// tenant_id、channel_id、deleted_atの複合インデックス
+					assert.Equal(t, bson.D{{Key: "tenant_id", Value: 1}, {Key: "channel_id", Value: 1}, {Key: "deleted_at", Value: 1}}, models[1].Keys)
+
+					// tenant_id、sender、deleted_atの複合インデックス
+					assert.Equal(t, bson.D{{Key: "tenant_id", Value: 1}, {Key: "sender", Value: 1}, {Key: "deleted_at", Value: 1}}, models[2].Keys)
 
-					// senderとdeleted_atの複合インデックス
-					assert.Equal(t, bson.D{{Key: "sender", Value: 1}, {Key: "deleted_at", Value: 1}}, models[2].Keys)
+					// tenant_id、sent_at（降順）、deleted_atの複合インデックス
+					assert.Equal(t, bson.D{{Key: "tenant_id", Value: 1}, {Key: "sent_at", Value: -1}, {Key: "deleted_at", Value: 1}}, models[3].Keys)
 
-					// sent_atとdeleted_atの複合インデックス（降順）
-					assert.Equal(t, bson.D{{Key: "sent_at", Value: -1}, {Key: "deleted_at", Value: 1}}, models[3].Keys)
+					// expires_atのTTLインデックス
+					assert.Equal(t, bson.D{{Key: "expires_at", Value: 1}}, models[4].Keys)
+					assert.Equal(t, int32(0), *models[4].Options.ExpireAfterSeconds)
+
+					// tenant_id、channel_id、sent_at、_idの複合インデックス
+					assert.Equal(t, bson.D{{Key: "tenant_id", Value: 1}, {Key: "channel_id", Value: 1}, {Key: "sent_at", Value: 1}, {Key: "_id", Value: 1}}, models[5].Keys)
 				}
 			},
 		},
@@ -209,6 +222,228 @@ func TestCreateIndexes(t *testing.T) {
 	}
 }
 
+// testSpec はテスト用のインデックス定義を作成する
+func testSpec(name string, keys bson.D, unique bool) *mongo.IndexSpecification {
+	raw, _ := bson.Marshal(keys)
+	spec := &mongo.IndexSpecification{Name: name, KeysDocument: raw}
+	if unique {
+		spec.Unique = &unique
+	}
+	return spec
+}
+
+// ttlSpec はテスト用のexpires_atのTTLインデックス定義を作成する
+func ttlSpec(name string, expireAfter int32) *mongo.IndexSpecification {
+	spec := testSpec(name, bson.D{{Key: "expires_at", Value: int32(1)}}, false)
+	spec.ExpireAfterSeconds = &expireAfter
+	return spec
+}
+
+// 宣言通りのトークンコレクションのインデックス
+func tokenSpecs() []*mongo.IndexSpecification {
+	return []*mongo.IndexSpecification{
+		testSpec("_id_", bson.D{{Key: "_id", Value: int32(1)}}, false),
+		testSpec("token_1_deleted_at_1", bson.D{{Key: "token", Value: int32(1)}, {Key: "deleted_at", Value: int32(1)}}, true),
+		testSpec("tenant_id_1_expires_at_1_deleted_at_1", bson.D{{Key: "tenant_id", Value: int32(1)}, {Key: "expires_at", Value: int32(1)}, {Key: "deleted_at", Value: int32(1)}}, false),
+		testSpec("tenant_id_1_created_at_-1_deleted_at_1", bson.D{{Key: "tenant_id", Value: int32(1)}, {Key: "created_at", Value: int32(-1)}, {Key: "deleted_at", Value: int32(1)}}, false),
+		ttlSpec("expires_at_1", 0),
+	}
+}
+
+func TestSyncIndexes(t *testing.T) {
+	tests := []struct {
+		name           string
+		messageSpecs   []*mongo.IndexSpecification
+		createErr      error
+		wantCreate     int
+		wantErr        bool
+		wantMessageRep IndexReport
+	}{
+		{
+			name:         "正常系：インデックスが無い場合は全て作成される",
+			messageSpecs: []*mongo.IndexSpecification{testSpec("_id_", bson.D{{Key: "_id", Value: int32(1)}}, false)},
+			wantCreate:   8,
+			wantMessageRep: IndexReport{
+				Collection: "messages",
+				Created:    []string{"tenant_id_1_uid_1_deleted_at_1", "tenant_id_1_channel_id_1_deleted_at_1", "tenant_id_1_sender_1_deleted_at_1", "tenant_id_1_sent_at_-1_deleted_at_1", "expires_at_1", "tenant_id_1_channel_id_1_sent_at_1__id_1", "tenant_id_1_reply_to_1_deleted_at_1", "updated_at_1__id_1"},
+			},
+		},
+		{
+			name: "正常系：不足しているインデックスのみ作成され、差分が報告される",
+			messageSpecs: []*mongo.IndexSpecification{
+				testSpec("_id_", bson.D{{Key: "_id", Value: int32(1)}}, false),
+				// 名前が異なってもキー構成で一致と判定する
+				testSpec("uid_unique", bson.D{{Key: "tenant_id", Value: int32(1)}, {Key: "uid", Value: int32(1)}, {Key: "deleted_at", Value: int32(1)}}, true),
+				// uniqueが宣言と異なる
+				testSpec("tenant_id_1_channel_id_1_deleted_at_1", bson.D{{Key: "tenant_id", Value: int32(1)}, {Key: "channel_id", Value: int32(1)}, {Key: "deleted_at", Value: int32(1)}}, true),
+				testSpec("tenant_id_1_sender_1_deleted_at_1", bson.D{{Key: "tenant_id", Value: 1.0}, {Key: "sender", Value: 1.0}, {Key: "deleted_at", Value: 1.0}}, false),
+				// TTLが宣言と異なる
+				ttlSpec("expires_at_1", 3600),
+				// 宣言されていないインデックス
+				testSpec("content_text", bson.D{{Key: "content", Value: "text"}}, false),
+			},
+			wantCreate: 4,
+			wantMessageRep: IndexReport{
+				Collection:  "messages",
+				Conflicting: []string{"tenant_id_1_channel_id_1_deleted_at_1", "expires_at_1"},
+				Extra:       []string{"content_text"},
+				Created:     []string{"tenant_id_1_sent_at_-1_deleted_at_1", "tenant_id_1_channel_id_1_sent_at_1__id_1", "tenant_id_1_reply_to_1_deleted_at_1", "updated_at_1__id_1"},
+			},
+		},
+		{
+			name:         "異常系：インデックスの作成に失敗",
+			messageSpecs: []*mongo.IndexSpecification{},
+			createErr:    assert.AnError,
+			wantCreate:   8,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := new(mockDatabase)
+			messagesCol := new(mockCollection)
+			tokensCol := new(mockCollection)
+			messagesIndexView := new(mockIndexView)
+			tokensIndexView := new(mockIndexView)
+
+			db.On("Collection", "messages").Return(messagesCol)
+			db.On("Collection", "tokens").Return(tokensCol)
+			messagesCol.On("Indexes").Return(messagesIndexView)
+			tokensCol.On("Indexes").Return(tokensIndexView)
+
+			messagesIndexView.On("ListSpecifications", mock.Anything).Return(tt.messageSpecs, nil)
+			tokensIndexView.On("ListSpecifications", mock.Anything).Return(tokenSpecs(), nil)
+			messagesIndexView.On("CreateMany", mock.Anything, mock.MatchedBy(func(models []mongo.IndexModel) bool {
+				return len(models) == tt.wantCreate
+			})).Return(tt.wantMessageRep.Created, tt.createErr)
+
+			reports, err := SyncIndexes(context.Background(), db)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []IndexReport{tt.wantMessageRep, {Collection: "tokens"}}, reports)
+			assert.Equal(t, len(tt.wantMessageRep.Conflicting) > 0 || len(tt.wantMessageRep.Extra) > 0, reports[0].HasDrift())
+			assert.False(t, reports[1].HasDrift())
+			// 宣言通りのコレクションではインデックスを作成しない
+			tokensIndexView.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCheckIndexes(t *testing.T) {
+	t.Run("正常系：インデックスを作成せずに差分を報告する", func(t *testing.T) {
+		db := new(mockDatabase)
+		messagesCol := new(mockCollection)
+		tokensCol := new(mockCollection)
+		messagesIndexView := new(mockIndexView)
+		tokensIndexView := new(mockIndexView)
+
+		db.On("Collection", "messages").Return(messagesCol)
+		db.On("Collection", "tokens").Return(tokensCol)
+		messagesCol.On("Indexes").Return(messagesIndexView)
+		tokensCol.On("Indexes").Return(tokensIndexView)
+		messagesIndexView.On("ListSpecifications", mock.Anything).Return([]*mongo.IndexSpecification{}, nil)
+		tokensIndexView.On("ListSpecifications", mock.Anything).Return(tokenSpecs(), nil)
+
+		reports, err := CheckIndexes(context.Background(), db)
+
+		assert.NoError(t, err)
+		assert.Len(t, reports, 2)
+		assert.Equal(t, []string{"tenant_id_1_uid_1_deleted_at_1", "tenant_id_1_channel_id_1_deleted_at_1", "tenant_id_1_sender_1_deleted_at_1", "tenant_id_1_sent_at_-1_deleted_at_1", "expires_at_1", "tenant_id_1_channel_id_1_sent_at_1__id_1", "tenant_id_1_reply_to_1_deleted_at_1", "updated_at_1__id_1"}, reports[0].Missing)
+		assert.True(t, reports[0].HasDrift())
+		assert.False(t, reports[1].HasDrift())
+		messagesIndexView.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系：インデックス一覧の取得に失敗", func(t *testing.T) {
+		db := new(mockDatabase)
+		messagesCol := new(mockCollection)
+		messagesIndexView := new(mockIndexView)
+
+		db.On("Collection", "messages").Return(messagesCol)
+		messagesCol.On("Indexes").Return(messagesIndexView)
+		messagesIndexView.On("ListSpecifications", mock.Anything).Return(nil, assert.AnError)
+
+		reports, err := CheckIndexes(context.Background(), db)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, reports)
+	})
+}
+
+func TestVerifyIndexes(t *testing.T) {
+	tests := []struct {
+		name          string
+		messageSpecs  []*mongo.IndexSpecification
+		listErr       error
+		expectedError string
+	}{
+		{
+			name:          "異常系：宣言されたインデックスが存在しない",
+			messageSpecs:  []*mongo.IndexSpecification{},
+			expectedError: "missing indexes on messages: [tenant_id_1_uid_1_deleted_at_1",
+		},
+		{
+			name:          "異常系：インデックス一覧の取得に失敗",
+			listErr:       assert.AnError,
+			expectedError: assert.AnError.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := new(mockDatabase)
+			messagesCol := new(mockCollection)
+			tokensCol := new(mockCollection)
+			messagesIndexView := new(mockIndexView)
+			tokensIndexView := new(mockIndexView)
+
+			db.On("Collection", "messages").Return(messagesCol)
+			db.On("Collection", "tokens").Return(tokensCol)
+			messagesCol.On("Indexes").Return(messagesIndexView)
+			tokensCol.On("Indexes").Return(tokensIndexView)
+			messagesIndexView.On("ListSpecifications", mock.Anything).Return(tt.messageSpecs, tt.listErr)
+			tokensIndexView.On("ListSpecifications", mock.Anything).Return(tokenSpecs(), nil)
+
+			err := VerifyIndexes(context.Background(), db)
+
+			assert.ErrorContains(t, err, tt.expectedError)
+			// 存在するtokensのインデックスはエラーに含まれない
+			assert.NotContains(t, err.Error(), "tokens")
+		})
+	}
+}
+
+func TestSameOptions(t *testing.T) {
+	ttl := int32(3600)
+	otherTTL := int32(60)
+	unique := true
+
+	tests := []struct {
+		name string
+		opts *options.IndexOptions
+		spec *mongo.IndexSpecification
+		want bool
+	}{
+		{name: "正常系：オプションなし", opts: nil, spec: &mongo.IndexSpecification{}, want: true},
+		{name: "正常系：uniqueが一致", opts: options.Index().SetUnique(true), spec: &mongo.IndexSpecification{Unique: &unique}, want: true},
+		{name: "異常系：uniqueが不一致", opts: options.Index().SetUnique(true), spec: &mongo.IndexSpecification{}, want: false},
+		{name: "正常系：TTLが一致", opts: options.Index().SetExpireAfterSeconds(ttl), spec: &mongo.IndexSpecification{ExpireAfterSeconds: &ttl}, want: true},
+		{name: "異常系：TTLが不一致", opts: options.Index().SetExpireAfterSeconds(ttl), spec: &mongo.IndexSpecification{ExpireAfterSeconds: &otherTTL}, want: false},
+		{name: "異常系：TTLが未設定", opts: options.Index().SetExpireAfterSeconds(ttl), spec: &mongo.IndexSpecification{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sameOptions(tt.opts, tt.spec))
+		})
+	}
+}
+
 func TestMongoDatabase_Collection(t *testing.T) {
 	// モックデータベースを作成
 	mockDB := new(mockDatabase)
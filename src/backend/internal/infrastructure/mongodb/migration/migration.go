@@ -0,0 +1,247 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	// ErrLocked は他のプロセスがマイグレーションを実行中の場合のエラー
+	ErrLocked = errors.New("migration is locked by another process")
+	// ErrIrreversible はDownが定義されていないマイグレーションを戻そうとした場合のエラー
+	ErrIrreversible = errors.New("migration is irreversible")
+	// ErrLockLost は実行中にロックを延長できなかった場合のエラー
+	// 他のプロセスがロックを取得している可能性があるため、実行中のマイグレーションを中断する
+	ErrLockLost = errors.New("migration lock was lost")
+)
+
+const (
+	// defaultLockTimeout はロックの有効期間
+	// 実行中のプロセスが異常終了した場合でも、この時間が経過すれば他のプロセスがロックを取得できる
+	defaultLockTimeout = time.Minute
+	// defaultRenewInterval はロックを延長する間隔
+	// 延長が一時的に遅れても有効期限が切れないよう、有効期間より十分に短くする
+	defaultRenewInterval = 20 * time.Second
+)
+
+// Migration はバージョン付きのスキーマ変更
+// 既存ドキュメントへのフィールドの追加など、インデックス定義だけでは表現できない変更に使う
+type Migration struct {
+	Version     int64
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+	Down        func(ctx context.Context, db *mongo.Database) error
+}
+
+// Status はマイグレーションの適用状況
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// store は適用履歴とロックの永続化先
+type store interface {
+	lock(ctx context.Context, owner string, timeout time.Duration) error
+	// renew は保持しているロックの有効期限を延長する。ロックを保持していない場合はErrLockLostを返す
+	renew(ctx context.Context, owner string, timeout time.Duration) error
+	unlock(ctx context.Context, owner string) error
+	applied(ctx context.Context) (map[int64]time.Time, error)
+	markApplied(ctx context.Context, m Migration, at time.Time) error
+	markReverted(ctx context.Context, version int64) error
+}
+
+// Migrator はマイグレーションをバージョン順に適用・巻き戻す
+type Migrator struct {
+	db          *mongo.Database
+	store       store
+	migrations  []Migration
+	owner       string
+	lockTimeout time.Duration
+	// renewInterval は実行中にロックを延長する間隔
+	renewInterval time.Duration
+}
+
+// NewMigrator は適用履歴をschema_migrationsコレクションに記録するMigratorを作成する
+func NewMigrator(db *mongo.Database, migrations []Migration) (*Migrator, error) {
+	if db == nil {
+		panic("database connection is required")
+	}
+	return newMigrator(db, newMongoStore(db), migrations)
+}
+
+func newMigrator(db *mongo.Database, s store, migrations []Migration) (*Migrator, error) {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	for i, m := range sorted {
+		if m.Version <= 0 {
+			return nil, fmt.Errorf("migration version must be positive: %d", m.Version)
+		}
+		if m.Up == nil {
+			return nil, fmt.Errorf("migration %d has no up step", m.Version)
+		}
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, fmt.Errorf("duplicate migration version: %d", m.Version)
+		}
+	}
+
+	hostname, _ := os.Hostname()
+	return &Migrator{
+		db:            db,
+		store:         s,
+		migrations:    sorted,
+		owner:         fmt.Sprintf("%s/%d/%s", hostname, os.Getpid(), primitive.NewObjectID().Hex()),
+		lockTimeout:   defaultLockTimeout,
+		renewInterval: defaultRenewInterval,
+	}, nil
+}
+
+// Up は未適用のマイグレーションをバージョン順に全て適用し、適用したものを返す
+// 途中で失敗した場合はそれ以降を適用せず、それまでに適用したものとエラーを返す
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(ctx context.Context, applied map[int64]time.Time) error {
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			if err := mig.Up(ctx, m.db); err != nil {
+				return fmt.Errorf("migration %d up: %w", mig.Version, err)
+			}
+			if err := m.store.markApplied(ctx, mig, time.Now()); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down は適用済みのマイグレーションを新しいものから順にsteps件巻き戻し、巻き戻したものを返す
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(ctx context.Context, applied map[int64]time.Time) error {
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if mig.Down == nil {
+				return fmt.Errorf("migration %d: %w", mig.Version, ErrIrreversible)
+			}
+			if err := mig.Down(ctx, m.db); err != nil {
+				return fmt.Errorf("migration %d down: %w", mig.Version, err)
+			}
+			if err := m.store.markReverted(ctx, mig.Version); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status は登録されている全てのマイグレーションの適用状況をバージョン順に返す
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.store.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		status := Status{Migration: mig}
+		if at, ok := applied[mig.Version]; ok {
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Pending は未適用のマイグレーションをバージョン順に返す
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			pending = append(pending, s.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// withLock はロックを取得した状態で適用履歴を読み込み、fnを実行する
+// 複数のレプリカが同時に起動しても、マイグレーションを実行するのは1つだけになる
+// 実行中は定期的にロックを延長し、延長に失敗した場合はfnに渡したコンテキストをキャンセルしてErrLockLostを返す
+func (m *Migrator) withLock(ctx context.Context, fn func(ctx context.Context, applied map[int64]time.Time) error) (err error) {
+	if err := m.store.lock(ctx, m.owner, m.lockTimeout); err != nil {
+		return err
+	}
+	defer func() {
+		// 呼び出し元のコンテキストがキャンセルされていてもロックは解放する
+		if unlockErr := m.store.unlock(context.WithoutCancel(ctx), m.owner); unlockErr != nil && err == nil {
+			err = unlockErr
+		}
+	}()
+
+	lockCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		m.renewLock(lockCtx, cancel)
+	}()
+	defer func() {
+		cancel(nil)
+		<-stopped
+		// 延長に失敗していれば、fnの結果に関わらずロックを失ったことを返す
+		if cause := context.Cause(lockCtx); errors.Is(cause, ErrLockLost) {
+			err = errors.Join(cause, err)
+		}
+	}()
+
+	applied, err := m.store.applied(lockCtx)
+	if err != nil {
+		return err
+	}
+	return fn(lockCtx, applied)
+}
+
+// renewLock はctxがキャンセルされるまでロックを定期的に延長する
+// 延長に失敗した場合はErrLockLostを原因としてcancelを呼ぶ
+func (m *Migrator) renewLock(ctx context.Context, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(m.renewInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.store.renew(ctx, m.owner, m.lockTimeout); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if !errors.Is(err, ErrLockLost) {
+					err = fmt.Errorf("%w: %v", ErrLockLost, err)
+				}
+				cancel(err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// recordingMigrations は実行順を記録するマイグレーションを作成する
+func recordingMigrations(calls *[]string, versions ...int64) []Migration {
+	migrations := make([]Migration, 0, len(versions))
+	for _, v := range versions {
+		v := v
+		migrations = append(migrations, Migration{
+			Version: v,
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				*calls = append(*calls, fmt.Sprintf("up%d", v))
+				return nil
+			},
+			Down: func(ctx context.Context, db *mongo.Database) error {
+				*calls = append(*calls, fmt.Sprintf("down%d", v))
+				return nil
+			},
+		})
+	}
+	return migrations
+}
+
+func TestNewMigrator(t *testing.T) {
+	noop := func(ctx context.Context, db *mongo.Database) error { return nil }
+
+	tests := []struct {
+		name       string
+		migrations []Migration
+		wantErr    bool
+	}{
+		{
+			name:       "正常系：バージョン順に並べ替えられる",
+			migrations: []Migration{{Version: 2, Up: noop}, {Version: 1, Up: noop}},
+		},
+		{
+			name:       "異常系：バージョンが重複",
+			migrations: []Migration{{Version: 1, Up: noop}, {Version: 1, Up: noop}},
+			wantErr:    true,
+		},
+		{
+			name:       "異常系：バージョンが0以下",
+			migrations: []Migration{{Version: 0, Up: noop}},
+			wantErr:    true,
+		},
+		{
+			name:       "異常系：Upが未定義",
+			migrations: []Migration{{Version: 1}},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := newMigrator(nil, newMockStore(), tt.migrations)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, m)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), m.migrations[0].Version)
+			assert.Equal(t, int64(2), m.migrations[1].Version)
+		})
+	}
+}
+
+func TestMigrator_Up(t *testing.T) {
+	t.Run("正常系：未適用のものだけがバージョン順に適用される", func(t *testing.T) {
+		var calls []string
+		store := newMockStore()
+		store.history[1] = time.Now()
+		m, _ := newMigrator(nil, store, recordingMigrations(&calls, 3, 1, 2))
+
+		done, err := m.Up(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"up2", "up3"}, calls)
+		assert.Len(t, done, 2)
+		assert.Len(t, store.history, 3)
+		assert.Empty(t, store.owner, "ロックが解放されていない")
+	})
+
+	t.Run("正常系：全て適用済みの場合は何もしない", func(t *testing.T) {
+		var calls []string
+		store := newMockStore()
+		m, _ := newMigrator(nil, store, recordingMigrations(&calls, 1))
+		_, _ = m.Up(context.Background())
+		calls = nil
+
+		done, err := m.Up(context.Background())
+
+		assert.NoError(t, err)
+		assert.Empty(t, done)
+		assert.Empty(t, calls)
+	})
+
+	t.Run("異常系：失敗したマイグレーション以降は適用されない", func(t *testing.T) {
+		var calls []string
+		migrations := recordingMigrations(&calls, 1, 2, 3)
+		migrations[1].Up = func(ctx context.Context, db *mongo.Database) error {
+			return errors.New("backfill failed")
+		}
+		store := newMockStore()
+		m, _ := newMigrator(nil, store, migrations)
+
+		done, err := m.Up(context.Background())
+
+		assert.ErrorContains(t, err, "migration 2 up")
+		assert.Equal(t, []string{"up1"}, calls)
+		assert.Len(t, done, 1)
+		assert.Len(t, store.history, 1)
+		assert.Empty(t, store.owner, "ロックが解放されていない")
+	})
+
+	t.Run("異常系：他のプロセスがロックを保持している", func(t *testing.T) {
+		var calls []string
+		store := newMockStore()
+		store.owner = "other"
+		m, _ := newMigrator(nil, store, recordingMigrations(&calls, 1))
+
+		done, err := m.Up(context.Background())
+
+		assert.ErrorIs(t, err, ErrLocked)
+		assert.Empty(t, done)
+		assert.Empty(t, calls)
+		assert.Equal(t, "other", store.owner, "他のプロセスのロックが解放された")
+	})
+
+	t.Run("正常系：実行中はロックを延長する", func(t *testing.T) {
+		store := newMockStore()
+		migrations := []Migration{{Version: 1, Up: func(ctx context.Context, db *mongo.Database) error {
+			time.Sleep(50 * time.Millisecond)
+			return nil
+		}}}
+		m, _ := newMigrator(nil, store, migrations)
+		m.renewInterval = 5 * time.Millisecond
+
+		done, err := m.Up(context.Background())
+
+		assert.NoError(t, err)
+		assert.Len(t, done, 1)
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		assert.Positive(t, store.renewCount)
+		assert.Empty(t, store.owner, "ロックが解放されていない")
+	})
+
+	t.Run("異常系：ロックの延長に失敗すると実行中のマイグレーションを中断する", func(t *testing.T) {
+		var calls []string
+		migrations := recordingMigrations(&calls, 1, 2)
+		migrations[0].Up = func(ctx context.Context, db *mongo.Database) error {
+			// 中断されるまで終わらない長時間のマイグレーション
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		store := newMockStore()
+		store.renewErr = errors.New("connection reset")
+		m, _ := newMigrator(nil, store, migrations)
+		m.renewInterval = 5 * time.Millisecond
+
+		done, err := m.Up(context.Background())
+
+		assert.ErrorIs(t, err, ErrLockLost)
+		assert.ErrorContains(t, err, "connection reset")
+		assert.ErrorContains(t, err, "migration 1 up")
+		assert.Empty(t, done)
+		assert.Empty(t, calls, "中断後に次のマイグレーションが適用された")
+		assert.Empty(t, store.history)
+		assert.True(t, store.unlockCalled)
+	})
+
+	t.Run("異常系：ロックを他のプロセスに奪われると中断する", func(t *testing.T) {
+		store := newMockStore()
+		migrations := []Migration{{Version: 1, Up: func(ctx context.Context, db *mongo.Database) error {
+			// 有効期限が切れて他のプロセスがロックを取得した状態にする
+			store.mu.Lock()
+			store.owner = "other"
+			store.mu.Unlock()
+			<-ctx.Done()
+			return ctx.Err()
+		}}}
+		m, _ := newMigrator(nil, store, migrations)
+		m.renewInterval = 5 * time.Millisecond
+
+		_, err := m.Up(context.Background())
+
+		assert.ErrorIs(t, err, ErrLockLost)
+		assert.Equal(t, "other", store.owner, "他のプロセスのロックが解放された")
+	})
+
+	t.Run("異常系：適用履歴の取得に失敗してもロックは解放される", func(t *testing.T) {
+		var calls []string
+		store := newMockStore()
+		store.appliedErr = errors.New("history error")
+		m, _ := newMigrator(nil, store, recordingMigrations(&calls, 1))
+
+		_, err := m.Up(context.Background())
+
+		assert.Error(t, err)
+		assert.True(t, store.unlockCalled)
+		assert.Empty(t, store.owner)
+	})
+}
+
+func TestMigrator_Down(t *testing.T) {
+	t.Run("正常系：新しいものから指定件数だけ巻き戻される", func(t *testing.T) {
+		var calls []string
+		store := newMockStore()
+		m, _ := newMigrator(nil, store, recordingMigrations(&calls, 1, 2, 3))
+		_, _ = m.Up(context.Background())
+		calls = nil
+
+		done, err := m.Down(context.Background(), 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"down3", "down2"}, calls)
+		assert.Len(t, done, 2)
+		assert.Len(t, store.history, 1)
+		assert.Contains(t, store.history, int64(1))
+	})
+
+	t.Run("正常系：未適用のものは巻き戻さない", func(t *testing.T) {
+		var calls []string
+		store := newMockStore()
+		store.history[1] = time.Now()
+		m, _ := newMigrator(nil, store, recordingMigrations(&calls, 1, 2))
+
+		done, err := m.Down(context.Background(), 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"down1"}, calls)
+		assert.Len(t, done, 1)
+		assert.Empty(t, store.history)
+	})
+
+	t.Run("異常系：Downが未定義", func(t *testing.T) {
+		var calls []string
+		migrations := recordingMigrations(&calls, 1)
+		migrations[0].Down = nil
+		store := newMockStore()
+		store.history[1] = time.Now()
+		m, _ := newMigrator(nil, store, migrations)
+
+		done, err := m.Down(context.Background(), 1)
+
+		assert.ErrorIs(t, err, ErrIrreversible)
+		assert.Empty(t, done)
+		assert.Contains(t, store.history, int64(1))
+	})
+}
+
+func TestMigrator_Status(t *testing.T) {
+	var calls []string
+	appliedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := newMockStore()
+	store.history[1] = appliedAt
+	m, _ := newMigrator(nil, store, recordingMigrations(&calls, 1, 2))
+
+	statuses, err := m.Status(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, int64(1), statuses[0].Version)
+	assert.Equal(t, &appliedAt, statuses[0].AppliedAt)
+	assert.Equal(t, int64(2), statuses[1].Version)
+	assert.Nil(t, statuses[1].AppliedAt)
+}
+
+func TestMigrator_Pending(t *testing.T) {
+	var calls []string
+	store := newMockStore()
+	store.history[1] = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.history[3] = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	m, _ := newMigrator(nil, store, recordingMigrations(&calls, 1, 2, 3, 4))
+
+	pending, err := m.Pending(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, pending, 2)
+	assert.Equal(t, int64(2), pending[0].Version)
+	assert.Equal(t, int64(4), pending[1].Version)
+	assert.Empty(t, calls)
+}
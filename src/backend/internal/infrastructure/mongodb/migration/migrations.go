@@ -0,0 +1,9 @@
+package migration
+
+// Migrations はサービスに登録されているマイグレーションを返す
+// 新しいマイグレーションは既存のものより大きいバージョンで追加し、適用済みのものは変更しない
+func Migrations() []Migration {
+	return []Migration{
+		addTenants,
+	}
+}
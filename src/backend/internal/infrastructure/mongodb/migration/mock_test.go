@@ -0,0 +1,86 @@
+package migration
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// テスト用のインメモリ実装
+type mockStore struct {
+	mu           sync.Mutex
+	owner        string
+	history      map[int64]time.Time
+	lockErr      error
+	appliedErr   error
+	renewErr     error
+	renewCount   int
+	unlockCalled bool
+}
+
+func newMockStore() *mockStore {
+	return &mockStore{history: make(map[int64]time.Time)}
+}
+
+func (s *mockStore) lock(ctx context.Context, owner string, timeout time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lockErr != nil {
+		return s.lockErr
+	}
+	if s.owner != "" {
+		return ErrLocked
+	}
+	s.owner = owner
+	return nil
+}
+
+func (s *mockStore) renew(ctx context.Context, owner string, timeout time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.renewCount++
+	if s.renewErr != nil {
+		return s.renewErr
+	}
+	if s.owner != owner {
+		return ErrLockLost
+	}
+	return nil
+}
+
+func (s *mockStore) unlock(ctx context.Context, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.unlockCalled = true
+	if s.owner == owner {
+		s.owner = ""
+	}
+	return nil
+}
+
+func (s *mockStore) applied(ctx context.Context) (map[int64]time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.appliedErr != nil {
+		return nil, s.appliedErr
+	}
+	applied := make(map[int64]time.Time, len(s.history))
+	for v, at := range s.history {
+		applied[v] = at
+	}
+	return applied, nil
+}
+
+func (s *mockStore) markApplied(ctx context.Context, m Migration, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history[m.Version] = at
+	return nil
+}
+
+func (s *mockStore) markReverted(ctx context.Context, version int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.history, version)
+	return nil
+}
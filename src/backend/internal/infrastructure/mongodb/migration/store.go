@@ -0,0 +1,104 @@
+package migration
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	migrationsCollection = "schema_migrations"
+	lockCollection       = "schema_migrations_lock"
+	lockID               = "lock"
+)
+
+// record はschema_migrationsコレクションのドキュメント
+type record struct {
+	Version     int64     `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// mongoStore は適用履歴とロックをMongoDBに保存する
+type mongoStore struct {
+	migrations *mongo.Collection
+	locks      *mongo.Collection
+}
+
+func newMongoStore(db *mongo.Database) *mongoStore {
+	return &mongoStore{
+		migrations: db.Collection(migrationsCollection),
+		locks:      db.Collection(lockCollection),
+	}
+}
+
+// lock はロック用のドキュメントを作成してロックを取得する
+// 有効期限切れのロックは上書きし、有効なロックが存在する場合は_idの重複によりErrLockedを返す
+func (s *mongoStore) lock(ctx context.Context, owner string, timeout time.Duration) error {
+	now := time.Now()
+	_, err := s.locks.UpdateOne(ctx,
+		bson.M{"_id": lockID, "expires_at": bson.M{"$lt": now}},
+		bson.M{"$set": bson.M{"owner": owner, "locked_at": now, "expires_at": now.Add(timeout)}},
+		options.Update().SetUpsert(true),
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrLocked
+	}
+	return err
+}
+
+// renew は自身が保持しているロックの有効期限を延長する
+// 有効期限が切れて他のプロセスに上書きされている場合はErrLockLostを返す
+func (s *mongoStore) renew(ctx context.Context, owner string, timeout time.Duration) error {
+	result, err := s.locks.UpdateOne(ctx,
+		bson.M{"_id": lockID, "owner": owner},
+		bson.M{"$set": bson.M{"expires_at": time.Now().Add(timeout)}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrLockLost
+	}
+	return nil
+}
+
+func (s *mongoStore) unlock(ctx context.Context, owner string) error {
+	_, err := s.locks.DeleteOne(ctx, bson.M{"_id": lockID, "owner": owner})
+	return err
+}
+
+func (s *mongoStore) applied(ctx context.Context) (map[int64]time.Time, error) {
+	cursor, err := s.migrations.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	var records []record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]time.Time, len(records))
+	for _, r := range records {
+		applied[r.Version] = r.AppliedAt
+	}
+	return applied, nil
+}
+
+func (s *mongoStore) markApplied(ctx context.Context, m Migration, at time.Time) error {
+	_, err := s.migrations.InsertOne(ctx, record{
+		Version:     m.Version,
+		Description: m.Description,
+		AppliedAt:   at,
+	})
+	return err
+}
+
+func (s *mongoStore) markReverted(ctx context.Context, version int64) error {
+	_, err := s.migrations.DeleteOne(ctx, bson.M{"_id": version})
+	return err
+}
@@ -0,0 +1,98 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// newIntegrationDatabase は実際のMongoDBにテストごとのデータベースを作成する
+// MONGODB_URIが設定されていない場合はテストをスキップする
+func newIntegrationDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+	uri := os.Getenv("MONGODB_URI")
+	if uri == "" {
+		t.Skip("MONGODB_URI is not set")
+	}
+
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetServerSelectionTimeout(5*time.Second))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	db := client.Database(fmt.Sprintf("migration_test_%s", primitive.NewObjectID().Hex()))
+	t.Cleanup(func() {
+		_ = db.Drop(ctx)
+		_ = client.Disconnect(ctx)
+	})
+	return db
+}
+
+func TestMongoStore_Lock(t *testing.T) {
+	db := newIntegrationDatabase(t)
+	ctx := context.Background()
+	s := newMongoStore(db)
+
+	assert.NoError(t, s.lock(ctx, "a", time.Minute))
+	// 保持しているプロセスだけが延長できる
+	assert.NoError(t, s.renew(ctx, "a", time.Minute))
+	assert.ErrorIs(t, s.renew(ctx, "b", time.Minute), ErrLockLost)
+	// 有効なロックは他のプロセスが取得できない
+	assert.ErrorIs(t, s.lock(ctx, "b", time.Minute), ErrLocked)
+	// 他のプロセスは解放できない
+	assert.NoError(t, s.unlock(ctx, "b"))
+	assert.ErrorIs(t, s.lock(ctx, "b", time.Minute), ErrLocked)
+
+	assert.NoError(t, s.unlock(ctx, "a"))
+	assert.NoError(t, s.lock(ctx, "b", -time.Second))
+	// 有効期限切れのロックは上書きでき、上書きされたプロセスは延長できない
+	assert.NoError(t, s.lock(ctx, "c", time.Minute))
+	assert.ErrorIs(t, s.renew(ctx, "b", time.Minute), ErrLockLost)
+}
+
+func TestMigrator_Integration(t *testing.T) {
+	db := newIntegrationDatabase(t)
+	ctx := context.Background()
+
+	migrations := []Migration{
+		{
+			Version:     1,
+			Description: "backfill name",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection("tokens").UpdateMany(ctx,
+					bson.M{"name": bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{"name": ""}})
+				return err
+			},
+			Down: func(ctx context.Context, db *mongo.Database) error {
+				return nil
+			},
+		},
+	}
+	m, err := NewMigrator(db, migrations)
+	assert.NoError(t, err)
+
+	done, err := m.Up(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, done, 1)
+
+	statuses, err := m.Status(ctx)
+	assert.NoError(t, err)
+	assert.NotNil(t, statuses[0].AppliedAt)
+
+	done, err = m.Down(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, done, 1)
+
+	statuses, err = m.Status(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, statuses[0].AppliedAt)
+}
@@ -0,0 +1,126 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// defaultTenantID は既存のデータを割り当てるテナント
+// マイグレーションは適用後に変更しないため、ドメインの定数を参照せずに値を固定する
+const defaultTenantID = "default"
+
+// indexNotFoundCode は削除するインデックスが存在しない場合のエラーコード
+const indexNotFoundCode = 27
+
+// tenantCollections はテナントに属するドキュメントを持つコレクション
+var tenantCollections = []string{"messages", "tokens", "audit_logs"}
+
+// tenantIndexChange はテナントの導入で置き換えるインデックス
+type tenantIndexChange struct {
+	collection string
+	global     bson.D // テナント導入前のキー
+	unique     bool
+}
+
+// tenantIndexChanges はtenant_idを先頭に加えて置き換えるインデックス
+// 置き換え後のインデックスは起動時の同期で作成される
+var tenantIndexChanges = []tenantIndexChange{
+	{collection: "messages", global: bson.D{{Key: "uid", Value: 1}, {Key: "deleted_at", Value: 1}}, unique: true},
+	{collection: "messages", global: bson.D{{Key: "channel_id", Value: 1}, {Key: "deleted_at", Value: 1}}},
+	{collection: "messages", global: bson.D{{Key: "sender", Value: 1}, {Key: "deleted_at", Value: 1}}},
+	{collection: "messages", global: bson.D{{Key: "sent_at", Value: -1}, {Key: "deleted_at", Value: 1}}},
+	{collection: "messages", global: bson.D{{Key: "channel_id", Value: 1}, {Key: "sent_at", Value: 1}, {Key: "_id", Value: 1}}},
+	{collection: "tokens", global: bson.D{{Key: "expires_at", Value: 1}, {Key: "deleted_at", Value: 1}}},
+	{collection: "tokens", global: bson.D{{Key: "created_at", Value: -1}, {Key: "deleted_at", Value: 1}}},
+}
+
+// withTenant はキーの先頭にtenant_idを加える
+func (c tenantIndexChange) withTenant() bson.D {
+	return append(bson.D{{Key: "tenant_id", Value: 1}}, c.global...)
+}
+
+// addTenants は既存のデータを既定のテナントに割り当て、UIDの一意性をテナントごとに変更する
+var addTenants = Migration{
+	Version:     1,
+	Description: "assign existing data to the default tenant and scope indexes by tenant_id",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		now := time.Now()
+		if _, err := db.Collection("tenants").UpdateOne(ctx,
+			bson.M{"_id": defaultTenantID},
+			bson.M{"$setOnInsert": bson.M{
+				"name":       "Default",
+				"quota":      bson.M{"max_messages": 0, "max_tokens": 0},
+				"created_at": now,
+				"updated_at": now,
+			}},
+			options.Update().SetUpsert(true)); err != nil {
+			return err
+		}
+
+		for _, name := range tenantCollections {
+			if _, err := db.Collection(name).UpdateMany(ctx,
+				bson.M{"tenant_id": bson.M{"$exists": false}},
+				bson.M{"$set": bson.M{"tenant_id": defaultTenantID}}); err != nil {
+				return err
+			}
+		}
+
+		// 一意性が途切れないよう、新しいインデックスを作成してから古いものを削除する
+		for _, change := range tenantIndexChanges {
+			if err := createIndex(ctx, db, change.collection, change.withTenant(), change.unique); err != nil {
+				return err
+			}
+			if err := dropIndex(ctx, db, change.collection, change.global); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	// 他のテナントと重複するUIDがある場合は、テナントをまたぐ一意インデックスを作成できずに失敗する
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		for _, change := range tenantIndexChanges {
+			if err := createIndex(ctx, db, change.collection, change.global, change.unique); err != nil {
+				return err
+			}
+			if err := dropIndex(ctx, db, change.collection, change.withTenant()); err != nil {
+				return err
+			}
+		}
+
+		for _, name := range tenantCollections {
+			if _, err := db.Collection(name).UpdateMany(ctx,
+				bson.M{"tenant_id": bson.M{"$exists": true}},
+				bson.M{"$unset": bson.M{"tenant_id": ""}}); err != nil {
+				return err
+			}
+		}
+		return db.Collection("tenants").Drop(ctx)
+	},
+}
+
+func createIndex(ctx context.Context, db *mongo.Database, collection string, keys bson.D, unique bool) error {
+	model := mongo.IndexModel{Keys: keys}
+	if unique {
+		model.Options = options.Index().SetUnique(true)
+	}
+	_, err := db.Collection(collection).Indexes().CreateOne(ctx, model)
+	return err
+}
+
+// dropIndex はキーの構成が一致するインデックスを削除する。存在しない場合は何もしない
+func dropIndex(ctx context.Context, db *mongo.Database, collection string, keys bson.D) error {
+	err := db.RunCommand(ctx, bson.D{
+		{Key: "dropIndexes", Value: collection},
+		{Key: "index", Value: keys},
+	}).Err()
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == indexNotFoundCode {
+		return nil
+	}
+	return err
+}
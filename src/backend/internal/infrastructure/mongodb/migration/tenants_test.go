@@ -0,0 +1,58 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMigrations(t *testing.T) {
+	// 登録されているマイグレーションはバージョンが一意で、Upが定義されている
+	_, err := newMigrator(nil, newMockStore(), Migrations())
+
+	assert.NoError(t, err)
+}
+
+func TestAddTenants(t *testing.T) {
+	db := newIntegrationDatabase(t)
+	ctx := context.Background()
+	messages := db.Collection("messages")
+	_, err := messages.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "uid", Value: 1}, {Key: "deleted_at", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = messages.InsertMany(ctx, []interface{}{
+		bson.M{"uid": "legacy"},
+		bson.M{"uid": "assigned", "tenant_id": "team-a"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, addTenants.Up(ctx, db))
+	// 2回目の適用でも失敗しない
+	assert.NoError(t, addTenants.Up(ctx, db))
+
+	var legacy, assigned bson.M
+	assert.NoError(t, messages.FindOne(ctx, bson.M{"uid": "legacy"}).Decode(&legacy))
+	assert.NoError(t, messages.FindOne(ctx, bson.M{"uid": "assigned"}).Decode(&assigned))
+	assert.Equal(t, defaultTenantID, legacy["tenant_id"])
+	assert.Equal(t, "team-a", assigned["tenant_id"])
+
+	count, err := db.Collection("tenants").CountDocuments(ctx, bson.M{"_id": defaultTenantID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// UIDはテナントごとに一意になる
+	_, err = messages.InsertOne(ctx, bson.M{"uid": "legacy", "tenant_id": "team-a"})
+	assert.NoError(t, err)
+	_, err = messages.InsertOne(ctx, bson.M{"uid": "legacy", "tenant_id": defaultTenantID})
+	assert.True(t, mongo.IsDuplicateKeyError(err))
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"message-service/internal/domain/audit"
+	"message-service/internal/domain/tenant"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type AuditRepository struct {
+	collection MongoCollectionInterface
+}
+
+func NewAuditRepository(db *mongo.Database) audit.Repository {
+	if db == nil {
+		panic("database connection is required")
+	}
+	collection := db.Collection("audit_logs")
+	if collection == nil {
+		panic("failed to get audit_logs collection")
+	}
+	return &AuditRepository{
+		collection: NewMongoCollectionWrapper(collection),
+	}
+}
+
+func (r *AuditRepository) Create(ctx context.Context, log *audit.Log) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if log.CreatedAt.IsZero() {
+		log.CreatedAt = time.Now()
+	}
+	log.TenantID = tenantID
+
+	result, err := r.collection.InsertOne(ctx, log)
+	if err != nil {
+		return err
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		log.ID = id
+	}
+	return nil
+}
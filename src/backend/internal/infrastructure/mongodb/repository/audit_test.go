@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"errors"
+	"message-service/internal/domain/audit"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestNewAuditRepository(t *testing.T) {
+	t.Run("異常系：DB接続がnil", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewAuditRepository(nil)
+		})
+	})
+}
+
+func TestAuditRepository_Create(t *testing.T) {
+	insertedID := primitive.NewObjectID()
+
+	tests := []struct {
+		name    string
+		mockFn  func(*TestCollection)
+		wantErr bool
+	}{
+		{
+			name: "正常系：監査ログの作成",
+			mockFn: func(m *TestCollection) {
+				m.On("InsertOne", mock.Anything, mock.AnythingOfType("*audit.Log")).
+					Return(&mongo.InsertOneResult{InsertedID: insertedID}, nil)
+			},
+		},
+		{
+			name: "異常系：データベースエラー",
+			mockFn: func(m *TestCollection) {
+				m.On("InsertOne", mock.Anything, mock.AnythingOfType("*audit.Log")).
+					Return(nil, errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mockCollection := NewTestAuditRepository()
+			tt.mockFn(mockCollection)
+
+			log := &audit.Log{
+				Action:   audit.ActionMessageBulkDelete,
+				Criteria: map[string]string{"channel_id": "test-channel"},
+				Affected: 2,
+			}
+			err := repo.Create(testContext(), log)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, insertedID, log.ID)
+				assert.NotZero(t, log.CreatedAt)
+			}
+			mockCollection.AssertExpectations(t)
+		})
+	}
+}
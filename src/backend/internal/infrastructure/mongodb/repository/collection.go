@@ -4,10 +4,18 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"sync"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // SingleResult はドキュメント取得結果の操作に必要なメソッドを定義するインターフェース
@@ -21,14 +29,20 @@ type CursorInterface interface {
 	Decode(val interface{}) error
 	Close(ctx context.Context) error
 	All(ctx context.Context, results interface{}) error
+	Err() error
 }
 
 // MongoCollectionInterface はmongoドライバーの必要なメソッドを定義するインターフェース
 type MongoCollectionInterface interface {
 	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
+	InsertMany(ctx context.Context, documents []interface{}, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error)
 	UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error)
 	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (CursorInterface, error)
 	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) SingleResult
+	FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) SingleResult
+	Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (CursorInterface, error)
 }
 
 // MongoCursorWrapper は実際のmongo.Cursorをラップする構造体
@@ -53,6 +67,10 @@ func (w *MongoCursorWrapper) All(ctx context.Context, results interface{}) error
 	return w.Cursor.All(ctx, results)
 }
 
+func (w *MongoCursorWrapper) Err() error {
+	return w.Cursor.Err()
+}
+
 // MongoCollectionAdapter は*mongo.CollectionをMongoCollectionInterfaceに適合させるアダプター
 type MongoCollectionAdapter struct {
 	coll *mongo.Collection
@@ -62,10 +80,22 @@ func (a *MongoCollectionAdapter) InsertOne(ctx context.Context, document interfa
 	return a.coll.InsertOne(ctx, document, opts...)
 }
 
+func (a *MongoCollectionAdapter) InsertMany(ctx context.Context, documents []interface{}, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
+	return a.coll.InsertMany(ctx, documents, opts...)
+}
+
 func (a *MongoCollectionAdapter) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
 	return a.coll.UpdateOne(ctx, filter, update, opts...)
 }
 
+func (a *MongoCollectionAdapter) UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return a.coll.UpdateMany(ctx, filter, update, opts...)
+}
+
+func (a *MongoCollectionAdapter) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	return a.coll.CountDocuments(ctx, filter, opts...)
+}
+
 func (a *MongoCollectionAdapter) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (CursorInterface, error) {
 	cursor, err := a.coll.Find(ctx, filter, opts...)
 	if err != nil {
@@ -78,32 +108,179 @@ func (a *MongoCollectionAdapter) FindOne(ctx context.Context, filter interface{}
 	return a.coll.FindOne(ctx, filter, opts...)
 }
 
+func (a *MongoCollectionAdapter) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) SingleResult {
+	return a.coll.FindOneAndUpdate(ctx, filter, update, opts...)
+}
+
+func (a *MongoCollectionAdapter) Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (CursorInterface, error) {
+	cursor, err := a.coll.Aggregate(ctx, pipeline, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &MongoCursorWrapper{Cursor: cursor}, nil
+}
+
+// tracer はコレクションの操作ごとにスパンを作成する
+// グローバルなTracerProviderが設定されていない間は何も記録しない
+var tracer = otel.Tracer("message-service/internal/infrastructure/mongodb/repository")
+
 // MongoCollectionWrapper は実際のmongo.Collectionをラップする構造体
+// 操作ごとにトレースのスパンを作成する
 type MongoCollectionWrapper struct {
 	Collection MongoCollectionInterface
+	// name はスパンに記録するコレクション名
+	name string
+}
+
+// startSpan はコレクションの操作のスパンを開始する
+func (w *MongoCollectionWrapper) startSpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, operation+" "+w.name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemMongoDB,
+			semconv.DBCollectionName(w.name),
+			semconv.DBOperationName(operation),
+		),
+	)
+}
+
+// endSpan はエラーを記録してスパンを終了する
+// 該当なしは正常な結果として扱う
+// エラーはリクエストIDと対応付けられるよう、操作のコンテキストでログにも記録する
+// 一意制約違反は呼び出し元が処理する想定のためwarnとする
+func (w *MongoCollectionWrapper) endSpan(ctx context.Context, span trace.Span, operation string, err error) {
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		level := slog.LevelError
+		if mongo.IsDuplicateKeyError(err) {
+			level = slog.LevelWarn
+		}
+		slog.Log(ctx, level, "MongoDB operation failed",
+			slog.String("collection", w.name),
+			slog.String("db_operation", operation),
+			slog.String("error", err.Error()),
+		)
+	}
+	span.End()
 }
 
 func (w *MongoCollectionWrapper) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (CursorInterface, error) {
-	return w.Collection.Find(ctx, filter, opts...)
+	ctx, span := w.startSpan(ctx, "find")
+	cursor, err := w.Collection.Find(ctx, filter, opts...)
+	if err != nil {
+		w.endSpan(ctx, span, "find", err)
+		return nil, err
+	}
+	return w.traceCursor(ctx, span, "find", cursor), nil
 }
 
 func (w *MongoCollectionWrapper) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) SingleResult {
-	return w.Collection.FindOne(ctx, filter, opts...)
+	ctx, span := w.startSpan(ctx, "findOne")
+	result := w.Collection.FindOne(ctx, filter, opts...)
+	// mongo.SingleResultはFindOneの時点でクエリを実行済みで、結果のエラーを保持している
+	var err error
+	if r, ok := result.(interface{ Err() error }); ok {
+		err = r.Err()
+	}
+	w.endSpan(ctx, span, "findOne", err)
+	return result
+}
+
+func (w *MongoCollectionWrapper) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) SingleResult {
+	ctx, span := w.startSpan(ctx, "findAndModify")
+	result := w.Collection.FindOneAndUpdate(ctx, filter, update, opts...)
+	var err error
+	if r, ok := result.(interface{ Err() error }); ok {
+		err = r.Err()
+	}
+	w.endSpan(ctx, span, "findAndModify", err)
+	return result
 }
 
 func (w *MongoCollectionWrapper) FindByID(ctx context.Context, id interface{}, opts ...*options.FindOneOptions) SingleResult {
 	return w.FindOne(ctx, bson.M{"_id": id}, opts...)
 }
 
-func (w *MongoCollectionWrapper) InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+func (w *MongoCollectionWrapper) InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (result *mongo.InsertOneResult, err error) {
+	ctx, span := w.startSpan(ctx, "insert")
+	defer func() { w.endSpan(ctx, span, "insert", err) }()
 	return w.Collection.InsertOne(ctx, document, opts...)
 }
 
-func (w *MongoCollectionWrapper) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+func (w *MongoCollectionWrapper) InsertMany(ctx context.Context, documents []interface{}, opts ...*options.InsertManyOptions) (result *mongo.InsertManyResult, err error) {
+	ctx, span := w.startSpan(ctx, "insertMany")
+	span.SetAttributes(attribute.Int("db.operation.batch.size", len(documents)))
+	defer func() { w.endSpan(ctx, span, "insertMany", err) }()
+	return w.Collection.InsertMany(ctx, documents, opts...)
+}
+
+func (w *MongoCollectionWrapper) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (result *mongo.UpdateResult, err error) {
+	ctx, span := w.startSpan(ctx, "update")
+	defer func() { w.endSpan(ctx, span, "update", err) }()
 	return w.Collection.UpdateOne(ctx, filter, update, opts...)
 }
 
+func (w *MongoCollectionWrapper) UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (result *mongo.UpdateResult, err error) {
+	ctx, span := w.startSpan(ctx, "updateMany")
+	defer func() { w.endSpan(ctx, span, "updateMany", err) }()
+	return w.Collection.UpdateMany(ctx, filter, update, opts...)
+}
+
+func (w *MongoCollectionWrapper) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (count int64, err error) {
+	ctx, span := w.startSpan(ctx, "countDocuments")
+	defer func() { w.endSpan(ctx, span, "countDocuments", err) }()
+	return w.Collection.CountDocuments(ctx, filter, opts...)
+}
+
+func (w *MongoCollectionWrapper) Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (CursorInterface, error) {
+	ctx, span := w.startSpan(ctx, "aggregate")
+	cursor, err := w.Collection.Aggregate(ctx, pipeline, opts...)
+	if err != nil {
+		w.endSpan(ctx, span, "aggregate", err)
+		return nil, err
+	}
+	return w.traceCursor(ctx, span, "aggregate", cursor), nil
+}
+
+// tracedCursor は結果の読み出しが終わるまでスパンを継続するカーソル
+// 検索の所要時間の大半は結果の取得（getMore）にかかるため、AllまたはCloseでスパンを終了する
+type tracedCursor struct {
+	CursorInterface
+	finish func(err error)
+	once   sync.Once
+}
+
+// traceCursor はcursorの読み出しが終わったときにスパンを終了するカーソルを返す
+func (w *MongoCollectionWrapper) traceCursor(ctx context.Context, span trace.Span, operation string, cursor CursorInterface) CursorInterface {
+	return &tracedCursor{
+		CursorInterface: cursor,
+		finish:          func(err error) { w.endSpan(ctx, span, operation, err) },
+	}
+}
+
+func (c *tracedCursor) end(err error) {
+	c.once.Do(func() { c.finish(err) })
+}
+
+func (c *tracedCursor) All(ctx context.Context, results interface{}) error {
+	err := c.CursorInterface.All(ctx, results)
+	c.end(err)
+	return err
+}
+
+func (c *tracedCursor) Close(ctx context.Context) error {
+	err := c.CursorInterface.Close(ctx)
+	// 読み出し中のエラーはErrに残っている
+	if cursorErr := c.CursorInterface.Err(); cursorErr != nil {
+		c.end(cursorErr)
+	} else {
+		c.end(err)
+	}
+	return err
+}
+
 func NewMongoCollectionWrapper(coll *mongo.Collection) MongoCollectionInterface {
 	adapter := &MongoCollectionAdapter{coll: coll}
-	return &MongoCollectionWrapper{Collection: adapter}
+	return &MongoCollectionWrapper{Collection: adapter, name: coll.Name()}
 }
@@ -2,13 +2,24 @@
 package repository
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"log/slog"
+	"message-service/internal/config"
+	"message-service/internal/infrastructure/logging"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // モックの定義
@@ -36,6 +47,11 @@ func (m *mockMongoCursor) All(ctx context.Context, results interface{}) error {
 	return args.Error(0)
 }
 
+func (m *mockMongoCursor) Err() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
 // テストケース
 func TestMongoCursorWrapper_All(t *testing.T) {
 	ctx := context.Background()
@@ -78,7 +94,7 @@ func TestMongoCollectionWrapper_Find(t *testing.T) {
 		wrapper := &MongoCollectionWrapper{Collection: mockColl}
 
 		mockCursor := &mockMongoCursor{}
-		mockColl.On("Find", ctx, filter).Return(mockCursor, nil)
+		mockColl.On("Find", mock.Anything, filter).Return(mockCursor, nil)
 
 		cursor, err := wrapper.Find(ctx, filter)
 
@@ -92,7 +108,7 @@ func TestMongoCollectionWrapper_Find(t *testing.T) {
 		wrapper := &MongoCollectionWrapper{Collection: mockColl}
 
 		expectedErr := errors.New("find error")
-		mockColl.On("Find", ctx, filter).Return(nil, expectedErr)
+		mockColl.On("Find", mock.Anything, filter).Return(nil, expectedErr)
 
 		cursor, err := wrapper.Find(ctx, filter)
 
@@ -112,7 +128,7 @@ func TestMongoCollectionWrapper_FindOne(t *testing.T) {
 		wrapper := &MongoCollectionWrapper{Collection: mockColl}
 
 		expectedResult := NewTestSingleResult(&struct{}{}, nil)
-		mockColl.On("FindOne", ctx, filter).Return(expectedResult)
+		mockColl.On("FindOne", mock.Anything, filter).Return(expectedResult)
 
 		result := wrapper.FindOne(ctx, filter)
 
@@ -124,7 +140,7 @@ func TestMongoCollectionWrapper_FindOne(t *testing.T) {
 		mockColl := new(TestCollection)
 		wrapper := &MongoCollectionWrapper{Collection: mockColl}
 
-		mockColl.On("FindOne", ctx, filter).Return(NewTestSingleResult(nil, mongo.ErrNoDocuments))
+		mockColl.On("FindOne", mock.Anything, filter).Return(NewTestSingleResult(nil, mongo.ErrNoDocuments))
 
 		result := wrapper.FindOne(ctx, filter)
 
@@ -133,6 +149,22 @@ func TestMongoCollectionWrapper_FindOne(t *testing.T) {
 	})
 }
 
+func TestMongoCollectionWrapper_FindOneAndUpdate(t *testing.T) {
+	ctx := context.Background()
+	filter := map[string]interface{}{"key": "value"}
+	update := map[string]interface{}{"$set": map[string]interface{}{"field": 1}}
+
+	mockColl := new(TestCollection)
+	wrapper := &MongoCollectionWrapper{Collection: mockColl}
+
+	mockColl.On("FindOneAndUpdate", mock.Anything, filter, update).Return(NewTestSingleResult(&struct{}{}, nil))
+
+	result := wrapper.FindOneAndUpdate(ctx, filter, update)
+
+	assert.NotNil(t, result)
+	mockColl.AssertExpectations(t)
+}
+
 func TestMongoCollectionWrapper_FindByID(t *testing.T) {
 	ctx := context.Background()
 	id := "test_id"
@@ -142,7 +174,7 @@ func TestMongoCollectionWrapper_FindByID(t *testing.T) {
 		wrapper := &MongoCollectionWrapper{Collection: mockColl}
 
 		expectedResult := NewTestSingleResult(&struct{}{}, nil)
-		mockColl.On("FindOne", ctx, mock.Anything).Return(expectedResult)
+		mockColl.On("FindOne", mock.Anything, mock.Anything).Return(expectedResult)
 
 		result := wrapper.FindByID(ctx, id)
 
@@ -154,7 +186,7 @@ func TestMongoCollectionWrapper_FindByID(t *testing.T) {
 		mockColl := new(TestCollection)
 		wrapper := &MongoCollectionWrapper{Collection: mockColl}
 
-		mockColl.On("FindOne", ctx, mock.Anything).Return(NewTestSingleResult(nil, mongo.ErrNoDocuments))
+		mockColl.On("FindOne", mock.Anything, mock.Anything).Return(NewTestSingleResult(nil, mongo.ErrNoDocuments))
 
 		result := wrapper.FindByID(ctx, id)
 
@@ -210,6 +242,18 @@ func TestMongoCursorWrapper_Close(t *testing.T) {
 	mockCursor.AssertExpectations(t)
 }
 
+func TestMongoCursorWrapper_Err(t *testing.T) {
+	mockCursor := &mockMongoCursor{}
+	wrapper := &MongoCursorWrapper{Cursor: mockCursor}
+
+	mockCursor.On("Err").Return(errors.New("cursor error"))
+
+	err := wrapper.Err()
+
+	assert.EqualError(t, err, "cursor error")
+	mockCursor.AssertExpectations(t)
+}
+
 func TestMongoCollectionAdapter(t *testing.T) {
 	ctx := context.Background()
 	coll := &mongo.Collection{}
@@ -224,9 +268,175 @@ func TestMongoCollectionAdapter(t *testing.T) {
 	t.Run("メソッドの存在を確認", func(t *testing.T) {
 		assert.NotPanics(t, func() {
 			adapter.InsertOne(ctx, nil)
+			adapter.InsertMany(ctx, nil)
 			adapter.UpdateOne(ctx, nil, nil)
 			adapter.Find(ctx, nil)
 			adapter.FindOne(ctx, nil)
 		})
 	})
 }
+
+func TestMongoCollectionWrapper_InsertMany(t *testing.T) {
+	ctx := context.Background()
+	documents := []interface{}{"doc1", "doc2"}
+
+	mockColl := new(TestCollection)
+	wrapper := &MongoCollectionWrapper{Collection: mockColl}
+	mockColl.On("InsertMany", mock.Anything, documents).Return(&mongo.InsertManyResult{InsertedIDs: documents}, nil)
+
+	result, err := wrapper.InsertMany(ctx, documents)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.InsertedIDs, 2)
+	mockColl.AssertExpectations(t)
+}
+
+func TestMongoCollectionWrapper_UpdateMany(t *testing.T) {
+	ctx := context.Background()
+	filter := map[string]interface{}{"channel_id": "test-channel"}
+	update := map[string]interface{}{"$set": map[string]interface{}{"content": "updated"}}
+
+	mockColl := new(TestCollection)
+	wrapper := &MongoCollectionWrapper{Collection: mockColl}
+	mockColl.On("UpdateMany", mock.Anything, filter, update).Return(&mongo.UpdateResult{MatchedCount: 2, ModifiedCount: 2}, nil)
+
+	result, err := wrapper.UpdateMany(ctx, filter, update)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result.ModifiedCount)
+	mockColl.AssertExpectations(t)
+}
+
+func TestMongoCollectionWrapper_CountDocuments(t *testing.T) {
+	ctx := context.Background()
+	filter := map[string]interface{}{"channel_id": "test-channel"}
+
+	mockColl := new(TestCollection)
+	wrapper := &MongoCollectionWrapper{Collection: mockColl}
+	mockColl.On("CountDocuments", mock.Anything, filter).Return(int64(3), nil)
+
+	count, err := wrapper.CountDocuments(ctx, filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	mockColl.AssertExpectations(t)
+}
+
+func TestMongoCollectionWrapper_Tracing(t *testing.T) {
+	// グローバルなTracerProviderの差し替えはプロセスで最初の1回だけ反映されるため、
+	// 記録先は全てのケースで共有し、ケースごとに増えたスパンを確認する
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	ctx := context.Background()
+	filter := map[string]interface{}{"key": "value"}
+
+	tests := []struct {
+		name           string
+		run            func(wrapper *MongoCollectionWrapper, coll *TestCollection, cursor *mockMongoCursor)
+		expectedName   string
+		expectedStatus codes.Code
+	}{
+		{
+			name: "正常系：CountDocumentsのスパンを記録する",
+			run: func(wrapper *MongoCollectionWrapper, coll *TestCollection, cursor *mockMongoCursor) {
+				coll.On("CountDocuments", mock.Anything, filter).Return(int64(1), nil)
+				wrapper.CountDocuments(ctx, filter)
+			},
+			expectedName:   "countDocuments messages",
+			expectedStatus: codes.Unset,
+		},
+		{
+			name: "正常系：該当なしはエラーとして記録しない",
+			run: func(wrapper *MongoCollectionWrapper, coll *TestCollection, cursor *mockMongoCursor) {
+				coll.On("FindOne", mock.Anything, filter).Return(NewTestSingleResult(nil, mongo.ErrNoDocuments))
+				wrapper.FindOne(ctx, filter)
+			},
+			expectedName:   "findOne messages",
+			expectedStatus: codes.Unset,
+		},
+		{
+			name: "正常系：Findのスパンはカーソルを閉じるまで継続する",
+			run: func(wrapper *MongoCollectionWrapper, coll *TestCollection, cursor *mockMongoCursor) {
+				coll.On("Find", mock.Anything, filter).Return(cursor, nil)
+				cursor.On("Close", ctx).Return(nil)
+				cursor.On("Err").Return(nil)
+				ended := len(recorder.Ended())
+				result, _ := wrapper.Find(ctx, filter)
+				assert.Len(t, recorder.Ended(), ended, "カーソルを閉じる前にスパンが終了している")
+				result.Close(ctx)
+			},
+			expectedName:   "find messages",
+			expectedStatus: codes.Unset,
+		},
+		{
+			name: "異常系：エラーをスパンに記録する",
+			run: func(wrapper *MongoCollectionWrapper, coll *TestCollection, cursor *mockMongoCursor) {
+				coll.On("UpdateMany", mock.Anything, filter, filter).Return(nil, errors.New("update error"))
+				wrapper.UpdateMany(ctx, filter, filter)
+			},
+			expectedName:   "updateMany messages",
+			expectedStatus: codes.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(recorder.Ended())
+			coll := new(TestCollection)
+			wrapper := &MongoCollectionWrapper{Collection: coll, name: "messages"}
+
+			tt.run(wrapper, coll, &mockMongoCursor{})
+
+			spans := recorder.Ended()
+			if !assert.Len(t, spans, before+1) {
+				return
+			}
+			span := spans[before]
+			assert.Equal(t, tt.expectedName, span.Name())
+			assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+			assert.Contains(t, span.Attributes(), semconv.DBCollectionName("messages"))
+			assert.Equal(t, tt.expectedStatus, span.Status().Code)
+		})
+	}
+}
+
+func TestMongoCollectionWrapper_ErrorLog(t *testing.T) {
+	filter := map[string]interface{}{"key": "value"}
+	ctx := logging.ContextWithRequestID(context.Background(), "req-1")
+
+	tests := []struct {
+		name          string
+		countErr      error
+		expectedLevel string
+	}{
+		{name: "正常系：成功した操作は記録しない"},
+		{name: "異常系：リクエストIDとあわせて記録する", countErr: errors.New("connection reset"), expectedLevel: "ERROR"},
+		{name: "異常系：一意制約違反はwarnで記録する", countErr: mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}}, expectedLevel: "WARN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			defaultLogger := slog.Default()
+			slog.SetDefault(logging.New(&buf, config.LogConfig{Level: "info", Format: config.LogFormatJSON}))
+			defer slog.SetDefault(defaultLogger)
+
+			coll := new(TestCollection)
+			wrapper := &MongoCollectionWrapper{Collection: coll, name: "messages"}
+			coll.On("CountDocuments", mock.Anything, filter).Return(int64(0), tt.countErr)
+
+			_, _ = wrapper.CountDocuments(ctx, filter)
+
+			if tt.expectedLevel == "" {
+				assert.Empty(t, buf.String())
+				return
+			}
+			var line map[string]interface{}
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+			assert.Equal(t, tt.expectedLevel, line["level"])
+			assert.Equal(t, "req-1", line[logging.RequestIDKey])
+			assert.Equal(t, "messages", line["collection"])
+			assert.Equal(t, "countDocuments", line["db_operation"])
+		})
+	}
+}
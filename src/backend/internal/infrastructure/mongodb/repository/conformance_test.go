@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/domain/token"
+	"message-service/internal/infrastructure/mongodb"
+	"message-service/internal/repotest"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// skipWithoutMongoDB はMONGODB_URIが設定されていない場合にテストをスキップする
+func skipWithoutMongoDB(t *testing.T) string {
+	t.Helper()
+	uri := os.Getenv("MONGODB_URI")
+	if uri == "" {
+		t.Skip("MONGODB_URI is not set")
+	}
+	return uri
+}
+
+// newIntegrationDatabase は実際のMongoDBにテストごとのデータベースを作成する
+func newIntegrationDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+	uri := skipWithoutMongoDB(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("failed to connect to MongoDB: %v", err)
+	}
+	db := client.Database("repotest_" + primitive.NewObjectID().Hex())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+		_ = client.Disconnect(ctx)
+	})
+
+	// 重複の判定はユニークインデックスに依存する
+	if err := mongodb.CreateIndexes(ctx, mongodb.NewDatabase(db)); err != nil {
+		t.Fatalf("failed to create indexes: %v", err)
+	}
+	return db
+}
+
+func TestMessageRepository_Conformance(t *testing.T) {
+	skipWithoutMongoDB(t)
+	repotest.RunMessageRepositorySuite(t, func(t *testing.T) message.Repository {
+		return NewMessageRepository(newIntegrationDatabase(t))
+	})
+}
+
+func TestTokenRepository_Conformance(t *testing.T) {
+	skipWithoutMongoDB(t)
+	repotest.RunTokenRepositorySuite(t, func(t *testing.T) token.Repository {
+		return NewTokenRepository(newIntegrationDatabase(t))
+	})
+}
+
+func TestTenantRepository_Conformance(t *testing.T) {
+	skipWithoutMongoDB(t)
+	repotest.RunTenantRepositorySuite(t, func(t *testing.T) tenant.Repository {
+		repo := NewTenantRepository(newIntegrationDatabase(t))
+		// 既定のテナントは本来マイグレーションで作成する
+		if err := repo.Create(context.Background(), &tenant.Tenant{ID: tenant.DefaultID, Name: "Default"}); err != nil {
+			t.Fatalf("failed to create default tenant: %v", err)
+		}
+		return repo
+	})
+}
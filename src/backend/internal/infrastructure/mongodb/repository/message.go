@@ -2,14 +2,30 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+const (
+	// duplicateKeyCode はユニークインデックス違反のエラーコード
+	duplicateKeyCode = 11000
+	// iterateBatchSize はIterateでカーソルが1回に取得するドキュメント数
+	iterateBatchSize = 1000
+	// messageCollection はメッセージを保存するコレクション
+	messageCollection = "messages"
+)
+
+// MessageRepository はmessage.RepositoryのMongoDB実装
+// 全ての操作はコンテキストのテナントのメッセージのみを対象とする
 type MessageRepository struct {
 	collection MongoCollectionInterface
 }
@@ -18,7 +34,7 @@ func NewMessageRepository(db *mongo.Database) message.Repository {
 	if db == nil {
 		panic("database connection is required")
 	}
-	collection := db.Collection("messages")
+	collection := db.Collection(messageCollection)
 	if collection == nil {
 		panic("failed to get messages collection")
 	}
@@ -28,17 +44,74 @@ func NewMessageRepository(db *mongo.Database) message.Repository {
 }
 
 func (r *MessageRepository) Create(ctx context.Context, msg *message.Message) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
 	now := time.Now()
 	msg.CreatedAt = now
 	msg.UpdatedAt = now
+	msg.TenantID = tenantID
 
-	_, err := r.collection.InsertOne(ctx, msg)
+	_, err = r.collection.InsertOne(ctx, msg)
+	if mongo.IsDuplicateKeyError(err) {
+		return message.ErrDuplicateUID
+	}
 	return err
 }
 
+func (r *MessageRepository) CreateMany(ctx context.Context, msgs []*message.Message) ([]error, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	errs := make([]error, len(msgs))
+	if len(msgs) == 0 {
+		return errs, nil
+	}
+
+	now := time.Now()
+	documents := make([]interface{}, len(msgs))
+	for i, msg := range msgs {
+		msg.CreatedAt = now
+		msg.UpdatedAt = now
+		msg.TenantID = tenantID
+		documents[i] = msg
+	}
+
+	// 重複などで一部が失敗しても残りを挿入するため順序なしで書き込む
+	_, err = r.collection.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	if err == nil {
+		return errs, nil
+	}
+
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil || len(bulkErr.WriteErrors) == 0 {
+		return nil, err
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Index < 0 || writeErr.Index >= len(errs) {
+			continue
+		}
+		if writeErr.Code == duplicateKeyCode {
+			errs[writeErr.Index] = message.ErrDuplicateUID
+		} else {
+			errs[writeErr.Index] = errors.New(writeErr.Message)
+		}
+	}
+	return errs, nil
+}
+
 func (r *MessageRepository) Delete(ctx context.Context, uid string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
 	now := time.Now()
-	filter := bson.M{"uid": uid, "deleted_at": nil}
+	filter := activeFilter(tenantID, now)
+	filter["uid"] = uid
 	update := bson.M{"$set": bson.M{
 		"deleted_at": now,
 		"updated_at": now,
@@ -55,14 +128,430 @@ func (r *MessageRepository) Delete(ctx context.Context, uid string) error {
 	return nil
 }
 
-func (r *MessageRepository) Search(ctx context.Context, criteria message.SearchCriteria) ([]message.Message, error) {
-	filter := bson.M{"deleted_at": nil}
+func (r *MessageRepository) Search(ctx context.Context, criteria message.SearchCriteria, opts message.SearchOptions) ([]message.Message, error) {
+	var projection bson.D
+	if len(opts.Fields) > 0 {
+		// 本文などの大きなフィールドを読み出さないよう、指定されたフィールドのみを取得する
+		for _, field := range opts.Fields {
+			projection = append(projection, bson.E{Key: field, Value: 1})
+		}
+	}
+	filter, thread, err := r.searchQuery(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+	if opts.After != nil {
+		filter = bson.M{"$and": bson.A{filter, cursorFilter(opts.After)}}
+	}
+
+	if len(thread) > 0 {
+		// 並べ替えてから返信を探し、件数に達した時点で打ち切る
+		pipeline := mongo.Pipeline{
+			{{Key: "$match", Value: filter}},
+			{{Key: "$sort", Value: sortSpec(opts.Sort)}},
+		}
+		pipeline = append(pipeline, thread...)
+		if opts.Limit > 0 {
+			pipeline = append(pipeline, bson.D{{Key: "$limit", Value: int64(opts.Limit)}})
+		}
+		if projection != nil {
+			pipeline = append(pipeline, bson.D{{Key: "$project", Value: projection}})
+		}
+		return r.aggregate(ctx, pipeline)
+	}
+
+	findOpts := options.Find().SetSort(sortSpec(opts.Sort))
+	if projection != nil {
+		findOpts.SetProjection(projection)
+	}
+	if opts.Limit > 0 {
+		findOpts.SetLimit(int64(opts.Limit))
+	}
+	return r.find(ctx, filter, findOpts)
+}
+
+// cursorFilter はカーソルの位置より後のドキュメントを対象とする条件を返す
+// 並び順のキーが同じドキュメントは_idで位置を決める
+func cursorFilter(cursor *message.SearchCursor) bson.M {
+	field, op := cursor.Sort.Field(), "$gt"
+	if cursor.Sort.Descending() {
+		op = "$lt"
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{field: bson.M{op: cursor.Value}},
+		bson.M{field: cursor.Value, "_id": bson.M{op: cursor.ID}},
+	}}
+}
+
+// sortSpec は並び順をMongoDBのソート指定に変換する。同じ値のドキュメントは_idで並べる
+func sortSpec(sort message.SortOrder) bson.D {
+	switch sort {
+	case message.SortSentAtDesc:
+		return bson.D{{Key: "sent_at", Value: -1}, {Key: "_id", Value: -1}}
+	case message.SortCreatedAtAsc:
+		return bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}
+	}
+	return bson.D{{Key: "sent_at", Value: 1}, {Key: "_id", Value: 1}}
+}
+
+func (r *MessageRepository) Iterate(ctx context.Context, criteria message.SearchCriteria, fn func(*message.Message) error) error {
+	filter, thread, err := r.searchQuery(ctx, criteria)
+	if err != nil {
+		return err
+	}
+	var cursor CursorInterface
+	if len(thread) > 0 {
+		pipeline := mongo.Pipeline{
+			{{Key: "$match", Value: filter}},
+			{{Key: "$sort", Value: sortSpec(message.SortSentAtAsc)}},
+		}
+		pipeline = append(pipeline, thread...)
+		cursor, err = r.collection.Aggregate(ctx, pipeline, options.Aggregate().SetBatchSize(iterateBatchSize))
+	} else {
+		opts := options.Find().
+			SetSort(sortSpec(message.SortSentAtAsc)).
+			SetBatchSize(iterateBatchSize)
+		cursor, err = r.collection.Find(ctx, filter, opts)
+	}
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var msg message.Message
+		if err := cursor.Decode(&msg); err != nil {
+			return err
+		}
+		if err := fn(&msg); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+func (r *MessageRepository) Count(ctx context.Context, criteria message.SearchCriteria) (int64, error) {
+	filter, thread, err := r.searchQuery(ctx, criteria)
+	if err != nil {
+		return 0, err
+	}
+	if len(thread) > 0 {
+		return r.countThread(ctx, filter, thread, 0)
+	}
+	return r.collection.CountDocuments(ctx, filter)
+}
+
+func (r *MessageRepository) CountUpTo(ctx context.Context, criteria message.SearchCriteria, limit int64) (int64, error) {
+	filter, thread, err := r.searchQuery(ctx, criteria)
+	if err != nil {
+		return 0, err
+	}
+	if len(thread) > 0 {
+		return r.countThread(ctx, filter, thread, limit)
+	}
+	// limit件を読んだ時点で打ち切る
+	return r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(limit))
+}
+
+// countResult は$countの結果のドキュメント
+type countResult struct {
+	Count int64 `bson:"count"`
+}
+
+// countThread は返信の有無で絞り込んだメッセージを数える。limitが正の場合はlimit件で打ち切る
+func (r *MessageRepository) countThread(ctx context.Context, filter bson.M, thread mongo.Pipeline, limit int64) (int64, error) {
+	pipeline := append(mongo.Pipeline{{{Key: "$match", Value: filter}}}, thread...)
+	if limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$count", Value: "count"}})
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	// 一致するドキュメントが無い場合、$countは結果を返さない
+	var result countResult
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&result); err != nil {
+			return 0, err
+		}
+	}
+	return result.Count, cursor.Err()
+}
+
+func (r *MessageRepository) DeleteMany(ctx context.Context, criteria message.SearchCriteria) (int64, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	filter := searchFilter(tenantID, criteria)
+	now := time.Now()
+	update := bson.M{"$set": bson.M{
+		"deleted_at": now,
+		"updated_at": now,
+	}}
+	if criteria.HasThread != nil {
+		return r.deleteThread(ctx, tenantID, filter, *criteria.HasThread, now, update)
+	}
+
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// idResult は_idのみを取得した集計結果のドキュメント
+type idResult struct {
+	ID primitive.ObjectID `bson:"_id"`
+}
+
+// deleteThread は返信の有無で絞り込んだメッセージを、集計で求めたIDごとにまとめて削除する
+// 削除しながら返信を探すため、この削除で削除した返信も有効な返信として数え、削除の前と同じ判定にする
+func (r *MessageRepository) deleteThread(ctx context.Context, tenantID string, filter bson.M, hasThread bool, now time.Time, update bson.M) (int64, error) {
+	replies := activeFilter(tenantID, now)
+	replies["deleted_at"] = bson.M{"$in": bson.A{nil, now}}
+	pipeline := append(mongo.Pipeline{{{Key: "$match", Value: filter}}}, threadStages(replies, hasThread)...)
+	pipeline = append(pipeline, bson.D{{Key: "$project", Value: bson.M{"_id": 1}}})
 
-	if criteria.ChannelID != nil {
-		filter["channel_id"] = *criteria.ChannelID
+	cursor, err := r.collection.Aggregate(ctx, pipeline, options.Aggregate().SetBatchSize(iterateBatchSize))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var deleted int64
+	ids := make([]primitive.ObjectID, 0, iterateBatchSize)
+	flush := func() error {
+		if len(ids) == 0 {
+			return nil
+		}
+		batch := activeFilter(tenantID, now)
+		batch["_id"] = bson.M{"$in": ids}
+		result, err := r.collection.UpdateMany(ctx, batch, update)
+		if err != nil {
+			return err
+		}
+		deleted += result.ModifiedCount
+		ids = ids[:0]
+		return nil
+	}
+	for cursor.Next(ctx) {
+		var result idResult
+		if err := cursor.Decode(&result); err != nil {
+			return deleted, err
+		}
+		ids = append(ids, result.ID)
+		if len(ids) == iterateBatchSize {
+			if err := flush(); err != nil {
+				return deleted, err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return deleted, err
+	}
+	return deleted, flush()
+}
+
+func (r *MessageRepository) Around(ctx context.Context, target *message.Message, before, after int) ([]message.Message, []message.Message, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var older, newer []message.Message
+	if before > 0 {
+		opts := options.Find().
+			SetSort(bson.D{{Key: "sent_at", Value: -1}, {Key: "_id", Value: -1}}).
+			SetLimit(int64(before))
+		msgs, err := r.find(ctx, aroundFilter(tenantID, target, "$lt"), opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		// 近い順に取得しているため古い順に並べ替える
+		for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
+			msgs[i], msgs[j] = msgs[j], msgs[i]
+		}
+		older = msgs
+	}
+	if after > 0 {
+		opts := options.Find().
+			SetSort(bson.D{{Key: "sent_at", Value: 1}, {Key: "_id", Value: 1}}).
+			SetLimit(int64(after))
+		msgs, err := r.find(ctx, aroundFilter(tenantID, target, "$gt"), opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		newer = msgs
+	}
+	return older, newer, nil
+}
+
+// find はフィルターに一致するメッセージを全て取得する
+func (r *MessageRepository) find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) ([]message.Message, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var messages []message.Message
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// aggregate は集計パイプラインの結果のメッセージを全て取得する
+func (r *MessageRepository) aggregate(ctx context.Context, pipeline mongo.Pipeline) ([]message.Message, error) {
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var messages []message.Message
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// aroundFilter は対象と同じチャンネルで、送信日時とIDの順で対象より前($lt)または後($gt)のメッセージを対象とするフィルターを返す
+// activeFilterが$orを使うため、条件は$andで組み合わせる
+func aroundFilter(tenantID string, target *message.Message, op string) bson.M {
+	filter := activeFilter(tenantID, time.Now())
+	filter["channel_id"] = target.ChannelID
+	filter["$and"] = bson.A{
+		bson.M{"$or": bson.A{
+			bson.M{"sent_at": bson.M{op: target.SentAt}},
+			bson.M{"sent_at": target.SentAt, "_id": bson.M{op: target.ID}},
+		}},
+	}
+	return filter
+}
+
+// statsResult は集計パイプラインの結果のドキュメント
+// _idはチャンネルID・送信者の場合は文字列、時間単位の場合は期間の開始日時になる
+type statsResult struct {
+	ID      interface{} `bson:"_id"`
+	Count   int64       `bson:"count"`
+	Senders int64       `bson:"senders"`
+}
+
+func (r *MessageRepository) Stats(ctx context.Context, query message.StatsQuery) ([]message.StatsBucket, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	var groupKey interface{}
+	sort := bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}
+	switch query.GroupBy {
+	case message.GroupByChannel:
+		groupKey = "$channel_id"
+	case message.GroupBySender:
+		groupKey = "$sender"
+	default:
+		groupKey = periodStart(query)
+		sort = bson.D{{Key: "_id", Value: 1}}
+	}
+
+	filter, thread, err := r.searchQuery(ctx, query.Criteria)
+	if err != nil {
+		return nil, err
+	}
+	// 送信者の数は集計単位と送信者の組で一度まとめてから数える
+	// $addToSetで送信者を配列に集めると、送信者の多い集計単位でドキュメントの上限を超えるため
+	pipeline := append(mongo.Pipeline{{{Key: "$match", Value: filter}}}, thread...)
+	pipeline = append(pipeline, mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"key": groupKey, "sender": "$sender"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     "$_id.key",
+			"count":   bson.M{"$sum": "$count"},
+			"senders": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: sort}},
+	}...)
+
+	// グループ化と並べ替えがメモリの上限を超える場合は一時ファイルを使わせる
+	cursor, err := r.collection.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var buckets []message.StatsBucket
+	for cursor.Next(ctx) {
+		var result statsResult
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+
+		bucket := message.StatsBucket{Count: result.Count, Senders: result.Senders}
+		switch id := result.ID.(type) {
+		case string:
+			bucket.Key = id
+		case primitive.DateTime:
+			bucket.Key = query.PeriodKey(id.Time())
+		case nil:
+			// 集計対象のフィールドが無いドキュメント
+		default:
+			return nil, fmt.Errorf("unexpected stats key type %T", result.ID)
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, cursor.Err()
+}
+
+// periodStart は送信日時を集計期間の開始時刻に切り捨てる式を返す
+// MongoDB 4.4でも動くよう$dateTruncは使わず、タイムゾーン上の年月日・時から組み立て直す
+func periodStart(query message.StatsQuery) bson.M {
+	tz := query.TimeZone().String()
+	part := func(op string) bson.M {
+		return bson.M{op: bson.M{"date": "$sent_at", "timezone": tz}}
+	}
+	parts := bson.M{
+		"year":     part("$year"),
+		"month":    part("$month"),
+		"day":      part("$dayOfMonth"),
+		"timezone": tz,
+	}
+	if query.GroupBy == message.GroupByHour {
+		parts["hour"] = part("$hour")
+	}
+	return bson.M{"$dateFromParts": parts}
+}
+
+// activeFilter はテナントの削除されておらず、有効期限を過ぎていないメッセージを対象とするフィルターを返す
+// TTLインデックスによる削除は即時ではないため、読み取り時にも有効期限を確認する
+func activeFilter(tenantID string, now time.Time) bson.M {
+	return bson.M{
+		"tenant_id":  tenantID,
+		"deleted_at": nil,
+		"$or": bson.A{
+			bson.M{"expires_at": nil},
+			bson.M{"expires_at": bson.M{"$gt": now}},
+		},
+	}
+}
+
+// searchFilter はテナント内の検索条件をMongoDBのフィルターに変換する
+func searchFilter(tenantID string, criteria message.SearchCriteria) bson.M {
+	filter := activeFilter(tenantID, time.Now())
+
+	if channel := fieldCondition(criteria.ChannelIDs, nil, nil); channel != nil {
+		filter["channel_id"] = channel
 	}
-	if criteria.Sender != nil {
-		filter["sender"] = *criteria.Sender
+	if sender := fieldCondition(criteria.Senders, criteria.ExcludeSenders, criteria.SenderPrefix); sender != nil {
+		filter["sender"] = sender
 	}
 	if criteria.FromDate != nil || criteria.ToDate != nil {
 		dateFilter := bson.M{}
@@ -74,24 +563,85 @@ func (r *MessageRepository) Search(ctx context.Context, criteria message.SearchC
 		}
 		filter["sent_at"] = dateFilter
 	}
+	if criteria.IsReply != nil {
+		filter["reply_to"] = bson.M{"$exists": *criteria.IsReply}
+	}
+	if criteria.CreatedBefore != nil {
+		filter["created_at"] = bson.M{"$lt": criteria.CreatedBefore}
+	}
+
+	return filter
+}
 
-	opts := options.Find().SetSort(bson.D{{Key: "sent_at", Value: 1}})
-	cursor, err := r.collection.Find(ctx, filter, opts)
+// searchQuery はコンテキストのテナント内の検索条件を、フィルターと返信の有無で絞り込む集計ステージに変換する
+// 返信の有無は他のドキュメントとの関係で決まるため、指定された場合は集計パイプラインで$lookupを使って判定する
+func (r *MessageRepository) searchQuery(ctx context.Context, criteria message.SearchCriteria) (bson.M, mongo.Pipeline, error) {
+	tenantID, err := tenant.FromContext(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	defer cursor.Close(ctx)
+	filter := searchFilter(tenantID, criteria)
+	if criteria.HasThread == nil {
+		return filter, nil, nil
+	}
+	return filter, threadStages(activeFilter(tenantID, time.Now()), *criteria.HasThread), nil
+}
 
-	var messages []message.Message
-	if err := cursor.All(ctx, &messages); err != nil {
-		return nil, err
+// threadField は$lookupで見つけた返信を一時的に格納するフィールド
+const threadField = "_thread"
+
+// threadStages はrepliesに一致する返信があるか(true)、無いか(false)で絞り込む集計ステージを返す
+// 返信はreply_toのインデックスを使って1件だけ探し、結果のドキュメントには含めない
+func threadStages(replies bson.M, hasThread bool) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         messageCollection,
+			"localField":   "uid",
+			"foreignField": "reply_to",
+			"pipeline": bson.A{
+				bson.M{"$match": replies},
+				bson.M{"$limit": 1},
+				bson.M{"$project": bson.M{"_id": 1}},
+			},
+			"as": threadField,
+		}}},
+		{{Key: "$match", Value: bson.M{threadField + ".0": bson.M{"$exists": hasThread}}}},
+		{{Key: "$unset", Value: threadField}},
 	}
+}
 
-	return messages, nil
+// fieldCondition は1つのフィールドに対する条件をまとめる
+// 1つの値の一致のみの場合は値をそのまま返し、条件が無い場合はnilを返す
+func fieldCondition(in, nin []string, prefix *string) interface{} {
+	condition := bson.M{}
+	if len(in) > 0 {
+		condition["$in"] = in
+	}
+	if len(nin) > 0 {
+		condition["$nin"] = nin
+	}
+	if prefix != nil {
+		// 先頭に固定した正規表現はインデックスを使って検索できる
+		condition["$regex"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(*prefix)}
+	}
+
+	switch {
+	case len(condition) == 0:
+		return nil
+	case len(condition) == 1 && len(in) == 1:
+		return in[0]
+	}
+	return condition
 }
 
 func (r *MessageRepository) FindByUID(ctx context.Context, uid string) (*message.Message, error) {
-	filter := bson.M{"uid": uid, "deleted_at": nil}
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := activeFilter(tenantID, time.Now())
+	filter["uid"] = uid
 
 	var msg message.Message
 	if err := r.collection.FindOne(ctx, filter).Decode(&msg); err != nil {
@@ -1,7 +1,7 @@
 package repository
 
 import (
-	"context"
+	"errors"
 	"message-service/internal/domain/message"
 	"testing"
 	"time"
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -62,7 +63,7 @@ func TestMessageRepository_Create(t *testing.T) {
 			repo, mockCollection := NewTestRepository()
 			tt.mockFn(mockCollection)
 
-			err := repo.Create(context.Background(), tt.msg)
+			err := repo.Create(testContext(), tt.msg)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -118,7 +119,7 @@ func TestMessageRepository_Delete(t *testing.T) {
 			repo, mockCollection := NewTestRepository()
 			tt.mockFn(mockCollection)
 
-			err := repo.Delete(context.Background(), tt.uid)
+			err := repo.Delete(testContext(), tt.uid)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -139,6 +140,7 @@ func TestMessageRepository_Search(t *testing.T) {
 	sender := "test-sender"
 	fromDate := now.Add(-24 * time.Hour)
 	toDate := now
+	yes, no := true, false
 
 	tests := []struct {
 		name     string
@@ -150,10 +152,10 @@ func TestMessageRepository_Search(t *testing.T) {
 		{
 			name: "正常系：全ての検索条件を指定",
 			criteria: message.SearchCriteria{
-				ChannelID: &channelID,
-				Sender:    &sender,
-				FromDate:  &fromDate,
-				ToDate:    &toDate,
+				ChannelIDs: []string{channelID},
+				Senders:    []string{sender},
+				FromDate:   &fromDate,
+				ToDate:     &toDate,
 			},
 			mockFn: func(m *TestCollection) {
 				expectedMessages := []message.Message{
@@ -182,6 +184,46 @@ func TestMessageRepository_Search(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "正常系：返信のみを対象",
+			criteria: message.SearchCriteria{IsReply: &yes},
+			mockFn: func(m *TestCollection) {
+				m.On("Find", mock.Anything, mock.MatchedBy(func(filter bson.M) bool {
+					return assert.ObjectsAreEqual(bson.M{"$exists": true}, filter["reply_to"])
+				}), mock.Anything).Return(NewTestCursor([]message.Message{}), nil)
+			},
+			want: []message.Message{},
+		},
+		{
+			name:     "正常系：作成日時の上限で絞り込む",
+			criteria: message.SearchCriteria{CreatedBefore: &now},
+			mockFn: func(m *TestCollection) {
+				m.On("Find", mock.Anything, mock.MatchedBy(func(filter bson.M) bool {
+					return assert.ObjectsAreEqual(bson.M{"$lt": &now}, filter["created_at"])
+				}), mock.Anything).Return(NewTestCursor([]message.Message{}), nil)
+			},
+			want: []message.Message{},
+		},
+		{
+			name:     "正常系：返信があるメッセージは$lookupで返信を探して絞り込む",
+			criteria: message.SearchCriteria{HasThread: &yes},
+			mockFn: func(m *TestCollection) {
+				m.On("Aggregate", mock.Anything, mock.MatchedBy(func(pipeline mongo.Pipeline) bool {
+					return hasThreadStages(pipeline, true)
+				})).Return(NewTestCursor([]message.Message{{UID: "parent"}}), nil)
+			},
+			want: []message.Message{{UID: "parent"}},
+		},
+		{
+			name:     "正常系：返信が無いメッセージは$lookupで返信が見つからないものに絞り込む",
+			criteria: message.SearchCriteria{HasThread: &no},
+			mockFn: func(m *TestCollection) {
+				m.On("Aggregate", mock.Anything, mock.MatchedBy(func(pipeline mongo.Pipeline) bool {
+					return hasThreadStages(pipeline, false)
+				})).Return(NewTestCursor([]message.Message{}), nil)
+			},
+			want: []message.Message{},
+		},
 		{
 			name:     "異常系：データベースエラー",
 			criteria: message.SearchCriteria{},
@@ -191,6 +233,14 @@ func TestMessageRepository_Search(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:     "異常系：返信の有無の集計エラー",
+			criteria: message.SearchCriteria{HasThread: &yes},
+			mockFn: func(m *TestCollection) {
+				m.On("Aggregate", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -198,7 +248,224 @@ func TestMessageRepository_Search(t *testing.T) {
 			repo, mockCollection := NewTestRepository()
 			tt.mockFn(mockCollection)
 
-			got, err := repo.Search(context.Background(), tt.criteria)
+			got, err := repo.Search(testContext(), tt.criteria, message.SearchOptions{})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			mockCollection.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageRepository_Iterate(t *testing.T) {
+	now := time.Now()
+	channelID := "test-channel"
+	messages := []message.Message{
+		{UID: "msg1", ChannelID: channelID, SentAt: now, Content: "test message 1"},
+		{UID: "msg2", ChannelID: channelID, SentAt: now.Add(time.Second), Content: "test message 2"},
+	}
+	stopErr := errors.New("stop")
+
+	tests := []struct {
+		name     string
+		criteria message.SearchCriteria
+		mockFn   func(*TestCollection)
+		fnErr    error
+		wantUIDs []string
+		wantErr  error
+	}{
+		{
+			name:     "正常系：全件を順に渡す",
+			criteria: message.SearchCriteria{ChannelIDs: []string{channelID}},
+			mockFn: func(m *TestCollection) {
+				m.On("Find", mock.Anything, mock.MatchedBy(func(filter bson.M) bool {
+					return filter["channel_id"] == channelID && filter["deleted_at"] == nil
+				})).Return(NewTestCursor(messages), nil)
+			},
+			wantUIDs: []string{"msg1", "msg2"},
+		},
+		{
+			name: "異常系：コールバックのエラーで打ち切る",
+			mockFn: func(m *TestCollection) {
+				m.On("Find", mock.Anything, mock.Anything).Return(NewTestCursor(messages), nil)
+			},
+			fnErr:    stopErr,
+			wantUIDs: []string{"msg1"},
+			wantErr:  stopErr,
+		},
+		{
+			name: "異常系：カーソルのエラー",
+			mockFn: func(m *TestCollection) {
+				cursor := NewTestCursor(messages).(*TestCursor)
+				cursor.Error = errors.New("cursor error")
+				m.On("Find", mock.Anything, mock.Anything).Return(cursor, nil)
+			},
+			wantUIDs: []string{"msg1", "msg2"},
+			wantErr:  errors.New("cursor error"),
+		},
+		{
+			name: "異常系：データベースエラー",
+			mockFn: func(m *TestCollection) {
+				m.On("Find", mock.Anything, mock.Anything).
+					Return(nil, mongo.CommandError{Message: "database error"})
+			},
+			wantErr: mongo.CommandError{Message: "database error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mockCollection := NewTestRepository()
+			tt.mockFn(mockCollection)
+
+			var uids []string
+			err := repo.Iterate(testContext(), tt.criteria, func(msg *message.Message) error {
+				uids = append(uids, msg.UID)
+				return tt.fnErr
+			})
+
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantUIDs, uids)
+			mockCollection.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageRepository_Count(t *testing.T) {
+	channelID := "test-channel"
+	yes := true
+
+	tests := []struct {
+		name     string
+		criteria message.SearchCriteria
+		mockFn   func(*TestCollection)
+		want     int64
+		wantErr  bool
+	}{
+		{
+			name:     "正常系：条件に一致する件数",
+			criteria: message.SearchCriteria{ChannelIDs: []string{channelID}},
+			mockFn: func(m *TestCollection) {
+				m.On("CountDocuments", mock.Anything, mock.MatchedBy(func(filter bson.M) bool {
+					return filter["channel_id"] == channelID && filter["deleted_at"] == nil
+				})).Return(int64(5), nil)
+			},
+			want: 5,
+		},
+		{
+			name:     "正常系：返信の有無を指定した場合は集計パイプラインで数える",
+			criteria: message.SearchCriteria{HasThread: &yes},
+			mockFn: func(m *TestCollection) {
+				m.On("Aggregate", mock.Anything, mock.MatchedBy(func(pipeline mongo.Pipeline) bool {
+					return hasThreadStages(pipeline, true)
+				})).Return(NewTestCursor([]countResult{{Count: 2}}), nil)
+			},
+			want: 2,
+		},
+		{
+			name:     "正常系：一致するメッセージが無い場合は0件",
+			criteria: message.SearchCriteria{HasThread: &yes},
+			mockFn: func(m *TestCollection) {
+				m.On("Aggregate", mock.Anything, mock.Anything).Return(NewTestCursor([]countResult{}), nil)
+			},
+			want: 0,
+		},
+		{
+			name:     "異常系：データベースエラー",
+			criteria: message.SearchCriteria{},
+			mockFn: func(m *TestCollection) {
+				m.On("CountDocuments", mock.Anything, mock.Anything).Return(int64(0), errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mockCollection := NewTestRepository()
+			tt.mockFn(mockCollection)
+
+			got, err := repo.Count(testContext(), tt.criteria)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			mockCollection.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageRepository_DeleteMany(t *testing.T) {
+	sender := "test-sender"
+	yes := true
+	ids := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()}
+
+	tests := []struct {
+		name     string
+		criteria message.SearchCriteria
+		mockFn   func(*TestCollection)
+		want     int64
+		wantErr  bool
+	}{
+		{
+			name:     "正常系：条件に一致するメッセージを論理削除",
+			criteria: message.SearchCriteria{Senders: []string{sender}},
+			mockFn: func(m *TestCollection) {
+				m.On("UpdateMany", mock.Anything,
+					mock.MatchedBy(func(filter bson.M) bool {
+						return filter["sender"] == sender && filter["deleted_at"] == nil
+					}),
+					mock.MatchedBy(func(update bson.M) bool {
+						set, ok := update["$set"].(bson.M)
+						return ok && set["deleted_at"] != nil && set["updated_at"] != nil
+					})).
+					Return(&mongo.UpdateResult{MatchedCount: 3, ModifiedCount: 3}, nil)
+			},
+			want: 3,
+		},
+		{
+			name:     "正常系：返信の有無を指定した場合は集計したIDで論理削除",
+			criteria: message.SearchCriteria{HasThread: &yes},
+			mockFn: func(m *TestCollection) {
+				m.On("Aggregate", mock.Anything, mock.MatchedBy(func(pipeline mongo.Pipeline) bool {
+					return hasThreadStages(pipeline, true)
+				})).Return(NewTestCursor([]idResult{{ID: ids[0]}, {ID: ids[1]}}), nil)
+				m.On("UpdateMany", mock.Anything,
+					mock.MatchedBy(func(filter bson.M) bool {
+						return assert.ObjectsAreEqual(bson.M{"$in": ids}, filter["_id"]) && filter["deleted_at"] == nil
+					}), mock.Anything).
+					Return(&mongo.UpdateResult{MatchedCount: 2, ModifiedCount: 2}, nil)
+			},
+			want: 2,
+		},
+		{
+			name:     "異常系：データベースエラー",
+			criteria: message.SearchCriteria{Senders: []string{sender}},
+			mockFn: func(m *TestCollection) {
+				m.On("UpdateMany", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mockCollection := NewTestRepository()
+			tt.mockFn(mockCollection)
+
+			got, err := repo.DeleteMany(testContext(), tt.criteria)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -262,10 +529,325 @@ func TestMessageRepository_FindByUID(t *testing.T) {
 			repo, mockCollection := NewTestRepository()
 			tt.mockFn(mockCollection)
 
-			got, err := repo.FindByUID(context.Background(), tt.uid)
+			got, err := repo.FindByUID(testContext(), tt.uid)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			mockCollection.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageRepository_Create_Duplicate(t *testing.T) {
+	repo, mockCollection := NewTestRepository()
+	mockCollection.On("InsertOne", mock.Anything, mock.AnythingOfType("*message.Message")).
+		Return(nil, mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: duplicateKeyCode}}})
+
+	err := repo.Create(testContext(), &message.Message{UID: "duplicate-uid"})
+
+	assert.ErrorIs(t, err, message.ErrDuplicateUID)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestMessageRepository_CreateMany(t *testing.T) {
+	newMessages := func() []*message.Message {
+		return []*message.Message{
+			{UID: "uid-1"},
+			{UID: "uid-2"},
+			{UID: "uid-3"},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		msgs       []*message.Message
+		mockFn     func(*TestCollection)
+		wantErrs   []error
+		wantErr    bool
+		noDBAccess bool
+	}{
+		{
+			name: "正常系：全件作成",
+			msgs: newMessages(),
+			mockFn: func(m *TestCollection) {
+				m.On("InsertMany", mock.Anything, mock.MatchedBy(func(docs []interface{}) bool {
+					return len(docs) == 3
+				})).Return(&mongo.InsertManyResult{}, nil)
+			},
+			wantErrs: []error{nil, nil, nil},
+		},
+		{
+			name: "正常系：一部が重複・不正",
+			msgs: newMessages(),
+			mockFn: func(m *TestCollection) {
+				m.On("InsertMany", mock.Anything, mock.Anything).
+					Return(nil, mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{
+						{WriteError: mongo.WriteError{Index: 0, Code: duplicateKeyCode, Message: "E11000"}},
+						{WriteError: mongo.WriteError{Index: 2, Code: 2, Message: "bad value"}},
+					}})
+			},
+			wantErrs: []error{message.ErrDuplicateUID, nil, errors.New("bad value")},
+		},
+		{
+			name:       "正常系：空の入力",
+			msgs:       []*message.Message{},
+			mockFn:     func(m *TestCollection) {},
+			wantErrs:   []error{},
+			noDBAccess: true,
+		},
+		{
+			name: "異常系：データベースエラー",
+			msgs: newMessages(),
+			mockFn: func(m *TestCollection) {
+				m.On("InsertMany", mock.Anything, mock.Anything).
+					Return(nil, mongo.CommandError{Message: "database error"})
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mockCollection := NewTestRepository()
+			tt.mockFn(mockCollection)
+
+			errs, err := repo.CreateMany(testContext(), tt.msgs)
 
 			if tt.wantErr {
 				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantErrs, errs)
+				for _, msg := range tt.msgs {
+					assert.NotZero(t, msg.CreatedAt)
+				}
+			}
+			if tt.noDBAccess {
+				mockCollection.AssertNotCalled(t, "InsertMany", mock.Anything, mock.Anything)
+			}
+			mockCollection.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageRepository_Around(t *testing.T) {
+	sentAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	target := &message.Message{ID: primitive.NewObjectID(), UID: "target", ChannelID: "general", SentAt: sentAt}
+
+	// aroundOp はフィルターが対象の前後どちらを指しているかを返す
+	aroundOp := func(filter bson.M) string {
+		and, ok := filter["$and"].(bson.A)
+		if !ok || filter["channel_id"] != "general" || filter["$or"] == nil {
+			return ""
+		}
+		or := and[0].(bson.M)["$or"].(bson.A)
+		for op := range or[0].(bson.M)["sent_at"].(bson.M) {
+			return op
+		}
+		return ""
+	}
+
+	tests := []struct {
+		name      string
+		before    int
+		after     int
+		mockFn    func(*TestCollection)
+		wantOlder []string
+		wantNewer []string
+		wantErr   bool
+	}{
+		{
+			name:   "正常系：直前は近い順に取得して古い順に並べ替える",
+			before: 2,
+			after:  1,
+			mockFn: func(m *TestCollection) {
+				m.On("Find", mock.Anything, mock.MatchedBy(func(filter bson.M) bool {
+					return aroundOp(filter) == "$lt"
+				})).Return(NewTestCursor([]message.Message{{UID: "prev1"}, {UID: "prev2"}}), nil)
+				m.On("Find", mock.Anything, mock.MatchedBy(func(filter bson.M) bool {
+					return aroundOp(filter) == "$gt"
+				})).Return(NewTestCursor([]message.Message{{UID: "next1"}}), nil)
+			},
+			wantOlder: []string{"prev2", "prev1"},
+			wantNewer: []string{"next1"},
+		},
+		{
+			name:   "正常系：件数が0の側は問い合わせない",
+			before: 0,
+			after:  0,
+			mockFn: func(m *TestCollection) {},
+		},
+		{
+			name:   "異常系：データベースエラー",
+			before: 1,
+			after:  1,
+			mockFn: func(m *TestCollection) {
+				m.On("Find", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mockCollection := NewTestRepository()
+			tt.mockFn(mockCollection)
+
+			older, newer, err := repo.Around(testContext(), target, tt.before, tt.after)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantOlder, messageUIDs(older))
+				assert.Equal(t, tt.wantNewer, messageUIDs(newer))
+			}
+			mockCollection.AssertExpectations(t)
+		})
+	}
+}
+
+// hasThreadStages は集計パイプラインが$lookupで返信を探し、返信の有無(want)で絞り込んでいるかを判定する
+func hasThreadStages(pipeline mongo.Pipeline, want bool) bool {
+	var lookup, match bool
+	for _, stage := range pipeline {
+		for _, e := range stage {
+			switch e.Key {
+			case "$lookup":
+				v, ok := e.Value.(bson.M)
+				lookup = ok && v["localField"] == "uid" && v["foreignField"] == "reply_to"
+			case "$match":
+				if v, ok := e.Value.(bson.M); ok {
+					if cond, ok := v[threadField+".0"]; ok {
+						match = assert.ObjectsAreEqual(bson.M{"$exists": want}, cond)
+					}
+				}
+			}
+		}
+	}
+	return lookup && match
+}
+
+func messageUIDs(msgs []message.Message) []string {
+	var uids []string
+	for _, msg := range msgs {
+		uids = append(uids, msg.UID)
+	}
+	return uids
+}
+
+func TestMessageRepository_Stats(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	// groupKey は集計パイプラインを確認し、集計単位の式を取り出す
+	// 送信者の数を数えるため、集計単位と送信者の組でまとめてから集計単位でまとめ直す
+	groupKey := func(pipeline interface{}) interface{} {
+		stages, ok := pipeline.(mongo.Pipeline)
+		if !ok || len(stages) != 4 {
+			return nil
+		}
+		first, _ := stages[1][0].Value.(bson.M)
+		second, _ := stages[2][0].Value.(bson.M)
+		id, _ := first["_id"].(bson.M)
+		if id["sender"] != "$sender" || second["_id"] != "$_id.key" ||
+			!assert.ObjectsAreEqual(bson.M{"$sum": "$count"}, second["count"]) ||
+			!assert.ObjectsAreEqual(bson.M{"$sum": 1}, second["senders"]) {
+			return nil
+		}
+		return id["key"]
+	}
+	// periodParts は集計期間の開始時刻を組み立てる$dateFromPartsの引数を取り出す
+	periodParts := func(pipeline interface{}) bson.M {
+		key, _ := groupKey(pipeline).(bson.M)
+		parts, _ := key["$dateFromParts"].(bson.M)
+		return parts
+	}
+
+	tests := []struct {
+		name    string
+		query   message.StatsQuery
+		mockFn  func(*TestCollection)
+		want    []message.StatsBucket
+		wantErr error
+	}{
+		{
+			name:  "正常系：チャンネル単位の集計",
+			query: message.StatsQuery{GroupBy: message.GroupByChannel},
+			mockFn: func(m *TestCollection) {
+				m.On("Aggregate", mock.Anything, mock.MatchedBy(func(pipeline interface{}) bool {
+					return groupKey(pipeline) == "$channel_id"
+				})).Return(NewTestCursor([]statsResult{
+					{ID: "general", Count: 3, Senders: 2},
+					{ID: "random", Count: 1, Senders: 1},
+				}), nil)
+			},
+			want: []message.StatsBucket{
+				{Key: "general", Count: 3, Senders: 2},
+				{Key: "random", Count: 1, Senders: 1},
+			},
+		},
+		{
+			name:  "正常系：日単位の集計はタイムゾーンで区切る",
+			query: message.StatsQuery{GroupBy: message.GroupByDay, Location: tokyo},
+			mockFn: func(m *TestCollection) {
+				m.On("Aggregate", mock.Anything, mock.MatchedBy(func(pipeline interface{}) bool {
+					parts := periodParts(pipeline)
+					_, hasHour := parts["hour"]
+					return parts["timezone"] == "Asia/Tokyo" && !hasHour &&
+						assert.ObjectsAreEqual(bson.M{"$dayOfMonth": bson.M{"date": "$sent_at", "timezone": "Asia/Tokyo"}}, parts["day"])
+				})).Return(NewTestCursor([]statsResult{
+					{ID: primitive.NewDateTimeFromTime(time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC)), Count: 2, Senders: 1},
+				}), nil)
+			},
+			want: []message.StatsBucket{
+				{Key: "2024-01-02T00:00:00+09:00", Count: 2, Senders: 1},
+			},
+		},
+		{
+			name:  "正常系：時単位の集計は時まで組み立てる",
+			query: message.StatsQuery{GroupBy: message.GroupByHour},
+			mockFn: func(m *TestCollection) {
+				m.On("Aggregate", mock.Anything, mock.MatchedBy(func(pipeline interface{}) bool {
+					parts := periodParts(pipeline)
+					return parts["timezone"] == "UTC" &&
+						assert.ObjectsAreEqual(bson.M{"$hour": bson.M{"date": "$sent_at", "timezone": "UTC"}}, parts["hour"])
+				})).Return(NewTestCursor([]statsResult{
+					{ID: primitive.NewDateTimeFromTime(time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC)), Count: 2, Senders: 2},
+				}), nil)
+			},
+			want: []message.StatsBucket{
+				{Key: "2024-01-01T15:00:00Z", Count: 2, Senders: 2},
+			},
+		},
+		{
+			name:    "異常系：不正な集計単位",
+			query:   message.StatsQuery{GroupBy: "week"},
+			mockFn:  func(m *TestCollection) {},
+			wantErr: message.ErrInvalidGroupBy,
+		},
+		{
+			name:  "異常系：データベースエラー",
+			query: message.StatsQuery{GroupBy: message.GroupBySender},
+			mockFn: func(m *TestCollection) {
+				m.On("Aggregate", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mockCollection := NewTestRepository()
+			tt.mockFn(mockCollection)
+
+			got, err := repo.Stats(testContext(), tt.query)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.want, got)
@@ -274,3 +856,107 @@ func TestMessageRepository_FindByUID(t *testing.T) {
 		})
 	}
 }
+
+func TestSortSpec(t *testing.T) {
+	tests := []struct {
+		name string
+		sort message.SortOrder
+		want bson.D
+	}{
+		{name: "既定は送信日時の昇順", sort: "", want: bson.D{{Key: "sent_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{name: "送信日時の降順", sort: message.SortSentAtDesc, want: bson.D{{Key: "sent_at", Value: -1}, {Key: "_id", Value: -1}}},
+		{name: "作成日時の昇順", sort: message.SortCreatedAtAsc, want: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sortSpec(tt.sort))
+		})
+	}
+}
+
+func TestCursorFilter(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	id := primitive.NewObjectID()
+
+	tests := []struct {
+		name string
+		sort message.SortOrder
+		want bson.M
+	}{
+		{
+			name: "昇順は後の送信日時または同時刻で後のID",
+			sort: message.SortSentAtAsc,
+			want: bson.M{"$or": bson.A{
+				bson.M{"sent_at": bson.M{"$gt": at}},
+				bson.M{"sent_at": at, "_id": bson.M{"$gt": id}},
+			}},
+		},
+		{
+			name: "降順は前の送信日時または同時刻で前のID",
+			sort: message.SortSentAtDesc,
+			want: bson.M{"$or": bson.A{
+				bson.M{"sent_at": bson.M{"$lt": at}},
+				bson.M{"sent_at": at, "_id": bson.M{"$lt": id}},
+			}},
+		},
+		{
+			name: "作成日時の昇順は作成日時で比較する",
+			sort: message.SortCreatedAtAsc,
+			want: bson.M{"$or": bson.A{
+				bson.M{"created_at": bson.M{"$gt": at}},
+				bson.M{"created_at": at, "_id": bson.M{"$gt": id}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor := tt.sort.CursorAfter(&message.Message{ID: id, SentAt: at, CreatedAt: at})
+			assert.Equal(t, tt.want, cursorFilter(cursor))
+		})
+	}
+}
+
+func TestFieldCondition(t *testing.T) {
+	prefix := "bot."
+
+	tests := []struct {
+		name   string
+		in     []string
+		nin    []string
+		prefix *string
+		want   interface{}
+	}{
+		{name: "条件なし", want: nil},
+		{name: "1つの値の一致のみは値そのもの", in: []string{"alice"}, want: "alice"},
+		{name: "いずれかに一致", in: []string{"alice", "bob"}, want: bson.M{"$in": []string{"alice", "bob"}}},
+		{
+			name: "一致と除外の組み合わせ",
+			in:   []string{"alice"},
+			nin:  []string{"bob"},
+			want: bson.M{"$in": []string{"alice"}, "$nin": []string{"bob"}},
+		},
+		{name: "前方一致は正規表現の特殊文字をエスケープする", prefix: &prefix, want: bson.M{"$regex": primitive.Regex{Pattern: `^bot\.`}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fieldCondition(tt.in, tt.nin, tt.prefix))
+		})
+	}
+}
+
+func TestActiveFilter(t *testing.T) {
+	now := time.Now()
+
+	filter := activeFilter("tenant-a", now)
+
+	assert.Equal(t, "tenant-a", filter["tenant_id"])
+	assert.Nil(t, filter["deleted_at"])
+	assert.Contains(t, filter, "deleted_at")
+	assert.Equal(t, bson.A{
+		bson.M{"expires_at": nil},
+		bson.M{"expires_at": bson.M{"$gt": now}},
+	}, filter["$or"])
+}
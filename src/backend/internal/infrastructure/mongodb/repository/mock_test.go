@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
 	"message-service/internal/domain/token"
 	"reflect"
 
@@ -12,6 +13,11 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// testContext は既定のテナントを操作の対象とするコンテキストを返す
+func testContext() context.Context {
+	return tenant.WithID(context.Background(), tenant.DefaultID)
+}
+
 // TestCollection はテスト用のモックコレクション
 type TestCollection struct {
 	mock.Mock
@@ -27,6 +33,16 @@ func (m *TestCollection) InsertOne(ctx context.Context, document interface{}, op
 	return result.(*mongo.InsertOneResult), args.Error(1)
 }
 
+// InsertMany モックメソッド
+func (m *TestCollection) InsertMany(ctx context.Context, documents []interface{}, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
+	args := m.Called(ctx, documents)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(*mongo.InsertManyResult), args.Error(1)
+}
+
 // UpdateOne モックメソッド
 func (m *TestCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
 	args := m.Called(ctx, filter, update)
@@ -37,6 +53,22 @@ func (m *TestCollection) UpdateOne(ctx context.Context, filter interface{}, upda
 	return result.(*mongo.UpdateResult), args.Error(1)
 }
 
+// UpdateMany モックメソッド
+func (m *TestCollection) UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	args := m.Called(ctx, filter, update)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(*mongo.UpdateResult), args.Error(1)
+}
+
+// CountDocuments モックメソッド
+func (m *TestCollection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // Find モックメソッド
 func (m *TestCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (CursorInterface, error) {
 	args := m.Called(ctx, filter)
@@ -46,6 +78,15 @@ func (m *TestCollection) Find(ctx context.Context, filter interface{}, opts ...*
 	return nil, args.Error(1)
 }
 
+// Aggregate モックメソッド
+func (m *TestCollection) Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (CursorInterface, error) {
+	args := m.Called(ctx, pipeline)
+	if cursor, ok := args.Get(0).(CursorInterface); ok {
+		return cursor, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 // FindOne モックメソッド
 func (m *TestCollection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) SingleResult {
 	args := m.Called(ctx, filter)
@@ -55,10 +96,20 @@ func (m *TestCollection) FindOne(ctx context.Context, filter interface{}, opts .
 	return &mockSingleResult{err: mongo.ErrNoDocuments}
 }
 
+// FindOneAndUpdate モックメソッド
+func (m *TestCollection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) SingleResult {
+	args := m.Called(ctx, filter, update)
+	if result, ok := args.Get(0).(*mockSingleResult); ok {
+		return result
+	}
+	return &mockSingleResult{err: mongo.ErrNoDocuments}
+}
+
 // TestCursor はテスト用のモックカーソル
 type TestCursor struct {
 	Results  []interface{}
 	Position int
+	Error    error
 }
 
 func (m *TestCursor) Next(ctx context.Context) bool {
@@ -83,6 +134,10 @@ func (m *TestCursor) All(ctx context.Context, results interface{}) error {
 	return copyValue(results, m.Results)
 }
 
+func (m *TestCursor) Err() error {
+	return m.Error
+}
+
 // NewTestCursor テストカーソル作成
 func NewTestCursor[T any](results []T) CursorInterface {
 	var interfaceSlice []interface{}
@@ -152,6 +207,31 @@ func copyStruct(dst interface{}, src interface{}) error {
 			*v = *srcVal
 			return nil
 		}
+	case *statsResult:
+		if srcVal, ok := src.(*statsResult); ok {
+			*v = *srcVal
+			return nil
+		}
+	case *countResult:
+		if srcVal, ok := src.(*countResult); ok {
+			*v = *srcVal
+			return nil
+		}
+	case *idResult:
+		if srcVal, ok := src.(*idResult); ok {
+			*v = *srcVal
+			return nil
+		}
+	case *tenant.Tenant:
+		if srcVal, ok := src.(*tenant.Tenant); ok {
+			*v = *srcVal
+			return nil
+		}
+	case *reservationResult:
+		if srcVal, ok := src.(*reservationResult); ok {
+			*v = *srcVal
+			return nil
+		}
 	}
 	return fmt.Errorf("unsupported struct type for copy")
 }
@@ -215,6 +295,14 @@ func NewTestTokenRepository() (*TokenRepository, *TestCollection) {
 	}, mock
 }
 
+// NewTestAuditRepository はテスト用のAuditRepositoryを作成
+func NewTestAuditRepository() (*AuditRepository, *TestCollection) {
+	mock := new(TestCollection)
+	return &AuditRepository{
+		collection: mock,
+	}, mock
+}
+
 // NewTestRepository はテスト用のMessageRepositoryを作成
 func NewTestRepository() (*MessageRepository, *TestCollection) {
 	mock := new(TestCollection)
@@ -222,3 +310,13 @@ func NewTestRepository() (*MessageRepository, *TestCollection) {
 		collection: mock,
 	}, mock
 }
+
+// NewTestTenantRepository はテスト用のTenantRepositoryを作成
+// 予約のコレクションも同じモックを使う
+func NewTestTenantRepository() (*TenantRepository, *TestCollection) {
+	mock := new(TestCollection)
+	return &TenantRepository{
+		collection:   mock,
+		reservations: mock,
+	}, mock
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"message-service/internal/domain/tenant"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TenantRepository はtenant.RepositoryのMongoDB実装
+// テナントIDをドキュメントの_idとし、既定のテナントはマイグレーションで作成する
+type TenantRepository struct {
+	collection MongoCollectionInterface
+	// reservations は作成中の件数の予約。テナントとResourceの組み合わせごとに1件のドキュメントを持つ
+	reservations MongoCollectionInterface
+}
+
+func NewTenantRepository(db *mongo.Database) *TenantRepository {
+	if db == nil {
+		panic("database connection is required")
+	}
+	collection := db.Collection("tenants")
+	if collection == nil {
+		panic("failed to get tenants collection")
+	}
+	return &TenantRepository{
+		collection:   NewMongoCollectionWrapper(collection),
+		reservations: NewMongoCollectionWrapper(db.Collection("tenant_reservations")),
+	}
+}
+
+func (r *TenantRepository) Create(ctx context.Context, t *tenant.Tenant) error {
+	now := time.Now()
+	t.CreatedAt = now
+	t.UpdatedAt = now
+
+	_, err := r.collection.InsertOne(ctx, t)
+	if mongo.IsDuplicateKeyError(err) {
+		return tenant.ErrDuplicateTenant
+	}
+	return err
+}
+
+func (r *TenantRepository) Update(ctx context.Context, t *tenant.Tenant) error {
+	update := bson.M{"$set": bson.M{
+		"name":       t.Name,
+		"quota":      t.Quota,
+		"updated_at": time.Now(),
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": t.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	updated, err := r.Get(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	if updated != nil {
+		*t = *updated
+	}
+	return nil
+}
+
+func (r *TenantRepository) Get(ctx context.Context, id string) (*tenant.Tenant, error) {
+	var t tenant.Tenant
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&t); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *TenantRepository) List(ctx context.Context) ([]tenant.Tenant, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tenants []tenant.Tenant
+	if err := cursor.All(ctx, &tenants); err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
+
+// reservationResult は予約のドキュメントのうち、件数のみを読み取る
+type reservationResult struct {
+	Reserved int64 `bson:"reserved"`
+}
+
+// reservationID は予約のドキュメントの_id
+func reservationID(id string, resource tenant.Resource) string {
+	return id + "/" + string(resource)
+}
+
+// Reserve は予約のドキュメントを1回の操作で作成または更新し、更新後の件数を返す
+// 期限を過ぎた予約は解放されなかったものとして、今回の件数から数え直す
+func (r *TenantRepository) Reserve(ctx context.Context, id string, resource tenant.Resource, n int64) (int64, error) {
+	now := time.Now()
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"reserved": bson.M{"$cond": bson.A{
+			bson.M{"$gt": bson.A{"$expires_at", now}},
+			bson.M{"$add": bson.A{"$reserved", n}},
+			n,
+		}},
+		"expires_at": now.Add(tenant.ReservationTimeout),
+	}}}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var result reservationResult
+	if err := r.reservations.FindOneAndUpdate(ctx, bson.M{"_id": reservationID(id, resource)}, update, opts).Decode(&result); err != nil {
+		return 0, err
+	}
+	return result.Reserved, nil
+}
+
+func (r *TenantRepository) Release(ctx context.Context, id string, resource tenant.Resource, n int64) error {
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"reserved": bson.M{"$max": bson.A{bson.M{"$subtract": bson.A{"$reserved", n}}, 0}},
+	}}}}
+	_, err := r.reservations.UpdateOne(ctx, bson.M{"_id": reservationID(id, resource)}, update)
+	return err
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"message-service/internal/domain/tenant"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestNewTenantRepository(t *testing.T) {
+	t.Run("異常系：DB接続がnil", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewTenantRepository(nil)
+		})
+	})
+}
+
+func TestTenantRepository_Create(t *testing.T) {
+	tests := []struct {
+		name          string
+		insertErr     error
+		expectedError error
+	}{
+		{name: "正常系：テナントの作成"},
+		{
+			name:          "異常系：IDが重複",
+			insertErr:     mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: duplicateKeyCode}}},
+			expectedError: tenant.ErrDuplicateTenant,
+		},
+		{
+			name:          "異常系：データベースエラー",
+			insertErr:     errors.New("database error"),
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mockCollection := NewTestTenantRepository()
+			var result *mongo.InsertOneResult
+			if tt.insertErr == nil {
+				result = &mongo.InsertOneResult{InsertedID: "team-a"}
+			}
+			mockCollection.On("InsertOne", mock.Anything, mock.AnythingOfType("*tenant.Tenant")).Return(result, tt.insertErr)
+
+			created := &tenant.Tenant{ID: "team-a", Name: "Team A"}
+			err := repo.Create(context.Background(), created)
+
+			if tt.expectedError != nil {
+				if errors.Is(tt.expectedError, tenant.ErrDuplicateTenant) {
+					assert.ErrorIs(t, err, tt.expectedError)
+				} else {
+					assert.EqualError(t, err, tt.expectedError.Error())
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotZero(t, created.CreatedAt)
+			mockCollection.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTenantRepository_Update(t *testing.T) {
+	t.Run("正常系：名前と上限を更新し、更新後の値を返す", func(t *testing.T) {
+		repo, mockCollection := NewTestTenantRepository()
+		quota := tenant.Quota{MaxMessages: 100}
+		mockCollection.On("UpdateOne", mock.Anything, bson.M{"_id": "team-a"}, mock.MatchedBy(func(update bson.M) bool {
+			set, ok := update["$set"].(bson.M)
+			return ok && set["name"] == "Renamed" && set["quota"] == quota && set["updated_at"] != nil
+		})).Return(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil)
+		mockCollection.On("FindOne", mock.Anything, bson.M{"_id": "team-a"}).
+			Return(NewTestSingleResult(&tenant.Tenant{ID: "team-a", Name: "Renamed", Quota: quota}, nil))
+
+		updated := &tenant.Tenant{ID: "team-a", Name: "Renamed", Quota: quota}
+		err := repo.Update(context.Background(), updated)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Renamed", updated.Name)
+		mockCollection.AssertExpectations(t)
+	})
+
+	t.Run("異常系：存在しないテナント", func(t *testing.T) {
+		repo, mockCollection := NewTestTenantRepository()
+		mockCollection.On("UpdateOne", mock.Anything, bson.M{"_id": "unknown"}, mock.Anything).
+			Return(&mongo.UpdateResult{}, nil)
+
+		err := repo.Update(context.Background(), &tenant.Tenant{ID: "unknown", Name: "Unknown"})
+
+		assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+	})
+}
+
+func TestTenantRepository_Get(t *testing.T) {
+	t.Run("正常系：存在しないテナントはnilを返す", func(t *testing.T) {
+		repo, mockCollection := NewTestTenantRepository()
+		mockCollection.On("FindOne", mock.Anything, bson.M{"_id": "unknown"}).
+			Return(NewTestSingleResult(nil, mongo.ErrNoDocuments))
+
+		found, err := repo.Get(context.Background(), "unknown")
+
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
+
+func TestTenantRepository_Reserve(t *testing.T) {
+	t.Run("正常系：テナントと種類ごとのドキュメントを更新し、更新後の件数を返す", func(t *testing.T) {
+		repo, mockCollection := NewTestTenantRepository()
+		mockCollection.On("FindOneAndUpdate", mock.Anything, bson.M{"_id": "team-a/messages"}, mock.Anything).
+			Return(NewTestSingleResult(&reservationResult{Reserved: 3}, nil))
+
+		reserved, err := repo.Reserve(context.Background(), "team-a", tenant.ResourceMessages, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), reserved)
+		mockCollection.AssertExpectations(t)
+	})
+
+	t.Run("異常系：更新に失敗", func(t *testing.T) {
+		repo, mockCollection := NewTestTenantRepository()
+		mockCollection.On("FindOneAndUpdate", mock.Anything, bson.M{"_id": "team-a/tokens"}, mock.Anything).
+			Return(NewTestSingleResult(nil, errors.New("database error")))
+
+		_, err := repo.Reserve(context.Background(), "team-a", tenant.ResourceTokens, 1)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestTenantRepository_Release(t *testing.T) {
+	repo, mockCollection := NewTestTenantRepository()
+	mockCollection.On("UpdateOne", mock.Anything, bson.M{"_id": "team-a/messages"}, mock.Anything).
+		Return(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil)
+
+	assert.NoError(t, repo.Release(context.Background(), "team-a", tenant.ResourceMessages, 2))
+	mockCollection.AssertExpectations(t)
+}
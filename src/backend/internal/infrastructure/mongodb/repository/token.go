@@ -3,6 +3,7 @@ package repository
 
 import (
 	"context"
+	"message-service/internal/domain/tenant"
 	"message-service/internal/domain/token"
 	"time"
 
@@ -12,6 +13,8 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// TokenRepository はtoken.RepositoryのMongoDB実装
+// 認証に使うFindByTokenを除き、コンテキストのテナントのトークンのみを対象とする
 type TokenRepository struct {
 	collection MongoCollectionInterface
 }
@@ -29,18 +32,27 @@ func NewTokenRepository(db *mongo.Database) *TokenRepository {
 	}
 }
 
-func (r *TokenRepository) Create(ctx context.Context, token *token.Token) error {
+func (r *TokenRepository) Create(ctx context.Context, tkn *token.Token) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
 	now := time.Now()
-	token.CreatedAt = now
-	token.UpdatedAt = now
+	tkn.CreatedAt = now
+	tkn.UpdatedAt = now
+	tkn.TenantID = tenantID
 
-	result, err := r.collection.InsertOne(ctx, token)
+	result, err := r.collection.InsertOne(ctx, tkn)
+	if mongo.IsDuplicateKeyError(err) {
+		return token.ErrDuplicateToken
+	}
 	if err != nil {
 		return err
 	}
 
 	if insertedID, ok := result.InsertedID.(primitive.ObjectID); ok {
-		token.ID = insertedID
+		tkn.ID = insertedID
 	}
 	return nil
 }
@@ -50,9 +62,13 @@ func (r *TokenRepository) Delete(ctx context.Context, id string) error {
 	if err != nil {
 		return err
 	}
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
 
 	now := time.Now()
-	filter := bson.M{"_id": objectID, "deleted_at": nil}
+	filter := bson.M{"_id": objectID, "tenant_id": tenantID, "deleted_at": nil}
 	update := bson.M{"$set": bson.M{
 		"deleted_at": now,
 		"updated_at": now,
@@ -70,7 +86,13 @@ func (r *TokenRepository) Delete(ctx context.Context, id string) error {
 }
 
 func (r *TokenRepository) List(ctx context.Context) ([]token.Token, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	filter := bson.M{
+		"tenant_id":  tenantID,
 		"deleted_at": nil,
 		"expires_at": bson.M{"$gt": time.Now()},
 	}
@@ -90,14 +112,33 @@ func (r *TokenRepository) List(ctx context.Context) ([]token.Token, error) {
 	return tokens, nil
 }
 
+func (r *TokenRepository) CountUpTo(ctx context.Context, limit int64) (int64, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	filter := bson.M{
+		"tenant_id":  tenantID,
+		"deleted_at": nil,
+		"expires_at": bson.M{"$gt": time.Now()},
+	}
+	return r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(limit))
+}
+
 func (r *TokenRepository) FindByID(ctx context.Context, id string) (*token.Token, error) {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return nil, err
 	}
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	filter := bson.M{
 		"_id":        objectID,
+		"tenant_id":  tenantID,
 		"deleted_at": nil,
 	}
 
@@ -112,6 +153,7 @@ func (r *TokenRepository) FindByID(ctx context.Context, id string) (*token.Token
 	return &tkn, nil
 }
 
+// FindByToken は認証のため、全てのテナントからトークンを探す
 func (r *TokenRepository) FindByToken(ctx context.Context, tokenString string) (*token.Token, error) {
 	filter := bson.M{
 		"token":      tokenString,
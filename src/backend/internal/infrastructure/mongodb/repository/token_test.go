@@ -70,7 +70,7 @@ func TestTokenRepository_Create(t *testing.T) {
 			repo, mock := NewTestTokenRepository()
 			tt.mockFn(mock)
 
-			err := repo.Create(context.Background(), tt.token)
+			err := repo.Create(testContext(), tt.token)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -83,6 +83,17 @@ func TestTokenRepository_Create(t *testing.T) {
 	}
 }
 
+func TestTokenRepository_Create_Duplicate(t *testing.T) {
+	repo, mockCollection := NewTestTokenRepository()
+	mockCollection.On("InsertOne", mock.Anything, mock.AnythingOfType("*token.Token")).
+		Return(nil, mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: duplicateKeyCode}}})
+
+	err := repo.Create(testContext(), &token.Token{Token: "duplicate-token"})
+
+	assert.ErrorIs(t, err, token.ErrDuplicateToken)
+	mockCollection.AssertExpectations(t)
+}
+
 func TestTokenRepository_List(t *testing.T) {
 	testTime := time.Now()
 	mockTokens := []token.Token{
@@ -131,7 +142,7 @@ func TestTokenRepository_List(t *testing.T) {
 			repo, mock := NewTestTokenRepository()
 			tt.mockFn(mock)
 
-			got, err := repo.List(context.Background())
+			got, err := repo.List(testContext())
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Nil(t, got)
@@ -185,7 +196,7 @@ func TestTokenRepository_Delete(t *testing.T) {
 			repo, mock := NewTestTokenRepository()
 			tt.mockFn(mock)
 
-			err := repo.Delete(context.Background(), tt.id)
+			err := repo.Delete(testContext(), tt.id)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -313,7 +324,7 @@ func TestTokenRepository_FindByID(t *testing.T) {
 			repo, mock := NewTestTokenRepository()
 			tt.mockFn(mock)
 
-			got, err := repo.FindByID(context.Background(), tt.id)
+			got, err := repo.FindByID(testContext(), tt.id)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -0,0 +1,195 @@
+package watcher
+
+import (
+	"context"
+	"message-service/internal/domain/message"
+	"message-service/internal/infrastructure/mongodb/repository"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mockCollection はWatchableCollectionのモック
+type mockCollection struct {
+	mock.Mock
+}
+
+func (m *mockCollection) Watch(ctx context.Context, pipeline interface{}, opts ...*options.ChangeStreamOptions) (ChangeStreamInterface, error) {
+	args := m.Called(ctx, pipeline, opts)
+	if stream, ok := args.Get(0).(ChangeStreamInterface); ok {
+		return stream, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (repository.CursorInterface, error) {
+	args := m.Called(ctx, filter)
+	if cursor, ok := args.Get(0).(repository.CursorInterface); ok {
+		return cursor, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// testChangeStream は固定のイベント列を返すチェンジストリーム
+type testChangeStream struct {
+	events   []changeEvent
+	position int
+	err      error
+	closed   bool
+}
+
+func (s *testChangeStream) Next(ctx context.Context) bool {
+	s.position++
+	return s.position <= len(s.events)
+}
+
+func (s *testChangeStream) Decode(val interface{}) error {
+	*val.(*changeEvent) = s.events[s.position-1]
+	return nil
+}
+
+func (s *testChangeStream) ResumeToken() bson.Raw {
+	raw, _ := bson.Marshal(bson.M{"_data": s.position})
+	return raw
+}
+
+func (s *testChangeStream) Err() error {
+	return s.err
+}
+
+func (s *testChangeStream) Close(ctx context.Context) error {
+	s.closed = true
+	return nil
+}
+
+// testCursor は固定のメッセージを返すカーソル
+type testCursor struct {
+	messages []message.Message
+}
+
+func (c *testCursor) Next(ctx context.Context) bool   { return false }
+func (c *testCursor) Decode(val interface{}) error    { return nil }
+func (c *testCursor) Close(ctx context.Context) error { return nil }
+func (c *testCursor) Err() error                      { return nil }
+func (c *testCursor) All(ctx context.Context, results interface{}) error {
+	*results.(*[]message.Message) = c.messages
+	return nil
+}
+
+// mockResumeTokenStore はResumeTokenStoreのモック
+type mockResumeTokenStore struct {
+	mock.Mock
+	saved []ResumeState
+}
+
+func (m *mockResumeTokenStore) Load(ctx context.Context, name string) (*ResumeState, error) {
+	args := m.Called(ctx, name)
+	if state, ok := args.Get(0).(*ResumeState); ok {
+		return state, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockResumeTokenStore) Save(ctx context.Context, state *ResumeState) error {
+	m.saved = append(m.saved, *state)
+	return nil
+}
+
+// recordingPublisher は受け取ったイベントを記録する
+type recordingPublisher struct {
+	events []message.Event
+	err    error
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event message.Event) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.events = append(p.events, event)
+	return nil
+}
+
+// mockMongoCollection はrepository.MongoCollectionInterfaceのモック
+type mockMongoCollection struct {
+	mock.Mock
+}
+
+func (m *mockMongoCollection) InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	args := m.Called(ctx, document)
+	return nil, args.Error(1)
+}
+
+func (m *mockMongoCollection) InsertMany(ctx context.Context, documents []interface{}, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
+	args := m.Called(ctx, documents)
+	return nil, args.Error(1)
+}
+
+func (m *mockMongoCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	args := m.Called(ctx, filter, update)
+	if result, ok := args.Get(0).(*mongo.UpdateResult); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockMongoCollection) UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	args := m.Called(ctx, filter, update)
+	return nil, args.Error(1)
+}
+
+func (m *mockMongoCollection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	args := m.Called(ctx, filter)
+	return 0, args.Error(1)
+}
+
+func (m *mockMongoCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (repository.CursorInterface, error) {
+	args := m.Called(ctx, filter)
+	return nil, args.Error(1)
+}
+
+func (m *mockMongoCollection) Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (repository.CursorInterface, error) {
+	args := m.Called(ctx, pipeline)
+	return nil, args.Error(1)
+}
+
+func (m *mockMongoCollection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) repository.SingleResult {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(repository.SingleResult)
+}
+
+func (m *mockMongoCollection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) repository.SingleResult {
+	args := m.Called(ctx, filter, update)
+	return args.Get(0).(repository.SingleResult)
+}
+
+// testSingleResult は固定の結果を返すSingleResult
+type testSingleResult struct {
+	state *ResumeState
+	err   error
+}
+
+func (r *testSingleResult) Decode(v interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	*v.(*ResumeState) = *r.state
+	return nil
+}
+
+// newTestWatcher はテスト用のWatcherを作成
+func newTestWatcher(publisher message.EventPublisher) (*Watcher, *mockCollection, *mockResumeTokenStore) {
+	coll := new(mockCollection)
+	store := new(mockResumeTokenStore)
+	return &Watcher{
+		collection:   coll,
+		store:        store,
+		publisher:    publisher,
+		name:         messagesWatcherName,
+		pollInterval: DefaultPollInterval,
+		minBackoff:   time.Millisecond,
+		maxBackoff:   time.Millisecond,
+	}, coll, store
+}
@@ -0,0 +1,70 @@
+package watcher
+
+import (
+	"context"
+	"message-service/internal/infrastructure/mongodb/repository"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ResumeState は監視を再開するための位置情報
+// チェンジストリーム利用時はResumeToken、ポーリング時はPolledAtとPolledIDを使用する
+type ResumeState struct {
+	Name        string    `bson:"_id"`
+	ResumeToken bson.Raw  `bson:"resume_token,omitempty"`
+	PolledAt    time.Time `bson:"polled_at,omitempty"`
+	// PolledID はPolledAtと同じ更新日時のドキュメントのうち、最後に配信したドキュメントのID
+	// 未設定（ゼロ値）の場合はPolledAtと同じ更新日時のドキュメントを全て配信し直す
+	PolledID  primitive.ObjectID `bson:"polled_id,omitempty"`
+	UpdatedAt time.Time          `bson:"updated_at"`
+}
+
+// ResumeTokenStore は再開位置を永続化するインターフェース
+type ResumeTokenStore interface {
+	Load(ctx context.Context, name string) (*ResumeState, error)
+	Save(ctx context.Context, state *ResumeState) error
+}
+
+// MongoResumeTokenStore はresume_tokensコレクションに再開位置を保存する
+type MongoResumeTokenStore struct {
+	collection repository.MongoCollectionInterface
+}
+
+func NewMongoResumeTokenStore(db *mongo.Database) *MongoResumeTokenStore {
+	if db == nil {
+		panic("database connection is required")
+	}
+
+	return &MongoResumeTokenStore{
+		collection: repository.NewMongoCollectionWrapper(db.Collection("resume_tokens")),
+	}
+}
+
+func (s *MongoResumeTokenStore) Load(ctx context.Context, name string) (*ResumeState, error) {
+	var state ResumeState
+	if err := s.collection.FindOne(ctx, bson.M{"_id": name}).Decode(&state); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (s *MongoResumeTokenStore) Save(ctx context.Context, state *ResumeState) error {
+	state.UpdatedAt = time.Now()
+
+	filter := bson.M{"_id": state.Name}
+	update := bson.M{"$set": bson.M{
+		"resume_token": state.ResumeToken,
+		"polled_at":    state.PolledAt,
+		"polled_id":    state.PolledID,
+		"updated_at":   state.UpdatedAt,
+	}}
+	_, err := s.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
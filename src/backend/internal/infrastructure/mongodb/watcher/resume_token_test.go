@@ -0,0 +1,81 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestNewMongoResumeTokenStore(t *testing.T) {
+	assert.Panics(t, func() {
+		NewMongoResumeTokenStore(nil)
+	})
+}
+
+func TestMongoResumeTokenStore_Load(t *testing.T) {
+	token, _ := bson.Marshal(bson.M{"_data": "token"})
+
+	tests := []struct {
+		name    string
+		result  *testSingleResult
+		want    *ResumeState
+		wantErr bool
+	}{
+		{
+			name:   "正常系：保存済みの再開位置を取得",
+			result: &testSingleResult{state: &ResumeState{Name: "messages", ResumeToken: token}},
+			want:   &ResumeState{Name: "messages", ResumeToken: token},
+		},
+		{
+			name:   "正常系：未保存の場合はnil",
+			result: &testSingleResult{err: mongo.ErrNoDocuments},
+			want:   nil,
+		},
+		{
+			name:    "異常系：データベースエラー",
+			result:  &testSingleResult{err: errors.New("database error")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coll := new(mockMongoCollection)
+			store := &MongoResumeTokenStore{collection: coll}
+			coll.On("FindOne", mock.Anything, bson.M{"_id": "messages"}).Return(tt.result)
+
+			got, err := store.Load(context.Background(), "messages")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			coll.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMongoResumeTokenStore_Save(t *testing.T) {
+	coll := new(mockMongoCollection)
+	store := &MongoResumeTokenStore{collection: coll}
+	state := &ResumeState{Name: "messages", PolledAt: time.Now()}
+
+	coll.On("UpdateOne", mock.Anything, bson.M{"_id": "messages"}, mock.MatchedBy(func(update bson.M) bool {
+		set := update["$set"].(bson.M)
+		return set["polled_at"] == state.PolledAt
+	})).Return(&mongo.UpdateResult{UpsertedCount: 1}, nil)
+
+	err := store.Save(context.Background(), state)
+
+	assert.NoError(t, err)
+	assert.NotZero(t, state.UpdatedAt)
+	coll.AssertExpectations(t)
+}
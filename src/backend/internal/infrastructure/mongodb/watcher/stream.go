@@ -0,0 +1,51 @@
+package watcher
+
+import (
+	"context"
+	"message-service/internal/infrastructure/mongodb/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ChangeStreamInterface はチェンジストリーム操作に必要なメソッドを定義するインターフェース
+type ChangeStreamInterface interface {
+	Next(ctx context.Context) bool
+	Decode(val interface{}) error
+	ResumeToken() bson.Raw
+	Err() error
+	Close(ctx context.Context) error
+}
+
+// WatchableCollection は監視対象のコレクションに必要なメソッドを定義するインターフェース
+type WatchableCollection interface {
+	Watch(ctx context.Context, pipeline interface{}, opts ...*options.ChangeStreamOptions) (ChangeStreamInterface, error)
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (repository.CursorInterface, error)
+}
+
+// MongoWatchableCollection は*mongo.CollectionをWatchableCollectionに適合させるアダプター
+type MongoWatchableCollection struct {
+	coll *mongo.Collection
+}
+
+func (c *MongoWatchableCollection) Watch(ctx context.Context, pipeline interface{}, opts ...*options.ChangeStreamOptions) (ChangeStreamInterface, error) {
+	stream, err := c.coll.Watch(ctx, pipeline, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (c *MongoWatchableCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (repository.CursorInterface, error) {
+	cursor, err := c.coll.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.MongoCursorWrapper{Cursor: cursor}, nil
+}
+
+// NewWatchableCollection は*mongo.CollectionからWatchableCollectionを作成
+func NewWatchableCollection(coll *mongo.Collection) WatchableCollection {
+	return &MongoWatchableCollection{coll: coll}
+}
@@ -0,0 +1,20 @@
+package watcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestNewWatchableCollection(t *testing.T) {
+	coll := NewWatchableCollection(&mongo.Collection{})
+
+	assert.NotNil(t, coll)
+	assert.IsType(t, &MongoWatchableCollection{}, coll)
+
+	t.Run("インターフェースの実装を確認", func(t *testing.T) {
+		var _ WatchableCollection = &MongoWatchableCollection{}
+		var _ ChangeStreamInterface = &mongo.ChangeStream{}
+	})
+}
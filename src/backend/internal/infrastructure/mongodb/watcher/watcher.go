@@ -0,0 +1,267 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"message-service/internal/domain/message"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// DefaultPollInterval はチェンジストリームが使えない場合のポーリング間隔
+	DefaultPollInterval = 5 * time.Second
+
+	// defaultPollBatchSize は1回のポーリングで取得する最大件数
+	defaultPollBatchSize = 500
+
+	// DefaultRetryMinBackoff はRunWithRetryで監視が止まってから再開するまでの最初の待ち時間
+	DefaultRetryMinBackoff = time.Second
+	// DefaultRetryMaxBackoff はRunWithRetryで監視が止まってから再開するまでの最長の待ち時間
+	DefaultRetryMaxBackoff = time.Minute
+
+	// changeStreamUnsupportedCode はスタンドアロン構成でチェンジストリームを開いた時のエラーコード
+	changeStreamUnsupportedCode = 40573
+
+	messagesWatcherName = "messages"
+)
+
+// changeEvent はチェンジストリームから受け取るドキュメント
+type changeEvent struct {
+	OperationType     string           `bson:"operationType"`
+	FullDocument      *message.Message `bson:"fullDocument"`
+	UpdateDescription struct {
+		UpdatedFields bson.M `bson:"updatedFields"`
+	} `bson:"updateDescription"`
+}
+
+// Watcher はmessagesコレクションの変更をドメインイベントに変換して配信する
+type Watcher struct {
+	collection   WatchableCollection
+	store        ResumeTokenStore
+	publisher    message.EventPublisher
+	name         string
+	pollInterval time.Duration
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+}
+
+func NewWatcher(db *mongo.Database, publisher message.EventPublisher) *Watcher {
+	if db == nil {
+		panic("database connection is required")
+	}
+
+	return &Watcher{
+		collection:   NewWatchableCollection(db.Collection("messages")),
+		store:        NewMongoResumeTokenStore(db),
+		publisher:    publisher,
+		name:         messagesWatcherName,
+		pollInterval: DefaultPollInterval,
+		minBackoff:   DefaultRetryMinBackoff,
+		maxBackoff:   DefaultRetryMaxBackoff,
+	}
+}
+
+// Run はctxがキャンセルされるまで変更を監視する
+// チェンジストリームが利用できない場合はupdated_atによるポーリングに切り替える
+func (w *Watcher) Run(ctx context.Context) error {
+	state, err := w.store.Load(ctx, w.name)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		state = &ResumeState{Name: w.name}
+	}
+
+	err = w.watch(ctx, state)
+	if isChangeStreamUnsupported(err) {
+		return w.poll(ctx, state)
+	}
+	return err
+}
+
+// RunWithRetry はctxがキャンセルされるまでRunを繰り返す
+// 接続断などで監視が止まった場合は待ち時間を倍にしながら再開し、保存済みの位置から監視を続ける
+func (w *Watcher) RunWithRetry(ctx context.Context) {
+	backoff := w.minBackoff
+	for {
+		started := time.Now()
+		err := w.Run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		// しばらく監視できていた場合は、次に止まった時の待ち時間を最初に戻す
+		if time.Since(started) > w.maxBackoff {
+			backoff = w.minBackoff
+		}
+
+		attrs := []any{slog.String("watcher", w.name), slog.Duration("retry_in", backoff)}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+		slog.WarnContext(ctx, "Message watcher stopped, restarting", attrs...)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, w.maxBackoff)
+	}
+}
+
+func (w *Watcher) watch(ctx context.Context, state *ResumeState) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"operationType": bson.M{"$in": bson.A{"insert", "update", "replace"}},
+		}}},
+	}
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if len(state.ResumeToken) > 0 {
+		opts.SetResumeAfter(state.ResumeToken)
+	}
+
+	stream, err := w.collection.Watch(ctx, pipeline, opts)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.Background())
+
+	for stream.Next(ctx) {
+		var change changeEvent
+		if err := stream.Decode(&change); err != nil {
+			return err
+		}
+
+		if event, ok := toEvent(change); ok {
+			if err := w.publisher.Publish(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		state.ResumeToken = stream.ResumeToken()
+		if err := w.store.Save(ctx, state); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	return stream.Err()
+}
+
+func (w *Watcher) poll(ctx context.Context, state *ResumeState) error {
+	if state.PolledAt.IsZero() {
+		state.PolledAt = time.Now()
+	}
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.pollOnce(ctx, state); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollOnce は前回の位置より後に更新されたドキュメントを(updated_at, _id)の順に取得して配信する
+// 一括作成・更新では多数のドキュメントが同じupdated_atを持つため、
+// 同じ時刻のドキュメントが取得件数の上限で分かれても_idで続きから取得できるようにする
+func (w *Watcher) pollOnce(ctx context.Context, state *ResumeState) error {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"updated_at": bson.M{"$gt": state.PolledAt}},
+		bson.M{"updated_at": state.PolledAt, "_id": bson.M{"$gt": state.PolledID}},
+	}}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(defaultPollBatchSize)
+
+	cursor, err := w.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var messages []message.Message
+	if err := cursor.All(ctx, &messages); err != nil {
+		return err
+	}
+
+	for _, msg := range messages {
+		if err := w.publisher.Publish(ctx, message.Event{
+			Type:       pollEventType(msg),
+			Message:    msg,
+			OccurredAt: msg.UpdatedAt,
+		}); err != nil {
+			return err
+		}
+		state.PolledAt = msg.UpdatedAt
+		state.PolledID = msg.ID
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+	return w.store.Save(ctx, state)
+}
+
+// toEvent はチェンジストリームのイベントをドメインイベントに変換する
+func toEvent(change changeEvent) (message.Event, bool) {
+	if change.FullDocument == nil {
+		// updateLookup時点で物理削除されていた場合
+		return message.Event{}, false
+	}
+
+	var eventType message.EventType
+	switch change.OperationType {
+	case "insert":
+		eventType = message.EventCreated
+	case "update":
+		eventType = message.EventUpdated
+		if _, ok := change.UpdateDescription.UpdatedFields["deleted_at"]; ok && change.FullDocument.DeletedAt != nil {
+			eventType = message.EventDeleted
+		}
+	case "replace":
+		eventType = message.EventUpdated
+		if change.FullDocument.DeletedAt != nil {
+			eventType = message.EventDeleted
+		}
+	default:
+		return message.Event{}, false
+	}
+
+	return message.Event{
+		Type:       eventType,
+		Message:    *change.FullDocument,
+		OccurredAt: change.FullDocument.UpdatedAt,
+	}, true
+}
+
+// pollEventType はポーリングで取得したドキュメントからイベント種別を推定する
+func pollEventType(msg message.Message) message.EventType {
+	switch {
+	case msg.DeletedAt != nil && msg.DeletedAt.Equal(msg.UpdatedAt):
+		return message.EventDeleted
+	case msg.CreatedAt.Equal(msg.UpdatedAt):
+		return message.EventCreated
+	default:
+		return message.EventUpdated
+	}
+}
+
+func isChangeStreamUnsupported(err error) bool {
+	var serverErr mongo.ServerError
+	return errors.As(err, &serverErr) && serverErr.HasErrorCode(changeStreamUnsupportedCode)
+}
@@ -0,0 +1,292 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"message-service/internal/domain/message"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func createTestMessage(uid string) *message.Message {
+	now := time.Now()
+	return &message.Message{
+		UID:       uid,
+		SentAt:    now,
+		Sender:    "test-sender",
+		ChannelID: "test-channel",
+		Content:   "test message",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func TestNewWatcher(t *testing.T) {
+	assert.Panics(t, func() {
+		NewWatcher(nil, &recordingPublisher{})
+	})
+}
+
+func TestToEvent(t *testing.T) {
+	deletedMsg := createTestMessage("deleted-uid")
+	deletedAt := deletedMsg.UpdatedAt
+	deletedMsg.DeletedAt = &deletedAt
+
+	tests := []struct {
+		name     string
+		change   changeEvent
+		wantType message.EventType
+		wantOK   bool
+	}{
+		{
+			name:     "insertは作成イベント",
+			change:   changeEvent{OperationType: "insert", FullDocument: createTestMessage("uid")},
+			wantType: message.EventCreated,
+			wantOK:   true,
+		},
+		{
+			name:     "updateは更新イベント",
+			change:   changeEvent{OperationType: "update", FullDocument: createTestMessage("uid")},
+			wantType: message.EventUpdated,
+			wantOK:   true,
+		},
+		{
+			name: "deleted_atの更新は削除イベント",
+			change: func() changeEvent {
+				c := changeEvent{OperationType: "update", FullDocument: deletedMsg}
+				c.UpdateDescription.UpdatedFields = bson.M{"deleted_at": deletedAt}
+				return c
+			}(),
+			wantType: message.EventDeleted,
+			wantOK:   true,
+		},
+		{
+			name:     "削除済みドキュメントのreplaceは削除イベント",
+			change:   changeEvent{OperationType: "replace", FullDocument: deletedMsg},
+			wantType: message.EventDeleted,
+			wantOK:   true,
+		},
+		{
+			name:   "fullDocumentが無い場合は無視",
+			change: changeEvent{OperationType: "update"},
+			wantOK: false,
+		},
+		{
+			name:   "対象外の操作は無視",
+			change: changeEvent{OperationType: "drop", FullDocument: createTestMessage("uid")},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, ok := toEvent(tt.change)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.wantType, event.Type)
+				assert.Equal(t, tt.change.FullDocument.UID, event.Message.UID)
+			}
+		})
+	}
+}
+
+func TestPollEventType(t *testing.T) {
+	created := createTestMessage("created")
+
+	updated := createTestMessage("updated")
+	updated.UpdatedAt = updated.CreatedAt.Add(time.Minute)
+
+	deleted := createTestMessage("deleted")
+	deleted.UpdatedAt = deleted.CreatedAt.Add(time.Minute)
+	deletedAt := deleted.UpdatedAt
+	deleted.DeletedAt = &deletedAt
+
+	assert.Equal(t, message.EventCreated, pollEventType(*created))
+	assert.Equal(t, message.EventUpdated, pollEventType(*updated))
+	assert.Equal(t, message.EventDeleted, pollEventType(*deleted))
+}
+
+func TestWatcher_Run_ChangeStream(t *testing.T) {
+	t.Run("正常系：変更をイベントとして配信し再開トークンを保存", func(t *testing.T) {
+		publisher := &recordingPublisher{}
+		w, coll, store := newTestWatcher(publisher)
+		stream := &testChangeStream{events: []changeEvent{
+			{OperationType: "insert", FullDocument: createTestMessage("uid-1")},
+			{OperationType: "update", FullDocument: createTestMessage("uid-2")},
+		}}
+
+		store.On("Load", mock.Anything, messagesWatcherName).Return(nil, nil)
+		coll.On("Watch", mock.Anything, mock.Anything, mock.Anything).Return(stream, nil)
+
+		err := w.Run(context.Background())
+
+		assert.NoError(t, err)
+		assert.Len(t, publisher.events, 2)
+		assert.Equal(t, message.EventCreated, publisher.events[0].Type)
+		assert.Equal(t, message.EventUpdated, publisher.events[1].Type)
+		assert.Len(t, store.saved, 2)
+		assert.NotEmpty(t, store.saved[1].ResumeToken)
+		assert.True(t, stream.closed)
+	})
+
+	t.Run("正常系：保存済みのトークンから再開", func(t *testing.T) {
+		w, coll, store := newTestWatcher(&recordingPublisher{})
+		token, _ := bson.Marshal(bson.M{"_data": "saved"})
+
+		store.On("Load", mock.Anything, messagesWatcherName).
+			Return(&ResumeState{Name: messagesWatcherName, ResumeToken: token}, nil)
+		coll.On("Watch", mock.Anything, mock.Anything, mock.MatchedBy(func(opts []*options.ChangeStreamOptions) bool {
+			return len(opts) == 1 && opts[0].ResumeAfter != nil
+		})).Return(&testChangeStream{}, nil)
+
+		err := w.Run(context.Background())
+
+		assert.NoError(t, err)
+		coll.AssertExpectations(t)
+	})
+
+	t.Run("異常系：配信エラー", func(t *testing.T) {
+		w, coll, store := newTestWatcher(&recordingPublisher{err: errors.New("publish error")})
+		stream := &testChangeStream{events: []changeEvent{
+			{OperationType: "insert", FullDocument: createTestMessage("uid-1")},
+		}}
+
+		store.On("Load", mock.Anything, messagesWatcherName).Return(nil, nil)
+		coll.On("Watch", mock.Anything, mock.Anything, mock.Anything).Return(stream, nil)
+
+		err := w.Run(context.Background())
+
+		assert.Error(t, err)
+		assert.Empty(t, store.saved)
+	})
+
+	t.Run("異常系：再開位置の読み込みエラー", func(t *testing.T) {
+		w, _, store := newTestWatcher(&recordingPublisher{})
+		store.On("Load", mock.Anything, messagesWatcherName).Return(nil, errors.New("load error"))
+
+		err := w.Run(context.Background())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWatcher_RunWithRetry(t *testing.T) {
+	t.Run("正常系：監視が止まった場合は保存済みの位置から再開する", func(t *testing.T) {
+		w, coll, store := newTestWatcher(&recordingPublisher{})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		token, _ := bson.Marshal(bson.M{"_data": "saved"})
+
+		store.On("Load", mock.Anything, messagesWatcherName).Return(nil, errors.New("load error")).Once()
+		coll.On("Watch", mock.Anything, mock.Anything, mock.Anything).
+			Return(&testChangeStream{err: errors.New("connection reset")}, nil).Once()
+		store.On("Load", mock.Anything, messagesWatcherName).
+			Return(&ResumeState{Name: messagesWatcherName, ResumeToken: token}, nil)
+		coll.On("Watch", mock.Anything, mock.Anything, mock.MatchedBy(func(opts []*options.ChangeStreamOptions) bool {
+			return len(opts) == 1 && opts[0].ResumeAfter != nil
+		})).Run(func(args mock.Arguments) { cancel() }).Return(&testChangeStream{}, nil)
+
+		w.RunWithRetry(ctx)
+
+		store.AssertNumberOfCalls(t, "Load", 3)
+		coll.AssertNumberOfCalls(t, "Watch", 2)
+	})
+
+	t.Run("正常系：キャンセルされた場合は再開しない", func(t *testing.T) {
+		w, _, store := newTestWatcher(&recordingPublisher{})
+		ctx, cancel := context.WithCancel(context.Background())
+		store.On("Load", mock.Anything, messagesWatcherName).
+			Run(func(args mock.Arguments) { cancel() }).
+			Return(nil, context.Canceled)
+
+		w.RunWithRetry(ctx)
+
+		store.AssertNumberOfCalls(t, "Load", 1)
+	})
+}
+
+func TestWatcher_Run_PollingFallback(t *testing.T) {
+	publisher := &recordingPublisher{}
+	w, coll, store := newTestWatcher(publisher)
+	w.pollInterval = time.Millisecond
+	msg := createTestMessage("uid-1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store.On("Load", mock.Anything, messagesWatcherName).Return(nil, nil)
+	coll.On("Watch", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, mongo.CommandError{Code: changeStreamUnsupportedCode, Message: "not a replica set"})
+	coll.On("Find", mock.Anything, mock.Anything).
+		Return(&testCursor{messages: []message.Message{*msg}}, nil).Once()
+	coll.On("Find", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { cancel() }).
+		Return(&testCursor{}, nil)
+
+	err := w.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, publisher.events, 1)
+	assert.Equal(t, message.EventCreated, publisher.events[0].Type)
+	assert.Len(t, store.saved, 1)
+	assert.True(t, store.saved[0].PolledAt.Equal(msg.UpdatedAt))
+}
+
+func TestWatcher_PollOnce(t *testing.T) {
+	t.Run("異常系：検索エラー", func(t *testing.T) {
+		w, coll, _ := newTestWatcher(&recordingPublisher{})
+		coll.On("Find", mock.Anything, mock.Anything).Return(nil, errors.New("find error"))
+
+		err := w.pollOnce(context.Background(), &ResumeState{Name: messagesWatcherName})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("正常系：同じ更新日時のドキュメントは_idの続きから取得する", func(t *testing.T) {
+		publisher := &recordingPublisher{}
+		w, coll, store := newTestWatcher(publisher)
+		updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		polledID, lastID := primitive.NewObjectID(), primitive.NewObjectID()
+		msg := createTestMessage("uid-1")
+		msg.ID, msg.UpdatedAt = lastID, updatedAt
+
+		coll.On("Find", mock.Anything, bson.M{"$or": bson.A{
+			bson.M{"updated_at": bson.M{"$gt": updatedAt}},
+			bson.M{"updated_at": updatedAt, "_id": bson.M{"$gt": polledID}},
+		}}).Return(&testCursor{messages: []message.Message{*msg}}, nil)
+
+		state := &ResumeState{Name: messagesWatcherName, PolledAt: updatedAt, PolledID: polledID}
+		err := w.pollOnce(context.Background(), state)
+
+		assert.NoError(t, err)
+		coll.AssertExpectations(t)
+		assert.Len(t, publisher.events, 1)
+		assert.True(t, state.PolledAt.Equal(updatedAt))
+		assert.Equal(t, lastID, state.PolledID)
+		assert.Len(t, store.saved, 1)
+	})
+
+	t.Run("正常系：変更なしの場合は保存しない", func(t *testing.T) {
+		w, coll, store := newTestWatcher(&recordingPublisher{})
+		coll.On("Find", mock.Anything, mock.Anything).Return(&testCursor{}, nil)
+
+		err := w.pollOnce(context.Background(), &ResumeState{Name: messagesWatcherName})
+
+		assert.NoError(t, err)
+		assert.Empty(t, store.saved)
+	})
+}
+
+func TestIsChangeStreamUnsupported(t *testing.T) {
+	assert.True(t, isChangeStreamUnsupported(mongo.CommandError{Code: changeStreamUnsupportedCode}))
+	assert.False(t, isChangeStreamUnsupported(mongo.CommandError{Code: 1}))
+	assert.False(t, isChangeStreamUnsupported(errors.New("other error")))
+	assert.False(t, isChangeStreamUnsupported(nil))
+}
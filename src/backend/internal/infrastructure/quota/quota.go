@@ -0,0 +1,208 @@
+// Package quota はテナントの上限を超える作成を拒否するリポジトリを提供する
+// 作成の前にテナントの作成中の件数を予約し、既存の件数に他の作成中の件数を加えて上限を確認するため、
+// 複数のレプリカで同時に作成されても上限を超えない
+package quota
+
+import (
+	"context"
+	"log/slog"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/domain/token"
+)
+
+// limiter はテナントの上限に収まる件数を判定する
+type limiter struct {
+	tenants  tenant.Repository
+	resource tenant.Resource
+}
+
+// reserve はコンテキストのテナントでn件を作成中として予約し、そのうち上限に収まる件数を返す
+// countは削除されておらず有効期限内の件数をlimit件まで数える
+// 上限のないテナントは予約も件数の確認も行わない。作成が終わったらreleaseで予約を戻すこと
+func (l *limiter) reserve(ctx context.Context, n int64, count func(limit int64) (int64, error)) (allowed int64, release func(), err error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	t, err := l.tenants.Get(ctx, tenantID)
+	if err != nil {
+		return 0, nil, err
+	}
+	// 上限のないテナントは件数を数えない
+	if t == nil || t.Quota.Limit(l.resource) == 0 {
+		return n, func() {}, nil
+	}
+	limit := t.Quota.Limit(l.resource)
+
+	reserved, err := l.tenants.Reserve(ctx, tenantID, l.resource, n)
+	if err != nil {
+		return 0, nil, err
+	}
+	release = func() {
+		// リクエストが取り消された場合も予約は戻す。戻せなかった予約は期限を過ぎると数えなくなる
+		ctx := context.WithoutCancel(ctx)
+		if err := l.tenants.Release(ctx, tenantID, l.resource, n); err != nil {
+			slog.WarnContext(ctx, "Failed to release quota reservation",
+				slog.String("tenant_id", tenantID), slog.String("resource", string(l.resource)), slog.String("error", err.Error()))
+		}
+	}
+
+	used, err := count(limit)
+	if err != nil {
+		release()
+		return 0, nil, err
+	}
+	// 他の作成中の分は全て作成されるものとして扱う
+	// 作成済みで件数にも含まれている場合は二重に数えて早めに拒否することはあるが、上限を超えることはない
+	others := reserved - n
+	return min(max(limit-used-others, 0), n), release, nil
+}
+
+// messageRepository はテナントのメッセージ数の上限を確認してから作成する
+type messageRepository struct {
+	next    message.Repository
+	limiter *limiter
+}
+
+// NewMessageRepository はコンテキストのテナントのメッセージ数が上限に達している場合に
+// 作成をtenant.ErrQuotaExceededで拒否するリポジトリを返す
+func NewMessageRepository(repo message.Repository, tenants tenant.Repository) message.Repository {
+	return &messageRepository{
+		next:    repo,
+		limiter: &limiter{tenants: tenants, resource: tenant.ResourceMessages},
+	}
+}
+
+// reserve はn件のメッセージを予約し、そのうち上限に収まる件数を返す
+func (r *messageRepository) reserve(ctx context.Context, n int) (int64, func(), error) {
+	return r.limiter.reserve(ctx, int64(n), func(limit int64) (int64, error) {
+		return r.next.CountUpTo(ctx, message.SearchCriteria{}, limit)
+	})
+}
+
+func (r *messageRepository) Create(ctx context.Context, msg *message.Message) error {
+	allowed, release, err := r.reserve(ctx, 1)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if allowed < 1 {
+		return tenant.ErrQuotaExceeded
+	}
+	return r.next.Create(ctx, msg)
+}
+
+// CreateMany は上限に収まる先頭からの件数のみ作成し、残りのメッセージの位置にtenant.ErrQuotaExceededを入れる
+func (r *messageRepository) CreateMany(ctx context.Context, msgs []*message.Message) ([]error, error) {
+	allowed, release, err := r.reserve(ctx, len(msgs))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if allowed >= int64(len(msgs)) {
+		return r.next.CreateMany(ctx, msgs)
+	}
+
+	errs := make([]error, len(msgs))
+	for i := allowed; i < int64(len(msgs)); i++ {
+		errs[i] = tenant.ErrQuotaExceeded
+	}
+	if allowed == 0 {
+		return errs, nil
+	}
+	created, err := r.next.CreateMany(ctx, msgs[:allowed])
+	if err != nil {
+		return nil, err
+	}
+	copy(errs, created)
+	return errs, nil
+}
+
+func (r *messageRepository) Delete(ctx context.Context, uid string) error {
+	return r.next.Delete(ctx, uid)
+}
+
+func (r *messageRepository) Search(ctx context.Context, criteria message.SearchCriteria, opts message.SearchOptions) ([]message.Message, error) {
+	return r.next.Search(ctx, criteria, opts)
+}
+
+func (r *messageRepository) Iterate(ctx context.Context, criteria message.SearchCriteria, fn func(*message.Message) error) error {
+	return r.next.Iterate(ctx, criteria, fn)
+}
+
+func (r *messageRepository) FindByUID(ctx context.Context, uid string) (*message.Message, error) {
+	return r.next.FindByUID(ctx, uid)
+}
+
+func (r *messageRepository) Count(ctx context.Context, criteria message.SearchCriteria) (int64, error) {
+	return r.next.Count(ctx, criteria)
+}
+
+func (r *messageRepository) CountUpTo(ctx context.Context, criteria message.SearchCriteria, limit int64) (int64, error) {
+	return r.next.CountUpTo(ctx, criteria, limit)
+}
+
+func (r *messageRepository) DeleteMany(ctx context.Context, criteria message.SearchCriteria) (int64, error) {
+	return r.next.DeleteMany(ctx, criteria)
+}
+
+func (r *messageRepository) Around(ctx context.Context, target *message.Message, before, after int) ([]message.Message, []message.Message, error) {
+	return r.next.Around(ctx, target, before, after)
+}
+
+func (r *messageRepository) Stats(ctx context.Context, query message.StatsQuery) ([]message.StatsBucket, error) {
+	return r.next.Stats(ctx, query)
+}
+
+// tokenRepository はテナントのトークン数の上限を確認してから作成する
+type tokenRepository struct {
+	next    token.Repository
+	limiter *limiter
+}
+
+// NewTokenRepository はコンテキストのテナントのトークン数が上限に達している場合に
+// 作成をtenant.ErrQuotaExceededで拒否するリポジトリを返す
+func NewTokenRepository(repo token.Repository, tenants tenant.Repository) token.Repository {
+	return &tokenRepository{
+		next:    repo,
+		limiter: &limiter{tenants: tenants, resource: tenant.ResourceTokens},
+	}
+}
+
+func (r *tokenRepository) Create(ctx context.Context, tkn *token.Token) error {
+	allowed, release, err := r.limiter.reserve(ctx, 1, func(limit int64) (int64, error) {
+		return r.next.CountUpTo(ctx, limit)
+	})
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if allowed < 1 {
+		return tenant.ErrQuotaExceeded
+	}
+	return r.next.Create(ctx, tkn)
+}
+
+func (r *tokenRepository) Delete(ctx context.Context, id string) error {
+	return r.next.Delete(ctx, id)
+}
+
+func (r *tokenRepository) List(ctx context.Context) ([]token.Token, error) {
+	return r.next.List(ctx)
+}
+
+func (r *tokenRepository) CountUpTo(ctx context.Context, limit int64) (int64, error) {
+	return r.next.CountUpTo(ctx, limit)
+}
+
+func (r *tokenRepository) FindByID(ctx context.Context, id string) (*token.Token, error) {
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *tokenRepository) FindByToken(ctx context.Context, tokenString string) (*token.Token, error) {
+	return r.next.FindByToken(ctx, tokenString)
+}
@@ -0,0 +1,226 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/domain/token"
+	"message-service/internal/infrastructure/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMessage(uid string) *message.Message {
+	return &message.Message{
+		UID:       uid,
+		SentAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Sender:    "alice",
+		ChannelID: "general",
+		Content:   "hello",
+	}
+}
+
+// newTestTenants は上限付きのテナントlimitedを含むリポジトリを返す
+func newTestTenants(t *testing.T, quota tenant.Quota) tenant.Repository {
+	tenants := memory.NewTenantRepository()
+	require.NoError(t, tenants.Create(context.Background(), &tenant.Tenant{ID: "limited", Name: "Limited", Quota: quota}))
+	return tenants
+}
+
+func TestMessageRepository_Create(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenantID string
+		existing int
+		expected error
+	}{
+		{name: "正常系：上限未満のテナントは作成できる", tenantID: "limited", existing: 1},
+		{name: "正常系：上限のないテナントは件数を問わず作成できる", tenantID: tenant.DefaultID, existing: 2},
+		{name: "正常系：存在しないテナントは上限なしとして扱う", tenantID: "unknown", existing: 2},
+		{name: "異常系：上限に達したテナントは作成できない", tenantID: "limited", existing: 2, expected: tenant.ErrQuotaExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tenant.WithID(context.Background(), tt.tenantID)
+			next := memory.NewMessageRepository()
+			repo := NewMessageRepository(next, newTestTenants(t, tenant.Quota{MaxMessages: 2}))
+			for i := range tt.existing {
+				require.NoError(t, next.Create(ctx, newTestMessage(string(rune('a'+i)))))
+			}
+
+			err := repo.Create(ctx, newTestMessage("new"))
+
+			assert.ErrorIs(t, err, tt.expected)
+			if tt.expected != nil {
+				count, err := next.Count(ctx, message.SearchCriteria{})
+				require.NoError(t, err)
+				assert.EqualValues(t, tt.existing, count)
+			}
+		})
+	}
+}
+
+func TestMessageRepository_CreateMany(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing int
+		uids     []string
+		expected []error
+	}{
+		{
+			name:     "正常系：上限に収まる場合は全て作成する",
+			uids:     []string{"a", "b", "c"},
+			expected: []error{nil, nil, nil},
+		},
+		{
+			name:     "正常系：上限を超えた分のみ拒否する",
+			existing: 1,
+			uids:     []string{"a", "b", "c"},
+			expected: []error{nil, nil, tenant.ErrQuotaExceeded},
+		},
+		{
+			name:     "正常系：作成できなかったメッセージのエラーを元の位置に返す",
+			existing: 1,
+			uids:     []string{"x0", "a", "b"},
+			expected: []error{message.ErrDuplicateUID, nil, tenant.ErrQuotaExceeded},
+		},
+		{
+			name:     "正常系：上限に達している場合は全て拒否する",
+			existing: 3,
+			uids:     []string{"a", "b"},
+			expected: []error{tenant.ErrQuotaExceeded, tenant.ErrQuotaExceeded},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tenant.WithID(context.Background(), "limited")
+			next := memory.NewMessageRepository()
+			repo := NewMessageRepository(next, newTestTenants(t, tenant.Quota{MaxMessages: 3}))
+			for i := range tt.existing {
+				require.NoError(t, next.Create(ctx, newTestMessage("x"+string(rune('0'+i)))))
+			}
+			msgs := make([]*message.Message, len(tt.uids))
+			for i, uid := range tt.uids {
+				msgs[i] = newTestMessage(uid)
+			}
+
+			errs, err := repo.CreateMany(ctx, msgs)
+
+			require.NoError(t, err)
+			require.Len(t, errs, len(tt.expected))
+			for i := range tt.expected {
+				assert.ErrorIs(t, errs[i], tt.expected[i], "index %d", i)
+			}
+		})
+	}
+}
+
+// slowCountRepository は件数の確認から作成までの間に他の作成が割り込むよう、数えた後に待つ
+type slowCountRepository struct {
+	message.Repository
+}
+
+func (r slowCountRepository) CountUpTo(ctx context.Context, criteria message.SearchCriteria, limit int64) (int64, error) {
+	count, err := r.Repository.CountUpTo(ctx, criteria, limit)
+	time.Sleep(10 * time.Millisecond)
+	return count, err
+}
+
+func TestMessageRepository_Reservation(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "limited")
+
+	t.Run("異常系：他の作成中の分を含めて上限に達する場合は作成できない", func(t *testing.T) {
+		tenants := newTestTenants(t, tenant.Quota{MaxMessages: 2})
+		next := memory.NewMessageRepository()
+		repo := NewMessageRepository(next, tenants)
+		require.NoError(t, next.Create(ctx, newTestMessage("a")))
+		// 他のレプリカが1件を作成中
+		_, err := tenants.Reserve(ctx, "limited", tenant.ResourceMessages, 1)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, repo.Create(ctx, newTestMessage("new")), tenant.ErrQuotaExceeded)
+	})
+
+	t.Run("正常系：作成が終わると予約を戻す", func(t *testing.T) {
+		tenants := newTestTenants(t, tenant.Quota{MaxMessages: 3})
+		repo := NewMessageRepository(memory.NewMessageRepository(), tenants)
+
+		require.NoError(t, repo.Create(ctx, newTestMessage("a")))
+		_, err := repo.CreateMany(ctx, []*message.Message{newTestMessage("b"), newTestMessage("c"), newTestMessage("d")})
+		require.NoError(t, err)
+
+		reserved, err := tenants.Reserve(ctx, "limited", tenant.ResourceMessages, 0)
+		require.NoError(t, err)
+		assert.Zero(t, reserved)
+	})
+
+	t.Run("正常系：同時に作成しても上限を超えない", func(t *testing.T) {
+		next := memory.NewMessageRepository()
+		repo := NewMessageRepository(slowCountRepository{next}, newTestTenants(t, tenant.Quota{MaxMessages: 5}))
+
+		const workers = 20
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				err := repo.Create(ctx, newTestMessage(fmt.Sprintf("m%d", i)))
+				if err != nil {
+					assert.ErrorIs(t, err, tenant.ErrQuotaExceeded)
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		count, err := next.Count(ctx, message.SearchCriteria{})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, count, int64(5))
+	})
+}
+
+func newTestToken(tokenString string) *token.Token {
+	return &token.Token{Token: tokenString, Name: tokenString, ExpiresAt: time.Now().Add(time.Hour)}
+}
+
+func TestTokenRepository_Create(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing int
+		inFlight int64
+		expected error
+	}{
+		{name: "正常系：上限未満のテナントは作成できる", existing: 1},
+		{name: "異常系：上限に達したテナントは作成できない", existing: 2, expected: tenant.ErrQuotaExceeded},
+		{name: "異常系：他の作成中の分を含めて上限に達する場合は作成できない", existing: 1, inFlight: 1, expected: tenant.ErrQuotaExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tenant.WithID(context.Background(), "limited")
+			next := memory.NewTokenRepository()
+			tenants := newTestTenants(t, tenant.Quota{MaxTokens: 2})
+			repo := NewTokenRepository(next, tenants)
+			if tt.inFlight > 0 {
+				_, err := tenants.Reserve(ctx, "limited", tenant.ResourceTokens, tt.inFlight)
+				require.NoError(t, err)
+			}
+			for i := range tt.existing {
+				require.NoError(t, next.Create(ctx, newTestToken(string(rune('a'+i)))))
+			}
+			// 他のテナントのトークンは数えない
+			require.NoError(t, next.Create(tenant.WithID(context.Background(), tenant.DefaultID), newTestToken("other")))
+
+			err := repo.Create(ctx, newTestToken("new"))
+
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
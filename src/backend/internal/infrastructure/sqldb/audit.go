@@ -0,0 +1,49 @@
+package sqldb
+
+import (
+	"context"
+	"encoding/json"
+	"message-service/internal/domain/audit"
+	"message-service/internal/domain/tenant"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditRepository はaudit.RepositoryのSQL実装
+type AuditRepository struct {
+	db *DB
+}
+
+func NewAuditRepository(db *DB) audit.Repository {
+	if db == nil {
+		panic("database connection is required")
+	}
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) Create(ctx context.Context, log *audit.Log) error {
+	if log.CreatedAt.IsZero() {
+		log.CreatedAt = time.Now()
+	}
+	criteria, err := json.Marshal(log.Criteria)
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	id := primitive.NewObjectID()
+	if _, err := r.db.ExecContext(ctx, r.db.rebind(
+		"INSERT INTO audit_logs (id, tenant_id, action, token_id, criteria, affected, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)"),
+		id.Hex(), tenantID, string(log.Action), log.TokenID, string(criteria), log.Affected, log.CreatedAt.UTC()); err != nil {
+		return err
+	}
+
+	log.ID = id
+	log.TenantID = tenantID
+	return nil
+}
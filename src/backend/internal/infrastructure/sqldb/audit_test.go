@@ -0,0 +1,35 @@
+package sqldb
+
+import (
+	"context"
+	"message-service/internal/domain/audit"
+	"message-service/internal/domain/tenant"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditRepository_Create(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewAuditRepository(db)
+	log := &audit.Log{
+		Action:   audit.ActionMessageBulkDelete,
+		TokenID:  "token-id",
+		Criteria: map[string]string{"channel_id": "test-channel"},
+		Affected: 2,
+	}
+
+	assert.NoError(t, repo.Create(tenant.WithID(context.Background(), tenant.DefaultID), log))
+
+	assert.False(t, log.ID.IsZero())
+	var (
+		action   string
+		criteria string
+		affected int64
+	)
+	assert.NoError(t, db.QueryRow("SELECT action, criteria, affected FROM audit_logs WHERE id = ?", log.ID.Hex()).
+		Scan(&action, &criteria, &affected))
+	assert.Equal(t, "message.bulk_delete", action)
+	assert.JSONEq(t, `{"channel_id":"test-channel"}`, criteria)
+	assert.Equal(t, int64(2), affected)
+}
@@ -0,0 +1,105 @@
+// Package sqldb はdatabase/sqlを使ったリポジトリの実装を提供する
+// SQLiteとPostgreSQLに対応し、スキーマは埋め込みのマイグレーションで管理する
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Dialect は接続先のデータベースの種類
+type Dialect string
+
+const (
+	DialectSQLite   Dialect = "sqlite"
+	DialectPostgres Dialect = "postgres"
+)
+
+// postgresUniqueViolation はPostgreSQLの一意制約違反のエラーコード
+const postgresUniqueViolation = "23505"
+
+// DB はデータベース接続と方言の組
+type DB struct {
+	*sql.DB
+	dialect Dialect
+}
+
+// Open はデータベースに接続し、未適用のマイグレーションを適用する
+func Open(ctx context.Context, dialect Dialect, dsn string) (*DB, error) {
+	var driver string
+	switch dialect {
+	case DialectSQLite:
+		driver = "sqlite"
+	case DialectPostgres:
+		driver = "pgx"
+	default:
+		return nil, fmt.Errorf("unsupported sql dialect: %s", dialect)
+	}
+
+	conn, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if dialect == DialectSQLite {
+		// SQLiteは書き込みを直列化するため、接続を1つに絞ってロック競合を避ける
+		conn.SetMaxOpenConns(1)
+	}
+
+	db := &DB{DB: conn, dialect: dialect}
+	if err := db.PingContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := Migrate(ctx, db); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Dialect は接続先のデータベースの種類を返す
+func (db *DB) Dialect() Dialect {
+	return db.dialect
+}
+
+// rebind は?で書かれたプレースホルダーを方言に合わせて変換する
+func (db *DB) rebind(query string) string {
+	if db.dialect != DialectPostgres {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// isUniqueViolation は一意制約または主キーの制約違反のエラーかどうかを判定する
+func isUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE || sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == postgresUniqueViolation
+	}
+	return false
+}
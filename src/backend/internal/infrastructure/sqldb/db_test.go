@@ -0,0 +1,79 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+// openTestDB はテストごとに独立したSQLiteデータベースを作成する
+func openTestDB(t *testing.T) *DB {
+	t.Helper()
+	db, err := Open(context.Background(), DialectSQLite, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestOpen(t *testing.T) {
+	t.Run("正常系：SQLite", func(t *testing.T) {
+		db := openTestDB(t)
+		assert.Equal(t, DialectSQLite, db.Dialect())
+	})
+
+	t.Run("異常系：未対応の方言", func(t *testing.T) {
+		_, err := Open(context.Background(), Dialect("mysql"), "")
+		assert.EqualError(t, err, "unsupported sql dialect: mysql")
+	})
+}
+
+func TestDB_rebind(t *testing.T) {
+	query := "SELECT * FROM messages WHERE uid = ? AND sender = ?"
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		expected string
+	}{
+		{name: "SQLiteはそのまま", dialect: DialectSQLite, expected: query},
+		{name: "PostgreSQLは番号付きに変換", dialect: DialectPostgres, expected: "SELECT * FROM messages WHERE uid = $1 AND sender = $2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &DB{dialect: tt.dialect}
+			assert.Equal(t, tt.expected, db.rebind(query))
+		})
+	}
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	db := openTestDB(t)
+	_, err := db.Exec("INSERT INTO messages (id, uid, sent_at, sender, channel_id, content, created_at, updated_at) VALUES ('1', 'uid', '2024-01-01', 's', 'c', 'x', '2024-01-01', '2024-01-01')")
+	assert.NoError(t, err)
+	_, sqliteErr := db.Exec("INSERT INTO messages (id, uid, sent_at, sender, channel_id, content, created_at, updated_at) VALUES ('2', 'uid', '2024-01-01', 's', 'c', 'x', '2024-01-01', '2024-01-01')")
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "SQLiteの一意制約違反", err: sqliteErr, expected: true},
+		{name: "PostgreSQLの一意制約違反", err: &pgconn.PgError{Code: "23505"}, expected: true},
+		{name: "PostgreSQLのその他のエラー", err: &pgconn.PgError{Code: "23502"}, expected: false},
+		{name: "その他のエラー", err: errors.New("error"), expected: false},
+		{name: "エラーなし", err: nil, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isUniqueViolation(tt.err))
+		})
+	}
+}
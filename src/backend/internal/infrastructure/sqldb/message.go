@@ -0,0 +1,505 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const messageColumns = "id, tenant_id, uid, sent_at, sender, channel_id, content, created_at, updated_at, deleted_at, expires_at, reply_to"
+
+// activeCondition はテナントの削除されておらず有効期限内のメッセージを対象とする条件
+// 引数にテナントIDと現在時刻を渡す
+const activeCondition = "tenant_id = ? AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > ?)"
+
+// MessageRepository はmessage.RepositoryのSQL実装
+// 全ての操作はコンテキストのテナントのメッセージのみを対象とする
+type MessageRepository struct {
+	db *DB
+}
+
+func NewMessageRepository(db *DB) message.Repository {
+	if db == nil {
+		panic("database connection is required")
+	}
+	return &MessageRepository{db: db}
+}
+
+func (r *MessageRepository) Create(ctx context.Context, msg *message.Message) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	msg.CreatedAt = now
+	msg.UpdatedAt = now
+	msg.TenantID = tenantID
+	return r.insert(ctx, msg)
+}
+
+// CreateMany は1つのトランザクションで書き込む
+// 重複以外のエラーの場合はいずれのメッセージも作成しない
+func (r *MessageRepository) CreateMany(ctx context.Context, msgs []*message.Message) ([]error, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	errs := make([]error, len(msgs))
+	// MongoDBの順序なし挿入と同様に、重複したメッセージがあっても残りを挿入する
+	// 一意制約違反でトランザクションが中断されないよう、重複は挿入しなかった件数で判定する
+	for i, msg := range msgs {
+		msg.CreatedAt = now
+		msg.UpdatedAt = now
+		msg.TenantID = tenantID
+		inserted, err := r.insertIfAbsent(ctx, tx, msg)
+		if err != nil {
+			return nil, err
+		}
+		if !inserted {
+			errs[i] = message.ErrDuplicateUID
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+func (r *MessageRepository) insert(ctx context.Context, msg *message.Message) error {
+	id := msg.ID
+	if id.IsZero() {
+		id = primitive.NewObjectID()
+	}
+
+	_, err := r.db.ExecContext(ctx, r.db.rebind(
+		"INSERT INTO messages ("+messageColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"),
+		insertArgs(id, msg)...)
+	if isUniqueViolation(err) {
+		return message.ErrDuplicateUID
+	}
+	if err != nil {
+		return err
+	}
+
+	msg.ID = id
+	return nil
+}
+
+// insertIfAbsent はトランザクション内でメッセージを挿入する
+// 同じUIDのメッセージが既にある場合は挿入せずにfalseを返す
+func (r *MessageRepository) insertIfAbsent(ctx context.Context, tx *sql.Tx, msg *message.Message) (bool, error) {
+	id := msg.ID
+	if id.IsZero() {
+		id = primitive.NewObjectID()
+	}
+
+	result, err := tx.ExecContext(ctx, r.db.rebind(
+		"INSERT INTO messages ("+messageColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT DO NOTHING"),
+		insertArgs(id, msg)...)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, err
+	}
+
+	msg.ID = id
+	return true, nil
+}
+
+// insertArgs はmessageColumnsの順に挿入する値を返す
+func insertArgs(id primitive.ObjectID, msg *message.Message) []interface{} {
+	return []interface{}{
+		id.Hex(), msg.TenantID, msg.UID, msg.SentAt.UTC(), msg.Sender, msg.ChannelID, msg.Content,
+		msg.CreatedAt, msg.UpdatedAt, nullTime(msg.DeletedAt), nullTime(msg.ExpiresAt), nullString(msg.ReplyTo),
+	}
+}
+
+func (r *MessageRepository) Delete(ctx context.Context, uid string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	result, err := r.db.ExecContext(ctx, r.db.rebind(
+		"UPDATE messages SET deleted_at = ?, updated_at = ? WHERE uid = ? AND "+activeCondition),
+		now, now, uid, tenantID, now)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	// 呼び出し側はMongoDB実装と同じエラーで存在しないことを判定する
+	if affected == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Search は指定された並び順で返す。取得するフィールドの指定は無視し、全ての列を読み出す
+func (r *MessageRepository) Search(ctx context.Context, criteria message.SearchCriteria, opts message.SearchOptions) ([]message.Message, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	where, args := searchWhere(tenantID, criteria)
+	if opts.After != nil {
+		// 並び順のキーが同じメッセージはIDで位置を決める
+		column, op := opts.After.Sort.Field(), ">"
+		if opts.After.Sort.Descending() {
+			op = "<"
+		}
+		value := opts.After.Value.UTC()
+		where += " AND (" + column + " " + op + " ? OR (" + column + " = ? AND id " + op + " ?))"
+		args = append(args, value, value, opts.After.ID.Hex())
+	}
+	query := "SELECT " + messageColumns + " FROM messages" + where + " ORDER BY " + orderBy(opts.Sort)
+	if opts.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, opts.Limit)
+	}
+	return r.list(ctx, query, args...)
+}
+
+func (r *MessageRepository) Iterate(ctx context.Context, criteria message.SearchCriteria, fn func(*message.Message) error) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	where, args := searchWhere(tenantID, criteria)
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(
+		"SELECT "+messageColumns+" FROM messages"+where+" ORDER BY "+orderBy(message.SortSentAtAsc)), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		msg, err := scanMessage(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(msg); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *MessageRepository) FindByUID(ctx context.Context, uid string) (*message.Message, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row := r.db.QueryRowContext(ctx, r.db.rebind(
+		"SELECT "+messageColumns+" FROM messages WHERE uid = ? AND "+activeCondition),
+		uid, tenantID, time.Now().UTC())
+
+	msg, err := scanMessage(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return msg, err
+}
+
+func (r *MessageRepository) Count(ctx context.Context, criteria message.SearchCriteria) (int64, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := searchWhere(tenantID, criteria)
+
+	var count int64
+	err = r.db.QueryRowContext(ctx, r.db.rebind("SELECT COUNT(*) FROM messages"+where), args...).Scan(&count)
+	return count, err
+}
+
+func (r *MessageRepository) CountUpTo(ctx context.Context, criteria message.SearchCriteria, limit int64) (int64, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := searchWhere(tenantID, criteria)
+
+	// limit件を読んだ時点で打ち切る
+	var count int64
+	err = r.db.QueryRowContext(ctx, r.db.rebind(
+		"SELECT COUNT(*) FROM (SELECT 1 FROM messages"+where+" LIMIT ?) AS limited"),
+		append(args, limit)...).Scan(&count)
+	return count, err
+}
+
+func (r *MessageRepository) DeleteMany(ctx context.Context, criteria message.SearchCriteria) (int64, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := searchWhere(tenantID, criteria)
+	now := time.Now().UTC()
+
+	result, err := r.db.ExecContext(ctx, r.db.rebind(
+		"UPDATE messages SET deleted_at = ?, updated_at = ?"+where), append([]interface{}{now, now}, args...)...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Around は対象の前後それぞれを、送信日時とIDの順で対象に近いものから件数を絞って取得する
+func (r *MessageRepository) Around(ctx context.Context, target *message.Message, before, after int) ([]message.Message, []message.Message, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	sentAt, id := target.SentAt.UTC(), target.ID.Hex()
+	args := []interface{}{tenantID, time.Now().UTC(), target.ChannelID, sentAt, sentAt, id}
+
+	var older, newer []message.Message
+	if before > 0 {
+		msgs, err := r.list(ctx, "SELECT "+messageColumns+" FROM messages WHERE "+activeCondition+
+			" AND channel_id = ? AND (sent_at < ? OR (sent_at = ? AND id < ?))"+
+			" ORDER BY sent_at DESC, id DESC LIMIT ?", append(args, before)...)
+		if err != nil {
+			return nil, nil, err
+		}
+		// 近い順に取得しているため古い順に並べ替える
+		for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
+			msgs[i], msgs[j] = msgs[j], msgs[i]
+		}
+		older = msgs
+	}
+	if after > 0 {
+		msgs, err := r.list(ctx, "SELECT "+messageColumns+" FROM messages WHERE "+activeCondition+
+			" AND channel_id = ? AND (sent_at > ? OR (sent_at = ? AND id > ?))"+
+			" ORDER BY sent_at ASC, id ASC LIMIT ?", append(args, after)...)
+		if err != nil {
+			return nil, nil, err
+		}
+		newer = msgs
+	}
+	return older, newer, nil
+}
+
+// list はクエリの結果をメッセージのスライスとして返す
+func (r *MessageRepository) list(ctx context.Context, query string, args ...interface{}) ([]message.Message, error) {
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []message.Message
+	for rows.Next() {
+		msg, err := scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, *msg)
+	}
+	return messages, rows.Err()
+}
+
+// Stats はチャンネル・送信者単位ではSQLで集計する
+// 時・日単位はタイムゾーンの扱いが方言ごとに異なるため、メッセージを読み出して集計する
+func (r *MessageRepository) Stats(ctx context.Context, query message.StatsQuery) ([]message.StatsBucket, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	if query.GroupBy.IsTime() {
+		acc := message.NewStatsAccumulator(query)
+		err := r.Iterate(ctx, query.Criteria, func(msg *message.Message) error {
+			acc.Add(msg)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return acc.Buckets(), nil
+	}
+
+	column := "channel_id"
+	if query.GroupBy == message.GroupBySender {
+		column = "sender"
+	}
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	where, args := searchWhere(tenantID, query.Criteria)
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(
+		"SELECT "+column+", COUNT(*), COUNT(DISTINCT sender) FROM messages"+where+
+			" GROUP BY "+column+" ORDER BY COUNT(*) DESC, "+column+" ASC"), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var buckets []message.StatsBucket
+	for rows.Next() {
+		var bucket message.StatsBucket
+		if err := rows.Scan(&bucket.Key, &bucket.Count, &bucket.Senders); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, rows.Err()
+}
+
+// DeleteExpired は有効期限を過ぎたメッセージを物理削除し、削除した件数を返す
+// 定期的な掃除のための操作のため、全てのテナントを対象とする
+func (r *MessageRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, r.db.rebind(
+		"DELETE FROM messages WHERE expires_at <= ?"), now.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// searchWhere はテナント内の検索条件をWHERE句と引数に変換する
+func searchWhere(tenantID string, criteria message.SearchCriteria) (string, []interface{}) {
+	now := time.Now().UTC()
+	conditions := []string{activeCondition}
+	args := []interface{}{tenantID, now}
+
+	if len(criteria.ChannelIDs) > 0 {
+		conditions = append(conditions, "channel_id IN ("+placeholders(len(criteria.ChannelIDs))+")")
+		args = appendStrings(args, criteria.ChannelIDs)
+	}
+	if len(criteria.Senders) > 0 {
+		conditions = append(conditions, "sender IN ("+placeholders(len(criteria.Senders))+")")
+		args = appendStrings(args, criteria.Senders)
+	}
+	if criteria.SenderPrefix != nil {
+		// SQLiteのLIKEは大文字・小文字を区別しないため、他の実装に合わせて先頭の部分文字列で比較する
+		conditions = append(conditions, "substr(sender, 1, ?) = ?")
+		args = append(args, utf8.RuneCountInString(*criteria.SenderPrefix), *criteria.SenderPrefix)
+	}
+	if len(criteria.ExcludeSenders) > 0 {
+		conditions = append(conditions, "sender NOT IN ("+placeholders(len(criteria.ExcludeSenders))+")")
+		args = appendStrings(args, criteria.ExcludeSenders)
+	}
+	if criteria.FromDate != nil {
+		conditions = append(conditions, "sent_at >= ?")
+		args = append(args, criteria.FromDate.UTC())
+	}
+	if criteria.ToDate != nil {
+		conditions = append(conditions, "sent_at <= ?")
+		args = append(args, criteria.ToDate.UTC())
+	}
+	if criteria.IsReply != nil {
+		if *criteria.IsReply {
+			conditions = append(conditions, "reply_to IS NOT NULL")
+		} else {
+			conditions = append(conditions, "reply_to IS NULL")
+		}
+	}
+	if criteria.HasThread != nil {
+		// 外側の列はmessages.で修飾し、返信側の列と区別する
+		condition := "EXISTS (SELECT 1 FROM messages replies WHERE replies.tenant_id = messages.tenant_id" +
+			" AND replies.reply_to = messages.uid AND replies.deleted_at IS NULL" +
+			" AND (replies.expires_at IS NULL OR replies.expires_at > ?))"
+		if !*criteria.HasThread {
+			condition = "NOT " + condition
+		}
+		conditions = append(conditions, condition)
+		args = append(args, now)
+	}
+	if criteria.CreatedBefore != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, criteria.CreatedBefore.UTC())
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// placeholders はn個のプレースホルダーをカンマ区切りで返す
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func appendStrings(args []interface{}, values []string) []interface{} {
+	for _, v := range values {
+		args = append(args, v)
+	}
+	return args
+}
+
+// orderBy は並び順をORDER BY句に変換する。同じ値の行はIDで並べる
+func orderBy(sort message.SortOrder) string {
+	switch sort {
+	case message.SortSentAtDesc:
+		return "sent_at DESC, id DESC"
+	case message.SortCreatedAtAsc:
+		return "created_at ASC, id ASC"
+	}
+	return "sent_at ASC, id ASC"
+}
+
+// rowScanner は*sql.Rowと*sql.Rowsに共通するインターフェース
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMessage(row rowScanner) (*message.Message, error) {
+	var (
+		msg       message.Message
+		id        string
+		deletedAt sql.NullTime
+		expiresAt sql.NullTime
+		replyTo   sql.NullString
+	)
+	if err := row.Scan(&id, &msg.TenantID, &msg.UID, &msg.SentAt, &msg.Sender, &msg.ChannelID, &msg.Content,
+		&msg.CreatedAt, &msg.UpdatedAt, &deletedAt, &expiresAt, &replyTo); err != nil {
+		return nil, err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	msg.ID = objectID
+	if deletedAt.Valid {
+		msg.DeletedAt = &deletedAt.Time
+	}
+	if expiresAt.Valid {
+		msg.ExpiresAt = &expiresAt.Time
+	}
+	msg.ReplyTo = replyTo.String
+	return &msg, nil
+}
+
+// nullTime は*time.TimeをNULL許容の値に変換する
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+// nullString は空文字列をNULLとして扱う
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,66 @@
+package sqldb
+
+import (
+	"context"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/repotest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageRepository_Conformance(t *testing.T) {
+	repotest.RunMessageRepositorySuite(t, func(t *testing.T) message.Repository {
+		return NewMessageRepository(openTestDB(t))
+	})
+}
+
+func TestMessageRepository_TimeZone(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	repo := NewMessageRepository(openTestDB(t))
+	jst := time.FixedZone("JST", 9*60*60)
+
+	// 2024-01-01T09:00:00+09:00 は 2024-01-01T00:00:00Z と同じ時刻
+	sentAt := time.Date(2024, 1, 1, 9, 0, 0, 0, jst)
+	assert.NoError(t, repo.Create(ctx, &message.Message{
+		UID:       "msg1",
+		SentAt:    sentAt,
+		Sender:    "test-sender",
+		ChannelID: "test-channel",
+		Content:   "test message",
+	}))
+
+	fromDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	got, err := repo.Search(ctx, message.SearchCriteria{FromDate: &fromDate}, message.SearchOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.True(t, sentAt.Equal(got[0].SentAt))
+
+	got, err = repo.Search(ctx, message.SearchCriteria{ToDate: &toDate}, message.SearchOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestMessageRepository_CreateManyRollback(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	db := openTestDB(t)
+	repo := NewMessageRepository(db)
+	// 特定のUIDの挿入を失敗させ、途中のエラーで書き込みが取り消されることを確認する
+	_, err := db.ExecContext(ctx, `CREATE TRIGGER fail_insert BEFORE INSERT ON messages WHEN NEW.uid = 'fail'
+		BEGIN SELECT RAISE(ABORT, 'forced failure'); END`)
+	assert.NoError(t, err)
+
+	newMessage := func(uid string) *message.Message {
+		return &message.Message{UID: uid, SentAt: time.Now(), Sender: "test-sender", ChannelID: "test-channel", Content: "test message"}
+	}
+	_, err = repo.CreateMany(ctx, []*message.Message{newMessage("msg1"), newMessage("fail"), newMessage("msg2")})
+	assert.Error(t, err)
+
+	count, err := repo.Count(ctx, message.SearchCriteria{})
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,152 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations
+var migrations embed.FS
+
+// migration は1つのマイグレーションファイル
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// migrationLockKey はPostgreSQLでマイグレーションを直列化するアドバイザリーロックのキー
+// 同じデータベースを使う他のアプリケーションと衝突しないよう、固有の値にしている
+const migrationLockKey int64 = 0x6d73675f6d6967 // "msg_mig"
+
+// Migrate は未適用のマイグレーションをバージョン順に適用する
+// 適用済みのバージョンはschema_migrationsテーブルに記録する
+// PostgreSQLでは複数のレプリカが同時に起動しても1つずつ適用されるよう、アドバイザリーロックを取得してから適用する
+// SQLiteは接続を1つに絞っているため、同じプロセス内では常に直列に実行される
+func Migrate(ctx context.Context, db *DB) (err error) {
+	// セッション単位のロックを保持するため、全ての操作を1つの接続で行う
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if db.dialect == DialectPostgres {
+		if _, err := conn.ExecContext(ctx, db.rebind("SELECT pg_advisory_lock(?)"), migrationLockKey); err != nil {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+		defer func() {
+			// 呼び出し元のコンテキストがキャンセルされていてもロックは解放する
+			if _, unlockErr := conn.ExecContext(context.WithoutCancel(ctx), db.rebind("SELECT pg_advisory_unlock(?)"), migrationLockKey); unlockErr != nil && err == nil {
+				err = fmt.Errorf("release migration lock: %w", unlockErr)
+			}
+		}()
+	}
+	return migrate(ctx, db, conn)
+}
+
+// migrate はロックを取得した接続で適用済みのバージョンを読み込み、未適用のマイグレーションを適用する
+// 他のプロセスが先に適用していた場合は、ロックの取得後に読み込むため適用済みとして扱われる
+func migrate(ctx context.Context, db *DB, conn *sql.Conn) error {
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    applied_at TEXT NOT NULL
+)`); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	pending, err := loadMigrations(db.dialect)
+	if err != nil {
+		return err
+	}
+
+	applied := make(map[int]bool)
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return err
+		}
+		applied[version] = true
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	for _, m := range pending {
+		if applied[m.version] {
+			continue
+		}
+		if err := applyMigration(ctx, db, conn, m); err != nil {
+			return fmt.Errorf("apply migration %s: %w", m.name, err)
+		}
+	}
+	return nil
+}
+
+func applyMigration(ctx context.Context, db *DB, conn *sql.Conn, m migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.sql); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, db.rebind("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)"),
+		m.version, m.name, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// loadMigrations は方言ごとのマイグレーションファイルを読み込む
+// ファイル名は"<バージョン>_<説明>.sql"の形式とする
+func loadMigrations(dialect Dialect) ([]migration, error) {
+	dir := path.Join("migrations", string(dialect))
+	entries, err := fs.ReadDir(migrations, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []migration
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".sql") {
+			continue
+		}
+		prefix, _, ok := strings.Cut(name, "_")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name: %s", name)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", name)
+		}
+		content, err := fs.ReadFile(migrations, path.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, migration{version: version, name: name, sql: string(content)})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].version < result[j].version
+	})
+	return result, nil
+}
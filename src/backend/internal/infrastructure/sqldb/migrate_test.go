@@ -0,0 +1,49 @@
+package sqldb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+
+	// Openで適用済みのため、再実行しても何も適用されない
+	assert.NoError(t, Migrate(ctx, db))
+
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count))
+	migrations, err := loadMigrations(DialectSQLite)
+	assert.NoError(t, err)
+	assert.Equal(t, len(migrations), count)
+}
+
+func TestLoadMigrations(t *testing.T) {
+	for _, dialect := range []Dialect{DialectSQLite, DialectPostgres} {
+		t.Run(string(dialect), func(t *testing.T) {
+			migrations, err := loadMigrations(dialect)
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, migrations)
+			for i, m := range migrations {
+				assert.NotEmpty(t, m.sql)
+				if i > 0 {
+					assert.Greater(t, m.version, migrations[i-1].version)
+				}
+			}
+		})
+	}
+
+	t.Run("方言ごとに同じバージョンを持つ", func(t *testing.T) {
+		sqlite, _ := loadMigrations(DialectSQLite)
+		postgres, _ := loadMigrations(DialectPostgres)
+
+		assert.Equal(t, len(sqlite), len(postgres))
+		for i := range sqlite {
+			assert.Equal(t, sqlite[i].version, postgres[i].version)
+		}
+	})
+}
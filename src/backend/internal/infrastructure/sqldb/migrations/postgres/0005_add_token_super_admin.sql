@@ -0,0 +1,2 @@
+-- 全てのテナントを管理できるトークン。既存の管理用トークンは所属するテナントのみを管理できる
+ALTER TABLE tokens ADD COLUMN super_admin BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,8 @@
+-- 上限の確認から作成までの間、作成中の件数をテナントごとに予約する
+CREATE TABLE tenant_reservations (
+    tenant_id TEXT NOT NULL,
+    resource TEXT NOT NULL,
+    reserved BIGINT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (tenant_id, resource)
+);
@@ -0,0 +1,40 @@
+CREATE TABLE messages (
+    id TEXT PRIMARY KEY,
+    uid TEXT NOT NULL,
+    sent_at DATETIME NOT NULL,
+    sender TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    content TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+    deleted_at DATETIME
+);
+
+-- 削除されていないメッセージのUIDは一意
+CREATE UNIQUE INDEX messages_uid_active ON messages (uid) WHERE deleted_at IS NULL;
+CREATE INDEX messages_channel_id ON messages (channel_id, deleted_at);
+CREATE INDEX messages_sender ON messages (sender, deleted_at);
+CREATE INDEX messages_sent_at ON messages (sent_at, deleted_at);
+
+CREATE TABLE tokens (
+    id TEXT PRIMARY KEY,
+    token TEXT NOT NULL,
+    name TEXT NOT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+    deleted_at DATETIME
+);
+
+CREATE UNIQUE INDEX tokens_token_active ON tokens (token) WHERE deleted_at IS NULL;
+CREATE INDEX tokens_expires_at ON tokens (expires_at, deleted_at);
+CREATE INDEX tokens_created_at ON tokens (created_at, deleted_at);
+
+CREATE TABLE audit_logs (
+    id TEXT PRIMARY KEY,
+    action TEXT NOT NULL,
+    token_id TEXT NOT NULL,
+    criteria TEXT NOT NULL,
+    affected INTEGER NOT NULL,
+    created_at DATETIME NOT NULL
+);
@@ -0,0 +1,4 @@
+ALTER TABLE messages ADD COLUMN expires_at DATETIME;
+
+-- 有効期限を過ぎたメッセージの削除に使用する
+CREATE INDEX messages_expires_at ON messages (expires_at);
@@ -0,0 +1,4 @@
+ALTER TABLE messages ADD COLUMN reply_to TEXT;
+
+-- 返信の有無による絞り込みで、返信先のUIDから返信を探す
+CREATE INDEX messages_reply_to ON messages (reply_to, deleted_at);
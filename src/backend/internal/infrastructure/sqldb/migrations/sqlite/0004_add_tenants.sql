@@ -0,0 +1,34 @@
+CREATE TABLE tenants (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    max_messages INTEGER NOT NULL DEFAULT 0,
+    max_tokens INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+-- 既存のデータは既定のテナントに属する
+INSERT INTO tenants (id, name, created_at, updated_at) VALUES ('default', 'Default', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
+
+ALTER TABLE messages ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE tokens ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE tokens ADD COLUMN admin INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE audit_logs ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+
+-- 削除されていないメッセージのUIDはテナントごとに一意
+DROP INDEX messages_uid_active;
+CREATE UNIQUE INDEX messages_uid_active ON messages (tenant_id, uid) WHERE deleted_at IS NULL;
+DROP INDEX messages_channel_id;
+CREATE INDEX messages_channel_id ON messages (tenant_id, channel_id, deleted_at);
+DROP INDEX messages_sender;
+CREATE INDEX messages_sender ON messages (tenant_id, sender, deleted_at);
+DROP INDEX messages_sent_at;
+CREATE INDEX messages_sent_at ON messages (tenant_id, sent_at, deleted_at);
+
+-- トークン文字列は認証で全てのテナントから探すため、tokens_token_activeは変更しない
+DROP INDEX tokens_created_at;
+CREATE INDEX tokens_created_at ON tokens (tenant_id, created_at, deleted_at);
+
+-- 返信はテナント内で探す
+DROP INDEX messages_reply_to;
+CREATE INDEX messages_reply_to ON messages (tenant_id, reply_to, deleted_at);
@@ -0,0 +1,130 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"message-service/internal/domain/tenant"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const tenantColumns = "id, name, max_messages, max_tokens, created_at, updated_at"
+
+// TenantRepository はtenant.RepositoryのSQL実装
+// 既定のテナントはマイグレーションで作成する
+type TenantRepository struct {
+	db *DB
+}
+
+func NewTenantRepository(db *DB) *TenantRepository {
+	if db == nil {
+		panic("database connection is required")
+	}
+	return &TenantRepository{db: db}
+}
+
+func (r *TenantRepository) Create(ctx context.Context, t *tenant.Tenant) error {
+	now := time.Now().UTC()
+
+	_, err := r.db.ExecContext(ctx, r.db.rebind(
+		"INSERT INTO tenants ("+tenantColumns+") VALUES (?, ?, ?, ?, ?, ?)"),
+		t.ID, t.Name, t.Quota.MaxMessages, t.Quota.MaxTokens, now, now)
+	if isUniqueViolation(err) {
+		return tenant.ErrDuplicateTenant
+	}
+	if err != nil {
+		return err
+	}
+
+	t.CreatedAt = now
+	t.UpdatedAt = now
+	return nil
+}
+
+func (r *TenantRepository) Update(ctx context.Context, t *tenant.Tenant) error {
+	now := time.Now().UTC()
+	result, err := r.db.ExecContext(ctx, r.db.rebind(
+		"UPDATE tenants SET name = ?, max_messages = ?, max_tokens = ?, updated_at = ? WHERE id = ?"),
+		t.Name, t.Quota.MaxMessages, t.Quota.MaxTokens, now, t.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	updated, err := r.Get(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	if updated != nil {
+		*t = *updated
+	}
+	return nil
+}
+
+func (r *TenantRepository) Get(ctx context.Context, id string) (*tenant.Tenant, error) {
+	row := r.db.QueryRowContext(ctx, r.db.rebind(
+		"SELECT "+tenantColumns+" FROM tenants WHERE id = ?"), id)
+
+	t, err := scanTenant(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return t, err
+}
+
+func (r *TenantRepository) List(ctx context.Context) ([]tenant.Tenant, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+tenantColumns+" FROM tenants ORDER BY id ASC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tenants []tenant.Tenant
+	for rows.Next() {
+		t, err := scanTenant(rows)
+		if err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, *t)
+	}
+	return tenants, rows.Err()
+}
+
+// Reserve は予約の行を作成または更新し、更新後の件数を返す
+// 同じ行への同時の更新は直列化されるため、予約の件数を取りこぼさない
+func (r *TenantRepository) Reserve(ctx context.Context, id string, resource tenant.Resource, n int64) (int64, error) {
+	now := time.Now().UTC()
+
+	var reserved int64
+	err := r.db.QueryRowContext(ctx, r.db.rebind(
+		"INSERT INTO tenant_reservations (tenant_id, resource, reserved, expires_at) VALUES (?, ?, ?, ?) "+
+			"ON CONFLICT (tenant_id, resource) DO UPDATE SET "+
+			"reserved = CASE WHEN tenant_reservations.expires_at > ? THEN tenant_reservations.reserved + excluded.reserved ELSE excluded.reserved END, "+
+			"expires_at = excluded.expires_at "+
+			"RETURNING reserved"),
+		id, string(resource), n, now.Add(tenant.ReservationTimeout), now).Scan(&reserved)
+	return reserved, err
+}
+
+func (r *TenantRepository) Release(ctx context.Context, id string, resource tenant.Resource, n int64) error {
+	_, err := r.db.ExecContext(ctx, r.db.rebind(
+		"UPDATE tenant_reservations SET reserved = CASE WHEN reserved > ? THEN reserved - ? ELSE 0 END WHERE tenant_id = ? AND resource = ?"),
+		n, n, id, string(resource))
+	return err
+}
+
+func scanTenant(row rowScanner) (*tenant.Tenant, error) {
+	var t tenant.Tenant
+	if err := row.Scan(&t.ID, &t.Name, &t.Quota.MaxMessages, &t.Quota.MaxTokens, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,13 @@
+package sqldb
+
+import (
+	"message-service/internal/domain/tenant"
+	"message-service/internal/repotest"
+	"testing"
+)
+
+func TestTenantRepository_Conformance(t *testing.T) {
+	repotest.RunTenantRepositorySuite(t, func(t *testing.T) tenant.Repository {
+		return NewTenantRepository(openTestDB(t))
+	})
+}
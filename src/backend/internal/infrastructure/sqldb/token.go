@@ -0,0 +1,183 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/domain/token"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const tokenColumns = "id, tenant_id, token, name, admin, super_admin, expires_at, created_at, updated_at, deleted_at"
+
+// TokenRepository はtoken.RepositoryのSQL実装
+// 認証に使うFindByTokenと定期的な掃除を除き、コンテキストのテナントのトークンのみを対象とする
+type TokenRepository struct {
+	db *DB
+}
+
+func NewTokenRepository(db *DB) *TokenRepository {
+	if db == nil {
+		panic("database connection is required")
+	}
+	return &TokenRepository{db: db}
+}
+
+func (r *TokenRepository) Create(ctx context.Context, tkn *token.Token) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	id := primitive.NewObjectID()
+	_, err = r.db.ExecContext(ctx, r.db.rebind(
+		"INSERT INTO tokens ("+tokenColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"),
+		id.Hex(), tenantID, tkn.Token, tkn.Name, tkn.Admin, tkn.SuperAdmin, tkn.ExpiresAt.UTC(), now, now, nullTime(tkn.DeletedAt))
+	if isUniqueViolation(err) {
+		return token.ErrDuplicateToken
+	}
+	if err != nil {
+		return err
+	}
+
+	tkn.ID = id
+	tkn.TenantID = tenantID
+	tkn.CreatedAt = now
+	tkn.UpdatedAt = now
+	return nil
+}
+
+func (r *TokenRepository) Delete(ctx context.Context, id string) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return err
+	}
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	result, err := r.db.ExecContext(ctx, r.db.rebind(
+		"UPDATE tokens SET deleted_at = ?, updated_at = ? WHERE id = ? AND tenant_id = ? AND deleted_at IS NULL"),
+		now, now, id, tenantID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *TokenRepository) List(ctx context.Context) ([]token.Token, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(
+		"SELECT "+tokenColumns+" FROM tokens WHERE tenant_id = ? AND deleted_at IS NULL AND expires_at > ? ORDER BY created_at DESC"),
+		tenantID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []token.Token
+	for rows.Next() {
+		tkn, err := scanToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *tkn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (r *TokenRepository) CountUpTo(ctx context.Context, limit int64) (int64, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err = r.db.QueryRowContext(ctx, r.db.rebind(
+		"SELECT COUNT(*) FROM (SELECT 1 FROM tokens WHERE tenant_id = ? AND deleted_at IS NULL AND expires_at > ? LIMIT ?) AS limited"),
+		tenantID, time.Now().UTC(), limit).Scan(&count)
+	return count, err
+}
+
+func (r *TokenRepository) FindByID(ctx context.Context, id string) (*token.Token, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.db.QueryRowContext(ctx, r.db.rebind(
+		"SELECT "+tokenColumns+" FROM tokens WHERE id = ? AND tenant_id = ? AND deleted_at IS NULL"),
+		id, tenantID)
+	return findToken(row)
+}
+
+// FindByToken は認証のため、全てのテナントからトークンを探す
+func (r *TokenRepository) FindByToken(ctx context.Context, tokenString string) (*token.Token, error) {
+	row := r.db.QueryRowContext(ctx, r.db.rebind(
+		"SELECT "+tokenColumns+" FROM tokens WHERE token = ? AND deleted_at IS NULL AND expires_at > ?"),
+		tokenString, time.Now().UTC())
+	return findToken(row)
+}
+
+// DeleteExpired は有効期限を過ぎたトークンを物理削除し、削除した件数を返す
+func (r *TokenRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, r.db.rebind(
+		"DELETE FROM tokens WHERE expires_at <= ?"), now.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// findToken は1件のトークンを読み取り、存在しない場合はnilを返す
+func findToken(row *sql.Row) (*token.Token, error) {
+	tkn, err := scanToken(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return tkn, err
+}
+
+func scanToken(row rowScanner) (*token.Token, error) {
+	var (
+		tkn       token.Token
+		id        string
+		deletedAt sql.NullTime
+	)
+	if err := row.Scan(&id, &tkn.TenantID, &tkn.Token, &tkn.Name, &tkn.Admin, &tkn.SuperAdmin, &tkn.ExpiresAt, &tkn.CreatedAt, &tkn.UpdatedAt, &deletedAt); err != nil {
+		return nil, err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	tkn.ID = objectID
+	if deletedAt.Valid {
+		tkn.DeletedAt = &deletedAt.Time
+	}
+	return &tkn, nil
+}
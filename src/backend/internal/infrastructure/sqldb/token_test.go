@@ -0,0 +1,13 @@
+package sqldb
+
+import (
+	"message-service/internal/domain/token"
+	"message-service/internal/repotest"
+	"testing"
+)
+
+func TestTokenRepository_Conformance(t *testing.T) {
+	repotest.RunTokenRepositorySuite(t, func(t *testing.T) token.Repository {
+		return NewTokenRepository(openTestDB(t))
+	})
+}
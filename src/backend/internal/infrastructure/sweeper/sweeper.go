@@ -0,0 +1,79 @@
+// Package sweeper はTTLインデックスを持たないストレージで、有効期限を過ぎたデータを定期的に削除する
+package sweeper
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// Expirer は有効期限を過ぎたデータを削除できるリポジトリ
+type Expirer interface {
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Sweeper は登録されたリポジトリの期限切れデータを一定間隔で削除する
+type Sweeper struct {
+	interval time.Duration
+	targets  map[string]Expirer
+}
+
+func New(interval time.Duration) *Sweeper {
+	return &Sweeper{interval: interval, targets: make(map[string]Expirer)}
+}
+
+// Add は削除対象のリポジトリを登録する。nameはログの出力に使う
+func (s *Sweeper) Add(name string, target Expirer) {
+	s.targets[name] = target
+}
+
+// Len は登録されているリポジトリの数を返す
+func (s *Sweeper) Len() int {
+	return len(s.targets)
+}
+
+// Sweep は全てのリポジトリから指定時刻の時点で期限切れのデータを削除する
+// 一部のリポジトリで失敗しても残りの削除は行い、最初のエラーを返す
+func (s *Sweeper) Sweep(ctx context.Context, now time.Time) error {
+	names := make([]string, 0, len(s.targets))
+	for name := range s.targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var firstErr error
+	for _, name := range names {
+		deleted, err := s.targets[name].DeleteExpired(ctx, now)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to delete expired data", slog.String("target", name), slog.String("error", err.Error()))
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if deleted > 0 {
+			slog.InfoContext(ctx, "Deleted expired data", slog.String("target", name), slog.Int64("deleted", deleted))
+		}
+	}
+	return firstErr
+}
+
+// Run はctxがキャンセルされるまで一定間隔でSweepを実行する
+func (s *Sweeper) Run(ctx context.Context) {
+	if len(s.targets) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			_ = s.Sweep(ctx, now)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package sweeper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// テスト用のExpirer
+type mockExpirer struct {
+	mu      sync.Mutex
+	deleted int64
+	err     error
+	calls   []time.Time
+}
+
+func (m *mockExpirer) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, now)
+	return m.deleted, m.err
+}
+
+func (m *mockExpirer) callCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.calls)
+}
+
+func TestSweeper_Sweep(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		messages *mockExpirer
+		tokens   *mockExpirer
+		wantErr  bool
+	}{
+		{
+			name:     "正常系：全てのリポジトリで削除される",
+			messages: &mockExpirer{deleted: 3},
+			tokens:   &mockExpirer{},
+		},
+		{
+			name:     "異常系：一部のリポジトリで失敗しても残りは削除される",
+			messages: &mockExpirer{err: errors.New("database error")},
+			tokens:   &mockExpirer{deleted: 1},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(time.Minute)
+			s.Add("messages", tt.messages)
+			s.Add("tokens", tt.tokens)
+
+			err := s.Sweep(context.Background(), now)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, []time.Time{now}, tt.messages.calls)
+			assert.Equal(t, []time.Time{now}, tt.tokens.calls)
+		})
+	}
+}
+
+func TestSweeper_Run(t *testing.T) {
+	t.Run("正常系：キャンセルされるまで一定間隔で削除される", func(t *testing.T) {
+		expirer := &mockExpirer{}
+		s := New(10 * time.Millisecond)
+		s.Add("messages", expirer)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			s.Run(ctx)
+			close(done)
+		}()
+
+		assert.Eventually(t, func() bool { return expirer.callCount() >= 2 }, time.Second, 5*time.Millisecond)
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Run did not stop after cancel")
+		}
+	})
+
+	t.Run("正常系：対象が無い場合はすぐに終了する", func(t *testing.T) {
+		s := New(time.Minute)
+		assert.Equal(t, 0, s.Len())
+
+		done := make(chan struct{})
+		go func() {
+			s.Run(context.Background())
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Run did not return without targets")
+		}
+	})
+}
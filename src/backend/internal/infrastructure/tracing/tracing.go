@@ -0,0 +1,113 @@
+// Package tracing はOpenTelemetryによる分散トレーシングを設定する
+// トレースコンテキストはW3C Trace Context（traceparentヘッダー）で伝播する
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"message-service/internal/config"
+	"message-service/pkg/api"
+	"net/http"
+	"os"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName はトレースに記録するサービス名
+const ServiceName = "message-service"
+
+// Setup は設定に従ってグローバルなTracerProviderとプロパゲーターを設定する
+// エクスポーターがnoneの場合もプロパゲーターは設定し、受け取ったtraceparentを下流に引き継ぐ
+// 戻り値の関数は未送信のスパンを送信してから終了する
+func Setup(ctx context.Context, cfg config.TracingConfig) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	provider, err := newProvider(ctx, cfg, os.Stdout)
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// newProvider はエクスポーターを作成し、TracerProviderを返す。noneの場合はnilを返す
+// stdoutエクスポーターの出力先はテストで差し替えられるよう引数で受け取る
+func newProvider(ctx context.Context, cfg config.TracingConfig, stdout io.Writer) (*sdktrace.TracerProvider, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case config.TracingExporterNone:
+		return nil, nil
+	case config.TracingExporterOTLP:
+		exporter, err = otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+	case config.TracingExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(stdout))
+	default:
+		return nil, fmt.Errorf("unsupported tracing exporter: %s", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(ServiceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		// 呼び出し元がサンプリングしたトレースは、比率に関わらず記録する
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	), nil
+}
+
+// GinMiddleware はリクエストごとにスパンを作成し、traceparentヘッダーのトレースを引き継ぐ
+// excludedPathsはヘルスチェックなど、頻繁に呼ばれるためトレースしないパス
+func GinMiddleware(excludedPaths ...string) gin.HandlerFunc {
+	return otelgin.Middleware(ServiceName, otelgin.WithFilter(func(r *http.Request) bool {
+		return !slices.Contains(excludedPaths, r.URL.Path)
+	}))
+}
+
+// StrictMiddleware はストリクトハンドラーの処理をオペレーションIDのスパンで囲む
+// ハンドラーからリポジトリに渡るコンテキストにスパンを引き継ぐため、
+// ルーターのContextWithFallbackを有効にしておくこと
+func StrictMiddleware() api.StrictMiddlewareFunc {
+	tracer := otel.Tracer("message-service/internal/adapter/handler")
+	return func(f api.StrictHandlerFunc, operationID string) api.StrictHandlerFunc {
+		return func(c *gin.Context, request interface{}) (interface{}, error) {
+			ctx, span := tracer.Start(c.Request.Context(), operationID, trace.WithSpanKind(trace.SpanKindInternal))
+			defer span.End()
+
+			c.Request = c.Request.WithContext(ctx)
+			response, err := f(c, request)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return response, err
+		}
+	}
+}
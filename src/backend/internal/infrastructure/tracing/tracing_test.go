@@ -0,0 +1,191 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"message-service/internal/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name           string
+		cfg            config.TracingConfig
+		expectProvider bool
+		expectOutput   bool
+		expectedErr    string
+	}{
+		{
+			name: "正常系：noneではプロバイダーを作成しない",
+			cfg:  config.TracingConfig{Exporter: config.TracingExporterNone},
+		},
+		{
+			name:           "正常系：stdoutはスパンを出力する",
+			cfg:            config.TracingConfig{Exporter: config.TracingExporterStdout, SampleRatio: 1},
+			expectProvider: true,
+			expectOutput:   true,
+		},
+		{
+			name:           "正常系：サンプリング比率が0の場合は出力しない",
+			cfg:            config.TracingConfig{Exporter: config.TracingExporterStdout, SampleRatio: 0},
+			expectProvider: true,
+		},
+		{
+			name:           "正常系：otlpは送信先に接続せずに作成できる",
+			cfg:            config.TracingConfig{Exporter: config.TracingExporterOTLP, OTLPEndpoint: "http://127.0.0.1:4318/v1/traces", SampleRatio: 1},
+			expectProvider: true,
+		},
+		{
+			name:        "異常系：未対応のエクスポーター",
+			cfg:         config.TracingConfig{Exporter: "zipkin"},
+			expectedErr: "unsupported tracing exporter: zipkin",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			var out bytes.Buffer
+			provider, err := newProvider(ctx, tt.cfg, &out)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			if !tt.expectProvider {
+				assert.Nil(t, provider)
+				return
+			}
+
+			_, span := provider.Tracer("test").Start(ctx, "operation")
+			span.End()
+			// otlpの送信先は存在しないため、送信の失敗は確認しない
+			_ = provider.Shutdown(ctx)
+
+			if tt.expectOutput {
+				assert.Contains(t, out.String(), `"Name":"operation"`)
+				assert.Contains(t, out.String(), ServiceName)
+			} else {
+				assert.Empty(t, out.String())
+			}
+		})
+	}
+}
+
+func TestStrictMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	tests := []struct {
+		name           string
+		traceparent    string
+		handlerErr     error
+		expectedStatus codes.Code
+	}{
+		{
+			name:           "正常系：オペレーションIDのスパンを記録する",
+			expectedStatus: codes.Unset,
+		},
+		{
+			name:           "正常系：traceparentのトレースを引き継ぐ",
+			traceparent:    traceparent,
+			expectedStatus: codes.Unset,
+		},
+		{
+			name:           "異常系：ハンドラーのエラーを記録する",
+			handlerErr:     errors.New("handler error"),
+			expectedStatus: codes.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(recorder.Ended())
+			var handlerSpan trace.SpanContext
+			handler := func(c *gin.Context, request interface{}) (interface{}, error) {
+				// ContextWithFallbackにより、gin.Contextからスパンを参照できる
+				handlerSpan = trace.SpanContextFromContext(c)
+				return nil, tt.handlerErr
+			}
+			wrapped := StrictMiddleware()(handler, "ListMessages")
+
+			router := gin.New()
+			router.ContextWithFallback = true
+			router.Use(GinMiddleware("/healthz"))
+			router.GET("/api/messages", func(c *gin.Context) {
+				_, _ = wrapped(c, nil)
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/api/messages", nil)
+			if tt.traceparent != "" {
+				req.Header.Set("traceparent", tt.traceparent)
+			}
+			router.ServeHTTP(w, req)
+
+			spans := recorder.Ended()
+			if !assert.Len(t, spans, before+2) {
+				return
+			}
+			// ハンドラーのスパンが先に終了し、ginのリクエストのスパンの子になる
+			operation, request := spans[before], spans[before+1]
+			assert.Equal(t, "ListMessages", operation.Name())
+			assert.Equal(t, "/api/messages", request.Name())
+			assert.Equal(t, request.SpanContext().SpanID(), operation.Parent().SpanID())
+			assert.Equal(t, operation.SpanContext().SpanID(), handlerSpan.SpanID())
+			assert.Equal(t, tt.expectedStatus, operation.Status().Code)
+			if tt.traceparent != "" {
+				assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", request.SpanContext().TraceID().String())
+			}
+		})
+	}
+}
+
+func TestGinMiddleware_ExcludedPaths(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	tests := []struct {
+		name          string
+		path          string
+		expectedSpans int
+	}{
+		{name: "正常系：リクエストのスパンを記録する", path: "/api/messages", expectedSpans: 1},
+		{name: "正常系：除外したパスは記録しない", path: "/healthz", expectedSpans: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(recorder.Ended())
+			router := gin.New()
+			router.Use(GinMiddleware("/healthz"))
+			router.GET(tt.path, func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Len(t, recorder.Ended(), before+tt.expectedSpans)
+		})
+	}
+}
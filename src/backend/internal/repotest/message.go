@@ -0,0 +1,820 @@
+// Package repotest はリポジトリ実装が満たすべき振る舞いを検証する共通のテストスイートを提供する
+// 各実装のテストから呼び出し、MongoDB実装と同じ意味論を持つことを確認する
+package repotest
+
+import (
+	"context"
+	"errors"
+	"message-service/internal/domain/message"
+	"message-service/internal/domain/tenant"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MessageRepositoryFactory はテストごとに空のmessage.Repositoryを作成する
+type MessageRepositoryFactory func(t *testing.T) message.Repository
+
+// baseTime はテストデータの基準時刻
+// MongoDBはミリ秒精度で保存するため、ミリ秒未満を含まない値を使う
+var baseTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// RunMessageRepositorySuite はmessage.Repositoryの実装が満たすべき振る舞いを検証する
+func RunMessageRepositorySuite(t *testing.T, newRepo MessageRepositoryFactory) {
+	t.Run("Create", func(t *testing.T) { testMessageCreate(t, newRepo) })
+	t.Run("CreateMany", func(t *testing.T) { testMessageCreateMany(t, newRepo) })
+	t.Run("Delete", func(t *testing.T) { testMessageDelete(t, newRepo) })
+	t.Run("Search", func(t *testing.T) { testMessageSearch(t, newRepo) })
+	t.Run("SearchThreads", func(t *testing.T) { testMessageSearchThreads(t, newRepo) })
+	t.Run("SearchOptions", func(t *testing.T) { testMessageSearchOptions(t, newRepo) })
+	t.Run("SearchPages", func(t *testing.T) { testMessageSearchPages(t, newRepo) })
+	t.Run("Iterate", func(t *testing.T) { testMessageIterate(t, newRepo) })
+	t.Run("CountAndDeleteMany", func(t *testing.T) { testMessageCountAndDeleteMany(t, newRepo) })
+	t.Run("Expiry", func(t *testing.T) { testMessageExpiry(t, newRepo) })
+	t.Run("Stats", func(t *testing.T) { testMessageStats(t, newRepo) })
+	t.Run("Around", func(t *testing.T) { testMessageAround(t, newRepo) })
+	t.Run("TenantIsolation", func(t *testing.T) { testMessageTenantIsolation(t, newRepo) })
+}
+
+// defaultContext は既定のテナントを操作の対象とするコンテキストを返す
+func defaultContext() context.Context {
+	return tenant.WithID(context.Background(), tenant.DefaultID)
+}
+
+// expirer は有効期限切れのデータを自ら削除する実装が持つメソッド
+// TTLインデックスで削除するMongoDB実装は持たない
+type expirer interface {
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+func newMessage(uid string, sentAt time.Time) *message.Message {
+	return &message.Message{
+		UID:       uid,
+		SentAt:    sentAt,
+		Sender:    "sender-a",
+		ChannelID: "channel-a",
+		Content:   "content of " + uid,
+	}
+}
+
+func testMessageCreate(t *testing.T, newRepo MessageRepositoryFactory) {
+	ctx := defaultContext()
+
+	t.Run("正常系：作成したメッセージをUIDで取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+		msg := newMessage("msg1", baseTime)
+
+		assert.NoError(t, repo.Create(ctx, msg))
+		assert.NotZero(t, msg.CreatedAt)
+		assert.Equal(t, msg.CreatedAt, msg.UpdatedAt)
+
+		found, err := repo.FindByUID(ctx, "msg1")
+		assert.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.Equal(t, "msg1", found.UID)
+			assert.Equal(t, "sender-a", found.Sender)
+			assert.Equal(t, "channel-a", found.ChannelID)
+			assert.Equal(t, "content of msg1", found.Content)
+			assert.True(t, baseTime.Equal(found.SentAt))
+			assert.Nil(t, found.DeletedAt)
+		}
+	})
+
+	t.Run("正常系：存在しないUIDはnilを返す", func(t *testing.T) {
+		repo := newRepo(t)
+
+		found, err := repo.FindByUID(ctx, "unknown")
+
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("異常系：有効なメッセージとUIDが重複", func(t *testing.T) {
+		repo := newRepo(t)
+		assert.NoError(t, repo.Create(ctx, newMessage("msg1", baseTime)))
+
+		err := repo.Create(ctx, newMessage("msg1", baseTime.Add(time.Hour)))
+
+		assert.ErrorIs(t, err, message.ErrDuplicateUID)
+	})
+
+	t.Run("正常系：削除済みメッセージのUIDは再利用できる", func(t *testing.T) {
+		repo := newRepo(t)
+		assert.NoError(t, repo.Create(ctx, newMessage("msg1", baseTime)))
+		assert.NoError(t, repo.Delete(ctx, "msg1"))
+
+		assert.NoError(t, repo.Create(ctx, newMessage("msg1", baseTime.Add(time.Hour))))
+
+		found, err := repo.FindByUID(ctx, "msg1")
+		assert.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.True(t, baseTime.Add(time.Hour).Equal(found.SentAt))
+		}
+	})
+}
+
+func testMessageCreateMany(t *testing.T, newRepo MessageRepositoryFactory) {
+	ctx := defaultContext()
+
+	t.Run("正常系：重複したものだけ失敗し残りは作成される", func(t *testing.T) {
+		repo := newRepo(t)
+		assert.NoError(t, repo.Create(ctx, newMessage("existing", baseTime)))
+
+		errs, err := repo.CreateMany(ctx, []*message.Message{
+			newMessage("new1", baseTime),
+			newMessage("existing", baseTime),
+			newMessage("new1", baseTime),
+			newMessage("new2", baseTime),
+		})
+
+		assert.NoError(t, err)
+		if assert.Len(t, errs, 4) {
+			assert.NoError(t, errs[0])
+			assert.ErrorIs(t, errs[1], message.ErrDuplicateUID)
+			assert.ErrorIs(t, errs[2], message.ErrDuplicateUID)
+			assert.NoError(t, errs[3])
+		}
+		assertUIDs(t, repo, message.SearchCriteria{}, "existing", "new1", "new2")
+	})
+
+	t.Run("正常系：空の入力", func(t *testing.T) {
+		repo := newRepo(t)
+
+		errs, err := repo.CreateMany(ctx, nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, errs)
+	})
+}
+
+func testMessageDelete(t *testing.T, newRepo MessageRepositoryFactory) {
+	ctx := defaultContext()
+
+	t.Run("正常系：削除したメッセージは取得・検索の対象外になる", func(t *testing.T) {
+		repo := newRepo(t)
+		assert.NoError(t, repo.Create(ctx, newMessage("msg1", baseTime)))
+		assert.NoError(t, repo.Create(ctx, newMessage("msg2", baseTime.Add(time.Minute))))
+
+		assert.NoError(t, repo.Delete(ctx, "msg1"))
+
+		found, err := repo.FindByUID(ctx, "msg1")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+		assertUIDs(t, repo, message.SearchCriteria{}, "msg2")
+		count, err := repo.Count(ctx, message.SearchCriteria{})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("異常系：削除済みのメッセージ", func(t *testing.T) {
+		repo := newRepo(t)
+		assert.NoError(t, repo.Create(ctx, newMessage("msg1", baseTime)))
+		assert.NoError(t, repo.Delete(ctx, "msg1"))
+
+		assert.ErrorIs(t, repo.Delete(ctx, "msg1"), mongo.ErrNoDocuments)
+	})
+
+	t.Run("異常系：存在しないメッセージ", func(t *testing.T) {
+		repo := newRepo(t)
+
+		assert.ErrorIs(t, repo.Delete(ctx, "unknown"), mongo.ErrNoDocuments)
+	})
+}
+
+// seedSearchMessages は検索条件の検証用のメッセージを作成順と送信日時順を変えて登録する
+func seedSearchMessages(t *testing.T, repo message.Repository) {
+	t.Helper()
+	ctx := defaultContext()
+
+	msgs := []*message.Message{
+		{UID: "c-3", SentAt: baseTime.Add(3 * time.Hour), Sender: "alice", ChannelID: "general", Content: "3"},
+		{UID: "c-1", SentAt: baseTime.Add(1 * time.Hour), Sender: "alice", ChannelID: "general", Content: "1"},
+		{UID: "r-2", SentAt: baseTime.Add(2 * time.Hour), Sender: "bob", ChannelID: "random", Content: "2"},
+		{UID: "r-4", SentAt: baseTime.Add(4 * time.Hour), Sender: "alice", ChannelID: "random", Content: "4"},
+		{UID: "deleted", SentAt: baseTime.Add(2 * time.Hour), Sender: "alice", ChannelID: "general", Content: "x"},
+	}
+	for _, msg := range msgs {
+		if err := repo.Create(ctx, msg); err != nil {
+			t.Fatalf("failed to create %s: %v", msg.UID, err)
+		}
+	}
+	if err := repo.Delete(ctx, "deleted"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+}
+
+func searchCases() []struct {
+	name     string
+	criteria message.SearchCriteria
+	want     []string
+} {
+	alice := "alice"
+	al := "al"
+	upperAL := "AL"
+	wildcard := "a%"
+	hour2 := baseTime.Add(2 * time.Hour)
+	hour3 := baseTime.Add(3 * time.Hour)
+
+	return []struct {
+		name     string
+		criteria message.SearchCriteria
+		want     []string
+	}{
+		{name: "条件なしは送信日時の昇順", criteria: message.SearchCriteria{}, want: []string{"c-1", "r-2", "c-3", "r-4"}},
+		{name: "チャンネルで絞り込み", criteria: message.SearchCriteria{ChannelIDs: []string{"general"}}, want: []string{"c-1", "c-3"}},
+		{name: "送信者で絞り込み", criteria: message.SearchCriteria{Senders: []string{"alice"}}, want: []string{"c-1", "c-3", "r-4"}},
+		{name: "チャンネルと送信者の組み合わせ", criteria: message.SearchCriteria{ChannelIDs: []string{"random"}, Senders: []string{"alice"}}, want: []string{"r-4"}},
+		{name: "開始日時は境界を含む", criteria: message.SearchCriteria{FromDate: &hour2}, want: []string{"r-2", "c-3", "r-4"}},
+		{name: "終了日時は境界を含む", criteria: message.SearchCriteria{ToDate: &hour3}, want: []string{"c-1", "r-2", "c-3"}},
+		{name: "期間で絞り込み", criteria: message.SearchCriteria{FromDate: &hour2, ToDate: &hour3}, want: []string{"r-2", "c-3"}},
+		{name: "開始日時が終了日時より後", criteria: message.SearchCriteria{FromDate: &hour3, ToDate: &hour2}, want: nil},
+		{name: "一致しない", criteria: message.SearchCriteria{Senders: []string{"nobody"}}, want: nil},
+		{name: "複数のチャンネルのいずれか", criteria: message.SearchCriteria{ChannelIDs: []string{"random", "dev"}}, want: []string{"r-2", "r-4"}},
+		{name: "複数の送信者のいずれか", criteria: message.SearchCriteria{Senders: []string{"bob", "nobody"}}, want: []string{"r-2"}},
+		{name: "送信者の前方一致", criteria: message.SearchCriteria{SenderPrefix: &al}, want: []string{"c-1", "c-3", "r-4"}},
+		{name: "送信者の前方一致は大文字・小文字を区別する", criteria: message.SearchCriteria{SenderPrefix: &upperAL}, want: nil},
+		{name: "送信者の前方一致はワイルドカードを文字として扱う", criteria: message.SearchCriteria{SenderPrefix: &wildcard}, want: nil},
+		{name: "送信者を除外", criteria: message.SearchCriteria{ExcludeSenders: []string{"alice"}}, want: []string{"r-2"}},
+		{name: "送信者の指定と除外は両方を満たす", criteria: message.SearchCriteria{Senders: []string{"alice"}, ExcludeSenders: []string{"alice"}}, want: nil},
+		{name: "送信者の指定と前方一致は両方を満たす", criteria: message.SearchCriteria{Senders: []string{"alice", "bob"}, SenderPrefix: &alice}, want: []string{"c-1", "c-3", "r-4"}},
+	}
+}
+
+func testMessageSearch(t *testing.T, newRepo MessageRepositoryFactory) {
+	repo := newRepo(t)
+	seedSearchMessages(t, repo)
+
+	for _, tt := range searchCases() {
+		t.Run(tt.name, func(t *testing.T) {
+			assertUIDs(t, repo, tt.criteria, tt.want...)
+		})
+	}
+}
+
+func testMessageSearchThreads(t *testing.T, newRepo MessageRepositoryFactory) {
+	ctx := defaultContext()
+	ctxOther := tenant.WithID(ctx, "other")
+	repo := newRepo(t)
+
+	replyTo := func(msg *message.Message, parent string) *message.Message {
+		msg.ReplyTo = parent
+		return msg
+	}
+	seed := []struct {
+		ctx context.Context
+		msg *message.Message
+	}{
+		{ctx: ctx, msg: newMessage("parent", baseTime)},
+		{ctx: ctx, msg: replyTo(newMessage("reply-1", baseTime.Add(time.Minute)), "parent")},
+		{ctx: ctx, msg: replyTo(newMessage("reply-2", baseTime.Add(2*time.Minute)), "parent")},
+		// 返信が削除済みや有効期限切れ、他のテナントのもののみの場合は返信が無いものとする
+		{ctx: ctx, msg: newMessage("deleted-thread", baseTime.Add(3*time.Minute))},
+		{ctx: ctx, msg: replyTo(newMessage("deleted-reply", baseTime.Add(4*time.Minute)), "deleted-thread")},
+		{ctx: ctx, msg: newMessage("expired-thread", baseTime.Add(5*time.Minute))},
+		{ctx: ctx, msg: replyTo(withExpiry(newMessage("expired-reply", baseTime.Add(6*time.Minute)), time.Now().Add(-time.Second)), "expired-thread")},
+		{ctx: ctx, msg: newMessage("other-thread", baseTime.Add(7*time.Minute))},
+		{ctx: ctxOther, msg: replyTo(newMessage("other-reply", baseTime.Add(8*time.Minute)), "other-thread")},
+		// 返信先が存在しない返信も登録できる
+		{ctx: ctx, msg: replyTo(newMessage("orphan", baseTime.Add(9*time.Minute)), "missing")},
+	}
+	for _, s := range seed {
+		if err := repo.Create(s.ctx, s.msg); err != nil {
+			t.Fatalf("failed to create %s: %v", s.msg.UID, err)
+		}
+	}
+	if err := repo.Delete(ctx, "deleted-reply"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+
+	yes, no := true, false
+	tests := []struct {
+		name     string
+		criteria message.SearchCriteria
+		want     []string
+	}{
+		{name: "正常系：返信のみ", criteria: message.SearchCriteria{IsReply: &yes}, want: []string{"reply-1", "reply-2", "orphan"}},
+		{name: "正常系：返信以外", criteria: message.SearchCriteria{IsReply: &no}, want: []string{"parent", "deleted-thread", "expired-thread", "other-thread"}},
+		{name: "正常系：有効な返信があるもの", criteria: message.SearchCriteria{HasThread: &yes}, want: []string{"parent"}},
+		{
+			name:     "正常系：返信が無いもの",
+			criteria: message.SearchCriteria{HasThread: &no},
+			want:     []string{"reply-1", "reply-2", "deleted-thread", "expired-thread", "other-thread", "orphan"},
+		},
+		{name: "正常系：返信の無い返信以外のメッセージ", criteria: message.SearchCriteria{IsReply: &no, HasThread: &no}, want: []string{"deleted-thread", "expired-thread", "other-thread"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertUIDs(t, repo, tt.criteria, tt.want...)
+
+			count, err := repo.Count(ctx, tt.criteria)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(tt.want)), count)
+		})
+	}
+
+	t.Run("正常系：返信先を保存する", func(t *testing.T) {
+		found, err := repo.FindByUID(ctx, "reply-1")
+		if assert.NoError(t, err) && assert.NotNil(t, found) {
+			assert.Equal(t, "parent", found.ReplyTo)
+		}
+	})
+
+	t.Run("正常系：返信があるメッセージを一括削除する", func(t *testing.T) {
+		deleted, err := repo.DeleteMany(ctx, message.SearchCriteria{HasThread: &yes})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+		assertUIDs(t, repo, message.SearchCriteria{HasThread: &yes})
+	})
+}
+
+func testMessageSearchOptions(t *testing.T, newRepo MessageRepositoryFactory) {
+	ctx := defaultContext()
+	repo := newRepo(t)
+	seedSearchMessages(t, repo)
+
+	tests := []struct {
+		name string
+		opts message.SearchOptions
+		want []string
+	}{
+		{name: "正常系：既定は送信日時の昇順", opts: message.SearchOptions{}, want: []string{"c-1", "r-2", "c-3", "r-4"}},
+		{name: "正常系：送信日時の降順", opts: message.SearchOptions{Sort: message.SortSentAtDesc}, want: []string{"r-4", "c-3", "r-2", "c-1"}},
+		{name: "正常系：作成日時の昇順", opts: message.SearchOptions{Sort: message.SortCreatedAtAsc}, want: []string{"c-3", "c-1", "r-2", "r-4"}},
+		{name: "正常系：フィールドを指定しても指定したフィールドは取得できる", opts: message.SearchOptions{Fields: []string{"uid", "sender"}}, want: []string{"c-1", "r-2", "c-3", "r-4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msgs, err := repo.Search(ctx, message.SearchCriteria{}, tt.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, messageUIDs(msgs))
+			for _, msg := range msgs {
+				assert.NotEmpty(t, msg.Sender)
+			}
+		})
+	}
+}
+
+func testMessageSearchPages(t *testing.T, newRepo MessageRepositoryFactory) {
+	ctx := defaultContext()
+	repo := newRepo(t)
+
+	// 同時刻のメッセージはページの境界をまたいでもIDの順に並ぶ
+	seed := []struct {
+		uid     string
+		id      string
+		minutes int
+		channel string
+	}{
+		{uid: "a", id: "000000000000000000000001", minutes: 1, channel: "general"},
+		{uid: "b", id: "000000000000000000000002", minutes: 0, channel: "general"},
+		{uid: "c", id: "000000000000000000000003", minutes: 1, channel: "random"},
+		{uid: "d", id: "000000000000000000000004", minutes: 1, channel: "general"},
+		{uid: "e", id: "000000000000000000000005", minutes: 2, channel: "general"},
+	}
+	for _, s := range seed {
+		msg := newMessage(s.uid, baseTime.Add(time.Duration(s.minutes)*time.Minute))
+		msg.ChannelID = s.channel
+		msg.ID, _ = primitive.ObjectIDFromHex(s.id)
+		assert.NoError(t, repo.Create(ctx, msg))
+	}
+
+	tests := []struct {
+		name     string
+		criteria message.SearchCriteria
+		sort     message.SortOrder
+		limit    int
+		want     [][]string
+	}{
+		{name: "正常系：送信日時の昇順", limit: 2, want: [][]string{{"b", "a"}, {"c", "d"}, {"e"}}},
+		{name: "正常系：送信日時の降順", sort: message.SortSentAtDesc, limit: 2, want: [][]string{{"e", "d"}, {"c", "a"}, {"b"}}},
+		{name: "正常系：作成日時の昇順", sort: message.SortCreatedAtAsc, limit: 3, want: [][]string{{"a", "b", "c"}, {"d", "e"}}},
+		{name: "正常系：検索条件と組み合わせる", criteria: message.SearchCriteria{ChannelIDs: []string{"general"}}, limit: 3, want: [][]string{{"b", "a", "d"}, {"e"}}},
+		{name: "正常系：件数ちょうどで終わる場合は最後に空のページを返す", limit: 5, want: [][]string{{"b", "a", "c", "d", "e"}, nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := message.SearchOptions{Sort: tt.sort, Limit: tt.limit}
+			var pages [][]string
+			for range tt.want {
+				msgs, err := repo.Search(ctx, tt.criteria, opts)
+				if !assert.NoError(t, err) {
+					return
+				}
+				pages = append(pages, messageUIDs(msgs))
+				if len(msgs) == 0 {
+					break
+				}
+				opts.After = tt.sort.CursorAfter(&msgs[len(msgs)-1])
+			}
+			assert.Equal(t, tt.want, pages)
+		})
+	}
+
+	t.Run("正常系：カーソルの位置のメッセージが削除されても続きを取得できる", func(t *testing.T) {
+		msgs, err := repo.Search(ctx, message.SearchCriteria{}, message.SearchOptions{Limit: 2})
+		assert.NoError(t, err)
+		assert.NoError(t, repo.Delete(ctx, msgs[1].UID))
+
+		next, err := repo.Search(ctx, message.SearchCriteria{}, message.SearchOptions{Limit: 2, After: message.SortOrder("").CursorAfter(&msgs[1])})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"c", "d"}, messageUIDs(next))
+	})
+}
+
+func testMessageIterate(t *testing.T, newRepo MessageRepositoryFactory) {
+	ctx := defaultContext()
+	repo := newRepo(t)
+	seedSearchMessages(t, repo)
+
+	for _, tt := range searchCases() {
+		t.Run(tt.name, func(t *testing.T) {
+			var uids []string
+			err := repo.Iterate(ctx, tt.criteria, func(msg *message.Message) error {
+				uids = append(uids, msg.UID)
+				return nil
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, uids)
+		})
+	}
+
+	t.Run("コールバックのエラーで打ち切る", func(t *testing.T) {
+		stopErr := errors.New("stop")
+		calls := 0
+
+		err := repo.Iterate(ctx, message.SearchCriteria{}, func(msg *message.Message) error {
+			calls++
+			return stopErr
+		})
+
+		assert.ErrorIs(t, err, stopErr)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func testMessageCountAndDeleteMany(t *testing.T, newRepo MessageRepositoryFactory) {
+	ctx := defaultContext()
+
+	for _, tt := range searchCases() {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepo(t)
+			seedSearchMessages(t, repo)
+
+			count, err := repo.Count(ctx, tt.criteria)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(tt.want)), count)
+
+			// 上限を指定した場合は上限で打ち切る
+			limited, err := repo.CountUpTo(ctx, tt.criteria, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, min(int64(len(tt.want)), 1), limited)
+			limited, err = repo.CountUpTo(ctx, tt.criteria, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(tt.want)), limited)
+
+			deleted, err := repo.DeleteMany(ctx, tt.criteria)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(tt.want)), deleted)
+
+			// 削除後は一致するメッセージが無くなり、それ以外は残る
+			count, err = repo.Count(ctx, tt.criteria)
+			assert.NoError(t, err)
+			assert.Zero(t, count)
+			total, err := repo.Count(ctx, message.SearchCriteria{})
+			assert.NoError(t, err)
+			assert.Equal(t, int64(4-len(tt.want)), total)
+		})
+	}
+
+	t.Run("正常系：作成日時の上限以降に作成されたメッセージは対象外", func(t *testing.T) {
+		repo := newRepo(t)
+		base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		assert.NoError(t, repo.Create(ctx, newMessage("before", base)))
+		// 作成日時はリポジトリが設定するため、ミリ秒の精度でも前後が分かれるよう間を空ける
+		time.Sleep(5 * time.Millisecond)
+		cutoff := time.Now()
+		time.Sleep(5 * time.Millisecond)
+		assert.NoError(t, repo.Create(ctx, newMessage("after", base)))
+
+		criteria := message.SearchCriteria{ChannelIDs: []string{"channel-a"}, CreatedBefore: &cutoff}
+		count, err := repo.Count(ctx, criteria)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		deleted, err := repo.DeleteMany(ctx, criteria)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+		assertUIDs(t, repo, message.SearchCriteria{}, "after")
+	})
+}
+
+func testMessageExpiry(t *testing.T, newRepo MessageRepositoryFactory) {
+	ctx := defaultContext()
+	now := time.Now().Truncate(time.Millisecond)
+
+	repo := newRepo(t)
+	for _, msg := range []*message.Message{
+		newMessage("permanent", baseTime),
+		withExpiry(newMessage("live", baseTime.Add(time.Minute)), now.Add(time.Hour)),
+		withExpiry(newMessage("expired", baseTime.Add(2*time.Minute)), now.Add(-time.Second)),
+	} {
+		assert.NoError(t, repo.Create(ctx, msg))
+	}
+
+	t.Run("正常系：有効期限切れのメッセージは削除前でも検索されない", func(t *testing.T) {
+		assertUIDs(t, repo, message.SearchCriteria{}, "permanent", "live")
+
+		count, err := repo.Count(ctx, message.SearchCriteria{})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		found, err := repo.FindByUID(ctx, "expired")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("正常系：有効期限が保存される", func(t *testing.T) {
+		found, err := repo.FindByUID(ctx, "live")
+		assert.NoError(t, err)
+		if assert.NotNil(t, found) && assert.NotNil(t, found.ExpiresAt) {
+			assert.True(t, now.Add(time.Hour).Equal(*found.ExpiresAt))
+		}
+
+		found, err = repo.FindByUID(ctx, "permanent")
+		assert.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.Nil(t, found.ExpiresAt)
+		}
+	})
+
+	t.Run("異常系：有効期限切れのメッセージは削除できない", func(t *testing.T) {
+		assert.ErrorIs(t, repo.Delete(ctx, "expired"), mongo.ErrNoDocuments)
+	})
+
+	t.Run("正常系：有効期限切れのメッセージのみが物理削除される", func(t *testing.T) {
+		e, ok := repo.(expirer)
+		if !ok {
+			t.Skip("repository relies on TTL indexes")
+		}
+
+		deleted, err := e.DeleteExpired(ctx, now)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		// 削除後は同じUIDで作成できる
+		assert.NoError(t, repo.Create(ctx, newMessage("expired", baseTime)))
+		assertUIDs(t, repo, message.SearchCriteria{}, "permanent", "expired", "live")
+	})
+}
+
+func testMessageStats(t *testing.T, newRepo MessageRepositoryFactory) {
+	ctx := defaultContext()
+
+	tests := []struct {
+		name  string
+		query message.StatsQuery
+		want  []message.StatsBucket
+	}{
+		{
+			name:  "正常系：チャンネル単位は件数の多い順、同数はキーの昇順",
+			query: message.StatsQuery{GroupBy: message.GroupByChannel},
+			want: []message.StatsBucket{
+				{Key: "general", Count: 2, Senders: 1},
+				{Key: "random", Count: 2, Senders: 2},
+			},
+		},
+		{
+			name:  "正常系：送信者単位",
+			query: message.StatsQuery{GroupBy: message.GroupBySender},
+			want: []message.StatsBucket{
+				{Key: "alice", Count: 3, Senders: 1},
+				{Key: "bob", Count: 1, Senders: 1},
+			},
+		},
+		{
+			name:  "正常系：検索条件で絞り込んだ時間単位の集計は期間順",
+			query: message.StatsQuery{Criteria: message.SearchCriteria{ChannelIDs: []string{"random"}}, GroupBy: message.GroupByHour},
+			want: []message.StatsBucket{
+				{Key: "2024-01-01T02:00:00Z", Count: 1, Senders: 1},
+				{Key: "2024-01-01T04:00:00Z", Count: 1, Senders: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepo(t)
+			seedSearchMessages(t, repo)
+
+			got, err := repo.Stats(ctx, tt.query)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("正常系：日単位の境界は指定したタイムゾーンで決まる", func(t *testing.T) {
+		tokyo, err := time.LoadLocation("Asia/Tokyo")
+		assert.NoError(t, err)
+
+		repo := newRepo(t)
+		// UTCでは同じ日だが、日本時間では1月1日23時と1月2日1時になる
+		for _, msg := range []*message.Message{
+			newMessage("late", baseTime.Add(14*time.Hour)),
+			newMessage("early", baseTime.Add(16*time.Hour)),
+		} {
+			assert.NoError(t, repo.Create(ctx, msg))
+		}
+
+		got, err := repo.Stats(ctx, message.StatsQuery{GroupBy: message.GroupByDay, Location: tokyo})
+		assert.NoError(t, err)
+		assert.Equal(t, []message.StatsBucket{
+			{Key: "2024-01-01T00:00:00+09:00", Count: 1, Senders: 1},
+			{Key: "2024-01-02T00:00:00+09:00", Count: 1, Senders: 1},
+		}, got)
+
+		got, err = repo.Stats(ctx, message.StatsQuery{GroupBy: message.GroupByDay})
+		assert.NoError(t, err)
+		assert.Equal(t, []message.StatsBucket{
+			{Key: "2024-01-01T00:00:00Z", Count: 2, Senders: 1},
+		}, got)
+	})
+
+	t.Run("正常系：該当するメッセージが無い", func(t *testing.T) {
+		got, err := newRepo(t).Stats(ctx, message.StatsQuery{GroupBy: message.GroupByChannel})
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func testMessageAround(t *testing.T, newRepo MessageRepositoryFactory) {
+	ctx := defaultContext()
+	repo := newRepo(t)
+
+	// 同時刻のメッセージはIDの順に並ぶ
+	seed := []struct {
+		uid     string
+		id      string
+		minutes int
+		channel string
+	}{
+		{uid: "a", id: "000000000000000000000001", minutes: 0, channel: "general"},
+		{uid: "b", id: "000000000000000000000002", minutes: 1, channel: "general"},
+		{uid: "c", id: "000000000000000000000003", minutes: 2, channel: "general"},
+		{uid: "target", id: "000000000000000000000004", minutes: 2, channel: "general"},
+		{uid: "d", id: "000000000000000000000005", minutes: 2, channel: "general"},
+		{uid: "e", id: "000000000000000000000006", minutes: 3, channel: "general"},
+		{uid: "other", id: "000000000000000000000007", minutes: 2, channel: "random"},
+		{uid: "deleted", id: "000000000000000000000008", minutes: 1, channel: "general"},
+	}
+	for _, s := range seed {
+		msg := newMessage(s.uid, baseTime.Add(time.Duration(s.minutes)*time.Minute))
+		msg.ChannelID = s.channel
+		msg.ID, _ = primitive.ObjectIDFromHex(s.id)
+		assert.NoError(t, repo.Create(ctx, msg))
+	}
+	assert.NoError(t, repo.Delete(ctx, "deleted"))
+
+	target, err := repo.FindByUID(ctx, "target")
+	assert.NoError(t, err)
+	if !assert.NotNil(t, target) {
+		return
+	}
+
+	tests := []struct {
+		name      string
+		before    int
+		after     int
+		wantOlder []string
+		wantNewer []string
+	}{
+		{name: "正常系：対象に近いものから件数分を取得する", before: 2, after: 1, wantOlder: []string{"b", "c"}, wantNewer: []string{"d"}},
+		{name: "正常系：件数に満たない場合は全て返す", before: 10, after: 10, wantOlder: []string{"a", "b", "c"}, wantNewer: []string{"d", "e"}},
+		{name: "正常系：件数が0の側は空", before: 0, after: 1, wantNewer: []string{"d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			older, newer, err := repo.Around(ctx, target, tt.before, tt.after)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantOlder, messageUIDs(older))
+			assert.Equal(t, tt.wantNewer, messageUIDs(newer))
+		})
+	}
+}
+
+func testMessageTenantIsolation(t *testing.T, newRepo MessageRepositoryFactory) {
+	repo := newRepo(t)
+	ctxA := tenant.WithID(context.Background(), "tenant-a")
+	ctxB := tenant.WithID(context.Background(), "tenant-b")
+
+	// UIDはテナントごとに一意とする
+	msgA := newMessage("shared", baseTime)
+	assert.NoError(t, repo.Create(ctxA, msgA))
+	assert.Equal(t, "tenant-a", msgA.TenantID)
+	assert.NoError(t, repo.Create(ctxB, newMessage("shared", baseTime.Add(time.Minute))))
+	errs, err := repo.CreateMany(ctxB, []*message.Message{newMessage("only-b", baseTime.Add(2*time.Minute))})
+	assert.NoError(t, err)
+	assert.Equal(t, []error{nil}, errs)
+	assert.ErrorIs(t, repo.Create(ctxA, newMessage("shared", baseTime)), message.ErrDuplicateUID)
+
+	t.Run("正常系：他のテナントのメッセージは検索対象外", func(t *testing.T) {
+		msgs, err := repo.Search(ctxA, message.SearchCriteria{}, message.SearchOptions{})
+		assert.NoError(t, err)
+		if assert.Len(t, msgs, 1) {
+			assert.True(t, baseTime.Equal(msgs[0].SentAt))
+			assert.Equal(t, "tenant-a", msgs[0].TenantID)
+		}
+
+		count, err := repo.Count(ctxB, message.SearchCriteria{})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		found, err := repo.FindByUID(ctxA, "only-b")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		buckets, err := repo.Stats(ctxA, message.StatsQuery{GroupBy: message.GroupByChannel})
+		assert.NoError(t, err)
+		if assert.Len(t, buckets, 1) {
+			assert.Equal(t, int64(1), buckets[0].Count)
+		}
+	})
+
+	t.Run("異常系：コンテキストにテナントが無い場合は既定のテナントとみなさず拒否する", func(t *testing.T) {
+		ctx := context.Background()
+
+		assert.ErrorIs(t, repo.Create(ctx, newMessage("no-tenant", baseTime)), tenant.ErrNoTenant)
+		_, err := repo.CreateMany(ctx, []*message.Message{newMessage("no-tenant", baseTime)})
+		assert.ErrorIs(t, err, tenant.ErrNoTenant)
+		_, err = repo.FindByUID(ctx, "shared")
+		assert.ErrorIs(t, err, tenant.ErrNoTenant)
+		_, err = repo.Search(ctx, message.SearchCriteria{}, message.SearchOptions{})
+		assert.ErrorIs(t, err, tenant.ErrNoTenant)
+		_, err = repo.Count(ctx, message.SearchCriteria{})
+		assert.ErrorIs(t, err, tenant.ErrNoTenant)
+		_, err = repo.CountUpTo(ctx, message.SearchCriteria{}, 1)
+		assert.ErrorIs(t, err, tenant.ErrNoTenant)
+		_, err = repo.Stats(ctx, message.StatsQuery{GroupBy: message.GroupByChannel})
+		assert.ErrorIs(t, err, tenant.ErrNoTenant)
+		_, err = repo.DeleteMany(ctx, message.SearchCriteria{})
+		assert.ErrorIs(t, err, tenant.ErrNoTenant)
+		assert.ErrorIs(t, repo.Delete(ctx, "shared"), tenant.ErrNoTenant)
+	})
+
+	t.Run("正常系：前後のメッセージは同じテナントから取得する", func(t *testing.T) {
+		target, err := repo.FindByUID(ctxB, "shared")
+		if !assert.NoError(t, err) || !assert.NotNil(t, target) {
+			return
+		}
+		older, newer, err := repo.Around(ctxB, target, 10, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, older)
+		assert.Equal(t, []string{"only-b"}, messageUIDs(newer))
+	})
+
+	t.Run("正常系：削除は他のテナントに影響しない", func(t *testing.T) {
+		assert.ErrorIs(t, repo.Delete(ctxA, "only-b"), mongo.ErrNoDocuments)
+
+		deleted, err := repo.DeleteMany(ctxA, message.SearchCriteria{ChannelIDs: []string{msgA.ChannelID}})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		found, err := repo.FindByUID(ctxB, "shared")
+		assert.NoError(t, err)
+		assert.NotNil(t, found)
+	})
+}
+
+func withExpiry(msg *message.Message, expiresAt time.Time) *message.Message {
+	msg.ExpiresAt = &expiresAt
+	return msg
+}
+
+// assertUIDs は検索結果のUIDが期待した順序で一致することを検証する
+func assertUIDs(t *testing.T, repo message.Repository, criteria message.SearchCriteria, want ...string) {
+	t.Helper()
+
+	msgs, err := repo.Search(defaultContext(), criteria, message.SearchOptions{})
+	assert.NoError(t, err)
+
+	if len(want) == 0 {
+		want = nil
+	}
+	assert.Equal(t, want, messageUIDs(msgs))
+}
+
+// messageUIDs はメッセージのUIDを順に返す。メッセージが無い場合はnilを返す
+func messageUIDs(msgs []message.Message) []string {
+	var uids []string
+	for _, msg := range msgs {
+		uids = append(uids, msg.UID)
+	}
+	return uids
+}
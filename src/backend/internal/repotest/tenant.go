@@ -0,0 +1,176 @@
+package repotest
+
+import (
+	"context"
+	"message-service/internal/domain/tenant"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TenantRepositoryFactory はテストごとに既定のテナントのみを持つtenant.Repositoryを作成する
+type TenantRepositoryFactory func(t *testing.T) tenant.Repository
+
+// RunTenantRepositorySuite はtenant.Repositoryの実装が満たすべき振る舞いを検証する
+func RunTenantRepositorySuite(t *testing.T, newRepo TenantRepositoryFactory) {
+	t.Run("CreateAndGet", func(t *testing.T) { testTenantCreateAndGet(t, newRepo) })
+	t.Run("Update", func(t *testing.T) { testTenantUpdate(t, newRepo) })
+	t.Run("List", func(t *testing.T) { testTenantList(t, newRepo) })
+	t.Run("Reserve", func(t *testing.T) { testTenantReserve(t, newRepo) })
+}
+
+func testTenantCreateAndGet(t *testing.T, newRepo TenantRepositoryFactory) {
+	ctx := context.Background()
+
+	t.Run("正常系：作成したテナントをIDで取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+		created := &tenant.Tenant{ID: "team-a", Name: "Team A", Quota: tenant.Quota{MaxMessages: 100, MaxTokens: 2}}
+
+		assert.NoError(t, repo.Create(ctx, created))
+		assert.NotZero(t, created.CreatedAt)
+
+		found, err := repo.Get(ctx, "team-a")
+		assert.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.Equal(t, "Team A", found.Name)
+			assert.Equal(t, tenant.Quota{MaxMessages: 100, MaxTokens: 2}, found.Quota)
+		}
+	})
+
+	t.Run("正常系：既定のテナントが存在する", func(t *testing.T) {
+		found, err := newRepo(t).Get(ctx, tenant.DefaultID)
+		assert.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.Equal(t, tenant.Quota{}, found.Quota)
+		}
+	})
+
+	t.Run("正常系：存在しないIDはnilを返す", func(t *testing.T) {
+		found, err := newRepo(t).Get(ctx, "unknown")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("異常系：IDが重複", func(t *testing.T) {
+		repo := newRepo(t)
+		assert.NoError(t, repo.Create(ctx, &tenant.Tenant{ID: "team-a", Name: "Team A"}))
+
+		err := repo.Create(ctx, &tenant.Tenant{ID: "team-a", Name: "Another"})
+
+		assert.ErrorIs(t, err, tenant.ErrDuplicateTenant)
+	})
+}
+
+func testTenantUpdate(t *testing.T, newRepo TenantRepositoryFactory) {
+	ctx := context.Background()
+
+	t.Run("正常系：名前と上限を更新する", func(t *testing.T) {
+		repo := newRepo(t)
+		assert.NoError(t, repo.Create(ctx, &tenant.Tenant{ID: "team-a", Name: "Team A"}))
+
+		updated := &tenant.Tenant{ID: "team-a", Name: "Renamed", Quota: tenant.Quota{MaxTokens: 5}}
+		assert.NoError(t, repo.Update(ctx, updated))
+		assert.NotZero(t, updated.CreatedAt)
+
+		found, err := repo.Get(ctx, "team-a")
+		assert.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.Equal(t, "Renamed", found.Name)
+			assert.Equal(t, int64(5), found.Quota.MaxTokens)
+			assert.False(t, found.UpdatedAt.Before(found.CreatedAt))
+		}
+	})
+
+	t.Run("異常系：存在しないテナント", func(t *testing.T) {
+		err := newRepo(t).Update(ctx, &tenant.Tenant{ID: "unknown", Name: "Unknown"})
+
+		assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+	})
+}
+
+func testTenantList(t *testing.T, newRepo TenantRepositoryFactory) {
+	ctx := context.Background()
+	repo := newRepo(t)
+	for _, id := range []string{"team-b", "team-a"} {
+		assert.NoError(t, repo.Create(ctx, &tenant.Tenant{ID: id, Name: id}))
+	}
+
+	tenants, err := repo.List(ctx)
+
+	assert.NoError(t, err)
+	var ids []string
+	for _, tn := range tenants {
+		ids = append(ids, tn.ID)
+	}
+	assert.Equal(t, []string{tenant.DefaultID, "team-a", "team-b"}, ids)
+}
+
+func testTenantReserve(t *testing.T, newRepo TenantRepositoryFactory) {
+	ctx := context.Background()
+
+	t.Run("正常系：他の作成中の分を含めた件数を返し、解放すると戻る", func(t *testing.T) {
+		repo := newRepo(t)
+
+		reserved, err := repo.Reserve(ctx, "team-a", tenant.ResourceMessages, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), reserved)
+
+		reserved, err = repo.Reserve(ctx, "team-a", tenant.ResourceMessages, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), reserved)
+
+		assert.NoError(t, repo.Release(ctx, "team-a", tenant.ResourceMessages, 3))
+		assert.NoError(t, repo.Release(ctx, "team-a", tenant.ResourceMessages, 2))
+
+		reserved, err = repo.Reserve(ctx, "team-a", tenant.ResourceMessages, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), reserved)
+	})
+
+	t.Run("正常系：テナントと種類ごとに数える", func(t *testing.T) {
+		repo := newRepo(t)
+		_, err := repo.Reserve(ctx, "team-a", tenant.ResourceMessages, 3)
+		assert.NoError(t, err)
+
+		reserved, err := repo.Reserve(ctx, "team-a", tenant.ResourceTokens, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), reserved)
+
+		reserved, err = repo.Reserve(ctx, "team-b", tenant.ResourceMessages, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), reserved)
+	})
+
+	t.Run("正常系：予約していない分を解放しても負にならない", func(t *testing.T) {
+		repo := newRepo(t)
+		assert.NoError(t, repo.Release(ctx, "team-a", tenant.ResourceTokens, 1))
+		_, err := repo.Reserve(ctx, "team-a", tenant.ResourceTokens, 1)
+		assert.NoError(t, err)
+		assert.NoError(t, repo.Release(ctx, "team-a", tenant.ResourceTokens, 2))
+
+		reserved, err := repo.Reserve(ctx, "team-a", tenant.ResourceTokens, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), reserved)
+	})
+
+	t.Run("正常系：同時に予約しても取りこぼさない", func(t *testing.T) {
+		repo := newRepo(t)
+		const workers = 10
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := repo.Reserve(ctx, "team-a", tenant.ResourceMessages, 1)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		reserved, err := repo.Reserve(ctx, "team-a", tenant.ResourceMessages, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(workers+1), reserved)
+	})
+}
@@ -0,0 +1,319 @@
+package repotest
+
+import (
+	"context"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/domain/token"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TokenRepositoryFactory はテストごとに空のtoken.Repositoryを作成する
+type TokenRepositoryFactory func(t *testing.T) token.Repository
+
+// RunTokenRepositorySuite はtoken.Repositoryの実装が満たすべき振る舞いを検証する
+func RunTokenRepositorySuite(t *testing.T, newRepo TokenRepositoryFactory) {
+	t.Run("Create", func(t *testing.T) { testTokenCreate(t, newRepo) })
+	t.Run("FindByToken", func(t *testing.T) { testTokenFindByToken(t, newRepo) })
+	t.Run("FindByID", func(t *testing.T) { testTokenFindByID(t, newRepo) })
+	t.Run("List", func(t *testing.T) { testTokenList(t, newRepo) })
+	t.Run("Delete", func(t *testing.T) { testTokenDelete(t, newRepo) })
+	t.Run("DeleteExpired", func(t *testing.T) { testTokenDeleteExpired(t, newRepo) })
+	t.Run("TenantIsolation", func(t *testing.T) { testTokenTenantIsolation(t, newRepo) })
+}
+
+func newToken(value string, expiresAt time.Time) *token.Token {
+	return &token.Token{
+		Token:     value,
+		Name:      "name of " + value,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// createToken はトークンを作成し、失敗した場合はテストを中断する
+func createToken(t *testing.T, repo token.Repository, value string, expiresAt time.Time) *token.Token {
+	t.Helper()
+	tkn := newToken(value, expiresAt)
+	if err := repo.Create(defaultContext(), tkn); err != nil {
+		t.Fatalf("failed to create token %s: %v", value, err)
+	}
+	return tkn
+}
+
+func testTokenCreate(t *testing.T, newRepo TokenRepositoryFactory) {
+	ctx := defaultContext()
+
+	t.Run("正常系：IDと作成日時が設定される", func(t *testing.T) {
+		repo := newRepo(t)
+		tkn := newToken("token1", time.Now().Add(time.Hour))
+
+		assert.NoError(t, repo.Create(ctx, tkn))
+
+		assert.False(t, tkn.ID.IsZero())
+		assert.NotZero(t, tkn.CreatedAt)
+		assert.Equal(t, tkn.CreatedAt, tkn.UpdatedAt)
+	})
+
+	t.Run("異常系：有効なトークンと値が重複", func(t *testing.T) {
+		repo := newRepo(t)
+		createToken(t, repo, "token1", time.Now().Add(time.Hour))
+
+		err := repo.Create(ctx, newToken("token1", time.Now().Add(time.Hour)))
+
+		assert.ErrorIs(t, err, token.ErrDuplicateToken)
+	})
+}
+
+func testTokenFindByToken(t *testing.T, newRepo TokenRepositoryFactory) {
+	ctx := defaultContext()
+	now := time.Now()
+	repo := newRepo(t)
+
+	createToken(t, repo, "valid", now.Add(time.Hour))
+	createToken(t, repo, "expiring-soon", now.Add(time.Minute))
+	createToken(t, repo, "expired", now.Add(-time.Hour))
+	createToken(t, repo, "just-expired", now.Add(-10*time.Millisecond))
+	deleted := createToken(t, repo, "deleted", now.Add(time.Hour))
+	if err := repo.Delete(ctx, deleted.ID.Hex()); err != nil {
+		t.Fatalf("failed to delete token: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		value string
+		found bool
+	}{
+		{name: "有効なトークン", value: "valid", found: true},
+		{name: "まもなく期限切れのトークン", value: "expiring-soon", found: true},
+		{name: "期限切れのトークン", value: "expired", found: false},
+		{name: "期限を過ぎた直後のトークン", value: "just-expired", found: false},
+		{name: "削除済みのトークン", value: "deleted", found: false},
+		{name: "存在しないトークン", value: "unknown", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := repo.FindByToken(ctx, tt.value)
+
+			assert.NoError(t, err)
+			if tt.found {
+				if assert.NotNil(t, found) {
+					assert.Equal(t, tt.value, found.Token)
+					assert.Equal(t, "name of "+tt.value, found.Name)
+				}
+			} else {
+				assert.Nil(t, found)
+			}
+		})
+	}
+}
+
+func testTokenFindByID(t *testing.T, newRepo TokenRepositoryFactory) {
+	ctx := defaultContext()
+	repo := newRepo(t)
+	expired := createToken(t, repo, "expired", time.Now().Add(-time.Hour))
+	deleted := createToken(t, repo, "deleted", time.Now().Add(time.Hour))
+	if err := repo.Delete(ctx, deleted.ID.Hex()); err != nil {
+		t.Fatalf("failed to delete token: %v", err)
+	}
+
+	t.Run("正常系：期限切れのトークンも取得できる", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, expired.ID.Hex())
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.Equal(t, expired.ID, found.ID)
+			assert.Equal(t, "expired", found.Token)
+		}
+	})
+
+	t.Run("正常系：削除済みのトークンはnilを返す", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, deleted.ID.Hex())
+
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("正常系：存在しないIDはnilを返す", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("異常系：不正な形式のID", func(t *testing.T) {
+		_, err := repo.FindByID(ctx, "invalid-id")
+
+		assert.Error(t, err)
+	})
+}
+
+func testTokenList(t *testing.T, newRepo TokenRepositoryFactory) {
+	ctx := defaultContext()
+	repo := newRepo(t)
+
+	createToken(t, repo, "first", time.Now().Add(time.Hour))
+	// 作成日時の順序が確定するよう間隔を空ける
+	time.Sleep(5 * time.Millisecond)
+	createToken(t, repo, "second", time.Now().Add(time.Hour))
+	time.Sleep(5 * time.Millisecond)
+	createToken(t, repo, "expired", time.Now().Add(-time.Hour))
+	deleted := createToken(t, repo, "deleted", time.Now().Add(time.Hour))
+	if err := repo.Delete(ctx, deleted.ID.Hex()); err != nil {
+		t.Fatalf("failed to delete token: %v", err)
+	}
+
+	tokens, err := repo.List(ctx)
+
+	assert.NoError(t, err)
+	var values []string
+	for _, tkn := range tokens {
+		values = append(values, tkn.Token)
+	}
+	// 期限切れ・削除済みを除き、作成日時の降順
+	assert.Equal(t, []string{"second", "first"}, values)
+
+	t.Run("正常系：一覧と同じトークンを上限まで数える", func(t *testing.T) {
+		count, err := repo.CountUpTo(ctx, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		count, err = repo.CountUpTo(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
+
+func testTokenDelete(t *testing.T, newRepo TokenRepositoryFactory) {
+	ctx := defaultContext()
+
+	t.Run("正常系：削除したトークンは認証に使えない", func(t *testing.T) {
+		repo := newRepo(t)
+		tkn := createToken(t, repo, "token1", time.Now().Add(time.Hour))
+
+		assert.NoError(t, repo.Delete(ctx, tkn.ID.Hex()))
+
+		found, err := repo.FindByToken(ctx, "token1")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("正常系：削除済みのトークンの値は再利用できる", func(t *testing.T) {
+		repo := newRepo(t)
+		tkn := createToken(t, repo, "token1", time.Now().Add(time.Hour))
+		assert.NoError(t, repo.Delete(ctx, tkn.ID.Hex()))
+
+		assert.NoError(t, repo.Create(ctx, newToken("token1", time.Now().Add(time.Hour))))
+	})
+
+	t.Run("異常系：削除済みのトークン", func(t *testing.T) {
+		repo := newRepo(t)
+		tkn := createToken(t, repo, "token1", time.Now().Add(time.Hour))
+		assert.NoError(t, repo.Delete(ctx, tkn.ID.Hex()))
+
+		assert.ErrorIs(t, repo.Delete(ctx, tkn.ID.Hex()), mongo.ErrNoDocuments)
+	})
+
+	t.Run("異常系：存在しないトークン", func(t *testing.T) {
+		repo := newRepo(t)
+
+		assert.ErrorIs(t, repo.Delete(ctx, primitive.NewObjectID().Hex()), mongo.ErrNoDocuments)
+	})
+
+	t.Run("異常系：不正な形式のID", func(t *testing.T) {
+		repo := newRepo(t)
+
+		assert.Error(t, repo.Delete(ctx, "invalid-id"))
+	})
+}
+
+func testTokenDeleteExpired(t *testing.T, newRepo TokenRepositoryFactory) {
+	ctx := defaultContext()
+	repo := newRepo(t)
+	e, ok := repo.(expirer)
+	if !ok {
+		t.Skip("repository relies on TTL indexes")
+	}
+
+	now := time.Now()
+	live := createToken(t, repo, "live", now.Add(time.Hour))
+	expired := createToken(t, repo, "expired", now.Add(-time.Second))
+
+	deleted, err := e.DeleteExpired(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	found, err := repo.FindByID(ctx, expired.ID.Hex())
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+
+	found, err = repo.FindByID(ctx, live.ID.Hex())
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+}
+
+func testTokenTenantIsolation(t *testing.T, newRepo TokenRepositoryFactory) {
+	repo := newRepo(t)
+	ctxA := tenant.WithID(context.Background(), "tenant-a")
+	ctxB := tenant.WithID(context.Background(), "tenant-b")
+
+	tknA := newToken("token-a", time.Now().Add(time.Hour))
+	tknA.Admin = true
+	tknA.SuperAdmin = true
+	if err := repo.Create(ctxA, tknA); err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+	assert.Equal(t, "tenant-a", tknA.TenantID)
+
+	t.Run("異常系：トークン文字列はテナントをまたいで一意", func(t *testing.T) {
+		assert.ErrorIs(t, repo.Create(ctxB, newToken("token-a", time.Now().Add(time.Hour))), token.ErrDuplicateToken)
+	})
+
+	t.Run("正常系：認証では全てのテナントから探し、テナントを返す", func(t *testing.T) {
+		found, err := repo.FindByToken(ctxB, "token-a")
+		assert.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.Equal(t, "tenant-a", found.TenantID)
+			assert.True(t, found.Admin)
+			assert.True(t, found.SuperAdmin)
+		}
+	})
+
+	t.Run("正常系：他のテナントのトークンは参照も削除もできない", func(t *testing.T) {
+		tokens, err := repo.List(ctxB)
+		assert.NoError(t, err)
+		assert.Empty(t, tokens)
+
+		count, err := repo.CountUpTo(ctxB, 10)
+		assert.NoError(t, err)
+		assert.Zero(t, count)
+
+		found, err := repo.FindByID(ctxB, tknA.ID.Hex())
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		assert.ErrorIs(t, repo.Delete(ctxB, tknA.ID.Hex()), mongo.ErrNoDocuments)
+
+		tokens, err = repo.List(ctxA)
+		assert.NoError(t, err)
+		assert.Len(t, tokens, 1)
+	})
+
+	t.Run("異常系：コンテキストにテナントが無い場合は既定のテナントとみなさず拒否する", func(t *testing.T) {
+		ctx := context.Background()
+
+		assert.ErrorIs(t, repo.Create(ctx, newToken("no-tenant", time.Now().Add(time.Hour))), tenant.ErrNoTenant)
+		_, err := repo.List(ctx)
+		assert.ErrorIs(t, err, tenant.ErrNoTenant)
+		_, err = repo.CountUpTo(ctx, 1)
+		assert.ErrorIs(t, err, tenant.ErrNoTenant)
+		_, err = repo.FindByID(ctx, tknA.ID.Hex())
+		assert.ErrorIs(t, err, tenant.ErrNoTenant)
+		assert.ErrorIs(t, repo.Delete(ctx, tknA.ID.Hex()), tenant.ErrNoTenant)
+	})
+}
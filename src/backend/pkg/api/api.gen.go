@@ -1,6 +1,6 @@
 // Package api provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/deepmap/oapi-codegen version v1.16.3 DO NOT EDIT.
+// Code generated by github.com/deepmap/oapi-codegen version (devel) DO NOT EDIT.
 package api
 
 import (
@@ -10,6 +10,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
@@ -26,40 +27,258 @@ const (
 	BearerAuthScopes = "BearerAuth.Scopes"
 )
 
+// Defines values for MessageBatchItemResultStatus.
+const (
+	Created       MessageBatchItemResultStatus = "created"
+	Duplicate     MessageBatchItemResultStatus = "duplicate"
+	Failed        MessageBatchItemResultStatus = "failed"
+	Invalid       MessageBatchItemResultStatus = "invalid"
+	QuotaExceeded MessageBatchItemResultStatus = "quota_exceeded"
+)
+
+// Defines values for MessageStatsGroupBy.
+const (
+	MessageStatsGroupByChannel MessageStatsGroupBy = "channel"
+	MessageStatsGroupByDay     MessageStatsGroupBy = "day"
+	MessageStatsGroupByHour    MessageStatsGroupBy = "hour"
+	MessageStatsGroupBySender  MessageStatsGroupBy = "sender"
+)
+
+// Defines values for GetApiMessagesExportParamsFormat.
+const (
+	Csv    GetApiMessagesExportParamsFormat = "csv"
+	Json   GetApiMessagesExportParamsFormat = "json"
+	Ndjson GetApiMessagesExportParamsFormat = "ndjson"
+)
+
+// Defines values for GetApiMessagesSearchParamsSort.
+const (
+	CreatedAt   GetApiMessagesSearchParamsSort = "created_at"
+	MinusSentAt GetApiMessagesSearchParamsSort = "-sent_at"
+	SentAt      GetApiMessagesSearchParamsSort = "sent_at"
+)
+
+// Defines values for GetApiStatsMessagesParamsGroupBy.
+const (
+	GetApiStatsMessagesParamsGroupByChannel GetApiStatsMessagesParamsGroupBy = "channel"
+	GetApiStatsMessagesParamsGroupByDay     GetApiStatsMessagesParamsGroupBy = "day"
+	GetApiStatsMessagesParamsGroupByHour    GetApiStatsMessagesParamsGroupBy = "hour"
+	GetApiStatsMessagesParamsGroupBySender  GetApiStatsMessagesParamsGroupBy = "sender"
+)
+
 // Message defines model for Message.
 type Message struct {
 	ChannelId *string    `json:"channel_id,omitempty"`
 	Content   *string    `json:"content,omitempty"`
 	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// ExpiresAt Time after which the message is no longer returned and is removed
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// ReplyTo UID of the message this message replies to
+	ReplyTo   *string    `json:"reply_to,omitempty"`
 	Sender    *string    `json:"sender,omitempty"`
 	SentAt    *time.Time `json:"sent_at,omitempty"`
 	Uid       *string    `json:"uid,omitempty"`
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
+// MessageBatchCreate defines model for MessageBatchCreate.
+type MessageBatchCreate struct {
+	Messages []MessageCreate `json:"messages"`
+}
+
+// MessageBatchItemResult defines model for MessageBatchItemResult.
+type MessageBatchItemResult struct {
+	// Error Reason when the message was not created
+	Error *string `json:"error,omitempty"`
+
+	// Index Position of the message in the request
+	Index int `json:"index"`
+
+	// Status failed means the storage could not write the message. It can be sent again;
+	// if it was written before the failure, the retry reports it as duplicate.
+	Status MessageBatchItemResultStatus `json:"status"`
+	Uid    *string                      `json:"uid,omitempty"`
+}
+
+// MessageBatchItemResultStatus failed means the storage could not write the message. It can be sent again;
+// if it was written before the failure, the retry reports it as duplicate.
+type MessageBatchItemResultStatus string
+
+// MessageBatchResult defines model for MessageBatchResult.
+type MessageBatchResult struct {
+	Created   int `json:"created"`
+	Duplicate int `json:"duplicate"`
+
+	// Error Reason processing stopped early. Only set with status 207
+	Error *string `json:"error,omitempty"`
+
+	// Failed Number of messages the storage could not write
+	Failed  int `json:"failed"`
+	Invalid int `json:"invalid"`
+
+	// QuotaExceeded Number of messages rejected because the tenant reached its message quota
+	QuotaExceeded int `json:"quota_exceeded"`
+
+	// Results Results in request order. When processing stopped early, messages after the
+	// last result were not processed.
+	Results []MessageBatchItemResult `json:"results"`
+}
+
+// MessageBulkDelete defines model for MessageBulkDelete.
+type MessageBulkDelete struct {
+	// ChannelId Channel IDs to include. Matches any of the given channels.
+	ChannelId *[]string `json:"channel_id,omitempty"`
+
+	// ConfirmationToken Token returned by the dry run. Required when dry_run is false.
+	// The token is signed by the server and expires after a fixed period (10 minutes by default)
+	ConfirmationToken *string `json:"confirmation_token,omitempty"`
+
+	// DryRun When true, only counts the matching messages and issues a confirmation token
+	DryRun *bool `json:"dry_run,omitempty"`
+
+	// ExcludeSender Senders to exclude
+	ExcludeSender *[]string  `json:"exclude_sender,omitempty"`
+	FromDate      *time.Time `json:"from_date,omitempty"`
+
+	// Sender Senders to include. Matches any of the given senders.
+	Sender *[]string  `json:"sender,omitempty"`
+	ToDate *time.Time `json:"to_date,omitempty"`
+}
+
+// MessageBulkDeleteResult defines model for MessageBulkDeleteResult.
+type MessageBulkDeleteResult struct {
+	// ConfirmationToken Token to pass back to execute the deletion. Only set when dry_run is true
+	ConfirmationToken *string `json:"confirmation_token,omitempty"`
+
+	// Deleted Number of messages deleted. Only set when dry_run is false
+	Deleted *int64 `json:"deleted,omitempty"`
+	DryRun  bool   `json:"dry_run"`
+
+	// Matched Number of messages matching the criteria
+	Matched int64 `json:"matched"`
+}
+
+// MessageContext defines model for MessageContext.
+type MessageContext struct {
+	// After Messages sent after the target in the same channel, oldest first
+	After []Message `json:"after"`
+
+	// Before Messages sent before the target in the same channel, oldest first
+	Before  []Message `json:"before"`
+	Message Message   `json:"message"`
+}
+
 // MessageCreate defines model for MessageCreate.
 type MessageCreate struct {
-	ChannelId string    `json:"channel_id"`
-	Content   string    `json:"content"`
-	Sender    string    `json:"sender"`
-	SentAt    time.Time `json:"sent_at"`
-	Uid       string    `json:"uid"`
+	ChannelId string `json:"channel_id"`
+	Content   string `json:"content"`
+
+	// ExpiresAt Makes the message ephemeral. Cannot be combined with ttl_seconds
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// ReplyTo UID of the message this message replies to. The parent does not have to exist yet,
+	// so replies can be ingested before their parent.
+	ReplyTo *string   `json:"reply_to,omitempty"`
+	Sender  string    `json:"sender"`
+	SentAt  time.Time `json:"sent_at"`
+
+	// TtlSeconds Makes the message ephemeral, expiring this many seconds after creation. Cannot be combined with expires_at
+	TtlSeconds *int64 `json:"ttl_seconds,omitempty"`
+	Uid        string `json:"uid"`
+}
+
+// MessageStats defines model for MessageStats.
+type MessageStats struct {
+	// Buckets Time buckets are ordered by period. Channel and sender buckets are ordered by count, highest first.
+	// Periods without messages are omitted.
+	Buckets  []MessageStatsBucket `json:"buckets"`
+	GroupBy  MessageStatsGroupBy  `json:"group_by"`
+	Timezone string               `json:"timezone"`
+}
+
+// MessageStatsGroupBy defines model for MessageStats.GroupBy.
+type MessageStatsGroupBy string
+
+// MessageStatsBucket defines model for MessageStatsBucket.
+type MessageStatsBucket struct {
+	// Count Number of messages in the bucket
+	Count int64 `json:"count"`
+
+	// Key Channel ID, sender, or start of the period (RFC 3339 in the requested timezone)
+	Key string `json:"key"`
+
+	// Senders Number of distinct senders who posted in the bucket
+	Senders int64 `json:"senders"`
+}
+
+// Tenant defines model for Tenant.
+type Tenant struct {
+	CreatedAt *time.Time   `json:"created_at,omitempty"`
+	Id        string       `json:"id"`
+	Name      string       `json:"name"`
+	Quota     TenantQuota  `json:"quota"`
+	UpdatedAt *time.Time   `json:"updated_at,omitempty"`
+	Usage     *TenantUsage `json:"usage,omitempty"`
+}
+
+// TenantCreate defines model for TenantCreate.
+type TenantCreate struct {
+	// Id Lowercase letters, digits and hyphens. Cannot be changed later
+	Id    string       `json:"id"`
+	Name  string       `json:"name"`
+	Quota *TenantQuota `json:"quota,omitempty"`
+}
+
+// TenantQuota defines model for TenantQuota.
+type TenantQuota struct {
+	// MaxMessages Maximum number of active messages. 0 means unlimited
+	MaxMessages *int64 `json:"max_messages,omitempty"`
+
+	// MaxTokens Maximum number of active tokens. 0 means unlimited
+	MaxTokens *int64 `json:"max_tokens,omitempty"`
+}
+
+// TenantUpdate Only the given fields are updated
+type TenantUpdate struct {
+	Name  *string      `json:"name,omitempty"`
+	Quota *TenantQuota `json:"quota,omitempty"`
 }
 
-// Token defines model for Token.
+// TenantUsage defines model for TenantUsage.
+type TenantUsage struct {
+	// Messages Number of active messages
+	Messages int64 `json:"messages"`
+
+	// Tokens Number of active tokens
+	Tokens int64 `json:"tokens"`
+}
+
+// Token Token metadata. The token string is only returned when the token is created
 type Token struct {
+	// Admin Whether the token can read its own tenant and issue admin tokens in it
+	Admin     *bool      `json:"admin,omitempty"`
 	CreatedAt *time.Time `json:"created_at,omitempty"`
 	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 	Id        *string    `json:"id,omitempty"`
 
 	// Name Token identifier name
-	Name      *string    `json:"name,omitempty"`
-	Token     *string    `json:"token,omitempty"`
+	Name *string `json:"name,omitempty"`
+
+	// SuperAdmin Whether the token can manage every tenant. Only issued by the admin command
+	SuperAdmin *bool `json:"super_admin,omitempty"`
+
+	// TenantId Tenant the token belongs to. Every request made with the token is scoped to this tenant
+	TenantId  *string    `json:"tenant_id,omitempty"`
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
 // TokenCreate defines model for TokenCreate.
 type TokenCreate struct {
+	// Admin Issues an admin token. Only admin tokens can issue admin tokens
+	Admin *bool `json:"admin,omitempty"`
+
 	// ExpiresIn Token expiration period in seconds
 	ExpiresIn *int `json:"expires_in,omitempty"`
 
@@ -69,23 +288,158 @@ type TokenCreate struct {
 
 // TokenResponse defines model for TokenResponse.
 type TokenResponse struct {
-	ExpiresAt *time.Time `json:"expires_at,omitempty"`
-	Id        *string    `json:"id,omitempty"`
-	Name      *string    `json:"name,omitempty"`
-	Token     *string    `json:"token,omitempty"`
+	Admin      *bool      `json:"admin,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	Id         *string    `json:"id,omitempty"`
+	Name       *string    `json:"name,omitempty"`
+	SuperAdmin *bool      `json:"super_admin,omitempty"`
+	TenantId   *string    `json:"tenant_id,omitempty"`
+	Token      *string    `json:"token,omitempty"`
+}
+
+// ChannelIdFilter defines model for ChannelIdFilter.
+type ChannelIdFilter = []string
+
+// ExcludeSenderFilter defines model for ExcludeSenderFilter.
+type ExcludeSenderFilter = []string
+
+// FromDateFilter defines model for FromDateFilter.
+type FromDateFilter = time.Time
+
+// HasThreadFilter defines model for HasThreadFilter.
+type HasThreadFilter = bool
+
+// IsReplyFilter defines model for IsReplyFilter.
+type IsReplyFilter = bool
+
+// SenderFilter defines model for SenderFilter.
+type SenderFilter = []string
+
+// SenderPrefixFilter defines model for SenderPrefixFilter.
+type SenderPrefixFilter = string
+
+// ToDateFilter defines model for ToDateFilter.
+type ToDateFilter = time.Time
+
+// GetApiMessagesExportParams defines parameters for GetApiMessagesExport.
+type GetApiMessagesExportParams struct {
+	// ChannelId Channel IDs to include. Repeat the parameter to match any of several channels.
+	ChannelId *ChannelIdFilter `form:"channel_id,omitempty" json:"channel_id,omitempty"`
+
+	// Sender Senders to include. Repeat the parameter to match any of several senders.
+	Sender *SenderFilter `form:"sender,omitempty" json:"sender,omitempty"`
+
+	// SenderPrefix Case-sensitive prefix the sender must start with
+	SenderPrefix *SenderPrefixFilter `form:"sender_prefix,omitempty" json:"sender_prefix,omitempty"`
+
+	// ExcludeSender Senders to exclude. Repeat the parameter to exclude several senders.
+	ExcludeSender *ExcludeSenderFilter `form:"exclude_sender,omitempty" json:"exclude_sender,omitempty"`
+
+	// IsReply true returns only replies (messages with reply_to), false only messages that are not replies
+	IsReply *IsReplyFilter `form:"is_reply,omitempty" json:"is_reply,omitempty"`
+
+	// HasThread true returns only messages that have at least one reply, false only messages without replies.
+	// Deleted and expired replies are not counted.
+	HasThread *HasThreadFilter                  `form:"has_thread,omitempty" json:"has_thread,omitempty"`
+	FromDate  *FromDateFilter                   `form:"from_date,omitempty" json:"from_date,omitempty"`
+	ToDate    *ToDateFilter                     `form:"to_date,omitempty" json:"to_date,omitempty"`
+	Format    *GetApiMessagesExportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+	Gzip      *bool                             `form:"gzip,omitempty" json:"gzip,omitempty"`
 }
 
+// GetApiMessagesExportParamsFormat defines parameters for GetApiMessagesExport.
+type GetApiMessagesExportParamsFormat string
+
 // GetApiMessagesSearchParams defines parameters for GetApiMessagesSearch.
 type GetApiMessagesSearchParams struct {
-	ChannelId *string    `form:"channel_id,omitempty" json:"channel_id,omitempty"`
-	Sender    *string    `form:"sender,omitempty" json:"sender,omitempty"`
-	FromDate  *time.Time `form:"from_date,omitempty" json:"from_date,omitempty"`
-	ToDate    *time.Time `form:"to_date,omitempty" json:"to_date,omitempty"`
+	// ChannelId Channel IDs to include. Repeat the parameter to match any of several channels.
+	ChannelId *ChannelIdFilter `form:"channel_id,omitempty" json:"channel_id,omitempty"`
+
+	// Sender Senders to include. Repeat the parameter to match any of several senders.
+	Sender *SenderFilter `form:"sender,omitempty" json:"sender,omitempty"`
+
+	// SenderPrefix Case-sensitive prefix the sender must start with
+	SenderPrefix *SenderPrefixFilter `form:"sender_prefix,omitempty" json:"sender_prefix,omitempty"`
+
+	// ExcludeSender Senders to exclude. Repeat the parameter to exclude several senders.
+	ExcludeSender *ExcludeSenderFilter `form:"exclude_sender,omitempty" json:"exclude_sender,omitempty"`
+
+	// IsReply true returns only replies (messages with reply_to), false only messages that are not replies
+	IsReply *IsReplyFilter `form:"is_reply,omitempty" json:"is_reply,omitempty"`
+
+	// HasThread true returns only messages that have at least one reply, false only messages without replies.
+	// Deleted and expired replies are not counted.
+	HasThread *HasThreadFilter `form:"has_thread,omitempty" json:"has_thread,omitempty"`
+	FromDate  *FromDateFilter  `form:"from_date,omitempty" json:"from_date,omitempty"`
+	ToDate    *ToDateFilter    `form:"to_date,omitempty" json:"to_date,omitempty"`
+
+	// Sort Sort order of the results. Prefix with "-" for descending order.
+	// Messages with the same value are ordered by their ID.
+	Sort *GetApiMessagesSearchParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Fields Comma-separated list of fields to return (uid, sent_at, sender, channel_id, content, created_at, updated_at, expires_at, reply_to).
+	// All fields are returned when omitted.
+	Fields *[]string `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// Limit Maximum number of messages to return. When more messages may match, the X-Next-Cursor response header
+	// holds the cursor of the next page. All matching messages are returned when omitted.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Value of X-Next-Cursor from the previous page. Use it with the same filters and sort.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetApiMessagesSearchParamsSort defines parameters for GetApiMessagesSearch.
+type GetApiMessagesSearchParamsSort string
+
+// GetApiMessagesUidContextParams defines parameters for GetApiMessagesUidContext.
+type GetApiMessagesUidContextParams struct {
+	// Before Number of messages to return before the target
+	Before *int `form:"before,omitempty" json:"before,omitempty"`
+
+	// After Number of messages to return after the target
+	After *int `form:"after,omitempty" json:"after,omitempty"`
+}
+
+// GetApiStatsMessagesParams defines parameters for GetApiStatsMessages.
+type GetApiStatsMessagesParams struct {
+	GroupBy GetApiStatsMessagesParamsGroupBy `form:"group_by" json:"group_by"`
+
+	// ChannelId Channel IDs to include. Repeat the parameter to match any of several channels.
+	ChannelId *ChannelIdFilter `form:"channel_id,omitempty" json:"channel_id,omitempty"`
+
+	// Sender Senders to include. Repeat the parameter to match any of several senders.
+	Sender *SenderFilter `form:"sender,omitempty" json:"sender,omitempty"`
+
+	// ExcludeSender Senders to exclude. Repeat the parameter to exclude several senders.
+	ExcludeSender *ExcludeSenderFilter `form:"exclude_sender,omitempty" json:"exclude_sender,omitempty"`
+	FromDate      *time.Time           `form:"from_date,omitempty" json:"from_date,omitempty"`
+	ToDate        *time.Time           `form:"to_date,omitempty" json:"to_date,omitempty"`
+	Tz            *string              `form:"tz,omitempty" json:"tz,omitempty"`
+	IfNoneMatch   *string              `json:"If-None-Match,omitempty"`
 }
 
+// GetApiStatsMessagesParamsGroupBy defines parameters for GetApiStatsMessages.
+type GetApiStatsMessagesParamsGroupBy string
+
 // PostApiMessagesJSONRequestBody defines body for PostApiMessages for application/json ContentType.
 type PostApiMessagesJSONRequestBody = MessageCreate
 
+// PostApiMessagesBatchJSONRequestBody defines body for PostApiMessagesBatch for application/json ContentType.
+type PostApiMessagesBatchJSONRequestBody = MessageBatchCreate
+
+// PostApiMessagesBulkDeleteJSONRequestBody defines body for PostApiMessagesBulkDelete for application/json ContentType.
+type PostApiMessagesBulkDeleteJSONRequestBody = MessageBulkDelete
+
+// PostApiTenantsJSONRequestBody defines body for PostApiTenants for application/json ContentType.
+type PostApiTenantsJSONRequestBody = TenantCreate
+
+// PatchApiTenantsIdJSONRequestBody defines body for PatchApiTenantsId for application/json ContentType.
+type PatchApiTenantsIdJSONRequestBody = TenantUpdate
+
+// PostApiTenantsIdTokensJSONRequestBody defines body for PostApiTenantsIdTokens for application/json ContentType.
+type PostApiTenantsIdTokensJSONRequestBody = TokenCreate
+
 // PostApiTokensJSONRequestBody defines body for PostApiTokens for application/json ContentType.
 type PostApiTokensJSONRequestBody = TokenCreate
 
@@ -94,12 +448,42 @@ type ServerInterface interface {
 	// Create message
 	// (POST /api/messages)
 	PostApiMessages(c *gin.Context)
+	// Create messages in batch
+	// (POST /api/messages/batch)
+	PostApiMessagesBatch(c *gin.Context)
+	// Delete messages matching criteria
+	// (POST /api/messages/bulk-delete)
+	PostApiMessagesBulkDelete(c *gin.Context)
+	// Export messages
+	// (GET /api/messages/export)
+	GetApiMessagesExport(c *gin.Context, params GetApiMessagesExportParams)
 	// Search messages
 	// (GET /api/messages/search)
 	GetApiMessagesSearch(c *gin.Context, params GetApiMessagesSearchParams)
 	// Delete message
 	// (DELETE /api/messages/{uid})
 	DeleteApiMessagesUid(c *gin.Context, uid string)
+	// Messages around a message
+	// (GET /api/messages/{uid}/context)
+	GetApiMessagesUidContext(c *gin.Context, uid string, params GetApiMessagesUidContextParams)
+	// Message statistics
+	// (GET /api/stats/messages)
+	GetApiStatsMessages(c *gin.Context, params GetApiStatsMessagesParams)
+	// List tenants
+	// (GET /api/tenants)
+	GetApiTenants(c *gin.Context)
+	// Create tenant
+	// (POST /api/tenants)
+	PostApiTenants(c *gin.Context)
+	// Get tenant with its usage
+	// (GET /api/tenants/{id})
+	GetApiTenantsId(c *gin.Context, id string)
+	// Update tenant name or quota
+	// (PATCH /api/tenants/{id})
+	PatchApiTenantsId(c *gin.Context, id string)
+	// Issue token in tenant
+	// (POST /api/tenants/{id}/tokens)
+	PostApiTenantsIdTokens(c *gin.Context, id string)
 	// Get token list
 	// (GET /api/tokens)
 	GetApiTokens(c *gin.Context)
@@ -135,15 +519,45 @@ func (siw *ServerInterfaceWrapper) PostApiMessages(c *gin.Context) {
 	siw.Handler.PostApiMessages(c)
 }
 
-// GetApiMessagesSearch operation middleware
-func (siw *ServerInterfaceWrapper) GetApiMessagesSearch(c *gin.Context) {
+// PostApiMessagesBatch operation middleware
+func (siw *ServerInterfaceWrapper) PostApiMessagesBatch(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiMessagesBatch(c)
+}
+
+// PostApiMessagesBulkDelete operation middleware
+func (siw *ServerInterfaceWrapper) PostApiMessagesBulkDelete(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiMessagesBulkDelete(c)
+}
+
+// GetApiMessagesExport operation middleware
+func (siw *ServerInterfaceWrapper) GetApiMessagesExport(c *gin.Context) {
 
 	var err error
 
 	c.Set(BearerAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetApiMessagesSearchParams
+	var params GetApiMessagesExportParams
 
 	// ------------- Optional query parameter "channel_id" -------------
 
@@ -161,6 +575,38 @@ func (siw *ServerInterfaceWrapper) GetApiMessagesSearch(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "sender_prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sender_prefix", c.Request.URL.Query(), &params.SenderPrefix)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sender_prefix: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "exclude_sender" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "exclude_sender", c.Request.URL.Query(), &params.ExcludeSender)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter exclude_sender: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "is_reply" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "is_reply", c.Request.URL.Query(), &params.IsReply)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter is_reply: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "has_thread" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "has_thread", c.Request.URL.Query(), &params.HasThread)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter has_thread: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	// ------------- Optional query parameter "from_date" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "from_date", c.Request.URL.Query(), &params.FromDate)
@@ -177,6 +623,22 @@ func (siw *ServerInterfaceWrapper) GetApiMessagesSearch(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "gzip" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "gzip", c.Request.URL.Query(), &params.Gzip)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter gzip: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -184,90 +646,463 @@ func (siw *ServerInterfaceWrapper) GetApiMessagesSearch(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetApiMessagesSearch(c, params)
+	siw.Handler.GetApiMessagesExport(c, params)
 }
 
-// DeleteApiMessagesUid operation middleware
-func (siw *ServerInterfaceWrapper) DeleteApiMessagesUid(c *gin.Context) {
+// GetApiMessagesSearch operation middleware
+func (siw *ServerInterfaceWrapper) GetApiMessagesSearch(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "uid" -------------
-	var uid string
+	c.Set(BearerAuthScopes, []string{})
 
-	err = runtime.BindStyledParameter("simple", false, "uid", c.Param("uid"), &uid)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiMessagesSearchParams
+
+	// ------------- Optional query parameter "channel_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "channel_id", c.Request.URL.Query(), &params.ChannelId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter uid: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter channel_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	c.Set(BearerAuthScopes, []string{})
+	// ------------- Optional query parameter "sender" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
+	err = runtime.BindQueryParameter("form", true, false, "sender", c.Request.URL.Query(), &params.Sender)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sender: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	siw.Handler.DeleteApiMessagesUid(c, uid)
-}
+	// ------------- Optional query parameter "sender_prefix" -------------
 
-// GetApiTokens operation middleware
-func (siw *ServerInterfaceWrapper) GetApiTokens(c *gin.Context) {
+	err = runtime.BindQueryParameter("form", true, false, "sender_prefix", c.Request.URL.Query(), &params.SenderPrefix)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sender_prefix: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	c.Set(BearerAuthScopes, []string{})
+	// ------------- Optional query parameter "exclude_sender" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
+	err = runtime.BindQueryParameter("form", true, false, "exclude_sender", c.Request.URL.Query(), &params.ExcludeSender)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter exclude_sender: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	siw.Handler.GetApiTokens(c)
-}
+	// ------------- Optional query parameter "is_reply" -------------
 
-// PostApiTokens operation middleware
-func (siw *ServerInterfaceWrapper) PostApiTokens(c *gin.Context) {
+	err = runtime.BindQueryParameter("form", true, false, "is_reply", c.Request.URL.Query(), &params.IsReply)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter is_reply: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	c.Set(BearerAuthScopes, []string{})
+	// ------------- Optional query parameter "has_thread" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
+	err = runtime.BindQueryParameter("form", true, false, "has_thread", c.Request.URL.Query(), &params.HasThread)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter has_thread: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	siw.Handler.PostApiTokens(c)
-}
-
-// DeleteApiTokensId operation middleware
-func (siw *ServerInterfaceWrapper) DeleteApiTokensId(c *gin.Context) {
+	// ------------- Optional query parameter "from_date" -------------
 
-	var err error
+	err = runtime.BindQueryParameter("form", true, false, "from_date", c.Request.URL.Query(), &params.FromDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from_date: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	// ------------- Path parameter "id" -------------
-	var id string
+	// ------------- Optional query parameter "to_date" -------------
 
-	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	err = runtime.BindQueryParameter("form", true, false, "to_date", c.Request.URL.Query(), &params.ToDate)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to_date: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	c.Set(BearerAuthScopes, []string{})
+	// ------------- Optional query parameter "sort" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	siw.Handler.DeleteApiTokensId(c, id)
-}
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", c.Request.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter fields: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiMessagesSearch(c, params)
+}
+
+// DeleteApiMessagesUid operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiMessagesUid(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "uid" -------------
+	var uid string
+
+	err = runtime.BindStyledParameter("simple", false, "uid", c.Param("uid"), &uid)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter uid: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteApiMessagesUid(c, uid)
+}
+
+// GetApiMessagesUidContext operation middleware
+func (siw *ServerInterfaceWrapper) GetApiMessagesUidContext(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "uid" -------------
+	var uid string
+
+	err = runtime.BindStyledParameter("simple", false, "uid", c.Param("uid"), &uid)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter uid: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiMessagesUidContextParams
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "before", c.Request.URL.Query(), &params.Before)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter before: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "after", c.Request.URL.Query(), &params.After)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter after: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiMessagesUidContext(c, uid, params)
+}
+
+// GetApiStatsMessages operation middleware
+func (siw *ServerInterfaceWrapper) GetApiStatsMessages(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiStatsMessagesParams
+
+	// ------------- Required query parameter "group_by" -------------
+
+	if paramValue := c.Query("group_by"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument group_by is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "group_by", c.Request.URL.Query(), &params.GroupBy)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group_by: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "channel_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "channel_id", c.Request.URL.Query(), &params.ChannelId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter channel_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sender" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sender", c.Request.URL.Query(), &params.Sender)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sender: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "exclude_sender" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "exclude_sender", c.Request.URL.Query(), &params.ExcludeSender)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter exclude_sender: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "from_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from_date", c.Request.URL.Query(), &params.FromDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from_date: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to_date", c.Request.URL.Query(), &params.ToDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to_date: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "tz" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tz", c.Request.URL.Query(), &params.Tz)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tz: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, valueList[0], &IfNoneMatch)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiStatsMessages(c, params)
+}
+
+// GetApiTenants operation middleware
+func (siw *ServerInterfaceWrapper) GetApiTenants(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiTenants(c)
+}
+
+// PostApiTenants operation middleware
+func (siw *ServerInterfaceWrapper) PostApiTenants(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiTenants(c)
+}
+
+// GetApiTenantsId operation middleware
+func (siw *ServerInterfaceWrapper) GetApiTenantsId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiTenantsId(c, id)
+}
+
+// PatchApiTenantsId operation middleware
+func (siw *ServerInterfaceWrapper) PatchApiTenantsId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchApiTenantsId(c, id)
+}
+
+// PostApiTenantsIdTokens operation middleware
+func (siw *ServerInterfaceWrapper) PostApiTenantsIdTokens(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiTenantsIdTokens(c, id)
+}
+
+// GetApiTokens operation middleware
+func (siw *ServerInterfaceWrapper) GetApiTokens(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiTokens(c)
+}
+
+// PostApiTokens operation middleware
+func (siw *ServerInterfaceWrapper) PostApiTokens(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiTokens(c)
+}
+
+// DeleteApiTokensId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiTokensId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteApiTokensId(c, id)
+}
 
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
@@ -296,43 +1131,257 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
-	router.POST(options.BaseURL+"/api/messages", wrapper.PostApiMessages)
-	router.GET(options.BaseURL+"/api/messages/search", wrapper.GetApiMessagesSearch)
-	router.DELETE(options.BaseURL+"/api/messages/:uid", wrapper.DeleteApiMessagesUid)
-	router.GET(options.BaseURL+"/api/tokens", wrapper.GetApiTokens)
-	router.POST(options.BaseURL+"/api/tokens", wrapper.PostApiTokens)
-	router.DELETE(options.BaseURL+"/api/tokens/:id", wrapper.DeleteApiTokensId)
+	router.POST(options.BaseURL+"/api/messages", wrapper.PostApiMessages)
+	router.POST(options.BaseURL+"/api/messages/batch", wrapper.PostApiMessagesBatch)
+	router.POST(options.BaseURL+"/api/messages/bulk-delete", wrapper.PostApiMessagesBulkDelete)
+	router.GET(options.BaseURL+"/api/messages/export", wrapper.GetApiMessagesExport)
+	router.GET(options.BaseURL+"/api/messages/search", wrapper.GetApiMessagesSearch)
+	router.DELETE(options.BaseURL+"/api/messages/:uid", wrapper.DeleteApiMessagesUid)
+	router.GET(options.BaseURL+"/api/messages/:uid/context", wrapper.GetApiMessagesUidContext)
+	router.GET(options.BaseURL+"/api/stats/messages", wrapper.GetApiStatsMessages)
+	router.GET(options.BaseURL+"/api/tenants", wrapper.GetApiTenants)
+	router.POST(options.BaseURL+"/api/tenants", wrapper.PostApiTenants)
+	router.GET(options.BaseURL+"/api/tenants/:id", wrapper.GetApiTenantsId)
+	router.PATCH(options.BaseURL+"/api/tenants/:id", wrapper.PatchApiTenantsId)
+	router.POST(options.BaseURL+"/api/tenants/:id/tokens", wrapper.PostApiTenantsIdTokens)
+	router.GET(options.BaseURL+"/api/tokens", wrapper.GetApiTokens)
+	router.POST(options.BaseURL+"/api/tokens", wrapper.PostApiTokens)
+	router.DELETE(options.BaseURL+"/api/tokens/:id", wrapper.DeleteApiTokensId)
+}
+
+type PostApiMessagesRequestObject struct {
+	Body *PostApiMessagesJSONRequestBody
+}
+
+type PostApiMessagesResponseObject interface {
+	VisitPostApiMessagesResponse(w http.ResponseWriter) error
+}
+
+type PostApiMessages201JSONResponse Message
+
+func (response PostApiMessages201JSONResponse) VisitPostApiMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiMessages400Response struct {
+}
+
+func (response PostApiMessages400Response) VisitPostApiMessagesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostApiMessages401Response struct {
+}
+
+func (response PostApiMessages401Response) VisitPostApiMessagesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostApiMessages403Response struct {
+}
+
+func (response PostApiMessages403Response) VisitPostApiMessagesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PostApiMessagesBatchRequestObject struct {
+	JSONBody *PostApiMessagesBatchJSONRequestBody
+	Body     io.Reader
+}
+
+type PostApiMessagesBatchResponseObject interface {
+	VisitPostApiMessagesBatchResponse(w http.ResponseWriter) error
+}
+
+type PostApiMessagesBatch200JSONResponse MessageBatchResult
+
+func (response PostApiMessagesBatch200JSONResponse) VisitPostApiMessagesBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiMessagesBatch207JSONResponse MessageBatchResult
+
+func (response PostApiMessagesBatch207JSONResponse) VisitPostApiMessagesBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(207)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiMessagesBatch400Response struct {
+}
+
+func (response PostApiMessagesBatch400Response) VisitPostApiMessagesBatchResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostApiMessagesBatch401Response struct {
+}
+
+func (response PostApiMessagesBatch401Response) VisitPostApiMessagesBatchResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostApiMessagesBatch503Response struct {
+}
+
+func (response PostApiMessagesBatch503Response) VisitPostApiMessagesBatchResponse(w http.ResponseWriter) error {
+	w.WriteHeader(503)
+	return nil
+}
+
+type PostApiMessagesBulkDeleteRequestObject struct {
+	Body *PostApiMessagesBulkDeleteJSONRequestBody
+}
+
+type PostApiMessagesBulkDeleteResponseObject interface {
+	VisitPostApiMessagesBulkDeleteResponse(w http.ResponseWriter) error
+}
+
+type PostApiMessagesBulkDelete200JSONResponse MessageBulkDeleteResult
+
+func (response PostApiMessagesBulkDelete200JSONResponse) VisitPostApiMessagesBulkDeleteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiMessagesBulkDelete400Response struct {
+}
+
+func (response PostApiMessagesBulkDelete400Response) VisitPostApiMessagesBulkDeleteResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostApiMessagesBulkDelete401Response struct {
+}
+
+func (response PostApiMessagesBulkDelete401Response) VisitPostApiMessagesBulkDeleteResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostApiMessagesBulkDelete409Response struct {
+}
+
+func (response PostApiMessagesBulkDelete409Response) VisitPostApiMessagesBulkDeleteResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type GetApiMessagesExportRequestObject struct {
+	Params GetApiMessagesExportParams
+}
+
+type GetApiMessagesExportResponseObject interface {
+	VisitGetApiMessagesExportResponse(w http.ResponseWriter) error
+}
+
+type GetApiMessagesExport200ResponseHeaders struct {
+	ContentDisposition string
+}
+
+type GetApiMessagesExport200ApplicationgzipResponse struct {
+	Body          io.Reader
+	Headers       GetApiMessagesExport200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetApiMessagesExport200ApplicationgzipResponse) VisitGetApiMessagesExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/gzip")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetApiMessagesExport200JSONResponse struct {
+	Body    []Message
+	Headers GetApiMessagesExport200ResponseHeaders
+}
+
+func (response GetApiMessagesExport200JSONResponse) VisitGetApiMessagesExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type PostApiMessagesRequestObject struct {
-	Body *PostApiMessagesJSONRequestBody
+type GetApiMessagesExport200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	Headers       GetApiMessagesExport200ResponseHeaders
+	ContentLength int64
 }
 
-type PostApiMessagesResponseObject interface {
-	VisitPostApiMessagesResponse(w http.ResponseWriter) error
+func (response GetApiMessagesExport200ApplicationxNdjsonResponse) VisitGetApiMessagesExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
 }
 
-type PostApiMessages201JSONResponse Message
+type GetApiMessagesExport200TextcsvResponse struct {
+	Body          io.Reader
+	Headers       GetApiMessagesExport200ResponseHeaders
+	ContentLength int64
+}
 
-func (response PostApiMessages201JSONResponse) VisitPostApiMessagesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+func (response GetApiMessagesExport200TextcsvResponse) VisitGetApiMessagesExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
 }
 
-type PostApiMessages400Response struct {
+type GetApiMessagesExport400Response struct {
 }
 
-func (response PostApiMessages400Response) VisitPostApiMessagesResponse(w http.ResponseWriter) error {
+func (response GetApiMessagesExport400Response) VisitGetApiMessagesExportResponse(w http.ResponseWriter) error {
 	w.WriteHeader(400)
 	return nil
 }
 
-type PostApiMessages401Response struct {
+type GetApiMessagesExport401Response struct {
 }
 
-func (response PostApiMessages401Response) VisitPostApiMessagesResponse(w http.ResponseWriter) error {
+func (response GetApiMessagesExport401Response) VisitGetApiMessagesExportResponse(w http.ResponseWriter) error {
 	w.WriteHeader(401)
 	return nil
 }
@@ -345,13 +1394,29 @@ type GetApiMessagesSearchResponseObject interface {
 	VisitGetApiMessagesSearchResponse(w http.ResponseWriter) error
 }
 
-type GetApiMessagesSearch200JSONResponse []Message
+type GetApiMessagesSearch200ResponseHeaders struct {
+	XNextCursor string
+}
+
+type GetApiMessagesSearch200JSONResponse struct {
+	Body    []Message
+	Headers GetApiMessagesSearch200ResponseHeaders
+}
 
 func (response GetApiMessagesSearch200JSONResponse) VisitGetApiMessagesSearchResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Next-Cursor", fmt.Sprint(response.Headers.XNextCursor))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetApiMessagesSearch400Response struct {
+}
+
+func (response GetApiMessagesSearch400Response) VisitGetApiMessagesSearchResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
 }
 
 type GetApiMessagesSearch401Response struct {
@@ -378,18 +1443,341 @@ func (response DeleteApiMessagesUid204Response) VisitDeleteApiMessagesUidRespons
 	return nil
 }
 
-type DeleteApiMessagesUid401Response struct {
+type DeleteApiMessagesUid401Response struct {
+}
+
+func (response DeleteApiMessagesUid401Response) VisitDeleteApiMessagesUidResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DeleteApiMessagesUid404Response struct {
+}
+
+func (response DeleteApiMessagesUid404Response) VisitDeleteApiMessagesUidResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetApiMessagesUidContextRequestObject struct {
+	Uid    string `json:"uid"`
+	Params GetApiMessagesUidContextParams
+}
+
+type GetApiMessagesUidContextResponseObject interface {
+	VisitGetApiMessagesUidContextResponse(w http.ResponseWriter) error
+}
+
+type GetApiMessagesUidContext200JSONResponse MessageContext
+
+func (response GetApiMessagesUidContext200JSONResponse) VisitGetApiMessagesUidContextResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiMessagesUidContext400Response struct {
+}
+
+func (response GetApiMessagesUidContext400Response) VisitGetApiMessagesUidContextResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiMessagesUidContext401Response struct {
+}
+
+func (response GetApiMessagesUidContext401Response) VisitGetApiMessagesUidContextResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiMessagesUidContext404Response struct {
+}
+
+func (response GetApiMessagesUidContext404Response) VisitGetApiMessagesUidContextResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetApiStatsMessagesRequestObject struct {
+	Params GetApiStatsMessagesParams
+}
+
+type GetApiStatsMessagesResponseObject interface {
+	VisitGetApiStatsMessagesResponse(w http.ResponseWriter) error
+}
+
+type GetApiStatsMessages200ResponseHeaders struct {
+	CacheControl string
+	ETag         string
+}
+
+type GetApiStatsMessages200JSONResponse struct {
+	Body    MessageStats
+	Headers GetApiStatsMessages200ResponseHeaders
+}
+
+func (response GetApiStatsMessages200JSONResponse) VisitGetApiStatsMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetApiStatsMessages304ResponseHeaders struct {
+	CacheControl string
+	ETag         string
+}
+
+type GetApiStatsMessages304Response struct {
+	Headers GetApiStatsMessages304ResponseHeaders
+}
+
+func (response GetApiStatsMessages304Response) VisitGetApiStatsMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetApiStatsMessages400Response struct {
+}
+
+func (response GetApiStatsMessages400Response) VisitGetApiStatsMessagesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiStatsMessages401Response struct {
+}
+
+func (response GetApiStatsMessages401Response) VisitGetApiStatsMessagesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiTenantsRequestObject struct {
+}
+
+type GetApiTenantsResponseObject interface {
+	VisitGetApiTenantsResponse(w http.ResponseWriter) error
+}
+
+type GetApiTenants200JSONResponse []Tenant
+
+func (response GetApiTenants200JSONResponse) VisitGetApiTenantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiTenants401Response struct {
+}
+
+func (response GetApiTenants401Response) VisitGetApiTenantsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiTenants403Response struct {
+}
+
+func (response GetApiTenants403Response) VisitGetApiTenantsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PostApiTenantsRequestObject struct {
+	Body *PostApiTenantsJSONRequestBody
+}
+
+type PostApiTenantsResponseObject interface {
+	VisitPostApiTenantsResponse(w http.ResponseWriter) error
+}
+
+type PostApiTenants201JSONResponse Tenant
+
+func (response PostApiTenants201JSONResponse) VisitPostApiTenantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiTenants400Response struct {
+}
+
+func (response PostApiTenants400Response) VisitPostApiTenantsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostApiTenants401Response struct {
+}
+
+func (response PostApiTenants401Response) VisitPostApiTenantsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostApiTenants403Response struct {
+}
+
+func (response PostApiTenants403Response) VisitPostApiTenantsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PostApiTenants409Response struct {
+}
+
+func (response PostApiTenants409Response) VisitPostApiTenantsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type GetApiTenantsIdRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetApiTenantsIdResponseObject interface {
+	VisitGetApiTenantsIdResponse(w http.ResponseWriter) error
+}
+
+type GetApiTenantsId200JSONResponse Tenant
+
+func (response GetApiTenantsId200JSONResponse) VisitGetApiTenantsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiTenantsId401Response struct {
+}
+
+func (response GetApiTenantsId401Response) VisitGetApiTenantsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiTenantsId403Response struct {
+}
+
+func (response GetApiTenantsId403Response) VisitGetApiTenantsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetApiTenantsId404Response struct {
+}
+
+func (response GetApiTenantsId404Response) VisitGetApiTenantsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PatchApiTenantsIdRequestObject struct {
+	Id   string `json:"id"`
+	Body *PatchApiTenantsIdJSONRequestBody
+}
+
+type PatchApiTenantsIdResponseObject interface {
+	VisitPatchApiTenantsIdResponse(w http.ResponseWriter) error
+}
+
+type PatchApiTenantsId200JSONResponse Tenant
+
+func (response PatchApiTenantsId200JSONResponse) VisitPatchApiTenantsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchApiTenantsId400Response struct {
+}
+
+func (response PatchApiTenantsId400Response) VisitPatchApiTenantsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PatchApiTenantsId401Response struct {
+}
+
+func (response PatchApiTenantsId401Response) VisitPatchApiTenantsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PatchApiTenantsId403Response struct {
+}
+
+func (response PatchApiTenantsId403Response) VisitPatchApiTenantsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PatchApiTenantsId404Response struct {
+}
+
+func (response PatchApiTenantsId404Response) VisitPatchApiTenantsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostApiTenantsIdTokensRequestObject struct {
+	Id   string `json:"id"`
+	Body *PostApiTenantsIdTokensJSONRequestBody
+}
+
+type PostApiTenantsIdTokensResponseObject interface {
+	VisitPostApiTenantsIdTokensResponse(w http.ResponseWriter) error
+}
+
+type PostApiTenantsIdTokens201JSONResponse TokenResponse
+
+func (response PostApiTenantsIdTokens201JSONResponse) VisitPostApiTenantsIdTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiTenantsIdTokens400Response struct {
+}
+
+func (response PostApiTenantsIdTokens400Response) VisitPostApiTenantsIdTokensResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostApiTenantsIdTokens401Response struct {
+}
+
+func (response PostApiTenantsIdTokens401Response) VisitPostApiTenantsIdTokensResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostApiTenantsIdTokens403Response struct {
 }
 
-func (response DeleteApiMessagesUid401Response) VisitDeleteApiMessagesUidResponse(w http.ResponseWriter) error {
-	w.WriteHeader(401)
+func (response PostApiTenantsIdTokens403Response) VisitPostApiTenantsIdTokensResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
 	return nil
 }
 
-type DeleteApiMessagesUid404Response struct {
+type PostApiTenantsIdTokens404Response struct {
 }
 
-func (response DeleteApiMessagesUid404Response) VisitDeleteApiMessagesUidResponse(w http.ResponseWriter) error {
+func (response PostApiTenantsIdTokens404Response) VisitPostApiTenantsIdTokensResponse(w http.ResponseWriter) error {
 	w.WriteHeader(404)
 	return nil
 }
@@ -418,6 +1806,14 @@ func (response GetApiTokens401Response) VisitGetApiTokensResponse(w http.Respons
 	return nil
 }
 
+type GetApiTokens403Response struct {
+}
+
+func (response GetApiTokens403Response) VisitGetApiTokensResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
 type PostApiTokensRequestObject struct {
 	Body *PostApiTokensJSONRequestBody
 }
@@ -443,6 +1839,22 @@ func (response PostApiTokens400Response) VisitPostApiTokensResponse(w http.Respo
 	return nil
 }
 
+type PostApiTokens401Response struct {
+}
+
+func (response PostApiTokens401Response) VisitPostApiTokensResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostApiTokens403Response struct {
+}
+
+func (response PostApiTokens403Response) VisitPostApiTokensResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
 type DeleteApiTokensIdRequestObject struct {
 	Id string `json:"id"`
 }
@@ -467,6 +1879,14 @@ func (response DeleteApiTokensId401Response) VisitDeleteApiTokensIdResponse(w ht
 	return nil
 }
 
+type DeleteApiTokensId403Response struct {
+}
+
+func (response DeleteApiTokensId403Response) VisitDeleteApiTokensIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
 type DeleteApiTokensId404Response struct {
 }
 
@@ -480,12 +1900,42 @@ type StrictServerInterface interface {
 	// Create message
 	// (POST /api/messages)
 	PostApiMessages(ctx context.Context, request PostApiMessagesRequestObject) (PostApiMessagesResponseObject, error)
+	// Create messages in batch
+	// (POST /api/messages/batch)
+	PostApiMessagesBatch(ctx context.Context, request PostApiMessagesBatchRequestObject) (PostApiMessagesBatchResponseObject, error)
+	// Delete messages matching criteria
+	// (POST /api/messages/bulk-delete)
+	PostApiMessagesBulkDelete(ctx context.Context, request PostApiMessagesBulkDeleteRequestObject) (PostApiMessagesBulkDeleteResponseObject, error)
+	// Export messages
+	// (GET /api/messages/export)
+	GetApiMessagesExport(ctx context.Context, request GetApiMessagesExportRequestObject) (GetApiMessagesExportResponseObject, error)
 	// Search messages
 	// (GET /api/messages/search)
 	GetApiMessagesSearch(ctx context.Context, request GetApiMessagesSearchRequestObject) (GetApiMessagesSearchResponseObject, error)
 	// Delete message
 	// (DELETE /api/messages/{uid})
 	DeleteApiMessagesUid(ctx context.Context, request DeleteApiMessagesUidRequestObject) (DeleteApiMessagesUidResponseObject, error)
+	// Messages around a message
+	// (GET /api/messages/{uid}/context)
+	GetApiMessagesUidContext(ctx context.Context, request GetApiMessagesUidContextRequestObject) (GetApiMessagesUidContextResponseObject, error)
+	// Message statistics
+	// (GET /api/stats/messages)
+	GetApiStatsMessages(ctx context.Context, request GetApiStatsMessagesRequestObject) (GetApiStatsMessagesResponseObject, error)
+	// List tenants
+	// (GET /api/tenants)
+	GetApiTenants(ctx context.Context, request GetApiTenantsRequestObject) (GetApiTenantsResponseObject, error)
+	// Create tenant
+	// (POST /api/tenants)
+	PostApiTenants(ctx context.Context, request PostApiTenantsRequestObject) (PostApiTenantsResponseObject, error)
+	// Get tenant with its usage
+	// (GET /api/tenants/{id})
+	GetApiTenantsId(ctx context.Context, request GetApiTenantsIdRequestObject) (GetApiTenantsIdResponseObject, error)
+	// Update tenant name or quota
+	// (PATCH /api/tenants/{id})
+	PatchApiTenantsId(ctx context.Context, request PatchApiTenantsIdRequestObject) (PatchApiTenantsIdResponseObject, error)
+	// Issue token in tenant
+	// (POST /api/tenants/{id}/tokens)
+	PostApiTenantsIdTokens(ctx context.Context, request PostApiTenantsIdTokensRequestObject) (PostApiTenantsIdTokensResponseObject, error)
 	// Get token list
 	// (GET /api/tokens)
 	GetApiTokens(ctx context.Context, request GetApiTokensRequestObject) (GetApiTokensResponseObject, error)
@@ -542,6 +1992,105 @@ func (sh *strictHandler) PostApiMessages(ctx *gin.Context) {
 	}
 }
 
+// PostApiMessagesBatch operation middleware
+func (sh *strictHandler) PostApiMessagesBatch(ctx *gin.Context) {
+	var request PostApiMessagesBatchRequestObject
+
+	if strings.HasPrefix(ctx.GetHeader("Content-Type"), "application/json") {
+
+		var body PostApiMessagesBatchJSONRequestBody
+		if err := ctx.ShouldBind(&body); err != nil {
+			ctx.Status(http.StatusBadRequest)
+			ctx.Error(err)
+			return
+		}
+		request.JSONBody = &body
+	}
+	if strings.HasPrefix(ctx.GetHeader("Content-Type"), "application/x-ndjson") {
+		request.Body = ctx.Request.Body
+	}
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostApiMessagesBatch(ctx, request.(PostApiMessagesBatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostApiMessagesBatch")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostApiMessagesBatchResponseObject); ok {
+		if err := validResponse.VisitPostApiMessagesBatchResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostApiMessagesBulkDelete operation middleware
+func (sh *strictHandler) PostApiMessagesBulkDelete(ctx *gin.Context) {
+	var request PostApiMessagesBulkDeleteRequestObject
+
+	var body PostApiMessagesBulkDeleteJSONRequestBody
+	if err := ctx.ShouldBind(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostApiMessagesBulkDelete(ctx, request.(PostApiMessagesBulkDeleteRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostApiMessagesBulkDelete")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostApiMessagesBulkDeleteResponseObject); ok {
+		if err := validResponse.VisitPostApiMessagesBulkDeleteResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetApiMessagesExport operation middleware
+func (sh *strictHandler) GetApiMessagesExport(ctx *gin.Context, params GetApiMessagesExportParams) {
+	var request GetApiMessagesExportRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiMessagesExport(ctx, request.(GetApiMessagesExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiMessagesExport")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetApiMessagesExportResponseObject); ok {
+		if err := validResponse.VisitGetApiMessagesExportResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetApiMessagesSearch operation middleware
 func (sh *strictHandler) GetApiMessagesSearch(ctx *gin.Context, params GetApiMessagesSearchParams) {
 	var request GetApiMessagesSearchRequestObject
@@ -596,6 +2145,216 @@ func (sh *strictHandler) DeleteApiMessagesUid(ctx *gin.Context, uid string) {
 	}
 }
 
+// GetApiMessagesUidContext operation middleware
+func (sh *strictHandler) GetApiMessagesUidContext(ctx *gin.Context, uid string, params GetApiMessagesUidContextParams) {
+	var request GetApiMessagesUidContextRequestObject
+
+	request.Uid = uid
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiMessagesUidContext(ctx, request.(GetApiMessagesUidContextRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiMessagesUidContext")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetApiMessagesUidContextResponseObject); ok {
+		if err := validResponse.VisitGetApiMessagesUidContextResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetApiStatsMessages operation middleware
+func (sh *strictHandler) GetApiStatsMessages(ctx *gin.Context, params GetApiStatsMessagesParams) {
+	var request GetApiStatsMessagesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiStatsMessages(ctx, request.(GetApiStatsMessagesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiStatsMessages")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetApiStatsMessagesResponseObject); ok {
+		if err := validResponse.VisitGetApiStatsMessagesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetApiTenants operation middleware
+func (sh *strictHandler) GetApiTenants(ctx *gin.Context) {
+	var request GetApiTenantsRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiTenants(ctx, request.(GetApiTenantsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiTenants")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetApiTenantsResponseObject); ok {
+		if err := validResponse.VisitGetApiTenantsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostApiTenants operation middleware
+func (sh *strictHandler) PostApiTenants(ctx *gin.Context) {
+	var request PostApiTenantsRequestObject
+
+	var body PostApiTenantsJSONRequestBody
+	if err := ctx.ShouldBind(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostApiTenants(ctx, request.(PostApiTenantsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostApiTenants")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostApiTenantsResponseObject); ok {
+		if err := validResponse.VisitPostApiTenantsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetApiTenantsId operation middleware
+func (sh *strictHandler) GetApiTenantsId(ctx *gin.Context, id string) {
+	var request GetApiTenantsIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiTenantsId(ctx, request.(GetApiTenantsIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiTenantsId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetApiTenantsIdResponseObject); ok {
+		if err := validResponse.VisitGetApiTenantsIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PatchApiTenantsId operation middleware
+func (sh *strictHandler) PatchApiTenantsId(ctx *gin.Context, id string) {
+	var request PatchApiTenantsIdRequestObject
+
+	request.Id = id
+
+	var body PatchApiTenantsIdJSONRequestBody
+	if err := ctx.ShouldBind(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchApiTenantsId(ctx, request.(PatchApiTenantsIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchApiTenantsId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PatchApiTenantsIdResponseObject); ok {
+		if err := validResponse.VisitPatchApiTenantsIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostApiTenantsIdTokens operation middleware
+func (sh *strictHandler) PostApiTenantsIdTokens(ctx *gin.Context, id string) {
+	var request PostApiTenantsIdTokensRequestObject
+
+	request.Id = id
+
+	var body PostApiTenantsIdTokensJSONRequestBody
+	if err := ctx.ShouldBind(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostApiTenantsIdTokens(ctx, request.(PostApiTenantsIdTokensRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostApiTenantsIdTokens")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostApiTenantsIdTokensResponseObject); ok {
+		if err := validResponse.VisitPostApiTenantsIdTokensResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetApiTokens operation middleware
 func (sh *strictHandler) GetApiTokens(ctx *gin.Context) {
 	var request GetApiTokensRequestObject
@@ -684,22 +2443,90 @@ func (sh *strictHandler) DeleteApiTokensId(ctx *gin.Context, id string) {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7xW32/bNhD+Vwhuj1qtZN3D9JauQ2FgBYKlfQqMgJVONjuJZHmnbEag/30gqR9WRLt2",
-	"YvdNpsm74/d9/O6eeK5roxUoQp49ccw3UAv/+REQxRrcp7HagCUJ/o98I5SC6kEW7hdtDfCMI1mp1rxN",
-	"eK4VgaL4fxYEQfEg/N+ltrX74oUg+IVkDTyZn0FQBdhoOARFJ8Vq9tTcmOLEutphRX/5Cjm5KB1if/hL",
-	"nhW3y2PQJtzCt0ZaKHh27zeNsYcCkt1LjCWvImB80v+AioDwAgXAf0ZawJPO7AFZidozUwDmVhqSWvEs",
-	"1MpkAYpkKcEyvy0SlfpLXUhBvpB9+ulhkCrcoBRNRTy7/u336zRNk+id/BnhlpgBK3XBpGIIuVYFjiVJ",
-	"RbAG+2qAnonIb9qrjb8BjVZ44KJn5PtoJues+DeWN1bS9s65Y6jxHQgL9qahzWCb7tAXvzwWuCEyvHUx",
-	"pCr1HNib2yUrtWW1UGIt1ZrVwUOQCVUwX6TnSVLlwnUOw+7APsoc2M3tkif8ESyGcFdv0jepu542oISR",
-	"POO/+qWEG0EbX/lCGLno03jwNXqgHQVeKsuCZ/xWI90Y+bHfGKgFpHe62PqXPPqVMKaSuT+7+IpajZ3E",
-	"ff1soeQZ/2kxtppF12cWU8tspwoi24BfCELxxV6nV+dOHtJOeemB7uyKYZPngFg2VbV1+L5N0zmZS/Uo",
-	"KlmwDqiw7ypCekMb95JC0Wy48K7UeHY/Fdn9ql0lHJu6FnbLMx4g6/XiNCLW6F7dQO3KxZuwvUAQNveK",
-	"XUOE8w+wS/ld2OykY0UNBBZ9Vc5/+LcG7Jb3D2zaFkb0Z48rfnroLiefLK2uH5wxTA4fZ7/xiKRfGG81",
-	"U2p6klIlQY1HS3bIL6wV25iEA33MAjYV4WXF2OWqR7M4Ro1PjSzaUFEFoeNN9fjer+9I8rPX1zM9xl/u",
-	"5+V7Rpp1oZPAtTPBkeow5Ez95pAG5wy/nePZ5w+JY85xCglu/4EkShMrdaNOpCvgepx3dD3osGV86hvV",
-	"5V9AmCuP0P9fEonpsm+iF9X/B6CQh1USaQfQLvmqTQ632R0Az99kd6fKH9xip5NehKUwWb6uzbaRvqjg",
-	"38BIjIypthdPx9pQYGn5XQ8KlwoGJEO1Yp8JXcKDunF9yHwhFwppXuhBy6G6Azx9P2B35nlpf6rCaKkI",
-	"w3Td2aWfsqF2qh7wHyzPDQSHgogpOuG5xyL2hrNq/w8AAP//4+m5jV4RAAA=",
+	"H4sIAAAAAAAC/+w8a3PcNpJ/BcXbqkuqqJFsJ7sVp+6DYju7uoodry1friqjUzBkzwxiEmAAUNLEpf9+",
+	"1Q2ArwFnOHo42ar9ZIskgO5Gvx/zKclUWSkJ0prk+aek4pqXYEHTXy/WXEoozvLvRWFB46McTKZFZYWS",
+	"yfPwATt7aZhVTMisqHOYsXdQAbfMroE1O+IHJbfZmnG5YWrJDFyB5gXL3CZmlqSJwF1/q0FvkjSRvITk",
+	"eeLfX4o8SROTraHkCImwUBKUdlPhZ8ZqIVfJbZqU/ObMvXxycpKG91xrvklub9Pk1Q2B+R5kDnoMNfeW",
+	"0IKbPWj5DxqMjFs7hpD//NJ99kBIfa9V+ZJbaPGJHb3UqrzMuYXeqUulS26T5wm+ObKihKQ5IUBwmyb/",
+	"4OZ8rYGPsoPVNTANttbSMCWLDSvBGL4Cw+yaW7bmV8C4ZQVwY5mS+HFVbFK25IWBwYprYdeqtvSJADOb",
+	"y5dQgIWccZkzuKmEhjy8ZVwDk8qyTNXSQj6byxHir7m5tIRFjwQe24VSBXBJ6J6ZdwjddGQDLF/0cHA4",
+	"Xlr1ZRxPokwA328xArswl7TZPsgnc/bdBHYPez8oWztg32pYiptRLcQNHBmQRlhxBayijwkXBwora2OZ",
+	"sVxbupGdcF+65TESd0ThXO2XNavuKGm3YQWR7LVjFPxvpVUF2gqgFx29GKNspqQFaePvNHAL+SW3U4FK",
+	"Eydxxq/p38C5KIHxJTLN9VpkayK+53AmDJOKFUquQHuB8UIsDNNQqitAWZwGRRCmbRg+nL1EJu2ebNfC",
+	"NH8E6bQqtq9n2hixDEh7EKXqkRupq/xAqt82T9TiV8gs7uL54TuUzBd0j9usEZRLT/b+omGZPE/+47g1",
+	"+ceez479pn6/oVzGBFPDbzVq4OT5z+1xF3vgxS3fgakLuw0zaK0iwv0OuFGSXa9B9u72mhun8B0rx+5B",
+	"yBxutnd8q1BRKDnkFuEOQMTA2HZDIS2sQBMrWG5rs73lkosCclYCl4Y2MVZp3DNTdZETnNdaWOieN2Nn",
+	"lmVcsgXpKcv4igv57VyKJROW8MM1FvCLpdJuMZ5Ua0g9qFaT2VHaGlzEDcvrqhAZt+BMIMi6xCtqydR8",
+	"QGrwihfkV/1WK8sv4SYDyJ04EkrJRYSucf4e8IQjfkOyfZwxxhUB7uefItfRohJ9vZujKq0yMEbIFd5W",
+	"VUHOgOtiM2M/on024IwFcwiwpyd/i/GYJ9PWIW/qcgEaWaxj6Ef5Isps4XKiuA3ua8r5GpDwkLMFZLw2",
+	"jp8sSC4t08CzNeRM2FZh0hFRyDRdlomRll6gKHkxYkrnoGfspzWMkzxtgXRGxK5hLgtuEDDckV2Dd5D8",
+	"Fo2Hd4ByG2qg2z1a7a4i09JnF9PXxUfnz+4z7NMirteIHNLPuWp4tStxBbIfXd3NDSNfYinQXAklL636",
+	"CDLiA+Dj1r4vNgREjgqqluhhOso6TZ7rzaWuJXoA5BPP5vIcuZH2EIYZsepsYkBfge74/YFPOFuKG8hZ",
+	"BVqonH3x5ISVQtYWDC7NYcnrwn5JnLKFsgfBIUIfJs+triEd4EWcSy+c404RhpNm8o6Rm1v2Ja/G1Phf",
+	"1qWaQy1Jt9z1dBgOTohE73OVbQQ42Z+ZANh+PuwGDXeF3aqDIL+dIn6jhmcyy1vFKm4MW/Dso7skyGpv",
+	"63M8QyjZNSoD/kfWivKni3YnqXb/7Y5TSMq6XraQ9q9fRbV7RzC2mZVYfiJUjXggJTItLGjBp8AwUMQB",
+	"oPb0HYr1hZIWbiIXShpjG+7XAVrngwXzwyzXK7DBKTS8hKBKU6aKHIxlS6GN7fLzBCO0bXXSxLl3+0Dr",
+	"OIGfEbayjT4n7TC4Oo9au0/q72HXDY4ENfeId3fFrq/5R++cBccHqjWUoHkxYy+4RK9jgR5buRBok8gp",
+	"tLa4NJApmZvPGrrO2LlL0SBH5ApcDER5NdI8wli2AZvOpVHNMh9kCLkC4/y/wElC+71mcRv5kFFxl2SH",
+	"XELqbL7TI0gUNC1+Iy+v5KmRlh27rw4DRPRPKaQoMU56EtOHk8Kd2mWnPVHSNgnWy14HHt3B/e8tt2ab",
+	"+Rd19hGsGUm9+LeURiSH2/lOzi2aseA0onfiABtbQc5NytZitW60yGwu39JGbU629XdweSmsvYtDTqh+",
+	"R4DEVM9Kq7q6XGxwtyaMdZh0CbxWNf6T8000WEV2/F1J2H+FzXmdRWlD+H1X5vGI+BG1tJPMpVfn7sBp",
+	"pvojbHaFCKm/7ZQp7fOfXs0Eh/nd9y/Ys2fPvhkkPyBngQRfjusFswutXBgrZGY9CIZdrxWrFO19MKaD",
+	"m0K0U0/ZFpjYDZ1TeDuaVjhIgY3YHZftjbxw8fMeUXAA/pM+vVOKME3qKQbanfMhaqRJNREaAehxUo4Z",
+	"51is+oO6Bp1xA6wAa0GblOViJawLk9abag3S9HT2mssV5KzglkS74rgMt/q/n/nR7ydH31z4f48uPp2k",
+	"f316+5cYQR7wRkYpNU6hf4ZDBilZfnPZTcsO7d8NWiAmG/nhGRUzwpIZO/HpxVoWohQW8pjgNJbsJKYu",
+	"EAYKZQ6BwC14gPNvR0n2oQphXR8mimbaEHIpoMidzfFykqQDKj/s1Y+BG6/HjN/um7Fbnablx67sTfyq",
+	"7qBQO/D4PaL8vSsKLsHynFvuPFTahTnyY/jp66M+N9Qk85t8T5trGwRteSkiR/60BrsG3dkDfVwN3GUw",
+	"1bUMic0mIcNoK08itEDCRrMx9y+NPYg1iZFY5CCtWArQzGvrreWmrkBfHkS1kkvyt69AbzzVfB6B6NYk",
+	"4Rz9MlWWXOZR0rnF0bSlE5zOyQvAgqALaV7R0SFhXPIcfJDVywhmqoIcoxyKAtxZUXP4IFU2oviYsevQ",
+	"1ycOKb0yzBye+USg7PKep22PG/Eatpl0JFnoWG1w/tOvv3lKdboY39Aal4X0fp+QrI1etxXOvZhwoFrG",
+	"rSVu9w5MpaTZReNxGjym8zYQpD3MvrW8SRjuZTU8C7JaC7t5j2bIYf8dcA36tLZr/IvsE51Pj1v81tZW",
+	"rmFAyGUksXD69owtlXYyvpWobtlM2ALahBN7D/pKZMBO354laXIF2rjtnsxOZieInqpA8kokz5Nn9Ihc",
+	"tTVBfswrcdw1hejz4794ucSEZ7krwtrTSrxuDY+X/+9UvvH515DO4ZUrvwglj381SjYk4QeWtfu8aXUN",
+	"9MCxIAH79OTJQx/ujo1m9oLZY6bOMjBmWRcFhb5fnZxsX+aZqzw1BWr67knk0mu7Rhl1QLMGYfr+2ahu",
+	"7pX9WFPW6jJo8vznPmv+fHF7gbJSllxvMPQkfFib7LN8ZfodArhfj0eOF5jU7XLKIJylPQ2rK1T+2I3Q",
+	"cjEWMxhn//3+xzdsofINBbqYH5Jb4fVcGquBl5Azbtibl7TmCyWB9ZgENSQrhIQvZ+wVz9bdLhYiP97X",
+	"XDZ512u+wf3e/vj+nPXQIglzpXmKeHNxJfKaF8VmNpf+eNGW+NG0rmv50SDMfSQ5lfapV4bnM3a27FaS",
+	"5xKrjgbxdk8RR5Y1IRWu8Vkyo0rotNeBbvgvdQXXIAl41tOTv7VG2NczBzlK0xZjmVFsyfWMnfbhqjWE",
+	"bCPeSrd3I7C+MC2VuGFfn5zM5vKn4CD6yh/lLgwz69paVGO5upYpUsVYVTnC4GNH1pSI6tKJc9mSscDP",
+	"NvRx6m/HNe91cRU6YOvyWTu1FlWUH1d1ddt8UHS6G94cyXx708YeLoTketMai25/2T4tePIoeITC+7ZC",
+	"fAv6qM12E/1RX2HTxecF5P22kIQuDdeJ07ZOhF4O3/wzkEBs8Jh3pXDG3KmGZQp5WoXgNiJOXma2+yWQ",
+	"KSfahxl7o2ISdxe78XXUbgzkE/K+gAYsgnR2NMCMYe24m3F03U/3sTYU1C28RE6yO3Xx8ShvezCi1ue9",
+	"Wlr/kfExUqBptLyJQNhrxYyFClXI90IbyzJeFE7F+Frmf1H3sFVsBS4q6hispoKeR3sIUrI+0u1JVGsV",
+	"tSsFelDS5jDXdsw9xbe3HHZfNJ1CYtlHTukRYEPmDq8A7DWAJFPsWtnW3IR+7dlc/tjrfg5moFPd9C0j",
+	"lOfxhe1vtxeQTbvm2ieEPkJlp2jstu3mcdV2e84fpG+HDQ4RXffSE1rppk/BK9/H9UG/iTh5WzzZVjdd",
+	"DzrxYa2p8Nnlxw5zHaY9HHUi3QqdToUpagRu0HtBlFYQUyBkDybrDl9BdDW5pt62qJdL0OF7d0fMAHL8",
+	"aZZBZU0r/ktqSie3sQ+oAa6z9WwuPxhgq99F1SghDRlQ/nANzKGDqzl9dIQ8pp1ZwvXiCmJy9nfoitkr",
+	"R5O0N0/0c5xx20+Oh/NGt+neJb1Rh8nf9+YJJqyKTQxNWNafIJmwYDhhM2HJYO5nwopzNfg+OijkvMju",
+	"7EKTbEq829l2FzcPMnOVpAn9cRHJDMXPQjaLn+TTalsjLhcHKVDa/0AfOd2jgu/XV/NAjnyaWLixx0j0",
+	"w0OAvppyEks97E1GZg08VHlfOMoevRSm8u3z/SO3Dng0C3KIjndYdXGaotGdohzV6C5dHUpShQ0DRqTT",
+	"Z6xt+Oj2W/geHA2Vc1+Gs1Woeq94UYPZr13fO/j+rV3/JbTrMJ7QwbirZceamxlzZHPO/Dw5mieUusXl",
+	"ICmAomWzuXzdGy9sDD+xz7Chx6U0zl6OD0QaZ6ljer5tZwqKvn1y1P63UzKLK/2hr1eW/MgAEg1FoRCG",
+	"+lJ8edcqn5hhX9QiT4NH1PaytAKWMq/xU9bCkLK2ApR2Gr/SdhAT3aai6NaT+6XJXkcT3PCyovQ4guOB",
+	"6FDmpipUDo2tihpTOmjiROSwDcrYTREscoShtuv27cBJoKQfvyiV7vm7G6eC3CzR/x69gRt79KLWRmkW",
+	"rCtzNmAu14ruxjnhRjXcK+HGsopCeiRppCN+N3Vj5KL+gh61SodkGEbb1a+3TaH/IblQywGKlDtGFCoN",
+	"V0LVxqOB3rGwA9lqnGrU6Erb0Vl12nvn8OjFPYO/e3oe2xkvsiZN1q1n9nsUi0RtY6zwqqzsxt02XSa1",
+	"ALhr9zkEDX7muc+Vs52k+3M4FZ5gBzoVn2qR3zqIQsapb+NdPNox8x+o22hg4+N1JGwgtspnSwJrYkmu",
+	"5UzXoNrPQxzGpl+Ndqj7g2N1rENzAzsOkcqyparlveL8Q27rOGtnCaKe4DtfQOjNpQa94RxEdzMr13jR",
+	"vCt7nf2/ovfYlElCuUbYuYx0+M/Y617zbWvqG0OJe/RP4Lbdx1LD8NAz2OVvfhB5mKo4gB/9mRlI2zo7",
+	"WbPPA7BoOmXosvEmtuYnRlR4M7IQ8YienqQ9W7Sv4e0gAIezJyPw0WcPAt7F46chA9+M18CRjVGou4g/",
+	"avbx4TXMEBM+TdkYy63ptUpElcwLN2+4e6qKytbNDFDwlLE1nrK7HIvO/8C/UDfkfMMWCCnXAovoqijU",
+	"taO/b/r2ygu1xO/si7PTN6fU7JOyD+cvupOVs3nTwWNYxrXeMC7Zq3O+ooOaoLepnDsVeLY8eqMkHNGw",
+	"4Lj+ob76TofIQPVEU0ltD/+4HrnrMMFt+meIscei5Yf+uZ8H/lGTsf1+723VBlunRvDjc/Vxo3Zs5lzV",
+	"drceYz2qFz51vGVX94+x3ApjRTZMtvFsDUeoOrUqdqfZ0gSFbX8q7llM7b3B4orKsZkvf3QI/ni/PUr2",
+	"HfrZdfh1FXNMSZ37rz5HVOfOmhLU/eBzKh6Hrpt49vLBOsfe12h2Oj2rd7wZgtY2hAx3Ep5c3Ka7uwi7",
+	"d/DwJd3e4Mtn7iEMN759w+7Nn6ODcBcfjBR8PfihKYsmV82d2j/aTvAtthlI8vEnH4LvF+ezfMTf6Ecs",
+	"946pTz4fp4T2j0iisG0GpnYNTPU9Hm/4Hs3uoxAduntK9zvsHqU7+ut/h6BtnDsqrGH1wF/v6Z7QoDpQ",
+	"Pvj4s7DMY+k0P3f1mTtU9nKqz9//uXXag3Olu4zAmMgtKCjNjzBNUm7H7ahYvJ0tjKT0pnAomFSdpFWQ",
+	"Q6w/eY+c3tI4eCuynEm49h+HFjL64z+N/4igZ8QqOMK4RVPT6VT200Z2rVW9cmmy07dnO3q6Grk7D3MM",
+	"/zLS1xku+twORW/qJiaDbjDsT+lWHGA66FP3sMeL7VzDowgxyZc/UMhJvkkjsdHsD7rGpp2EM318mwYx",
+	"l8ztTl2a5ie3tibQRtItjRh9hkAGjzoojnGwPRRnnd47ZCEfgtZj+bp7w35+thOzxOdZeIdN2gsNeXJe",
+	"FE4t44WxAX7CNFB/O5dDzdyO07YFh19sR6x/CVOc4TQcE3Od3LvUbcse/9aIf+yoVkyhxXQjdv23v+ix",
+	"2KDFVvLIfYIs5kfBt2IqsuvhB+uGjN3XXE1Q1dY1h0WyuBZip8MRWPqNojZVvBhMyaplp4jl1P1cUkF+",
+	"262g4YrFJvIqxuFN6dXx+NneuqtjCVd0bUFO0odyOfbVXc+95miJdf/K60Q1SXxmRp3jPkUY3/503PLS",
+	"Rnc1vO2Z44y7f0O/ZqvlT+aVEtIaN03bNHFLvoISpG1v278zkU6d/ia8fxeOirEdG9O3ez9vP4jQwljH",
+	"3bO5/IF+h2flO6TcHE7uQiz8IyQI/f3FLiz1v+mNIurkqnHW4/aFHR3RJk7R/NL/5QAqGfmBoOGPNDQW",
+	"z/9AA+OFUYxTh3vU7+MhkJ/LDsEcSsntxe3/DwD66RW032IAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
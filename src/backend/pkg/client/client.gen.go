@@ -0,0 +1,3009 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version (devel) DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+const (
+	BearerAuthScopes = "BearerAuth.Scopes"
+)
+
+// Defines values for MessageBatchItemResultStatus.
+const (
+	Created       MessageBatchItemResultStatus = "created"
+	Duplicate     MessageBatchItemResultStatus = "duplicate"
+	Failed        MessageBatchItemResultStatus = "failed"
+	Invalid       MessageBatchItemResultStatus = "invalid"
+	QuotaExceeded MessageBatchItemResultStatus = "quota_exceeded"
+)
+
+// Defines values for MessageStatsGroupBy.
+const (
+	MessageStatsGroupByChannel MessageStatsGroupBy = "channel"
+	MessageStatsGroupByDay     MessageStatsGroupBy = "day"
+	MessageStatsGroupByHour    MessageStatsGroupBy = "hour"
+	MessageStatsGroupBySender  MessageStatsGroupBy = "sender"
+)
+
+// Defines values for GetApiMessagesExportParamsFormat.
+const (
+	Csv    GetApiMessagesExportParamsFormat = "csv"
+	Json   GetApiMessagesExportParamsFormat = "json"
+	Ndjson GetApiMessagesExportParamsFormat = "ndjson"
+)
+
+// Defines values for GetApiMessagesSearchParamsSort.
+const (
+	CreatedAt   GetApiMessagesSearchParamsSort = "created_at"
+	MinusSentAt GetApiMessagesSearchParamsSort = "-sent_at"
+	SentAt      GetApiMessagesSearchParamsSort = "sent_at"
+)
+
+// Defines values for GetApiStatsMessagesParamsGroupBy.
+const (
+	GetApiStatsMessagesParamsGroupByChannel GetApiStatsMessagesParamsGroupBy = "channel"
+	GetApiStatsMessagesParamsGroupByDay     GetApiStatsMessagesParamsGroupBy = "day"
+	GetApiStatsMessagesParamsGroupByHour    GetApiStatsMessagesParamsGroupBy = "hour"
+	GetApiStatsMessagesParamsGroupBySender  GetApiStatsMessagesParamsGroupBy = "sender"
+)
+
+// Message defines model for Message.
+type Message struct {
+	ChannelId *string    `json:"channel_id,omitempty"`
+	Content   *string    `json:"content,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// ExpiresAt Time after which the message is no longer returned and is removed
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// ReplyTo UID of the message this message replies to
+	ReplyTo   *string    `json:"reply_to,omitempty"`
+	Sender    *string    `json:"sender,omitempty"`
+	SentAt    *time.Time `json:"sent_at,omitempty"`
+	Uid       *string    `json:"uid,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// MessageBatchCreate defines model for MessageBatchCreate.
+type MessageBatchCreate struct {
+	Messages []MessageCreate `json:"messages"`
+}
+
+// MessageBatchItemResult defines model for MessageBatchItemResult.
+type MessageBatchItemResult struct {
+	// Error Reason when the message was not created
+	Error *string `json:"error,omitempty"`
+
+	// Index Position of the message in the request
+	Index int `json:"index"`
+
+	// Status failed means the storage could not write the message. It can be sent again;
+	// if it was written before the failure, the retry reports it as duplicate.
+	Status MessageBatchItemResultStatus `json:"status"`
+	Uid    *string                      `json:"uid,omitempty"`
+}
+
+// MessageBatchItemResultStatus failed means the storage could not write the message. It can be sent again;
+// if it was written before the failure, the retry reports it as duplicate.
+type MessageBatchItemResultStatus string
+
+// MessageBatchResult defines model for MessageBatchResult.
+type MessageBatchResult struct {
+	Created   int `json:"created"`
+	Duplicate int `json:"duplicate"`
+
+	// Error Reason processing stopped early. Only set with status 207
+	Error *string `json:"error,omitempty"`
+
+	// Failed Number of messages the storage could not write
+	Failed  int `json:"failed"`
+	Invalid int `json:"invalid"`
+
+	// QuotaExceeded Number of messages rejected because the tenant reached its message quota
+	QuotaExceeded int `json:"quota_exceeded"`
+
+	// Results Results in request order. When processing stopped early, messages after the
+	// last result were not processed.
+	Results []MessageBatchItemResult `json:"results"`
+}
+
+// MessageBulkDelete defines model for MessageBulkDelete.
+type MessageBulkDelete struct {
+	// ChannelId Channel IDs to include. Matches any of the given channels.
+	ChannelId *[]string `json:"channel_id,omitempty"`
+
+	// ConfirmationToken Token returned by the dry run. Required when dry_run is false.
+	// The token is signed by the server and expires after a fixed period (10 minutes by default)
+	ConfirmationToken *string `json:"confirmation_token,omitempty"`
+
+	// DryRun When true, only counts the matching messages and issues a confirmation token
+	DryRun *bool `json:"dry_run,omitempty"`
+
+	// ExcludeSender Senders to exclude
+	ExcludeSender *[]string  `json:"exclude_sender,omitempty"`
+	FromDate      *time.Time `json:"from_date,omitempty"`
+
+	// Sender Senders to include. Matches any of the given senders.
+	Sender *[]string  `json:"sender,omitempty"`
+	ToDate *time.Time `json:"to_date,omitempty"`
+}
+
+// MessageBulkDeleteResult defines model for MessageBulkDeleteResult.
+type MessageBulkDeleteResult struct {
+	// ConfirmationToken Token to pass back to execute the deletion. Only set when dry_run is true
+	ConfirmationToken *string `json:"confirmation_token,omitempty"`
+
+	// Deleted Number of messages deleted. Only set when dry_run is false
+	Deleted *int64 `json:"deleted,omitempty"`
+	DryRun  bool   `json:"dry_run"`
+
+	// Matched Number of messages matching the criteria
+	Matched int64 `json:"matched"`
+}
+
+// MessageContext defines model for MessageContext.
+type MessageContext struct {
+	// After Messages sent after the target in the same channel, oldest first
+	After []Message `json:"after"`
+
+	// Before Messages sent before the target in the same channel, oldest first
+	Before  []Message `json:"before"`
+	Message Message   `json:"message"`
+}
+
+// MessageCreate defines model for MessageCreate.
+type MessageCreate struct {
+	ChannelId string `json:"channel_id"`
+	Content   string `json:"content"`
+
+	// ExpiresAt Makes the message ephemeral. Cannot be combined with ttl_seconds
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// ReplyTo UID of the message this message replies to. The parent does not have to exist yet,
+	// so replies can be ingested before their parent.
+	ReplyTo *string   `json:"reply_to,omitempty"`
+	Sender  string    `json:"sender"`
+	SentAt  time.Time `json:"sent_at"`
+
+	// TtlSeconds Makes the message ephemeral, expiring this many seconds after creation. Cannot be combined with expires_at
+	TtlSeconds *int64 `json:"ttl_seconds,omitempty"`
+	Uid        string `json:"uid"`
+}
+
+// MessageStats defines model for MessageStats.
+type MessageStats struct {
+	// Buckets Time buckets are ordered by period. Channel and sender buckets are ordered by count, highest first.
+	// Periods without messages are omitted.
+	Buckets  []MessageStatsBucket `json:"buckets"`
+	GroupBy  MessageStatsGroupBy  `json:"group_by"`
+	Timezone string               `json:"timezone"`
+}
+
+// MessageStatsGroupBy defines model for MessageStats.GroupBy.
+type MessageStatsGroupBy string
+
+// MessageStatsBucket defines model for MessageStatsBucket.
+type MessageStatsBucket struct {
+	// Count Number of messages in the bucket
+	Count int64 `json:"count"`
+
+	// Key Channel ID, sender, or start of the period (RFC 3339 in the requested timezone)
+	Key string `json:"key"`
+
+	// Senders Number of distinct senders who posted in the bucket
+	Senders int64 `json:"senders"`
+}
+
+// Tenant defines model for Tenant.
+type Tenant struct {
+	CreatedAt *time.Time   `json:"created_at,omitempty"`
+	Id        string       `json:"id"`
+	Name      string       `json:"name"`
+	Quota     TenantQuota  `json:"quota"`
+	UpdatedAt *time.Time   `json:"updated_at,omitempty"`
+	Usage     *TenantUsage `json:"usage,omitempty"`
+}
+
+// TenantCreate defines model for TenantCreate.
+type TenantCreate struct {
+	// Id Lowercase letters, digits and hyphens. Cannot be changed later
+	Id    string       `json:"id"`
+	Name  string       `json:"name"`
+	Quota *TenantQuota `json:"quota,omitempty"`
+}
+
+// TenantQuota defines model for TenantQuota.
+type TenantQuota struct {
+	// MaxMessages Maximum number of active messages. 0 means unlimited
+	MaxMessages *int64 `json:"max_messages,omitempty"`
+
+	// MaxTokens Maximum number of active tokens. 0 means unlimited
+	MaxTokens *int64 `json:"max_tokens,omitempty"`
+}
+
+// TenantUpdate Only the given fields are updated
+type TenantUpdate struct {
+	Name  *string      `json:"name,omitempty"`
+	Quota *TenantQuota `json:"quota,omitempty"`
+}
+
+// TenantUsage defines model for TenantUsage.
+type TenantUsage struct {
+	// Messages Number of active messages
+	Messages int64 `json:"messages"`
+
+	// Tokens Number of active tokens
+	Tokens int64 `json:"tokens"`
+}
+
+// Token Token metadata. The token string is only returned when the token is created
+type Token struct {
+	// Admin Whether the token can read its own tenant and issue admin tokens in it
+	Admin     *bool      `json:"admin,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Id        *string    `json:"id,omitempty"`
+
+	// Name Token identifier name
+	Name *string `json:"name,omitempty"`
+
+	// SuperAdmin Whether the token can manage every tenant. Only issued by the admin command
+	SuperAdmin *bool `json:"super_admin,omitempty"`
+
+	// TenantId Tenant the token belongs to. Every request made with the token is scoped to this tenant
+	TenantId  *string    `json:"tenant_id,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// TokenCreate defines model for TokenCreate.
+type TokenCreate struct {
+	// Admin Issues an admin token. Only admin tokens can issue admin tokens
+	Admin *bool `json:"admin,omitempty"`
+
+	// ExpiresIn Token expiration period in seconds
+	ExpiresIn *int `json:"expires_in,omitempty"`
+
+	// Name Token identifier name
+	Name string `json:"name"`
+}
+
+// TokenResponse defines model for TokenResponse.
+type TokenResponse struct {
+	Admin      *bool      `json:"admin,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	Id         *string    `json:"id,omitempty"`
+	Name       *string    `json:"name,omitempty"`
+	SuperAdmin *bool      `json:"super_admin,omitempty"`
+	TenantId   *string    `json:"tenant_id,omitempty"`
+	Token      *string    `json:"token,omitempty"`
+}
+
+// ChannelIdFilter defines model for ChannelIdFilter.
+type ChannelIdFilter = []string
+
+// ExcludeSenderFilter defines model for ExcludeSenderFilter.
+type ExcludeSenderFilter = []string
+
+// FromDateFilter defines model for FromDateFilter.
+type FromDateFilter = time.Time
+
+// HasThreadFilter defines model for HasThreadFilter.
+type HasThreadFilter = bool
+
+// IsReplyFilter defines model for IsReplyFilter.
+type IsReplyFilter = bool
+
+// SenderFilter defines model for SenderFilter.
+type SenderFilter = []string
+
+// SenderPrefixFilter defines model for SenderPrefixFilter.
+type SenderPrefixFilter = string
+
+// ToDateFilter defines model for ToDateFilter.
+type ToDateFilter = time.Time
+
+// GetApiMessagesExportParams defines parameters for GetApiMessagesExport.
+type GetApiMessagesExportParams struct {
+	// ChannelId Channel IDs to include. Repeat the parameter to match any of several channels.
+	ChannelId *ChannelIdFilter `form:"channel_id,omitempty" json:"channel_id,omitempty"`
+
+	// Sender Senders to include. Repeat the parameter to match any of several senders.
+	Sender *SenderFilter `form:"sender,omitempty" json:"sender,omitempty"`
+
+	// SenderPrefix Case-sensitive prefix the sender must start with
+	SenderPrefix *SenderPrefixFilter `form:"sender_prefix,omitempty" json:"sender_prefix,omitempty"`
+
+	// ExcludeSender Senders to exclude. Repeat the parameter to exclude several senders.
+	ExcludeSender *ExcludeSenderFilter `form:"exclude_sender,omitempty" json:"exclude_sender,omitempty"`
+
+	// IsReply true returns only replies (messages with reply_to), false only messages that are not replies
+	IsReply *IsReplyFilter `form:"is_reply,omitempty" json:"is_reply,omitempty"`
+
+	// HasThread true returns only messages that have at least one reply, false only messages without replies.
+	// Deleted and expired replies are not counted.
+	HasThread *HasThreadFilter                  `form:"has_thread,omitempty" json:"has_thread,omitempty"`
+	FromDate  *FromDateFilter                   `form:"from_date,omitempty" json:"from_date,omitempty"`
+	ToDate    *ToDateFilter                     `form:"to_date,omitempty" json:"to_date,omitempty"`
+	Format    *GetApiMessagesExportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+	Gzip      *bool                             `form:"gzip,omitempty" json:"gzip,omitempty"`
+}
+
+// GetApiMessagesExportParamsFormat defines parameters for GetApiMessagesExport.
+type GetApiMessagesExportParamsFormat string
+
+// GetApiMessagesSearchParams defines parameters for GetApiMessagesSearch.
+type GetApiMessagesSearchParams struct {
+	// ChannelId Channel IDs to include. Repeat the parameter to match any of several channels.
+	ChannelId *ChannelIdFilter `form:"channel_id,omitempty" json:"channel_id,omitempty"`
+
+	// Sender Senders to include. Repeat the parameter to match any of several senders.
+	Sender *SenderFilter `form:"sender,omitempty" json:"sender,omitempty"`
+
+	// SenderPrefix Case-sensitive prefix the sender must start with
+	SenderPrefix *SenderPrefixFilter `form:"sender_prefix,omitempty" json:"sender_prefix,omitempty"`
+
+	// ExcludeSender Senders to exclude. Repeat the parameter to exclude several senders.
+	ExcludeSender *ExcludeSenderFilter `form:"exclude_sender,omitempty" json:"exclude_sender,omitempty"`
+
+	// IsReply true returns only replies (messages with reply_to), false only messages that are not replies
+	IsReply *IsReplyFilter `form:"is_reply,omitempty" json:"is_reply,omitempty"`
+
+	// HasThread true returns only messages that have at least one reply, false only messages without replies.
+	// Deleted and expired replies are not counted.
+	HasThread *HasThreadFilter `form:"has_thread,omitempty" json:"has_thread,omitempty"`
+	FromDate  *FromDateFilter  `form:"from_date,omitempty" json:"from_date,omitempty"`
+	ToDate    *ToDateFilter    `form:"to_date,omitempty" json:"to_date,omitempty"`
+
+	// Sort Sort order of the results. Prefix with "-" for descending order.
+	// Messages with the same value are ordered by their ID.
+	Sort *GetApiMessagesSearchParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Fields Comma-separated list of fields to return (uid, sent_at, sender, channel_id, content, created_at, updated_at, expires_at, reply_to).
+	// All fields are returned when omitted.
+	Fields *[]string `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// Limit Maximum number of messages to return. When more messages may match, the X-Next-Cursor response header
+	// holds the cursor of the next page. All matching messages are returned when omitted.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Value of X-Next-Cursor from the previous page. Use it with the same filters and sort.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetApiMessagesSearchParamsSort defines parameters for GetApiMessagesSearch.
+type GetApiMessagesSearchParamsSort string
+
+// GetApiMessagesUidContextParams defines parameters for GetApiMessagesUidContext.
+type GetApiMessagesUidContextParams struct {
+	// Before Number of messages to return before the target
+	Before *int `form:"before,omitempty" json:"before,omitempty"`
+
+	// After Number of messages to return after the target
+	After *int `form:"after,omitempty" json:"after,omitempty"`
+}
+
+// GetApiStatsMessagesParams defines parameters for GetApiStatsMessages.
+type GetApiStatsMessagesParams struct {
+	GroupBy GetApiStatsMessagesParamsGroupBy `form:"group_by" json:"group_by"`
+
+	// ChannelId Channel IDs to include. Repeat the parameter to match any of several channels.
+	ChannelId *ChannelIdFilter `form:"channel_id,omitempty" json:"channel_id,omitempty"`
+
+	// Sender Senders to include. Repeat the parameter to match any of several senders.
+	Sender *SenderFilter `form:"sender,omitempty" json:"sender,omitempty"`
+
+	// ExcludeSender Senders to exclude. Repeat the parameter to exclude several senders.
+	ExcludeSender *ExcludeSenderFilter `form:"exclude_sender,omitempty" json:"exclude_sender,omitempty"`
+	FromDate      *time.Time           `form:"from_date,omitempty" json:"from_date,omitempty"`
+	ToDate        *time.Time           `form:"to_date,omitempty" json:"to_date,omitempty"`
+	Tz            *string              `form:"tz,omitempty" json:"tz,omitempty"`
+	IfNoneMatch   *string              `json:"If-None-Match,omitempty"`
+}
+
+// GetApiStatsMessagesParamsGroupBy defines parameters for GetApiStatsMessages.
+type GetApiStatsMessagesParamsGroupBy string
+
+// PostApiMessagesJSONRequestBody defines body for PostApiMessages for application/json ContentType.
+type PostApiMessagesJSONRequestBody = MessageCreate
+
+// PostApiMessagesBatchJSONRequestBody defines body for PostApiMessagesBatch for application/json ContentType.
+type PostApiMessagesBatchJSONRequestBody = MessageBatchCreate
+
+// PostApiMessagesBulkDeleteJSONRequestBody defines body for PostApiMessagesBulkDelete for application/json ContentType.
+type PostApiMessagesBulkDeleteJSONRequestBody = MessageBulkDelete
+
+// PostApiTenantsJSONRequestBody defines body for PostApiTenants for application/json ContentType.
+type PostApiTenantsJSONRequestBody = TenantCreate
+
+// PatchApiTenantsIdJSONRequestBody defines body for PatchApiTenantsId for application/json ContentType.
+type PatchApiTenantsIdJSONRequestBody = TenantUpdate
+
+// PostApiTenantsIdTokensJSONRequestBody defines body for PostApiTenantsIdTokens for application/json ContentType.
+type PostApiTenantsIdTokensJSONRequestBody = TokenCreate
+
+// PostApiTokensJSONRequestBody defines body for PostApiTokens for application/json ContentType.
+type PostApiTokensJSONRequestBody = TokenCreate
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// RawClient which conforms to the OpenAPI3 specification for this service.
+type RawClient struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*RawClient) error
+
+// Creates a new RawClient, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*RawClient, error) {
+	// create a client with sane default values
+	client := RawClient{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *RawClient) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *RawClient) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// PostApiMessagesWithBody request with any body
+	PostApiMessagesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiMessages(ctx context.Context, body PostApiMessagesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiMessagesBatchWithBody request with any body
+	PostApiMessagesBatchWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiMessagesBatch(ctx context.Context, body PostApiMessagesBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiMessagesBulkDeleteWithBody request with any body
+	PostApiMessagesBulkDeleteWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiMessagesBulkDelete(ctx context.Context, body PostApiMessagesBulkDeleteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiMessagesExport request
+	GetApiMessagesExport(ctx context.Context, params *GetApiMessagesExportParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiMessagesSearch request
+	GetApiMessagesSearch(ctx context.Context, params *GetApiMessagesSearchParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteApiMessagesUid request
+	DeleteApiMessagesUid(ctx context.Context, uid string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiMessagesUidContext request
+	GetApiMessagesUidContext(ctx context.Context, uid string, params *GetApiMessagesUidContextParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiStatsMessages request
+	GetApiStatsMessages(ctx context.Context, params *GetApiStatsMessagesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiTenants request
+	GetApiTenants(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiTenantsWithBody request with any body
+	PostApiTenantsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiTenants(ctx context.Context, body PostApiTenantsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiTenantsId request
+	GetApiTenantsId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchApiTenantsIdWithBody request with any body
+	PatchApiTenantsIdWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchApiTenantsId(ctx context.Context, id string, body PatchApiTenantsIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiTenantsIdTokensWithBody request with any body
+	PostApiTenantsIdTokensWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiTenantsIdTokens(ctx context.Context, id string, body PostApiTenantsIdTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiTokens request
+	GetApiTokens(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiTokensWithBody request with any body
+	PostApiTokensWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiTokens(ctx context.Context, body PostApiTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteApiTokensId request
+	DeleteApiTokensId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *RawClient) PostApiMessagesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiMessagesRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) PostApiMessages(ctx context.Context, body PostApiMessagesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiMessagesRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) PostApiMessagesBatchWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiMessagesBatchRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) PostApiMessagesBatch(ctx context.Context, body PostApiMessagesBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiMessagesBatchRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) PostApiMessagesBulkDeleteWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiMessagesBulkDeleteRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) PostApiMessagesBulkDelete(ctx context.Context, body PostApiMessagesBulkDeleteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiMessagesBulkDeleteRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) GetApiMessagesExport(ctx context.Context, params *GetApiMessagesExportParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiMessagesExportRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) GetApiMessagesSearch(ctx context.Context, params *GetApiMessagesSearchParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiMessagesSearchRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) DeleteApiMessagesUid(ctx context.Context, uid string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteApiMessagesUidRequest(c.Server, uid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) GetApiMessagesUidContext(ctx context.Context, uid string, params *GetApiMessagesUidContextParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiMessagesUidContextRequest(c.Server, uid, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) GetApiStatsMessages(ctx context.Context, params *GetApiStatsMessagesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiStatsMessagesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) GetApiTenants(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiTenantsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) PostApiTenantsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiTenantsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) PostApiTenants(ctx context.Context, body PostApiTenantsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiTenantsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) GetApiTenantsId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiTenantsIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) PatchApiTenantsIdWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchApiTenantsIdRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) PatchApiTenantsId(ctx context.Context, id string, body PatchApiTenantsIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchApiTenantsIdRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) PostApiTenantsIdTokensWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiTenantsIdTokensRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) PostApiTenantsIdTokens(ctx context.Context, id string, body PostApiTenantsIdTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiTenantsIdTokensRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) GetApiTokens(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiTokensRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) PostApiTokensWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiTokensRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) PostApiTokens(ctx context.Context, body PostApiTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiTokensRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *RawClient) DeleteApiTokensId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteApiTokensIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewPostApiMessagesRequest calls the generic PostApiMessages builder with application/json body
+func NewPostApiMessagesRequest(server string, body PostApiMessagesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiMessagesRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostApiMessagesRequestWithBody generates requests for PostApiMessages with any type of body
+func NewPostApiMessagesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/messages")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostApiMessagesBatchRequest calls the generic PostApiMessagesBatch builder with application/json body
+func NewPostApiMessagesBatchRequest(server string, body PostApiMessagesBatchJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiMessagesBatchRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostApiMessagesBatchRequestWithBody generates requests for PostApiMessagesBatch with any type of body
+func NewPostApiMessagesBatchRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/messages/batch")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostApiMessagesBulkDeleteRequest calls the generic PostApiMessagesBulkDelete builder with application/json body
+func NewPostApiMessagesBulkDeleteRequest(server string, body PostApiMessagesBulkDeleteJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiMessagesBulkDeleteRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostApiMessagesBulkDeleteRequestWithBody generates requests for PostApiMessagesBulkDelete with any type of body
+func NewPostApiMessagesBulkDeleteRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/messages/bulk-delete")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetApiMessagesExportRequest generates requests for GetApiMessagesExport
+func NewGetApiMessagesExportRequest(server string, params *GetApiMessagesExportParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/messages/export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ChannelId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "channel_id", runtime.ParamLocationQuery, *params.ChannelId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Sender != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sender", runtime.ParamLocationQuery, *params.Sender); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.SenderPrefix != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sender_prefix", runtime.ParamLocationQuery, *params.SenderPrefix); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ExcludeSender != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "exclude_sender", runtime.ParamLocationQuery, *params.ExcludeSender); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.IsReply != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "is_reply", runtime.ParamLocationQuery, *params.IsReply); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.HasThread != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "has_thread", runtime.ParamLocationQuery, *params.HasThread); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.FromDate != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from_date", runtime.ParamLocationQuery, *params.FromDate); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ToDate != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to_date", runtime.ParamLocationQuery, *params.ToDate); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Gzip != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "gzip", runtime.ParamLocationQuery, *params.Gzip); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiMessagesSearchRequest generates requests for GetApiMessagesSearch
+func NewGetApiMessagesSearchRequest(server string, params *GetApiMessagesSearchParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/messages/search")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ChannelId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "channel_id", runtime.ParamLocationQuery, *params.ChannelId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Sender != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sender", runtime.ParamLocationQuery, *params.Sender); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.SenderPrefix != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sender_prefix", runtime.ParamLocationQuery, *params.SenderPrefix); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ExcludeSender != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "exclude_sender", runtime.ParamLocationQuery, *params.ExcludeSender); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.IsReply != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "is_reply", runtime.ParamLocationQuery, *params.IsReply); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.HasThread != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "has_thread", runtime.ParamLocationQuery, *params.HasThread); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.FromDate != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from_date", runtime.ParamLocationQuery, *params.FromDate); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ToDate != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to_date", runtime.ParamLocationQuery, *params.ToDate); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Sort != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Fields != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", false, "fields", runtime.ParamLocationQuery, *params.Fields); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteApiMessagesUidRequest generates requests for DeleteApiMessagesUid
+func NewDeleteApiMessagesUidRequest(server string, uid string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uid", runtime.ParamLocationPath, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/messages/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiMessagesUidContextRequest generates requests for GetApiMessagesUidContext
+func NewGetApiMessagesUidContextRequest(server string, uid string, params *GetApiMessagesUidContextParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uid", runtime.ParamLocationPath, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/messages/%s/context", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Before != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "before", runtime.ParamLocationQuery, *params.Before); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.After != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "after", runtime.ParamLocationQuery, *params.After); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiStatsMessagesRequest generates requests for GetApiStatsMessages
+func NewGetApiStatsMessagesRequest(server string, params *GetApiStatsMessagesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/stats/messages")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "group_by", runtime.ParamLocationQuery, params.GroupBy); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.ChannelId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "channel_id", runtime.ParamLocationQuery, *params.ChannelId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Sender != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sender", runtime.ParamLocationQuery, *params.Sender); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ExcludeSender != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "exclude_sender", runtime.ParamLocationQuery, *params.ExcludeSender); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.FromDate != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from_date", runtime.ParamLocationQuery, *params.FromDate); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ToDate != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to_date", runtime.ParamLocationQuery, *params.ToDate); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Tz != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tz", runtime.ParamLocationQuery, *params.Tz); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetApiTenantsRequest generates requests for GetApiTenants
+func NewGetApiTenantsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/tenants")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostApiTenantsRequest calls the generic PostApiTenants builder with application/json body
+func NewPostApiTenantsRequest(server string, body PostApiTenantsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiTenantsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostApiTenantsRequestWithBody generates requests for PostApiTenants with any type of body
+func NewPostApiTenantsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/tenants")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetApiTenantsIdRequest generates requests for GetApiTenantsId
+func NewGetApiTenantsIdRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/tenants/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPatchApiTenantsIdRequest calls the generic PatchApiTenantsId builder with application/json body
+func NewPatchApiTenantsIdRequest(server string, id string, body PatchApiTenantsIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchApiTenantsIdRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPatchApiTenantsIdRequestWithBody generates requests for PatchApiTenantsId with any type of body
+func NewPatchApiTenantsIdRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/tenants/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostApiTenantsIdTokensRequest calls the generic PostApiTenantsIdTokens builder with application/json body
+func NewPostApiTenantsIdTokensRequest(server string, id string, body PostApiTenantsIdTokensJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiTenantsIdTokensRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPostApiTenantsIdTokensRequestWithBody generates requests for PostApiTenantsIdTokens with any type of body
+func NewPostApiTenantsIdTokensRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/tenants/%s/tokens", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetApiTokensRequest generates requests for GetApiTokens
+func NewGetApiTokensRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostApiTokensRequest calls the generic PostApiTokens builder with application/json body
+func NewPostApiTokensRequest(server string, body PostApiTokensJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiTokensRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostApiTokensRequestWithBody generates requests for PostApiTokens with any type of body
+func NewPostApiTokensRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteApiTokensIdRequest generates requests for DeleteApiTokensId
+func NewDeleteApiTokensIdRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/tokens/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *RawClient) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *RawClient) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// PostApiMessagesWithBodyWithResponse request with any body
+	PostApiMessagesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiMessagesResponse, error)
+
+	PostApiMessagesWithResponse(ctx context.Context, body PostApiMessagesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiMessagesResponse, error)
+
+	// PostApiMessagesBatchWithBodyWithResponse request with any body
+	PostApiMessagesBatchWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiMessagesBatchResponse, error)
+
+	PostApiMessagesBatchWithResponse(ctx context.Context, body PostApiMessagesBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiMessagesBatchResponse, error)
+
+	// PostApiMessagesBulkDeleteWithBodyWithResponse request with any body
+	PostApiMessagesBulkDeleteWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiMessagesBulkDeleteResponse, error)
+
+	PostApiMessagesBulkDeleteWithResponse(ctx context.Context, body PostApiMessagesBulkDeleteJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiMessagesBulkDeleteResponse, error)
+
+	// GetApiMessagesExportWithResponse request
+	GetApiMessagesExportWithResponse(ctx context.Context, params *GetApiMessagesExportParams, reqEditors ...RequestEditorFn) (*GetApiMessagesExportResponse, error)
+
+	// GetApiMessagesSearchWithResponse request
+	GetApiMessagesSearchWithResponse(ctx context.Context, params *GetApiMessagesSearchParams, reqEditors ...RequestEditorFn) (*GetApiMessagesSearchResponse, error)
+
+	// DeleteApiMessagesUidWithResponse request
+	DeleteApiMessagesUidWithResponse(ctx context.Context, uid string, reqEditors ...RequestEditorFn) (*DeleteApiMessagesUidResponse, error)
+
+	// GetApiMessagesUidContextWithResponse request
+	GetApiMessagesUidContextWithResponse(ctx context.Context, uid string, params *GetApiMessagesUidContextParams, reqEditors ...RequestEditorFn) (*GetApiMessagesUidContextResponse, error)
+
+	// GetApiStatsMessagesWithResponse request
+	GetApiStatsMessagesWithResponse(ctx context.Context, params *GetApiStatsMessagesParams, reqEditors ...RequestEditorFn) (*GetApiStatsMessagesResponse, error)
+
+	// GetApiTenantsWithResponse request
+	GetApiTenantsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiTenantsResponse, error)
+
+	// PostApiTenantsWithBodyWithResponse request with any body
+	PostApiTenantsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiTenantsResponse, error)
+
+	PostApiTenantsWithResponse(ctx context.Context, body PostApiTenantsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiTenantsResponse, error)
+
+	// GetApiTenantsIdWithResponse request
+	GetApiTenantsIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetApiTenantsIdResponse, error)
+
+	// PatchApiTenantsIdWithBodyWithResponse request with any body
+	PatchApiTenantsIdWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchApiTenantsIdResponse, error)
+
+	PatchApiTenantsIdWithResponse(ctx context.Context, id string, body PatchApiTenantsIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchApiTenantsIdResponse, error)
+
+	// PostApiTenantsIdTokensWithBodyWithResponse request with any body
+	PostApiTenantsIdTokensWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiTenantsIdTokensResponse, error)
+
+	PostApiTenantsIdTokensWithResponse(ctx context.Context, id string, body PostApiTenantsIdTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiTenantsIdTokensResponse, error)
+
+	// GetApiTokensWithResponse request
+	GetApiTokensWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiTokensResponse, error)
+
+	// PostApiTokensWithBodyWithResponse request with any body
+	PostApiTokensWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiTokensResponse, error)
+
+	PostApiTokensWithResponse(ctx context.Context, body PostApiTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiTokensResponse, error)
+
+	// DeleteApiTokensIdWithResponse request
+	DeleteApiTokensIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteApiTokensIdResponse, error)
+}
+
+type PostApiMessagesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Message
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiMessagesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiMessagesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiMessagesBatchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageBatchResult
+	JSON207      *MessageBatchResult
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiMessagesBatchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiMessagesBatchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiMessagesBulkDeleteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageBulkDeleteResult
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiMessagesBulkDeleteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiMessagesBulkDeleteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiMessagesExportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Message
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiMessagesExportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiMessagesExportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiMessagesSearchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Message
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiMessagesSearchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiMessagesSearchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteApiMessagesUidResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteApiMessagesUidResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteApiMessagesUidResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiMessagesUidContextResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageContext
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiMessagesUidContextResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiMessagesUidContextResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiStatsMessagesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageStats
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiStatsMessagesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiStatsMessagesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiTenantsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Tenant
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiTenantsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiTenantsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiTenantsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Tenant
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiTenantsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiTenantsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiTenantsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Tenant
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiTenantsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiTenantsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchApiTenantsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Tenant
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchApiTenantsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchApiTenantsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiTenantsIdTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *TokenResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiTenantsIdTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiTenantsIdTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Token
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *TokenResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteApiTokensIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteApiTokensIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteApiTokensIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// PostApiMessagesWithBodyWithResponse request with arbitrary body returning *PostApiMessagesResponse
+func (c *ClientWithResponses) PostApiMessagesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiMessagesResponse, error) {
+	rsp, err := c.PostApiMessagesWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiMessagesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiMessagesWithResponse(ctx context.Context, body PostApiMessagesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiMessagesResponse, error) {
+	rsp, err := c.PostApiMessages(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiMessagesResponse(rsp)
+}
+
+// PostApiMessagesBatchWithBodyWithResponse request with arbitrary body returning *PostApiMessagesBatchResponse
+func (c *ClientWithResponses) PostApiMessagesBatchWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiMessagesBatchResponse, error) {
+	rsp, err := c.PostApiMessagesBatchWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiMessagesBatchResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiMessagesBatchWithResponse(ctx context.Context, body PostApiMessagesBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiMessagesBatchResponse, error) {
+	rsp, err := c.PostApiMessagesBatch(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiMessagesBatchResponse(rsp)
+}
+
+// PostApiMessagesBulkDeleteWithBodyWithResponse request with arbitrary body returning *PostApiMessagesBulkDeleteResponse
+func (c *ClientWithResponses) PostApiMessagesBulkDeleteWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiMessagesBulkDeleteResponse, error) {
+	rsp, err := c.PostApiMessagesBulkDeleteWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiMessagesBulkDeleteResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiMessagesBulkDeleteWithResponse(ctx context.Context, body PostApiMessagesBulkDeleteJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiMessagesBulkDeleteResponse, error) {
+	rsp, err := c.PostApiMessagesBulkDelete(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiMessagesBulkDeleteResponse(rsp)
+}
+
+// GetApiMessagesExportWithResponse request returning *GetApiMessagesExportResponse
+func (c *ClientWithResponses) GetApiMessagesExportWithResponse(ctx context.Context, params *GetApiMessagesExportParams, reqEditors ...RequestEditorFn) (*GetApiMessagesExportResponse, error) {
+	rsp, err := c.GetApiMessagesExport(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiMessagesExportResponse(rsp)
+}
+
+// GetApiMessagesSearchWithResponse request returning *GetApiMessagesSearchResponse
+func (c *ClientWithResponses) GetApiMessagesSearchWithResponse(ctx context.Context, params *GetApiMessagesSearchParams, reqEditors ...RequestEditorFn) (*GetApiMessagesSearchResponse, error) {
+	rsp, err := c.GetApiMessagesSearch(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiMessagesSearchResponse(rsp)
+}
+
+// DeleteApiMessagesUidWithResponse request returning *DeleteApiMessagesUidResponse
+func (c *ClientWithResponses) DeleteApiMessagesUidWithResponse(ctx context.Context, uid string, reqEditors ...RequestEditorFn) (*DeleteApiMessagesUidResponse, error) {
+	rsp, err := c.DeleteApiMessagesUid(ctx, uid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteApiMessagesUidResponse(rsp)
+}
+
+// GetApiMessagesUidContextWithResponse request returning *GetApiMessagesUidContextResponse
+func (c *ClientWithResponses) GetApiMessagesUidContextWithResponse(ctx context.Context, uid string, params *GetApiMessagesUidContextParams, reqEditors ...RequestEditorFn) (*GetApiMessagesUidContextResponse, error) {
+	rsp, err := c.GetApiMessagesUidContext(ctx, uid, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiMessagesUidContextResponse(rsp)
+}
+
+// GetApiStatsMessagesWithResponse request returning *GetApiStatsMessagesResponse
+func (c *ClientWithResponses) GetApiStatsMessagesWithResponse(ctx context.Context, params *GetApiStatsMessagesParams, reqEditors ...RequestEditorFn) (*GetApiStatsMessagesResponse, error) {
+	rsp, err := c.GetApiStatsMessages(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiStatsMessagesResponse(rsp)
+}
+
+// GetApiTenantsWithResponse request returning *GetApiTenantsResponse
+func (c *ClientWithResponses) GetApiTenantsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiTenantsResponse, error) {
+	rsp, err := c.GetApiTenants(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiTenantsResponse(rsp)
+}
+
+// PostApiTenantsWithBodyWithResponse request with arbitrary body returning *PostApiTenantsResponse
+func (c *ClientWithResponses) PostApiTenantsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiTenantsResponse, error) {
+	rsp, err := c.PostApiTenantsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiTenantsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiTenantsWithResponse(ctx context.Context, body PostApiTenantsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiTenantsResponse, error) {
+	rsp, err := c.PostApiTenants(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiTenantsResponse(rsp)
+}
+
+// GetApiTenantsIdWithResponse request returning *GetApiTenantsIdResponse
+func (c *ClientWithResponses) GetApiTenantsIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetApiTenantsIdResponse, error) {
+	rsp, err := c.GetApiTenantsId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiTenantsIdResponse(rsp)
+}
+
+// PatchApiTenantsIdWithBodyWithResponse request with arbitrary body returning *PatchApiTenantsIdResponse
+func (c *ClientWithResponses) PatchApiTenantsIdWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchApiTenantsIdResponse, error) {
+	rsp, err := c.PatchApiTenantsIdWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchApiTenantsIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchApiTenantsIdWithResponse(ctx context.Context, id string, body PatchApiTenantsIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchApiTenantsIdResponse, error) {
+	rsp, err := c.PatchApiTenantsId(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchApiTenantsIdResponse(rsp)
+}
+
+// PostApiTenantsIdTokensWithBodyWithResponse request with arbitrary body returning *PostApiTenantsIdTokensResponse
+func (c *ClientWithResponses) PostApiTenantsIdTokensWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiTenantsIdTokensResponse, error) {
+	rsp, err := c.PostApiTenantsIdTokensWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiTenantsIdTokensResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiTenantsIdTokensWithResponse(ctx context.Context, id string, body PostApiTenantsIdTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiTenantsIdTokensResponse, error) {
+	rsp, err := c.PostApiTenantsIdTokens(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiTenantsIdTokensResponse(rsp)
+}
+
+// GetApiTokensWithResponse request returning *GetApiTokensResponse
+func (c *ClientWithResponses) GetApiTokensWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiTokensResponse, error) {
+	rsp, err := c.GetApiTokens(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiTokensResponse(rsp)
+}
+
+// PostApiTokensWithBodyWithResponse request with arbitrary body returning *PostApiTokensResponse
+func (c *ClientWithResponses) PostApiTokensWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiTokensResponse, error) {
+	rsp, err := c.PostApiTokensWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiTokensResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiTokensWithResponse(ctx context.Context, body PostApiTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiTokensResponse, error) {
+	rsp, err := c.PostApiTokens(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiTokensResponse(rsp)
+}
+
+// DeleteApiTokensIdWithResponse request returning *DeleteApiTokensIdResponse
+func (c *ClientWithResponses) DeleteApiTokensIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteApiTokensIdResponse, error) {
+	rsp, err := c.DeleteApiTokensId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteApiTokensIdResponse(rsp)
+}
+
+// ParsePostApiMessagesResponse parses an HTTP response from a PostApiMessagesWithResponse call
+func ParsePostApiMessagesResponse(rsp *http.Response) (*PostApiMessagesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiMessagesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Message
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiMessagesBatchResponse parses an HTTP response from a PostApiMessagesBatchWithResponse call
+func ParsePostApiMessagesBatchResponse(rsp *http.Response) (*PostApiMessagesBatchResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiMessagesBatchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageBatchResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 207:
+		var dest MessageBatchResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON207 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiMessagesBulkDeleteResponse parses an HTTP response from a PostApiMessagesBulkDeleteWithResponse call
+func ParsePostApiMessagesBulkDeleteResponse(rsp *http.Response) (*PostApiMessagesBulkDeleteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiMessagesBulkDeleteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageBulkDeleteResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiMessagesExportResponse parses an HTTP response from a GetApiMessagesExportWithResponse call
+func ParseGetApiMessagesExportResponse(rsp *http.Response) (*GetApiMessagesExportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiMessagesExportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Message
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (text/csv) unsupported
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiMessagesSearchResponse parses an HTTP response from a GetApiMessagesSearchWithResponse call
+func ParseGetApiMessagesSearchResponse(rsp *http.Response) (*GetApiMessagesSearchResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiMessagesSearchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Message
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteApiMessagesUidResponse parses an HTTP response from a DeleteApiMessagesUidWithResponse call
+func ParseDeleteApiMessagesUidResponse(rsp *http.Response) (*DeleteApiMessagesUidResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteApiMessagesUidResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetApiMessagesUidContextResponse parses an HTTP response from a GetApiMessagesUidContextWithResponse call
+func ParseGetApiMessagesUidContextResponse(rsp *http.Response) (*GetApiMessagesUidContextResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiMessagesUidContextResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageContext
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiStatsMessagesResponse parses an HTTP response from a GetApiStatsMessagesWithResponse call
+func ParseGetApiStatsMessagesResponse(rsp *http.Response) (*GetApiStatsMessagesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiStatsMessagesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageStats
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiTenantsResponse parses an HTTP response from a GetApiTenantsWithResponse call
+func ParseGetApiTenantsResponse(rsp *http.Response) (*GetApiTenantsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiTenantsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Tenant
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiTenantsResponse parses an HTTP response from a PostApiTenantsWithResponse call
+func ParsePostApiTenantsResponse(rsp *http.Response) (*PostApiTenantsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiTenantsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Tenant
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiTenantsIdResponse parses an HTTP response from a GetApiTenantsIdWithResponse call
+func ParseGetApiTenantsIdResponse(rsp *http.Response) (*GetApiTenantsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiTenantsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Tenant
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchApiTenantsIdResponse parses an HTTP response from a PatchApiTenantsIdWithResponse call
+func ParsePatchApiTenantsIdResponse(rsp *http.Response) (*PatchApiTenantsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchApiTenantsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Tenant
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiTenantsIdTokensResponse parses an HTTP response from a PostApiTenantsIdTokensWithResponse call
+func ParsePostApiTenantsIdTokensResponse(rsp *http.Response) (*PostApiTenantsIdTokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiTenantsIdTokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest TokenResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiTokensResponse parses an HTTP response from a GetApiTokensWithResponse call
+func ParseGetApiTokensResponse(rsp *http.Response) (*GetApiTokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiTokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Token
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiTokensResponse parses an HTTP response from a PostApiTokensWithResponse call
+func ParsePostApiTokensResponse(rsp *http.Response) (*PostApiTokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiTokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest TokenResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteApiTokensIdResponse parses an HTTP response from a DeleteApiTokensIdWithResponse call
+func ParseDeleteApiTokensIdResponse(rsp *http.Response) (*DeleteApiTokensIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteApiTokensIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
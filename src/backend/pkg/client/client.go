@@ -0,0 +1,119 @@
+// Package client はメッセージサービスのHTTP APIのGoクライアント
+//
+// client.gen.goはOpenAPI仕様（src/openapi/index.yaml）からoapi-codegenで生成した型付きのクライアントで、
+// このファイルでBearerトークンの付与、429と5xxの再試行、コンテキストの伝播を設定する
+//
+//	c, err := client.New("https://messages.example.com", client.WithToken(token))
+//	resp, err := c.PostApiMessagesWithResponse(ctx, client.MessageCreate{...})
+//	for msg, err := range c.SearchMessages(ctx, client.GetApiMessagesSearchParams{...}) {...}
+//
+// 生成するには src/backend で次を実行する
+//
+//	oapi-codegen -config client.config.yaml ../openapi/index.yaml
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// RequestIDHeader はリクエストIDを伝えるヘッダー
+// サーバーは受け取ったIDをログに記録し、レスポンスでも返す
+const RequestIDHeader = "X-Request-ID"
+
+// Client はメッセージサービスのクライアント
+// 生成されたClientWithResponsesの全てのメソッドを持つ
+type Client struct {
+	*ClientWithResponses
+}
+
+// Option はNewで作成するクライアントの設定
+type Option func(*options)
+
+type options struct {
+	doer  HttpRequestDoer
+	token string
+	retry RetryPolicy
+}
+
+// WithToken はリクエストにBearerトークンを付与する
+func WithToken(token string) Option {
+	return func(o *options) { o.token = token }
+}
+
+// WithRetryPolicy は再試行の設定を変更する
+// 再試行しない場合はMaxRetriesを0にする
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) { o.retry = policy }
+}
+
+// WithDoer はリクエストの送信に使うHTTPクライアントを指定する
+// 省略した場合はhttp.DefaultClientを使う
+func WithDoer(doer HttpRequestDoer) Option {
+	return func(o *options) { o.doer = doer }
+}
+
+// New はserverのAPIを呼び出すクライアントを作成する
+// serverはスキームを含むURLで、パスを含む場合はその配下のAPIを呼び出す
+func New(server string, opts ...Option) (*Client, error) {
+	o := options{doer: http.DefaultClient, retry: DefaultRetryPolicy}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c, err := NewClientWithResponses(server,
+		WithHTTPClient(&retryDoer{doer: o.doer, policy: o.retry}),
+		WithRequestEditorFn(propagateContext),
+		WithRequestEditorFn(bearerToken(o.token)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{ClientWithResponses: c}, nil
+}
+
+// bearerToken はAuthorizationヘッダーにトークンを設定する
+func bearerToken(token string) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return nil
+	}
+}
+
+type requestIDKey struct{}
+
+// ContextWithRequestID はリクエストIDを格納したコンテキストを返す
+// このコンテキストで呼び出したリクエストにはX-Request-IDヘッダーが付与される
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// propagateContext はコンテキストのリクエストIDとトレースをヘッダーで伝える
+// トレースはアプリケーションがotel.SetTextMapPropagatorで設定した形式で伝える
+func propagateContext(ctx context.Context, req *http.Request) error {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok && id != "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return nil
+}
+
+// APIError はサービスが成功以外のステータスを返した場合のエラー
+type APIError struct {
+	StatusCode int
+	// Body はレスポンスの本文
+	Body []byte
+}
+
+func (e *APIError) Error() string {
+	if len(e.Body) == 0 {
+		return fmt.Sprintf("message service returned %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("message service returned %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
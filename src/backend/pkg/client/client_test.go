@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"message-service/internal/adapter/handler"
+	"message-service/internal/domain/tenant"
+	"message-service/internal/domain/token"
+	"message-service/internal/infrastructure/logging"
+	"message-service/internal/infrastructure/memory"
+	"message-service/internal/infrastructure/middleware"
+	"message-service/pkg/api"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// testToken はテスト用のサーバーで有効な管理用のトークン
+const testToken = "test-token"
+
+// newTestServer はインメモリのストレージでサーバーと同じルーターを起動する
+// wrapでルーターの前に処理を挟み、一時的なエラーなどを再現できる
+func newTestServer(t *testing.T, wrap func(http.Handler) http.Handler) *httptest.Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	messageRepo := memory.NewMessageRepository()
+	tokenRepo := memory.NewTokenRepository()
+	if err := tokenRepo.Create(tenant.WithID(context.Background(), tenant.DefaultID), &token.Token{
+		Token:     testToken,
+		Name:      "client test",
+		Admin:     true,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	router := gin.New()
+	router.ContextWithFallback = true
+	router.Use(logging.RequestID())
+	router.Use(middleware.NewAuthMiddleware(tokenRepo).RequireAuth())
+	api.RegisterHandlers(router, api.NewStrictHandler(
+		handler.NewHandler(messageRepo, tokenRepo, memory.NewAuditRepository(), memory.NewTenantRepository(), time.Hour, handler.NewConfirmationSecret(), handler.DefaultConfirmationTTL),
+		[]api.StrictMiddlewareFunc{handler.ErrorResponseMiddleware()}))
+
+	var h http.Handler = router
+	if wrap != nil {
+		h = wrap(router)
+	}
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTestClient は待ち時間を短くしたクライアントを作成する
+func newTestClient(t *testing.T, server *httptest.Server, opts ...Option) *Client {
+	t.Helper()
+	opts = append([]Option{
+		WithToken(testToken),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}),
+	}, opts...)
+	c, err := New(server.URL, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestClient_CreateAndSearch(t *testing.T) {
+	server := newTestServer(t, nil)
+	c := newTestClient(t, server)
+	ctx := context.Background()
+	uid, sender, channel, content := "msg-1", "alice", "general", "hello"
+	sentAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	created, err := c.PostApiMessagesWithResponse(ctx, MessageCreate{
+		Uid: uid, Sender: sender, ChannelId: channel, Content: content, SentAt: sentAt,
+	})
+
+	assert.NoError(t, err)
+	if assert.Equal(t, http.StatusCreated, created.StatusCode()) {
+		assert.Equal(t, uid, *created.JSON201.Uid)
+	}
+
+	found, err := c.GetApiMessagesSearchWithResponse(ctx, &GetApiMessagesSearchParams{ChannelId: &[]string{channel}})
+	assert.NoError(t, err)
+	if assert.NotNil(t, found.JSON200) && assert.Len(t, *found.JSON200, 1) {
+		assert.Equal(t, content, *(*found.JSON200)[0].Content)
+	}
+}
+
+func TestClient_Token(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           []Option
+		expectedStatus int
+	}{
+		{name: "正常系：トークンを付与する", expectedStatus: http.StatusOK},
+		{name: "異常系：無効なトークン", opts: []Option{WithToken("unknown")}, expectedStatus: http.StatusUnauthorized},
+		{name: "異常系：トークンなし", opts: []Option{WithToken("")}, expectedStatus: http.StatusUnauthorized},
+	}
+
+	server := newTestServer(t, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, server, tt.opts...)
+
+			resp, err := c.GetApiTokensWithResponse(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode())
+		})
+	}
+}
+
+func TestClient_RequestID(t *testing.T) {
+	var received string
+	server := newTestServer(t, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r.Header.Get(RequestIDHeader)
+			next.ServeHTTP(w, r)
+		})
+	})
+	c := newTestClient(t, server)
+
+	resp, err := c.GetApiTokensWithResponse(ContextWithRequestID(context.Background(), "req-123"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "req-123", received)
+	// サーバーは受け取ったリクエストIDを返す
+	assert.Equal(t, "req-123", resp.HTTPResponse.Header.Get(RequestIDHeader))
+}
+
+func TestClient_ContextCancel(t *testing.T) {
+	server := newTestServer(t, nil)
+	c := newTestClient(t, server)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.GetApiTokensWithResponse(ctx)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy は一時的なエラーの再試行の設定
+//
+// 429と503はサーバーが処理していないため、全てのメソッドを再試行する
+// その他の5xxと通信エラーは、処理済みの可能性があるため冪等なメソッドのみを再試行する
+type RetryPolicy struct {
+	// MaxRetries は最初のリクエストの後に再試行する最大回数
+	MaxRetries int
+	// InitialBackoff は1回目の再試行までの待ち時間。再試行ごとに2倍になる
+	InitialBackoff time.Duration
+	// MaxBackoff は待ち時間の上限。Retry-Afterヘッダーの指定もこの値までとする
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy はNewで作成したクライアントの再試行の設定
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+}
+
+// backoff はattempt回目（0始まり）の再試行までの待ち時間を返す
+// 複数のクライアントが同時に再試行しないよう、待ち時間の後半でばらつかせる
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	wait := p.InitialBackoff
+	for i := 0; i < attempt && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if wait > 0 {
+		wait = wait/2 + rand.N(wait/2+1)
+	}
+
+	// サーバーが待ち時間を指定した場合はそれに従う
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			wait = max(wait, min(time.Duration(seconds)*time.Second, p.MaxBackoff))
+		}
+	}
+	return wait
+}
+
+// retryDoer は一時的なエラーのリクエストを待ち時間を空けて再試行する
+type retryDoer struct {
+	doer   HttpRequestDoer
+	policy RetryPolicy
+}
+
+func (d *retryDoer) Do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := d.doer.Do(req)
+		if attempt >= d.policy.MaxRetries || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		wait := d.policy.backoff(attempt, resp)
+		if resp != nil {
+			// 接続を再利用できるよう本文を読み切る
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable はリクエストを再試行できるかどうかを判定する
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	// 本文を作り直せないリクエストは再送できない
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		// 呼び出し元のキャンセルやタイムアウトは再試行しない
+		if req.Context().Err() != nil {
+			return false
+		}
+		return idempotent(req.Method)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode == http.StatusServiceUnavailable:
+		return true
+	case resp.StatusCode >= 500:
+		return idempotent(req.Method)
+	}
+	return false
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failFirst は最初のn件のリクエストにstatusを返し、その後はルーターに渡す
+func failFirst(n int32, status int, calls *atomic.Int32) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) <= n {
+				w.WriteHeader(status)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	tests := []struct {
+		name           string
+		failures       int32
+		status         int
+		post           bool
+		expectedStatus int
+		expectedCalls  int32
+	}{
+		{name: "正常系：503は再試行して成功する", failures: 2, status: http.StatusServiceUnavailable, expectedStatus: http.StatusOK, expectedCalls: 3},
+		{name: "正常系：GETの500は再試行する", failures: 1, status: http.StatusInternalServerError, expectedStatus: http.StatusOK, expectedCalls: 2},
+		{name: "正常系：POSTの429は本文を再送して成功する", failures: 2, status: http.StatusTooManyRequests, post: true, expectedStatus: http.StatusCreated, expectedCalls: 3},
+		{name: "異常系：POSTの500は処理済みの可能性があるため再試行しない", failures: 1, status: http.StatusInternalServerError, post: true, expectedStatus: http.StatusInternalServerError, expectedCalls: 1},
+		{name: "異常系：再試行の上限を超えると最後のレスポンスを返す", failures: 10, status: http.StatusServiceUnavailable, expectedStatus: http.StatusServiceUnavailable, expectedCalls: 4},
+		{name: "異常系：4xxは再試行しない", failures: 1, status: http.StatusBadRequest, expectedStatus: http.StatusBadRequest, expectedCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := newTestServer(t, failFirst(tt.failures, tt.status, &calls))
+			c := newTestClient(t, server)
+			ctx := context.Background()
+
+			var status int
+			var err error
+			if tt.post {
+				var resp *PostApiMessagesResponse
+				resp, err = c.PostApiMessagesWithResponse(ctx, MessageCreate{
+					Uid: "msg-1", Sender: "alice", ChannelId: "general", Content: "hello", SentAt: time.Now(),
+				})
+				if resp != nil {
+					status = resp.StatusCode()
+				}
+			} else {
+				var resp *GetApiTokensResponse
+				resp, err = c.GetApiTokensWithResponse(ctx)
+				if resp != nil {
+					status = resp.StatusCode()
+				}
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedCalls, calls.Load())
+		})
+	}
+}
+
+func TestClient_Retry_ContextCancel(t *testing.T) {
+	var calls atomic.Int32
+	server := newTestServer(t, failFirst(10, http.StatusServiceUnavailable, &calls))
+	c := newTestClient(t, server, WithRetryPolicy(RetryPolicy{MaxRetries: 3, InitialBackoff: time.Minute, MaxBackoff: time.Minute}))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.GetApiTokensWithResponse(ctx)
+
+	// 待ち時間の途中でも呼び出し元のタイムアウトで終了する
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	retryAfter := func(seconds int) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{strconv.Itoa(seconds)}}}
+	}
+
+	tests := []struct {
+		name     string
+		attempt  int
+		resp     *http.Response
+		min, max time.Duration
+	}{
+		{name: "1回目は初期値の半分から初期値まで", attempt: 0, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "再試行ごとに2倍になる", attempt: 2, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{name: "上限を超えない", attempt: 10, min: 500 * time.Millisecond, max: time.Second},
+		{name: "Retry-Afterに従う", attempt: 0, resp: retryAfter(1), min: time.Second, max: time.Second},
+		{name: "Retry-Afterも上限を超えない", attempt: 0, resp: retryAfter(60), min: time.Second, max: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				wait := policy.backoff(tt.attempt, tt.resp)
+				assert.GreaterOrEqual(t, wait, tt.min)
+				assert.LessOrEqual(t, wait, tt.max)
+			}
+		})
+	}
+}
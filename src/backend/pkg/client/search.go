@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"iter"
+)
+
+// NextCursorHeader は検索結果の次のページのカーソルを返すヘッダー
+const NextCursorHeader = "X-Next-Cursor"
+
+// DefaultPageSize はSearchMessagesでLimitが指定されていない場合に1回で取得する件数
+const DefaultPageSize = 100
+
+// SearchMessages は検索条件に一致するメッセージを1件ずつ返す
+// params.Limit件（省略時はDefaultPageSize件）ずつページを取得し、次のページのカーソルが無くなるまで続ける
+// エラーが発生した場合はそのエラーを返して終了する
+//
+//	for msg, err := range c.SearchMessages(ctx, params) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *Client) SearchMessages(ctx context.Context, params GetApiMessagesSearchParams) iter.Seq2[Message, error] {
+	return func(yield func(Message, error) bool) {
+		if params.Limit == nil {
+			size := DefaultPageSize
+			params.Limit = &size
+		}
+
+		for {
+			resp, err := c.GetApiMessagesSearchWithResponse(ctx, &params)
+			if err != nil {
+				yield(Message{}, err)
+				return
+			}
+			if resp.JSON200 == nil {
+				yield(Message{}, &APIError{StatusCode: resp.StatusCode(), Body: resp.Body})
+				return
+			}
+
+			for _, msg := range *resp.JSON200 {
+				if !yield(msg, nil) {
+					return
+				}
+			}
+
+			next := resp.HTTPResponse.Header.Get(NextCursorHeader)
+			if next == "" {
+				return
+			}
+			params.Cursor = &next
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createMessages はn件のメッセージを送信日時の順に作成する
+func createMessages(t *testing.T, c *Client, channel string, n int) []string {
+	t.Helper()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	uids := make([]string, n)
+	for i := range uids {
+		uids[i] = fmt.Sprintf("%s-%02d", channel, i)
+		resp, err := c.PostApiMessagesWithResponse(context.Background(), MessageCreate{
+			Uid: uids[i], Sender: "alice", ChannelId: channel, Content: "hello", SentAt: base.Add(time.Duration(i) * time.Minute),
+		})
+		if err != nil || resp.StatusCode() != http.StatusCreated {
+			t.Fatalf("failed to create %s: %v %v", uids[i], err, resp.StatusCode())
+		}
+	}
+	return uids
+}
+
+func TestClient_SearchMessages(t *testing.T) {
+	var searches atomic.Int32
+	server := newTestServer(t, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/messages/search" {
+				searches.Add(1)
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	c := newTestClient(t, server)
+	want := createMessages(t, c, "general", 7)
+	createMessages(t, c, "random", 2)
+
+	tests := []struct {
+		name          string
+		limit         *int
+		want          []string
+		expectedPages int32
+	}{
+		{name: "正常系：ページをまたいで全件を返す", limit: intPtr(3), want: want, expectedPages: 3},
+		{name: "正常系：件数ちょうどのページで終わる", limit: intPtr(7), want: want, expectedPages: 1},
+		{name: "正常系：件数の省略時は既定のページサイズで取得する", want: want, expectedPages: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searches.Store(0)
+			params := GetApiMessagesSearchParams{ChannelId: &[]string{"general"}, Limit: tt.limit}
+
+			var uids []string
+			for msg, err := range c.SearchMessages(context.Background(), params) {
+				if !assert.NoError(t, err) {
+					return
+				}
+				uids = append(uids, *msg.Uid)
+			}
+
+			assert.Equal(t, tt.want, uids)
+			assert.Equal(t, tt.expectedPages, searches.Load())
+		})
+	}
+
+	t.Run("正常系：途中で打ち切ると残りのページを取得しない", func(t *testing.T) {
+		searches.Store(0)
+		params := GetApiMessagesSearchParams{ChannelId: &[]string{"general"}, Limit: intPtr(2)}
+
+		var uids []string
+		for msg := range c.SearchMessages(context.Background(), params) {
+			uids = append(uids, *msg.Uid)
+			if len(uids) == 3 {
+				break
+			}
+		}
+
+		assert.Equal(t, want[:3], uids)
+		assert.Equal(t, int32(2), searches.Load())
+	})
+
+	t.Run("異常系：エラーのレスポンスはAPIErrorとして返す", func(t *testing.T) {
+		params := GetApiMessagesSearchParams{Limit: intPtr(2), Cursor: strPtr("broken")}
+
+		var errs []error
+		for _, err := range c.SearchMessages(context.Background(), params) {
+			errs = append(errs, err)
+		}
+
+		if assert.Len(t, errs, 1) {
+			var apiErr *APIError
+			assert.ErrorAs(t, errs[0], &apiErr)
+			assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+		}
+	})
+}
+
+func intPtr(v int) *int       { return &v }
+func strPtr(v string) *string { return &v }